@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var aggregateCmd = &cobra.Command{
+	Use:   "aggregate <file.xlsx> <column> <sum|avg|min|max|count>",
+	Short: "Aggregate a numeric column",
+	Long: `Compute sum, avg, min, max or count over the numeric cells of a column.
+The column is a letter (B) or header label (Age); a label skips the header row.
+Non-numeric cells are skipped and reported as ignored.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+
+		sheet, err := cmd.Flags().GetString("sheet")
+		if err != nil {
+			return fmt.Errorf("failed to get sheet flag: %w", err)
+		}
+
+		f, err := openInput(cmd, filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		result, err := xlsx.AggregateSheet(f, sheet, args[1], args[2])
+		if err != nil {
+			return err
+		}
+
+		return output.Print(result, GetFormatFromCmd(cmd), GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	aggregateCmd.Flags().StringP("sheet", "s", "", "Sheet name (default: first sheet)")
+	addOutputFlag(aggregateCmd)
+	rootCmd.AddCommand(aggregateCmd)
+}
@@ -17,7 +17,7 @@ var appendCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		basepath := GetBasepathFromCmd(cmd)
-		file, err := ResolveFilePath(basepath, args[0])
+		file, err := ResolveWritePath(basepath, args[0])
 		if err != nil {
 			return err
 		}
@@ -42,17 +42,31 @@ var appendCmd = &cobra.Command{
 			return fmt.Errorf("failed to parse data as JSON array: %w", err)
 		}
 
-		result, err := xlsx.AppendRows(file, sheet, rows)
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		if err := applySanitizeFlag(cmd); err != nil {
+			return err
+		}
+
+		result, err := xlsx.Edit(file, dryRun, func(wb *xlsx.Workbook) (*xlsx.AppendResult, error) {
+			return wb.AppendRows(sheet, rows)
+		})
 		if err != nil {
 			return err
 		}
+		result.DryRun = dryRun
 
 		format := GetFormatFromCmd(cmd)
-		return output.Print(result, format)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
 	},
 }
 
 func init() {
 	appendCmd.Flags().StringP("sheet", "s", "", "Sheet name (default: first sheet)")
+	appendCmd.Flags().Bool("sanitize", false, sanitizeFlagUsage)
+	appendCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
 	rootCmd.AddCommand(appendCmd)
 }
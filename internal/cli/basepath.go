@@ -10,11 +10,12 @@ import (
 )
 
 // ResolveFilePath resolves a file path relative to a basepath.
-// If basepath is empty or file is absolute, file is returned unchanged.
+// If basepath is empty, file is absolute or file is "-" (stdin), file is
+// returned unchanged.
 // Otherwise, filepath.Join(basepath, file) is returned after verifying
 // the resolved path does not escape the basepath via path traversal.
 func ResolveFilePath(basepath, file string) (string, error) {
-	if basepath == "" {
+	if basepath == "" || file == stdinPath {
 		return file, nil
 	}
 	if filepath.IsAbs(file) {
@@ -2,7 +2,6 @@ package cli
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/fuabioo/xlq/internal/output"
 	"github.com/fuabioo/xlq/internal/xlsx"
@@ -12,13 +11,18 @@ import (
 var cellCmd = &cobra.Command{
 	Use:   "cell <file.xlsx> [sheet] <address>",
 	Short: "Get single cell value",
-	Args:  cobra.RangeArgs(2, 3),
+	Long: `Get a single cell value. The address may be copied straight from Excel,
+including a sheet name and $ anchors (e.g., 'Sheet1!$B$3').
+
+Values are shown formatted as Excel displays them, e.g. 50% or $1,234.50;
+--raw shows the stored value behind the format instead, e.g. 0.5 or 1234.5.`,
+	Args: cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
 		if err != nil {
 			return err
 		}
-		f, err := xlsx.OpenFile(filePath)
+		f, err := openInput(cmd, filePath)
 		if err != nil {
 			return err
 		}
@@ -26,11 +30,7 @@ var cellCmd = &cobra.Command{
 
 		var sheet, address string
 		if len(args) == 2 {
-			// Only file and address provided, use default sheet
-			sheet, err = xlsx.GetDefaultSheet(f)
-			if err != nil {
-				return err
-			}
+			// Only file and address provided
 			address = args[1]
 		} else {
 			// File, sheet, and address provided
@@ -38,7 +38,33 @@ var cellCmd = &cobra.Command{
 			address = args[2]
 		}
 
-		cell, err := xlsx.GetCell(f, sheet, address)
+		// Accept references copied from Excel, such as Sheet1!$B$3
+		refSheet, col, row, err := xlsx.ParseQualifiedAddress(address)
+		if err != nil {
+			return err
+		}
+		if refSheet != "" {
+			sheet = refSheet
+		}
+		address = xlsx.FormatCellAddress(col, row)
+
+		// Resolve sheet name, using the default sheet if none was given
+		sheet, err = xlsx.ResolveSheetName(f, sheet)
+		if err != nil {
+			return err
+		}
+
+		raw, err := cmd.Flags().GetBool("raw")
+		if err != nil {
+			return fmt.Errorf("failed to get raw flag: %w", err)
+		}
+
+		calc, err := cmd.Flags().GetBool("calc")
+		if err != nil {
+			return fmt.Errorf("failed to get calc flag: %w", err)
+		}
+
+		cell, err := xlsx.GetCellWithOptions(f, sheet, address, xlsx.StreamOptions{RawValues: raw, Calc: calc})
 		if err != nil {
 			return err
 		}
@@ -48,11 +74,13 @@ var cellCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Fprint(os.Stdout, string(out))
-		return nil
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
 	},
 }
 
 func init() {
+	cellCmd.Flags().Bool("calc", false, "Evaluate a formula cell now and show its formula and computed result")
+	cellCmd.Flags().Bool("raw", false, "Read the stored value without number formats (e.g. 0.5 instead of 50%)")
+	addOutputFlag(cellCmd)
 	rootCmd.AddCommand(cellCmd)
 }
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var clearCmd = &cobra.Command{
+	Use:   "clear <file> <range>",
+	Short: "Clear a range of cells",
+	Long:  "Remove values and formulas from a range of cells (e.g., A2:C10), keeping their styles. Use --sheet to specify sheet.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := ResolveWritePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		rangeStr := args[1]
+
+		sheet, err := cmd.Flags().GetString("sheet")
+		if err != nil {
+			return fmt.Errorf("failed to get sheet flag: %w", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		result, err := xlsx.Edit(file, dryRun, func(wb *xlsx.Workbook) (*xlsx.ClearRangeResult, error) {
+			return wb.ClearRange(sheet, rangeStr)
+		})
+		if err != nil {
+			return err
+		}
+		result.DryRun = dryRun
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	clearCmd.Flags().StringP("sheet", "s", "", "Sheet name (default: first sheet)")
+	clearCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	rootCmd.AddCommand(clearCmd)
+}
@@ -2,12 +2,19 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/xuri/excelize/v2"
 )
 
@@ -91,6 +98,23 @@ func captureOutput(t *testing.T, f func()) string {
 	return buf.String()
 }
 
+// resetFlags restores a command's local flags to their defaults after the
+// test, since rootCmd and its subcommands are shared package globals
+func resetFlags(t *testing.T, cmd *cobra.Command) {
+	t.Helper()
+	t.Cleanup(func() {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			// Setting a slice flag appends, and its DefValue reads "[]"
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				_ = sv.Replace(nil)
+			} else {
+				_ = f.Value.Set(f.DefValue)
+			}
+			f.Changed = false
+		})
+	})
+}
+
 func TestSheetsCommand(t *testing.T) {
 	testFile := createTestFile(t)
 
@@ -106,6 +130,35 @@ func TestSheetsCommand(t *testing.T) {
 	}
 }
 
+func TestSheetsCommandStdin(t *testing.T) {
+	data, err := os.ReadFile(createTestFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCmd.SetIn(bytes.NewReader(data))
+	t.Cleanup(func() { rootCmd.SetIn(nil) })
+
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"sheets", "-"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("sheets command failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "Sheet1") {
+		t.Errorf("Expected output to contain Sheet1, got: %s", output)
+	}
+}
+
+func TestWriteCommandRejectsStdin(t *testing.T) {
+	resetFlags(t, writeCmd)
+	rootCmd.SetArgs([]string{"write", "-", "A1", "x"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "stdin") {
+		t.Errorf("Expected write to stdin input to fail, got: %v", err)
+	}
+}
+
 func TestInfoCommand(t *testing.T) {
 	testFile := createTestFile(t)
 
@@ -124,6 +177,21 @@ func TestInfoCommand(t *testing.T) {
 	}
 }
 
+func TestLegendCommand(t *testing.T) {
+	testFile := createTestFile(t)
+
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"legend", testFile})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("legend command failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, `{"A":"Name","B":"Age","C":"City"}`) {
+		t.Errorf("Expected letter to header map, got: %s", output)
+	}
+}
+
 func TestHeadCommand(t *testing.T) {
 	testFile := createTestFile(t)
 
@@ -154,6 +222,72 @@ func TestTailCommand(t *testing.T) {
 	}
 }
 
+func TestTailFollowerPoll(t *testing.T) {
+	testFile := createTestFile(t)
+
+	f, err := xlsx.OpenFile(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	printed, err := xlsx.StreamTail(f, "Sheet1", 2)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	follower, err := newTailFollower(testFile, "Sheet1", 2, xlsx.StreamOptions{}, printed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unchanged file: nothing new
+	if rows, truncated, err := follower.poll(); err != nil || truncated || len(rows) != 0 {
+		t.Fatalf("poll() = %v, %v, %v; want no rows", rows, truncated, err)
+	}
+
+	// Appended rows are returned once
+	if _, err := xlsx.AppendRows(testFile, "Sheet1", [][]any{{"Dana", 41, "Denver"}}); err != nil {
+		t.Fatal(err)
+	}
+	follower.modTime = time.Time{} // The append may land within the same mtime tick
+	rows, truncated, err := follower.poll()
+	if err != nil || truncated {
+		t.Fatalf("poll() failed: %v (truncated %v)", err, truncated)
+	}
+	if len(rows) != 1 || rows[0].Number != 5 || rows[0].Cells[0].Value != "Dana" {
+		t.Errorf("poll() rows = %+v, want row 5 (Dana)", rows)
+	}
+
+	// A rewritten, shorter file starts over from its last rows
+	if _, err := xlsx.DeleteRows(testFile, "Sheet1", 3, 3); err != nil {
+		t.Fatal(err)
+	}
+	follower.modTime = time.Time{}
+	rows, truncated, err = follower.poll()
+	if err != nil || !truncated {
+		t.Fatalf("poll() = %v, %v; want truncation", err, truncated)
+	}
+	if len(rows) != 2 || rows[1].Number != 2 {
+		t.Errorf("poll() rows after truncation = %+v, want the last 2 rows", rows)
+	}
+}
+
+func TestTailFollowWithOutputFlag(t *testing.T) {
+	resetFlags(t, tailCmd)
+	testFile := createTestFile(t)
+	dir := t.TempDir()
+	t.Setenv("XLQ_ALLOWED_PATHS", dir)
+
+	outFile := filepath.Join(dir, "tail.json")
+	rootCmd.SetArgs([]string{"tail", testFile, "--follow", "-o", outFile})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("expected --follow and -o to be rejected together, got: %v", err)
+	}
+	if _, err := os.Stat(outFile); !os.IsNotExist(err) {
+		t.Errorf("expected no output file to be written, got: %v", err)
+	}
+}
+
 func TestCellCommand(t *testing.T) {
 	testFile := createTestFile(t)
 
@@ -169,6 +303,27 @@ func TestCellCommand(t *testing.T) {
 	}
 }
 
+func TestCellCommandQualified(t *testing.T) {
+	resetFlags(t, cellCmd)
+	testFile := createTestFile(t)
+
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"cell", testFile, "'Sheet1'!$A$3"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("cell command failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "Bob") {
+		t.Errorf("Expected output to contain 'Bob', got: %s", output)
+	}
+
+	rootCmd.SetArgs([]string{"cell", testFile, "Missing!A1"})
+	if err := rootCmd.Execute(); !errors.Is(err, xlsx.ErrSheetNotFound) {
+		t.Errorf("Expected ErrSheetNotFound, got: %v", err)
+	}
+}
+
 func TestSearchCommand(t *testing.T) {
 	testFile := createTestFile(t)
 
@@ -184,6 +339,111 @@ func TestSearchCommand(t *testing.T) {
 	}
 }
 
+func TestSearchCommandColumn(t *testing.T) {
+	resetFlags(t, searchCmd)
+	testFile := createTestFile(t)
+
+	// "o" is in Boston and New York in column C, and in Bob in column A
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"search", testFile, "o", "--column", "C", "--format", "json"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("search command failed: %v", err)
+		}
+	})
+	if strings.Contains(output, "Bob") || !strings.Contains(output, "Boston") {
+		t.Errorf("Expected only column C matches, got: %s", output)
+	}
+
+	resetFlags(t, searchCmd)
+	rootCmd.SetArgs([]string{"search", testFile, "o", "--column", "C", "--range", "A1:B2"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("Expected error combining --column and --range")
+	}
+}
+
+func TestSearchCommandOutputFile(t *testing.T) {
+	resetFlags(t, searchCmd)
+
+	dir := t.TempDir()
+	t.Setenv("XLQ_ALLOWED_PATHS", dir)
+	testFile := filepath.Join(dir, "many.xlsx")
+
+	// More matches than the MCP search cap to prove file output is exhaustive
+	const matches = 1500
+	f := excelize.NewFile()
+	for i := 1; i <= matches; i++ {
+		cell, _ := excelize.CoordinatesToCellName(1, i)
+		if err := f.SetCellValue("Sheet1", cell, "needle"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.SaveAs(testFile); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	outFile := filepath.Join(dir, "matches.csv")
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"search", testFile, "needle", "--output-file", outFile, "--format", "json"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("search command failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, `"matches":1500`) {
+		t.Errorf("Expected summary with 1500 matches, got: %s", output)
+	}
+
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) != matches+1 {
+		t.Fatalf("expected %d lines (header + matches), got %d", matches+1, len(lines))
+	}
+	if lines[0] != "sheet,address,value" {
+		t.Errorf("unexpected header line: %q", lines[0])
+	}
+	if lines[matches] != "Sheet1,A1500,needle" {
+		t.Errorf("unexpected last line: %q", lines[matches])
+	}
+}
+
+func TestSearchOutputFileWithOutputFlag(t *testing.T) {
+	resetFlags(t, searchCmd)
+	testFile := createTestFile(t)
+	dir := t.TempDir()
+	t.Setenv("XLQ_ALLOWED_PATHS", dir)
+
+	csvFile := filepath.Join(dir, "matches.csv")
+	rootCmd.SetArgs([]string{"search", testFile, "Alice", "--output-file", csvFile, "-o", filepath.Join(dir, "results.json"), "-f", "json"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("expected --output-file and -o to be rejected together, got: %v", err)
+	}
+	if _, err := os.Stat(csvFile); !os.IsNotExist(err) {
+		t.Errorf("expected no CSV file to be written, got: %v", err)
+	}
+}
+
+func TestSearchOutputFileValidated(t *testing.T) {
+	resetFlags(t, searchCmd)
+	testFile := createTestFile(t)
+	dir := t.TempDir()
+	t.Setenv("XLQ_ALLOWED_PATHS", dir)
+
+	script := filepath.Join(dir, "matches.sh")
+	rootCmd.SetArgs([]string{"search", testFile, "Alice", "--output-file", script})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "cannot write output") {
+		t.Errorf("expected a disallowed --output-file extension to be rejected, got: %v", err)
+	}
+	if _, err := os.Stat(script); !os.IsNotExist(err) {
+		t.Errorf("expected no output file to be written, got: %v", err)
+	}
+}
+
 func TestReadCommand(t *testing.T) {
 	testFile := createTestFile(t)
 
@@ -202,6 +462,269 @@ func TestReadCommand(t *testing.T) {
 	}
 }
 
+func TestReadCommandQualified(t *testing.T) {
+	resetFlags(t, readCmd)
+	testFile := createTestFile(t)
+
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"read", testFile, "Sheet1!$A$3:$A$4"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "Bob") || !strings.Contains(output, "Charlie") {
+		t.Errorf("Expected output to contain Bob and Charlie, got: %s", output)
+	}
+	if strings.Contains(output, "Alice") {
+		t.Errorf("Expected output to stop at the range, got: %s", output)
+	}
+}
+
+func TestReadCommandObjects(t *testing.T) {
+	resetFlags(t, readCmd)
+	testFile := createTestFile(t)
+
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"read", testFile, "--objects", "--format", "json"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, `{"Name":"Alice","Age":"30","City":"New York"}`) {
+		t.Errorf("Expected header-keyed objects in column order, got: %s", output)
+	}
+	if strings.Contains(output, `"Name":"Name"`) {
+		t.Errorf("Header row should not be emitted as an object, got: %s", output)
+	}
+
+	output = captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"read", testFile, "--objects", "--format", "yaml"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+	if !strings.Contains(output, "- Name: Alice\n  Age: \"30\"\n  City: New York\n") {
+		t.Errorf("Expected YAML objects in column order, got: %s", output)
+	}
+
+	// Headers line up with a range that does not start at column A
+	output = captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"read", testFile, "Sheet1", "B1:C2", "--objects", "--max-columns", "1", "--format", "json"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+	if strings.TrimSpace(output) != `[{"Age":"30"}]` {
+		t.Errorf("Expected objects keyed by the range's own headers, got: %s", output)
+	}
+}
+
+func TestReadCommandGlob(t *testing.T) {
+	resetFlags(t, readCmd)
+	dir := t.TempDir()
+	t.Setenv("XLQ_ALLOWED_PATHS", dir)
+	data, err := os.ReadFile(createTestFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"jan.xlsx", "feb.xlsx"} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"read", filepath.Join(dir, "*.xlsx"), "Sheet1", "A1:B2", "--format", "csv"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+
+	want := "feb.xlsx,Name,Age\nfeb.xlsx,Alice,30\njan.xlsx,Name,Age\njan.xlsx,Alice,30\n"
+	if output != want {
+		t.Errorf("Expected rows tagged by source, got: %q", output)
+	}
+
+	output = captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"read", filepath.Join(dir, "j*.xlsx"), "--objects", "--format", "json"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+	if !strings.Contains(output, `{"__source":"jan.xlsx","Name":"Alice"`) || strings.Contains(output, "feb.xlsx") {
+		t.Errorf("Expected objects with a __source key, got: %s", output)
+	}
+}
+
+func TestExportAllCommand(t *testing.T) {
+	resetFlags(t, exportAllCmd)
+	dir := t.TempDir()
+	t.Setenv("XLQ_ALLOWED_PATHS", dir)
+
+	path := filepath.Join(dir, "report.xlsx")
+	f := excelize.NewFile()
+	// Excel forbids "/" in sheet names, but "<" and "|" are unsafe in file names too
+	for _, sheet := range []string{"Q1<Sales", "Q1|Sales", "CON"} {
+		if _, err := f.NewSheet(sheet); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.SetCellValue(sheet, "A1", sheet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	outDir := filepath.Join(dir, "csv")
+	if err := os.Mkdir(outDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"export-all", path, "--out-dir", outDir})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("export-all command failed: %v", err)
+		}
+	})
+	if !strings.Contains(output, `"sheet":"Q1|Sales"`) {
+		t.Errorf("Expected the exported files to be listed, got: %s", output)
+	}
+
+	for name, want := range map[string]string{
+		"Sheet1.csv":     "",
+		"Q1_Sales.csv":   "Q1<Sales\n",
+		"Q1_Sales-2.csv": "Q1|Sales\n",
+		"CON_.csv":       "CON\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil {
+			t.Errorf("Expected %s to be written: %v", name, err)
+			continue
+		}
+		if string(data) != want {
+			t.Errorf("%s: expected %q, got %q", name, want, data)
+		}
+	}
+}
+
+func TestColumnsFlag(t *testing.T) {
+	testFile := createTestFile(t)
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"read", testFile, "--columns", "City,name"}, "City,Name\nNew York,Alice\nBoston,Bob\nChicago,Charlie\n"},
+		{[]string{"head", testFile, "-n", "2", "--columns", "C,A"}, "City,Name\nNew York,Alice\n"},
+		{[]string{"tail", testFile, "-n", "1", "--columns", "B"}, "35\n"},
+	} {
+		resetFlags(t, readCmd)
+		resetFlags(t, headCmd)
+		resetFlags(t, tailCmd)
+		output := captureOutput(t, func() {
+			rootCmd.SetArgs(append(tt.args, "--format", "csv"))
+			if err := rootCmd.Execute(); err != nil {
+				t.Errorf("%s command failed: %v", tt.args[0], err)
+			}
+		})
+		if output != tt.want {
+			t.Errorf("%v: expected %q, got %q", tt.args, tt.want, output)
+		}
+	}
+
+	resetFlags(t, readCmd)
+	rootCmd.SetArgs([]string{"read", testFile, "--columns", "Name,Country"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "Country") {
+		t.Errorf("Expected an error naming the unknown column, got %v", err)
+	}
+}
+
+func TestReadCommandTyped(t *testing.T) {
+	resetFlags(t, readCmd)
+	testFile := createTestFile(t)
+
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"read", testFile, "--typed"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, `["Alice",30,"New York"]`) {
+		t.Errorf("Expected native JSON number for Age, got: %s", output)
+	}
+}
+
+func TestReadCommandNullRepresentation(t *testing.T) {
+	resetFlags(t, readCmd)
+	testFile := createTestFile(t)
+
+	output := captureOutput(t, func() {
+		// Column D is empty, so the range yields one empty cell per row
+		rootCmd.SetArgs([]string{"read", testFile, "A1:D2", "--null-representation", "null"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, `["Name","Age","City",null]`) {
+		t.Errorf("Expected empty cell as JSON null, got: %s", output)
+	}
+}
+
+func TestReadCommandWhere(t *testing.T) {
+	resetFlags(t, readCmd)
+	testFile := createTestFile(t)
+
+	output := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"read", testFile, "--where", "Age>=30"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("read command failed: %v", err)
+		}
+	})
+
+	want := `[["Name","Age","City"],["Alice","30","New York"],["Charlie","35","Chicago"]]`
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected header and rows with Age>=30, got: %s", output)
+	}
+}
+
+func TestNoTrailingNewlineFlag(t *testing.T) {
+	testFile := createTestFile(t)
+	flag := rootCmd.PersistentFlags().Lookup("no-trailing-newline")
+	t.Cleanup(func() {
+		_ = flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+	})
+
+	for _, format := range []string{"json", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			run := func(extra ...string) string {
+				return captureOutput(t, func() {
+					args := append([]string{"sheets", testFile, "--format", format}, extra...)
+					rootCmd.SetArgs(args)
+					if err := rootCmd.Execute(); err != nil {
+						t.Errorf("sheets command failed: %v", err)
+					}
+				})
+			}
+
+			withNewline := run("--no-trailing-newline=false")
+			trimmed := run("--no-trailing-newline")
+
+			if !strings.HasSuffix(withNewline, "\n") {
+				t.Fatalf("expected default output to end with a newline, got: %q", withNewline)
+			}
+			if trimmed != strings.TrimSuffix(withNewline, "\n") {
+				t.Errorf("expected exactly one trailing newline removed, got: %q", trimmed)
+			}
+		})
+	}
+}
+
 func TestFormatFlag(t *testing.T) {
 	testFile := createTestFile(t)
 
@@ -261,3 +784,99 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 	os.Exit(code)
 }
+
+func TestPrettyFlag(t *testing.T) {
+	testFile := createTestFile(t)
+	resetFlags(t, rootCmd)
+	resetFlags(t, headCmd)
+	t.Cleanup(func() { output.SetPrettyJSON(false) })
+
+	out := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"head", "--pretty", "-f", "json", "-n", "1", testFile})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("head --pretty failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "[\n  [\n    \"Name\",") {
+		t.Errorf("expected indented JSON, got %s", out)
+	}
+	var rows [][]string
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		t.Errorf("--pretty output does not parse: %v", err)
+	}
+}
+
+func TestOutputFlag(t *testing.T) {
+	testFile := createTestFile(t)
+	resetFlags(t, rootCmd)
+	resetFlags(t, headCmd)
+	dir := t.TempDir()
+	t.Setenv("XLQ_ALLOWED_PATHS", dir)
+
+	outPath := filepath.Join(dir, "dump.json")
+	out := captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"head", testFile, "-n", "2", "-f", "json", "-o", outPath})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("head -o failed: %v", err)
+		}
+	})
+	if out != "" {
+		t.Errorf("expected nothing on stdout, got %q", out)
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	if want := "[[\"Name\",\"Age\",\"City\"],[\"Alice\",\"30\",\"New York\"]]"; string(data) != want {
+		t.Errorf("expected %q in the output file, got %q", want, data)
+	}
+
+	out = captureOutput(t, func() {
+		rootCmd.SetArgs([]string{"head", testFile, "-n", "1", "-f", "json", "-o", "-"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("head -o - failed: %v", err)
+		}
+	})
+	if !strings.HasPrefix(out, `[["Name"`) {
+		t.Errorf("expected -o - to write to stdout, got %q", out)
+	}
+
+	rootCmd.SetArgs([]string{"head", testFile, "-o", filepath.Join(dir, "dump.exe")})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("expected an error for a file type output cannot write")
+	}
+}
+
+func TestColorFromFlag(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	for color, want := range map[string]bool{"always": true, "never": false, "ALWAYS": true} {
+		got, err := colorFromFlag(color)
+		if err != nil || got != want {
+			t.Errorf("colorFromFlag(%q) = %v, %v; want %v", color, got, err, want)
+		}
+	}
+
+	// Tests write to a pipe, and NO_COLOR wins over a terminal anyway
+	t.Setenv("NO_COLOR", "1")
+	if got, _ := colorFromFlag("auto"); got {
+		t.Error("expected auto to disable color with NO_COLOR set")
+	}
+	if got, _ := colorFromFlag("always"); !got {
+		t.Error("expected always to override NO_COLOR")
+	}
+
+	if _, err := colorFromFlag("sometimes"); err == nil {
+		t.Error("expected error for unknown color mode")
+	}
+}
+
+func TestMCPDefaultAddr(t *testing.T) {
+	flag := mcpCmd.Flags().Lookup("addr")
+	if flag == nil {
+		t.Fatal("mcp has no --addr flag")
+	}
+	if flag.DefValue != "127.0.0.1:8080" {
+		t.Errorf("expected --addr to default to localhost, got %q", flag.DefValue)
+	}
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+	"github.com/xuri/excelize/v2"
+)
+
+// columnsFlagUsage describes the --columns flag of the read commands
+const columnsFlagUsage = "Only these columns, in this order, by letter or header label (e.g. A,C,Name)"
+
+// columnSelection resolves the --columns flag against the sheet's header
+// row, read once. It returns nil when no columns were selected.
+func columnSelection(cmd *cobra.Command, f *excelize.File, sheet string) ([]int, error) {
+	refs, err := cmd.Flags().GetStringSlice("columns")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns flag: %w", err)
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	if maxColumns, _ := cmd.Flags().GetInt("max-columns"); maxColumns > 0 {
+		return nil, fmt.Errorf("--columns cannot be combined with --max-columns")
+	}
+
+	headers, err := xlsx.GetHeaderRow(context.Background(), f, sheet)
+	if err != nil {
+		return nil, err
+	}
+	return xlsx.ResolveColumns(headers, refs)
+}
+
+// selectColumns projects rows down to cols, or returns them unchanged
+// when no columns were selected
+func selectColumns(rows []xlsx.Row, cols []int) []xlsx.Row {
+	if cols == nil {
+		return rows
+	}
+	return xlsx.SelectColumns(rows, cols)
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var combineCmd = &cobra.Command{
+	Use:   "combine <out.xlsx> <file.csv>...",
+	Short: "Combine CSV files into a new workbook, one sheet each",
+	Long: `Create a workbook with one sheet per CSV file, named after the file without its extension.
+Fields that parse as numbers are written as numbers, as with import. When two files share a
+name, the later sheet gets a " (2)" suffix. Each file is limited to the create row limit.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		basepath := GetBasepathFromCmd(cmd)
+		file, err := ResolveWritePath(basepath, args[0])
+		if err != nil {
+			return err
+		}
+
+		csvFiles := make([]string, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			if arg == stdinPath {
+				return fmt.Errorf("combine reads CSV files by name; stdin is not supported")
+			}
+			csvFile, err := ResolveFilePath(basepath, arg)
+			if err != nil {
+				return err
+			}
+			csvFiles = append(csvFiles, csvFile)
+		}
+
+		delimiter, err := cmd.Flags().GetString("delimiter")
+		if err != nil {
+			return fmt.Errorf("failed to get delimiter flag: %w", err)
+		}
+		if delimiter == `\t` {
+			delimiter = "\t"
+		}
+		if utf8.RuneCountInString(delimiter) != 1 {
+			return fmt.Errorf("delimiter must be a single character, got %q", delimiter)
+		}
+		comma, _ := utf8.DecodeRuneInString(delimiter)
+
+		overwrite, err := cmd.Flags().GetBool("overwrite")
+		if err != nil {
+			return fmt.Errorf("failed to get overwrite flag: %w", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		if err := applySanitizeFlag(cmd); err != nil {
+			return err
+		}
+
+		result, err := xlsx.CombineCSV(file, csvFiles, xlsx.CombineOptions{
+			Delimiter: comma,
+			Overwrite: overwrite,
+			DryRun:    dryRun,
+		})
+		if err != nil {
+			return err
+		}
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	combineCmd.Flags().StringP("delimiter", "d", ",", `Field delimiter (use \t for tab)`)
+	combineCmd.Flags().Bool("overwrite", false, "Overwrite existing file")
+	combineCmd.Flags().Bool("sanitize", false, sanitizeFlagUsage)
+	combineCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	rootCmd.AddCommand(combineCmd)
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var compactCmd = &cobra.Command{
+	Use:   "compact <file> [sheet]",
+	Short: "Remove empty rows and columns",
+	Long: `Remove the rows and columns in which every cell is empty, shifting the rest
+up and left to close the gaps. A row or column with a single value or formula is kept.
+
+  xlq compact data.xlsx Sheet1 --rows-only`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := ResolveWritePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		sheet := ""
+		if len(args) > 1 {
+			sheet = args[1]
+		}
+
+		rowsOnly, err := cmd.Flags().GetBool("rows-only")
+		if err != nil {
+			return fmt.Errorf("failed to get rows-only flag: %w", err)
+		}
+		colsOnly, err := cmd.Flags().GetBool("cols-only")
+		if err != nil {
+			return fmt.Errorf("failed to get cols-only flag: %w", err)
+		}
+		if rowsOnly && colsOnly {
+			return fmt.Errorf("--rows-only and --cols-only cannot be used together")
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		result, err := xlsx.Edit(file, dryRun, func(wb *xlsx.Workbook) (*xlsx.CompactResult, error) {
+			result := &xlsx.CompactResult{Success: true}
+			if !colsOnly {
+				rows, err := wb.RemoveEmptyRows(sheet)
+				if err != nil {
+					return nil, err
+				}
+				result.Sheet = rows.Sheet
+				result.RowsRemoved = rows.RowsRemoved
+			}
+			if !rowsOnly {
+				cols, err := wb.RemoveEmptyCols(sheet)
+				if err != nil {
+					return nil, err
+				}
+				result.Sheet = cols.Sheet
+				result.ColsRemoved = cols.ColsRemoved
+			}
+			return result, nil
+		})
+		if err != nil {
+			return err
+		}
+		result.DryRun = dryRun
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	compactCmd.Flags().Bool("rows-only", false, "Remove empty rows only")
+	compactCmd.Flags().Bool("cols-only", false, "Remove empty columns only")
+	compactCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	rootCmd.AddCommand(compactCmd)
+}
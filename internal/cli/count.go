@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var countCmd = &cobra.Command{
+	Use:   "count <file.xlsx> <pattern>",
+	Short: "Count cells matching pattern",
+	Long: `Count the cells matching a pattern, per sheet and in total, with the same matching
+options as search. Matches are counted as they stream, so memory stays constant however
+many cells match.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := searchOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		basepath := GetBasepathFromCmd(cmd)
+		filePath, err := ResolveFilePath(basepath, args[0])
+		if err != nil {
+			return err
+		}
+		f, err := openInput(cmd, filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		result, err := xlsx.Count(context.Background(), f, args[1], opts)
+		if err != nil {
+			return err
+		}
+
+		return output.Print(result, GetFormatFromCmd(cmd), GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	addSearchFlags(countCmd)
+	addOutputFlag(countCmd)
+	rootCmd.AddCommand(countCmd)
+}
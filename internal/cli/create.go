@@ -17,7 +17,7 @@ var createCmd = &cobra.Command{
 	Long:  "Create a new xlsx file with optional headers and initial data.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		file, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+		file, err := ResolveWritePath(GetBasepathFromCmd(cmd), args[0])
 		if err != nil {
 			return err
 		}
@@ -37,6 +37,26 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to get overwrite flag: %w", err)
 		}
 
+		freezeHeader, err := cmd.Flags().GetBool("freeze-header")
+		if err != nil {
+			return fmt.Errorf("failed to get freeze-header flag: %w", err)
+		}
+
+		styleHeaders, err := cmd.Flags().GetBool("style-headers")
+		if err != nil {
+			return fmt.Errorf("failed to get style-headers flag: %w", err)
+		}
+
+		backup, err := cmd.Flags().GetBool("backup")
+		if err != nil {
+			return fmt.Errorf("failed to get backup flag: %w", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
 		dataFile, err := cmd.Flags().GetString("data")
 		if err != nil {
 			return fmt.Errorf("failed to get data flag: %w", err)
@@ -63,13 +83,23 @@ var createCmd = &cobra.Command{
 			}
 		}
 
-		result, err := xlsx.CreateFile(file, sheetName, headers, rows, overwrite)
+		if err := applySanitizeFlag(cmd); err != nil {
+			return err
+		}
+
+		result, err := xlsx.CreateFileWithOptions(file, sheetName, headers, rows, xlsx.CreateFileOptions{
+			Overwrite:    overwrite,
+			FreezeHeader: freezeHeader,
+			StyleHeaders: styleHeaders,
+			DryRun:       dryRun,
+			Backup:       backup && overwrite,
+		})
 		if err != nil {
 			return err
 		}
 
 		format := GetFormatFromCmd(cmd)
-		return output.Print(result, format)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
 	},
 }
 
@@ -77,6 +107,11 @@ func init() {
 	createCmd.Flags().StringP("sheet", "s", "Sheet1", "Name for the first sheet")
 	createCmd.Flags().StringP("headers", "H", "", "Comma-separated header row")
 	createCmd.Flags().BoolP("overwrite", "o", false, "Overwrite existing file")
+	createCmd.Flags().Bool("backup", false, "Copy an existing file to <file>.bak before overwriting it")
+	createCmd.Flags().Bool("freeze-header", false, "Freeze the header row")
+	createCmd.Flags().Bool("style-headers", false, "Make the header row bold with a light fill")
 	createCmd.Flags().StringP("data", "d", "", "JSON file with initial data (array of arrays)")
+	createCmd.Flags().Bool("sanitize", false, sanitizeFlagUsage)
+	createCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
 	rootCmd.AddCommand(createCmd)
 }
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var dedupCmd = &cobra.Command{
+	Use:   "dedup <file> [sheet]",
+	Short: "Remove duplicate rows",
+	Long: `Remove rows that repeat an earlier row, keeping the first occurrence and closing the gaps.
+Compare only some columns with --columns, named by header or letter:
+
+  xlq dedup data.xlsx Sheet1 --columns Email --keep last
+
+The first row is kept in place as the header unless --no-header is given.
+Rows whose compared cells are all empty are never removed.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := ResolveWritePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		sheet := ""
+		if len(args) > 1 {
+			sheet = args[1]
+		}
+
+		columns, err := cmd.Flags().GetStringSlice("columns")
+		if err != nil {
+			return fmt.Errorf("failed to get columns flag: %w", err)
+		}
+		keep, err := cmd.Flags().GetString("keep")
+		if err != nil {
+			return fmt.Errorf("failed to get keep flag: %w", err)
+		}
+		noHeader, err := cmd.Flags().GetBool("no-header")
+		if err != nil {
+			return fmt.Errorf("failed to get no-header flag: %w", err)
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		opts := xlsx.DedupOptions{Columns: columns, Keep: keep, Header: !noHeader}
+		result, err := xlsx.Edit(file, dryRun, func(wb *xlsx.Workbook) (*xlsx.DedupResult, error) {
+			return wb.Dedup(sheet, opts)
+		})
+		if err != nil {
+			return err
+		}
+		result.DryRun = dryRun
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	dedupCmd.Flags().StringSlice("columns", nil, "Key columns by header or letter, comma-separated (default: the whole row)")
+	dedupCmd.Flags().String("keep", xlsx.KeepFirst, "Occurrence to keep: first or last")
+	dedupCmd.Flags().Bool("no-header", false, "Compare the first row too instead of keeping it as the header")
+	dedupCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	rootCmd.AddCommand(dedupCmd)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old.xlsx> <new.xlsx>",
+	Short: "Compare two sheets cell by cell",
+	Long: `Compare a sheet in one file with a sheet in another and list the cells that changed, were added or were removed.
+Pass the same file twice with --sheet and --new-sheet to compare two sheets of one workbook.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sheet, _ := cmd.Flags().GetString("sheet")
+		newSheet, _ := cmd.Flags().GetString("new-sheet")
+		ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
+		ignoreWhitespace, _ := cmd.Flags().GetBool("ignore-whitespace")
+		max, _ := cmd.Flags().GetInt("max")
+		if !cmd.Flags().Changed("new-sheet") {
+			newSheet = sheet
+		}
+
+		basepath := GetBasepathFromCmd(cmd)
+		oldPath, err := ResolveFilePath(basepath, args[0])
+		if err != nil {
+			return err
+		}
+		newPath, err := ResolveFilePath(basepath, args[1])
+		if err != nil {
+			return err
+		}
+		if err := checkSingleStdin(oldPath, newPath); err != nil {
+			return err
+		}
+
+		oldF, err := openInput(cmd, oldPath)
+		if err != nil {
+			return err
+		}
+		defer oldF.Close()
+
+		newF, err := openInput(cmd, newPath)
+		if err != nil {
+			return err
+		}
+		defer newF.Close()
+
+		result, err := xlsx.DiffSheets(context.Background(), oldF, sheet, newF, newSheet, xlsx.DiffOptions{
+			IgnoreCase:       ignoreCase,
+			IgnoreWhitespace: ignoreWhitespace,
+			MaxResults:       max,
+		})
+		if err != nil {
+			return err
+		}
+
+		out, err := output.FormatSingle(GetFormatFromCmd(cmd), result)
+		if err != nil {
+			return err
+		}
+
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringP("sheet", "s", "", "Sheet to compare (default: first sheet)")
+	diffCmd.Flags().String("new-sheet", "", "Sheet in the new file, if named differently (default: --sheet)")
+	diffCmd.Flags().BoolP("ignore-case", "i", false, "Treat values differing only in case as equal")
+	diffCmd.Flags().BoolP("ignore-whitespace", "w", false, "Ignore leading, trailing and repeated whitespace")
+	diffCmd.Flags().IntP("max", "m", 0, "Maximum differences (0 = unlimited)")
+	addOutputFlag(diffCmd)
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <file.xlsx> [sheet] -o <out.csv>",
+	Short: "Export a sheet to a CSV, TSV or JSON file",
+	Long: `Stream a sheet to a file on disk, one row at a time, so large sheets are never held in memory.
+The export format comes from the output file's extension (.csv, .tsv, .json, or .ndjson/.jsonl) unless --type is given.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		basepath := GetBasepathFromCmd(cmd)
+		filePath, err := ResolveFilePath(basepath, args[0])
+		if err != nil {
+			return err
+		}
+
+		outPath, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return fmt.Errorf("failed to get output flag: %w", err)
+		}
+		if outPath == "" {
+			return fmt.Errorf("an output file is required (use -o)")
+		}
+		outPath, err = ResolveFilePath(basepath, outPath)
+		if err != nil {
+			return err
+		}
+
+		exportFormat, err := cmd.Flags().GetString("type")
+		if err != nil {
+			return fmt.Errorf("failed to get type flag: %w", err)
+		}
+		if exportFormat == "" {
+			exportFormat, err = output.ExportFormatForPath(outPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		f, err := openInput(cmd, filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		sheet := ""
+		if len(args) > 1 {
+			sheet = args[1]
+		}
+
+		result, err := output.ExportSheet(context.Background(), f, sheet, outPath, exportFormat)
+		if err != nil {
+			return err
+		}
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringP("output", "o", "", "File to write the sheet to")
+	exportCmd.Flags().StringP("type", "t", "", "Export format: csv, tsv, json or ndjson (default: from the output file extension)")
+	rootCmd.AddCommand(exportCmd)
+}
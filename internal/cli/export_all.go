@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fuabioo/xlq/internal/mcp"
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var exportAllCmd = &cobra.Command{
+	Use:   "export-all <file.xlsx> --out-dir <dir>",
+	Short: "Export every sheet to its own CSV file",
+	Long: `Stream each sheet of a workbook to a file in an existing directory, one row at a time.
+Files are named after their sheet. Characters that are unsafe in file names become "_",
+and names that would still collide get a numeric suffix, e.g. "Q1_Sales-2.csv".
+Existing files with the same names are replaced. Every target is checked before anything is written.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		basepath := GetBasepathFromCmd(cmd)
+		filePath, err := ResolveFilePath(basepath, args[0])
+		if err != nil {
+			return err
+		}
+
+		outDir, err := cmd.Flags().GetString("out-dir")
+		if err != nil {
+			return fmt.Errorf("failed to get out-dir flag: %w", err)
+		}
+		if outDir == "" {
+			return fmt.Errorf("an output directory is required (use --out-dir)")
+		}
+		outDir, err = ResolveFilePath(basepath, outDir)
+		if err != nil {
+			return err
+		}
+
+		exportFormat, err := cmd.Flags().GetString("type")
+		if err != nil {
+			return fmt.Errorf("failed to get type flag: %w", err)
+		}
+		exportFormat = strings.ToLower(exportFormat)
+		switch output.Format(exportFormat) {
+		case output.FormatCSV, output.FormatTSV, output.FormatJSON, output.FormatNDJSON:
+		default:
+			return fmt.Errorf("unsupported export format: %s (valid: csv, tsv, json, ndjson)", exportFormat)
+		}
+
+		f, err := openInput(cmd, filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		sheets, err := xlsx.GetSheets(f)
+		if err != nil {
+			return err
+		}
+
+		// Check every target before writing, so a refused name leaves no
+		// partial export behind
+		if err := mcp.LoadAllowedPathsFromEnv(); err != nil {
+			return err
+		}
+		names := output.ExportFileNames(sheets, "."+exportFormat)
+		paths := make([]string, len(names))
+		for i, name := range names {
+			if paths[i], err = mcp.ValidateExportPath(filepath.Join(outDir, name), true); err != nil {
+				return fmt.Errorf("cannot export sheet %s: %w", sheets[i], err)
+			}
+		}
+
+		result := &output.ExportAllResult{Success: true, Dir: outDir, Files: make([]output.ExportResult, 0, len(sheets))}
+		for i, sheet := range sheets {
+			exported, err := output.ExportSheet(context.Background(), f, sheet, paths[i], exportFormat)
+			if err != nil {
+				return fmt.Errorf("failed to export sheet %s: %w", sheet, err)
+			}
+			result.Files = append(result.Files, *exported)
+		}
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	exportAllCmd.Flags().String("out-dir", "", "Existing directory to write one file per sheet to")
+	exportAllCmd.Flags().StringP("type", "t", "csv", "Export format: csv, tsv, json or ndjson")
+	rootCmd.AddCommand(exportAllCmd)
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var fillCmd = &cobra.Command{
+	Use:   "fill <file> <column> [sheet]",
+	Short: "Forward-fill blank cells in a column",
+	Long: `Fill each blank cell of a column with the last non-empty value above it.
+Name the column by header or letter; by header, the header row is left alone.
+
+  xlq fill data.xlsx Region
+  xlq fill data.xlsx B Sheet2 --rows 2:500 --direction up
+
+Blank cells before the first value stay blank.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := ResolveWritePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		sheet := ""
+		if len(args) > 2 {
+			sheet = args[2]
+		}
+
+		rows, err := cmd.Flags().GetString("rows")
+		if err != nil {
+			return fmt.Errorf("failed to get rows flag: %w", err)
+		}
+		direction, err := cmd.Flags().GetString("direction")
+		if err != nil {
+			return fmt.Errorf("failed to get direction flag: %w", err)
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		opts := xlsx.FillOptions{Column: args[1], Rows: rows, Direction: direction}
+		result, err := xlsx.Edit(file, dryRun, func(wb *xlsx.Workbook) (*xlsx.FillResult, error) {
+			return wb.Fill(sheet, opts)
+		})
+		if err != nil {
+			return err
+		}
+		result.DryRun = dryRun
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	fillCmd.Flags().String("rows", "", "Row range to fill, e.g. 2:500 (default: the used rows)")
+	fillCmd.Flags().String("direction", xlsx.FillDown, "Fill direction: down or up")
+	fillCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	rootCmd.AddCommand(fillCmd)
+}
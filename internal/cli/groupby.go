@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var groupbyCmd = &cobra.Command{
+	Use:   "groupby <file.xlsx> [sheet] --group <column> --agg <column:op>",
+	Short: "Aggregate columns per group",
+	Long: `Group rows by the values of one column and aggregate other columns per group with sum, avg, min, max or count.
+The sheet must start with a header row. Columns are header labels or letters; --agg may be repeated.
+Non-numeric cells in an aggregated column are skipped and reported on stderr.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		group, _ := cmd.Flags().GetString("group")
+		aggSpecs, _ := cmd.Flags().GetStringArray("agg")
+		where, _ := cmd.Flags().GetString("where")
+		if group == "" {
+			return fmt.Errorf("a group column is required (use --group)")
+		}
+
+		opts := xlsx.GroupByOptions{Group: group}
+		if len(args) > 1 {
+			opts.Sheet = args[1]
+		}
+		for _, spec := range aggSpecs {
+			agg, err := xlsx.ParseGroupAgg(spec)
+			if err != nil {
+				return err
+			}
+			opts.Aggs = append(opts.Aggs, agg)
+		}
+		if where != "" {
+			pred, err := xlsx.ParsePredicate(where)
+			if err != nil {
+				return err
+			}
+			opts.Where = pred
+		}
+
+		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		f, err := openInput(cmd, filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		result, err := xlsx.GroupBy(context.Background(), f, opts)
+		if err != nil {
+			return err
+		}
+		for i, ignored := range result.Ignored {
+			if ignored > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: %s skipped %d non-numeric cells\n", result.Headers[i+1], ignored)
+			}
+		}
+
+		data := append([][]string{result.Headers}, result.Rows...)
+		out, err := output.FormatRows(GetFormatFromCmd(cmd), data)
+		if err != nil {
+			return err
+		}
+
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	groupbyCmd.Flags().StringP("group", "g", "", "Column to group by: header label or letter (required)")
+	groupbyCmd.Flags().StringArrayP("agg", "a", nil, "Aggregation as column:op, e.g. Amount:sum (ops: sum, avg, min, max, count; repeatable)")
+	groupbyCmd.Flags().String("where", "", "Only group rows where a column matches, e.g. 'Year==2024'")
+	addOutputFlag(groupbyCmd)
+	rootCmd.AddCommand(groupbyCmd)
+}
@@ -2,8 +2,6 @@ package cli
 
 import (
 	"context"
-	"fmt"
-	"os"
 
 	"github.com/fuabioo/xlq/internal/output"
 	"github.com/fuabioo/xlq/internal/xlsx"
@@ -16,12 +14,13 @@ var headCmd = &cobra.Command{
 	Args:  cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		n, _ := cmd.Flags().GetInt("number")
+		maxColumns, _ := cmd.Flags().GetInt("max-columns")
 
 		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
 		if err != nil {
 			return err
 		}
-		f, err := xlsx.OpenFile(filePath)
+		f, err := openInput(cmd, filePath)
 		if err != nil {
 			return err
 		}
@@ -37,9 +36,15 @@ var headCmd = &cobra.Command{
 			}
 		}
 
-		ctx := context.Background()
+		cols, err := columnSelection(cmd, f, sheet)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
-		ch, err := xlsx.StreamHead(ctx, f, sheet, n)
+		ch, err := xlsx.StreamHeadWithOptions(ctx, f, sheet, n, cleanupOptions(cmd))
 		if err != nil {
 			return err
 		}
@@ -48,6 +53,8 @@ var headCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		rows = selectColumns(rows, cols)
+		rows, _ = xlsx.LimitColumns(rows, maxColumns)
 
 		data := xlsx.RowsToStringSlice(rows)
 		out, err := output.FormatRows(GetFormatFromCmd(cmd), data)
@@ -55,12 +62,16 @@ var headCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Fprint(os.Stdout, string(out))
-		return nil
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
 	},
 }
 
 func init() {
 	headCmd.Flags().IntP("number", "n", 10, "Number of rows to show")
+	headCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
+	headCmd.Flags().Bool("skip-empty", false, skipEmptyFlagUsage)
+	headCmd.Flags().Bool("trim", false, trimFlagUsage)
+	headCmd.Flags().Int("max-columns", 0, "Keep only the first N columns of each row (0 = no limit)")
+	addOutputFlag(headCmd)
 	rootCmd.AddCommand(headCmd)
 }
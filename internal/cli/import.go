@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file> <csv-file>",
+	Short: "Import a CSV file into a sheet",
+	Long: `Import CSV records into a sheet after its last row with data.
+The sheet is created if it does not exist. Use - as the CSV file to read from stdin.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		basepath := GetBasepathFromCmd(cmd)
+		file, err := ResolveWritePath(basepath, args[0])
+		if err != nil {
+			return err
+		}
+
+		var source io.Reader = cmd.InOrStdin()
+		if args[1] != "-" {
+			csvFile, err := ResolveFilePath(basepath, args[1])
+			if err != nil {
+				return err
+			}
+			cf, err := os.Open(csvFile)
+			if err != nil {
+				return fmt.Errorf("failed to open CSV file: %w", err)
+			}
+			defer cf.Close()
+			source = cf
+		}
+
+		sheet, err := cmd.Flags().GetString("sheet")
+		if err != nil {
+			return fmt.Errorf("failed to get sheet flag: %w", err)
+		}
+
+		delimiter, err := cmd.Flags().GetString("delimiter")
+		if err != nil {
+			return fmt.Errorf("failed to get delimiter flag: %w", err)
+		}
+		if delimiter == `\t` {
+			delimiter = "\t"
+		}
+		if utf8.RuneCountInString(delimiter) != 1 {
+			return fmt.Errorf("delimiter must be a single character, got %q", delimiter)
+		}
+		comma, _ := utf8.DecodeRuneInString(delimiter)
+
+		header, err := cmd.Flags().GetBool("header")
+		if err != nil {
+			return fmt.Errorf("failed to get header flag: %w", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		if err := applySanitizeFlag(cmd); err != nil {
+			return err
+		}
+
+		opts := xlsx.ImportOptions{Delimiter: comma, Header: header}
+		result, err := xlsx.Edit(file, dryRun, func(wb *xlsx.Workbook) (*xlsx.ImportResult, error) {
+			return wb.ImportCSV(source, sheet, opts)
+		})
+		if err != nil {
+			return err
+		}
+		result.DryRun = dryRun
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	importCmd.Flags().StringP("sheet", "s", "", "Target sheet, created if missing (default: first sheet)")
+	importCmd.Flags().StringP("delimiter", "d", ",", `Field delimiter (use \t for tab)`)
+	importCmd.Flags().Bool("header", false, "First record is a header; skipped when the sheet already has rows")
+	importCmd.Flags().Bool("sanitize", false, sanitizeFlagUsage)
+	importCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	rootCmd.AddCommand(importCmd)
+}
@@ -1,9 +1,6 @@
 package cli
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/fuabioo/xlq/internal/output"
 	"github.com/fuabioo/xlq/internal/xlsx"
 	"github.com/spf13/cobra"
@@ -18,7 +15,7 @@ var infoCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		f, err := xlsx.OpenFile(filePath)
+		f, err := openInput(cmd, filePath)
 		if err != nil {
 			return err
 		}
@@ -45,11 +42,11 @@ var infoCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Fprint(os.Stdout, string(out))
-		return nil
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
 	},
 }
 
 func init() {
+	addOutputFlag(infoCmd)
 	rootCmd.AddCommand(infoCmd)
 }
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var joinCmd = &cobra.Command{
+	Use:   "join <left.xlsx> <right.xlsx> --on <column> -o <out.xlsx>",
+	Short: "Join two sheets on a key column into a new file",
+	Long: `Merge the rows of a sheet in right.xlsx into a sheet in left.xlsx by matching a key column, VLOOKUP-style,
+and write the combined rows to a new xlsx file. Both sheets must start with a header row. Key columns are
+header labels or column letters. A left join keeps unmatched left rows with the looked-up cells blank;
+an inner join drops them. Pass the same file twice with --left-sheet and --right-sheet to join two sheets of one workbook.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		leftSheet, _ := cmd.Flags().GetString("left-sheet")
+		rightSheet, _ := cmd.Flags().GetString("right-sheet")
+		on, _ := cmd.Flags().GetString("on")
+		rightOn, _ := cmd.Flags().GetString("right-on")
+		joinType, _ := cmd.Flags().GetString("type")
+		strict, _ := cmd.Flags().GetBool("strict")
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		basepath := GetBasepathFromCmd(cmd)
+		leftPath, err := ResolveFilePath(basepath, args[0])
+		if err != nil {
+			return err
+		}
+		rightPath, err := ResolveFilePath(basepath, args[1])
+		if err != nil {
+			return err
+		}
+		if err := checkSingleStdin(leftPath, rightPath); err != nil {
+			return err
+		}
+
+		outPath, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return fmt.Errorf("failed to get output flag: %w", err)
+		}
+		if outPath == "" {
+			return fmt.Errorf("an output file is required (use -o)")
+		}
+		outPath, err = ResolveFilePath(basepath, outPath)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(outPath); err == nil && !overwrite {
+			return fmt.Errorf("output file already exists: %s (use --overwrite to replace it)", outPath)
+		}
+
+		left, err := openInput(cmd, leftPath)
+		if err != nil {
+			return err
+		}
+		defer left.Close()
+
+		right, err := openInput(cmd, rightPath)
+		if err != nil {
+			return err
+		}
+		defer right.Close()
+
+		result, err := xlsx.JoinSheets(context.Background(), left, right, outPath, xlsx.JoinOptions{
+			LeftSheet:        leftSheet,
+			RightSheet:       rightSheet,
+			LeftKey:          on,
+			RightKey:         rightOn,
+			Type:             joinType,
+			ErrorOnDuplicate: strict,
+			DryRun:           dryRun,
+		})
+		if err != nil {
+			return err
+		}
+
+		return output.Print(result, GetFormatFromCmd(cmd), GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	joinCmd.Flags().String("on", "", "Key column in the left sheet: header label or letter (required)")
+	joinCmd.Flags().String("right-on", "", "Key column in the right sheet, if different (default: --on)")
+	joinCmd.Flags().String("left-sheet", "", "Sheet in the left file (default: first sheet)")
+	joinCmd.Flags().String("right-sheet", "", "Sheet in the right file (default: first sheet)")
+	joinCmd.Flags().StringP("type", "t", xlsx.JoinLeft, "Join type: left or inner")
+	joinCmd.Flags().Bool("strict", false, "Fail when a key repeats in the right sheet instead of using its first row")
+	joinCmd.Flags().StringP("output", "o", "", "xlsx file to write the joined rows to")
+	joinCmd.Flags().Bool("overwrite", false, "Replace the output file if it exists")
+	joinCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	rootCmd.AddCommand(joinCmd)
+}
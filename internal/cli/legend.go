@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var legendCmd = &cobra.Command{
+	Use:   "legend <file.xlsx> [sheet]",
+	Short: "Map column letters to headers",
+	Long:  `Print a map of column letters to header names from the first row, e.g. {"A":"Name","B":"Age"}.`,
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		f, err := openInput(cmd, filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		sheet := ""
+		if len(args) > 1 {
+			sheet = args[1]
+		}
+
+		legend, err := xlsx.GetLegend(context.Background(), f, sheet)
+		if err != nil {
+			return err
+		}
+
+		out, err := output.FormatSingle(GetFormatFromCmd(cmd), legend)
+		if err != nil {
+			return err
+		}
+
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	addOutputFlag(legendCmd)
+	rootCmd.AddCommand(legendCmd)
+}
@@ -5,14 +5,33 @@ import (
 	"log"
 
 	"github.com/fuabioo/xlq/internal/mcp"
+	"github.com/fuabioo/xlq/internal/xlsx"
 	"github.com/spf13/cobra"
 )
 
+// defaultMCPAddr keeps the unauthenticated HTTP transport on localhost
+const defaultMCPAddr = "127.0.0.1:8080"
+
 var mcpCmd = &cobra.Command{
 	Use:   "mcp",
-	Short: "Run as MCP server (stdio)",
-	Long:  `Run xlq as a Model Context Protocol server using stdio transport.`,
+	Short: "Run as MCP server (stdio or HTTP/SSE)",
+	Long: `Run xlq as a Model Context Protocol server. The default transport is
+stdio; use --transport http to serve HTTP with SSE on 127.0.0.1:8080 instead.
+The HTTP transport has no authentication, so it only listens on localhost
+unless --addr says otherwise.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		transport, err := cmd.Flags().GetString("transport")
+		if err != nil {
+			return fmt.Errorf("failed to get transport flag: %w", err)
+		}
+		addr, err := cmd.Flags().GetString("addr")
+		if err != nil {
+			return fmt.Errorf("failed to get addr flag: %w", err)
+		}
+		if transport != "stdio" && transport != "http" {
+			return fmt.Errorf("invalid transport %q (must be stdio or http)", transport)
+		}
+
 		allowedPaths, err := cmd.Flags().GetStringSlice("allowed-paths")
 		if err != nil {
 			return fmt.Errorf("failed to get allowed-paths flag: %w", err)
@@ -43,9 +62,49 @@ var mcpCmd = &cobra.Command{
 			}
 		}
 
+		// Optional XLQ_READ_EXTENSIONS override for the read extension allowlist
+		mcp.LoadReadExtensionsFromEnv()
+
+		// Optional XLQ_WRITE_EXTENSIONS override for the write extension allowlist
+		mcp.LoadWriteExtensionsFromEnv()
+
+		// Optional XLQ_BLOCKED_PATTERNS added to the default blocked write patterns
+		mcp.LoadBlockedPatternsFromEnv()
+
+		// Optional XLQ_MAX_* overrides for the output cap and write limits;
+		// invalid values fall back to the defaults
+		if err := mcp.LoadLimitsFromEnv(); err != nil {
+			log.Printf("xlq MCP server: %v", err)
+		}
+
+		// Neutralize formula-like strings from untrusted clients unless disabled
+		sanitize, err := cmd.Flags().GetBool("sanitize")
+		if err != nil {
+			return fmt.Errorf("failed to get sanitize flag: %w", err)
+		}
+		xlsx.SetSanitizeStrings(sanitize)
+
+		// Read-only mode drops every write tool; the flag or XLQ_READ_ONLY enables it
+		readOnly, err := cmd.Flags().GetBool("read-only")
+		if err != nil {
+			return fmt.Errorf("failed to get read-only flag: %w", err)
+		}
+		if readOnly {
+			mcp.SetReadOnly(true)
+		} else {
+			mcp.LoadReadOnlyFromEnv()
+		}
+
 		log.Printf("xlq MCP server allowed paths: %v", mcp.GetAllowedBasePaths())
+		if mcp.IsReadOnly() {
+			log.Printf("xlq MCP server is read-only: write tools are disabled")
+		}
 
 		srv := mcp.New(basepath)
+		if transport == "http" {
+			log.Printf("xlq MCP server listening on %s (HTTP/SSE)", addr)
+			return srv.RunHTTP(addr)
+		}
 		return srv.Run()
 	},
 }
@@ -54,4 +113,8 @@ func init() {
 	rootCmd.AddCommand(mcpCmd)
 	mcpCmd.Flags().StringSlice("allowed-paths", nil,
 		"Additional directories to allow file access (comma-separated or repeated, e.g. --allowed-paths /tmp,/data)")
+	mcpCmd.Flags().Bool("sanitize", true, sanitizeFlagUsage+" (disable with --sanitize=false)")
+	mcpCmd.Flags().String("transport", "stdio", "Transport to serve: stdio or http (HTTP with SSE)")
+	mcpCmd.Flags().String("addr", defaultMCPAddr, "Address to listen on with --transport http; other interfaces (e.g. :8080) expose every tool, unauthenticated, to anyone who can reach them")
+	mcpCmd.Flags().Bool("read-only", false, "Disable all write tools so no file can be modified (env: XLQ_READ_ONLY)")
 }
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var namesCmd = &cobra.Command{
+	Use:   "names <file.xlsx>",
+	Short: "List defined names in workbook",
+	Long: `List the workbook's defined names and the ranges they refer to.
+Sheet-scoped names are shown as Sheet!Name. Any name can be passed to 'xlq read' in place of a range.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		f, err := openInput(cmd, filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		out, err := output.FormatSingle(GetFormatFromCmd(cmd), xlsx.GetNamedRanges(f))
+		if err != nil {
+			return err
+		}
+
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	addOutputFlag(namesCmd)
+	rootCmd.AddCommand(namesCmd)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+// outputFlagAnnotation marks the commands addOutputFlag registered
+// -o/--output on. export and join have an --output flag of their own,
+// naming the file they produce rather than where their result goes.
+const outputFlagAnnotation = "xlq_output_flag"
+
+// addOutputFlag registers -o/--output on a read command, sending its
+// formatted output to a file instead of stdout
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", "", "Write output to this file instead of stdout (- for stdout)")
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[outputFlagAnnotation] = "true"
+}
+
+// outputPathFromCmd returns the file given to -o/--output, or "" when
+// output goes to stdout
+func outputPathFromCmd(cmd *cobra.Command) string {
+	if cmd.Annotations[outputFlagAnnotation] == "" {
+		return ""
+	}
+	path, _ := cmd.Flags().GetString("output")
+	if path == stdinPath {
+		return ""
+	}
+	return path
+}
+
+// resolveOutputFlag checks the -o/--output file with the same rules as
+// other writes, before the command does any work, and replaces the flag
+// value with the validated absolute path
+func resolveOutputFlag(cmd *cobra.Command) error {
+	path := outputPathFromCmd(cmd)
+	if path == "" {
+		return nil
+	}
+	valid, err := validateOutputFile(cmd, path)
+	if err != nil {
+		return err
+	}
+	return cmd.Flags().Set("output", valid)
+}
+
+// validateOutputFile resolves a file a read command writes its results to
+// and checks it with the same rules as other writes, returning the
+// validated absolute path
+func validateOutputFile(cmd *cobra.Command, path string) (string, error) {
+	resolved, err := ResolveFilePath(GetBasepathFromCmd(cmd), path)
+	if err != nil {
+		return "", err
+	}
+	if err := mcp.LoadAllowedPathsFromEnv(); err != nil {
+		return "", err
+	}
+	valid, err := mcp.ValidateOutputPath(resolved)
+	if err != nil {
+		return "", fmt.Errorf("cannot write output to %s: %w", path, err)
+	}
+	return valid, nil
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var pivotCmd = &cobra.Command{
+	Use:   "pivot <file.xlsx> [sheet] --rows <column> --cols <column> --values <column>",
+	Short: "Summarize a column by two others as a pivot table",
+	Long: `Build a two-dimensional summary: one row per value of --rows, one column per value of --cols,
+and each cell the aggregate of --values over the matching rows, e.g. the sum of Amount by Region and Month.
+
+  xlq pivot sales.xlsx --rows Region --cols Month --values Amount --op sum
+
+The sheet must start with a header row; fields are header labels or letters. --op is sum (default), avg,
+min, max or count (numeric cells). Row and column keys are sorted, numbers first. Combinations with no data
+are blank unless --fill is given, e.g. --fill 0. The table is printed, or written to a new sheet of the same
+file with --to-sheet.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := xlsx.PivotOptions{}
+		opts.Rows, _ = cmd.Flags().GetString("rows")
+		opts.Columns, _ = cmd.Flags().GetString("cols")
+		opts.Values, _ = cmd.Flags().GetString("values")
+		opts.Op, _ = cmd.Flags().GetString("op")
+		opts.Fill, _ = cmd.Flags().GetString("fill")
+		where, _ := cmd.Flags().GetString("where")
+		toSheet, _ := cmd.Flags().GetString("to-sheet")
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+		if len(args) > 1 {
+			opts.Sheet = args[1]
+		}
+		if where != "" {
+			pred, err := xlsx.ParsePredicate(where)
+			if err != nil {
+				return err
+			}
+			opts.Where = pred
+		}
+
+		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+
+		if toSheet != "" {
+			if filePath == stdinPath {
+				return fmt.Errorf("--to-sheet cannot modify a workbook read from stdin")
+			}
+			result, err := xlsx.Edit(filePath, dryRun, func(wb *xlsx.Workbook) (*xlsx.PivotWriteResult, error) {
+				return wb.WritePivot(toSheet, opts)
+			})
+			if err != nil {
+				return err
+			}
+			result.DryRun = dryRun
+			return output.Print(result, GetFormatFromCmd(cmd), GetPrintOptionsFromCmd(cmd))
+		}
+
+		f, err := openInput(cmd, filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		result, err := xlsx.Pivot(context.Background(), f, opts)
+		if err != nil {
+			return err
+		}
+		if result.Ignored > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: skipped %d non-numeric cells in %s\n", result.Ignored, opts.Values)
+		}
+
+		data := append([][]string{result.Headers}, result.Rows...)
+		out, err := output.FormatRows(GetFormatFromCmd(cmd), data)
+		if err != nil {
+			return err
+		}
+
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	pivotCmd.Flags().String("rows", "", "Column whose values become the rows (required)")
+	pivotCmd.Flags().String("cols", "", "Column whose values become the columns (required)")
+	pivotCmd.Flags().String("values", "", "Column to aggregate into each cell (required)")
+	pivotCmd.Flags().String("op", xlsx.AggregateSum, "Aggregation: sum, avg, min, max or count")
+	pivotCmd.Flags().String("fill", "", "Value for combinations with no data (default: blank)")
+	pivotCmd.Flags().String("where", "", "Only summarize rows where a column matches, e.g. 'Year==2024'")
+	pivotCmd.Flags().String("to-sheet", "", "Write the pivot to this new sheet instead of printing it")
+	pivotCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	addOutputFlag(pivotCmd)
+	rootCmd.AddCommand(pivotCmd)
+}
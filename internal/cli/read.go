@@ -4,109 +4,350 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/fuabioo/xlq/internal/mcp"
 	"github.com/fuabioo/xlq/internal/output"
 	"github.com/fuabioo/xlq/internal/xlsx"
 	"github.com/spf13/cobra"
+	"github.com/xuri/excelize/v2"
 )
 
 var readCmd = &cobra.Command{
 	Use:   "read <file.xlsx> [sheet] [range]",
 	Short: "Read cell range",
-	Long:  `Read cells from a range (e.g., A1:C10). If no range specified, reads entire sheet.`,
-	Args:  cobra.RangeArgs(1, 3),
+	Long: `Read cells from a range (e.g., A1:C10). If no range specified, reads entire sheet.
+Whole columns (A:C) and ranges open at the bottom (A5:C) end at the last row with data;
+whole rows (2:5) give each row's cells up to its last value.
+Ranges copied from Excel may include a sheet name and $ anchors (e.g., 'Sheet1!$A$1:$C$10').
+A defined name (see 'xlq names') can be given in place of a range.
+
+A quoted glob reads the same sheet from every matching file, tagging each row
+with its file name in a leading __source column (a __source key with --objects):
+
+  xlq read 'data/*.xlsx' Sheet1`,
+	Args: cobra.RangeArgs(1, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
 		if err != nil {
 			return err
 		}
-		f, err := xlsx.OpenFile(filePath)
+		if isGlobPattern(args[0]) {
+			// A file whose name merely contains a glob character is read as is
+			if _, err := os.Stat(filePath); err != nil {
+				return readGlob(cmd, args)
+			}
+		}
+		f, err := openInput(cmd, filePath)
 		if err != nil {
 			return err
 		}
 		defer f.Close()
 
-		sheet := ""
-		rangeStr := ""
+		result, err := readSheet(cmd, f, args[1:])
+		if err != nil {
+			return err
+		}
+		result.warn("")
 
-		if len(args) > 1 {
-			// Could be sheet name or range
-			if xlsx.IsValidRange(args[1]) {
-				rangeStr = args[1]
-			} else {
-				sheet = args[1]
-			}
+		out, err := formatRead(cmd, result.rows, result.objects)
+		if err != nil {
+			return err
 		}
-		if len(args) > 2 {
-			rangeStr = args[2]
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+// sheetRead holds the rows read from one sheet, and their objects when
+// --objects is set
+type sheetRead struct {
+	rows             []xlsx.Row
+	objects          []xlsx.Object
+	truncated        bool
+	columnsTruncated bool
+	maxColumns       int
+}
+
+// warn prints the truncation warnings of a read to stderr, prefixed with
+// the source file when reading several
+func (r *sheetRead) warn(source string) {
+	prefix := ""
+	if source != "" {
+		prefix = source + ": "
+	}
+	if r.truncated {
+		fmt.Fprintf(os.Stderr, "Warning: %sOutput truncated at limit (use --limit to adjust)\n", prefix)
+	}
+	if r.columnsTruncated {
+		fmt.Fprintf(os.Stderr, "Warning: %sRows truncated to %d columns (use --max-columns to adjust)\n", prefix, r.maxColumns)
+	}
+}
+
+// readSheet reads the rows selected by the read command's sheet and range
+// arguments and flags from an open workbook
+func readSheet(cmd *cobra.Command, f *excelize.File, args []string) (*sheetRead, error) {
+	sheet := ""
+	rangeStr := ""
+
+	if len(args) > 0 {
+		// Could be sheet name, range (possibly sheet-qualified) or a
+		// defined name; a sheet wins over a defined name of the same name
+		if refSheet, r, err := xlsx.SplitQualifiedRange(args[0]); err == nil {
+			sheet, rangeStr = refSheet, r
+		} else if nameSheet, r, err := xlsx.LookupNamedRange(f, args[0]); err == nil && !xlsx.SheetExists(f, args[0]) {
+			sheet, rangeStr = nameSheet, r
+		} else {
+			sheet = args[0]
+		}
+	}
+	if len(args) > 1 {
+		refSheet, r, err := xlsx.ResolveRangeRef(f, args[1])
+		if err != nil {
+			return nil, err
 		}
+		if refSheet != "" {
+			sheet = refSheet
+		}
+		rangeStr = r
+	}
 
-		// Resolve sheet name
-		if sheet == "" {
-			sheet, err = xlsx.GetDefaultSheet(f)
-			if err != nil {
-				return err
-			}
+	// Resolve sheet name, using the default sheet if none was given
+	sheet, err := xlsx.ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Optional row filter, applied while streaming
+	where, _ := cmd.Flags().GetString("where")
+	var pred *xlsx.Predicate
+	if where != "" {
+		pred, err = xlsx.ParsePredicate(where)
+		if err != nil {
+			return nil, err
 		}
+		headers, err := xlsx.GetHeaderRow(ctx, f, sheet)
+		if err != nil {
+			return nil, err
+		}
+		if err := pred.Resolve(headers); err != nil {
+			return nil, err
+		}
+	}
+	filter := func(ch <-chan xlsx.RowResult) <-chan xlsx.RowResult {
+		if pred == nil {
+			return ch
+		}
+		return xlsx.FilterRows(streamCtx, ch, pred, true)
+	}
 
-		ctx := context.Background()
+	mergedFill, _ := cmd.Flags().GetBool("merged-fill")
+	visibleOnly, _ := cmd.Flags().GetBool("visible-only")
+	raw, _ := cmd.Flags().GetBool("raw")
+	streamOpts := cleanupOptions(cmd)
+	streamOpts.MergedFill, streamOpts.VisibleOnly, streamOpts.RawValues = mergedFill, visibleOnly, raw
 
-		var rows []xlsx.Row
-		var truncated bool
+	result := &sheetRead{}
 
-		if rangeStr != "" {
-			// Specific range - no limit needed
-			ch, err := xlsx.StreamRange(ctx, f, sheet, rangeStr)
-			if err != nil {
-				return err
-			}
-			rows, err = xlsx.CollectRows(ch)
+	if rangeStr != "" {
+		// Specific range - no limit needed
+		ch, err := xlsx.StreamRangeWithOptions(streamCtx, f, sheet, rangeStr, streamOpts)
+		if err != nil {
+			return nil, err
+		}
+		result.rows, err = xlsx.CollectRows(filter(ch))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Full sheet - apply limit
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			return nil, err
+		}
+
+		ch, err := xlsx.StreamRowsWithOptions(streamCtx, f, sheet, 0, 0, streamOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		if limit <= 0 {
+			result.rows, err = xlsx.CollectRows(filter(ch))
 			if err != nil {
-				return err
+				return nil, err
 			}
 		} else {
-			// Full sheet - apply limit
-			limit, err := cmd.Flags().GetInt("limit")
+			result.rows, result.truncated, err = xlsx.CollectRowsAndCancel(filter(ch), limit, cancel)
 			if err != nil {
-				return err
+				return nil, err
 			}
+		}
+	}
 
-			ch, err := xlsx.StreamRows(ctx, f, sheet, 0, 0)
-			if err != nil {
-				return err
-			}
+	cols, err := columnSelection(cmd, f, sheet)
+	if err != nil {
+		return nil, err
+	}
+	result.rows = selectColumns(result.rows, cols)
 
-			if limit <= 0 {
-				rows, err = xlsx.CollectRows(ch)
-				if err != nil {
-					return err
-				}
-			} else {
-				var total int
-				rows, total, truncated, err = xlsx.CollectRowsWithLimit(ch, limit)
-				_ = total
-				if err != nil {
-					return err
-				}
-			}
+	result.maxColumns, _ = cmd.Flags().GetInt("max-columns")
+	result.rows, result.columnsTruncated = xlsx.LimitColumns(result.rows, result.maxColumns)
+
+	if rectangular, _ := cmd.Flags().GetBool("rectangular"); rectangular {
+		result.rows = xlsx.PadRows(result.rows)
+	}
+
+	if objects, _ := cmd.Flags().GetBool("objects"); objects {
+		headers, err := xlsx.GetHeaderRow(ctx, f, sheet)
+		if err != nil {
+			return nil, err
 		}
+		result.objects = xlsx.RowsToObjects(headers, xlsx.DropHeaderRow(result.rows))
+	}
+	return result, nil
+}
+
+// Usage of the --skip-empty and --trim flags of the read commands
+const (
+	skipEmptyFlagUsage = "Drop rows in which every cell is empty; the others keep their row numbers and -n/--limit count only them"
+	trimFlagUsage      = "Strip leading and trailing whitespace from every value"
+)
 
-		if truncated {
-			fmt.Fprintf(os.Stderr, "Warning: Output truncated at limit (use --limit to adjust)\n")
+// cleanupOptions returns the streaming options set by the --skip-empty and
+// --trim flags
+func cleanupOptions(cmd *cobra.Command) xlsx.StreamOptions {
+	skipEmpty, _ := cmd.Flags().GetBool("skip-empty")
+	trim, _ := cmd.Flags().GetBool("trim")
+	return xlsx.StreamOptions{SkipEmpty: skipEmpty, Trim: trim}
+}
+
+// formatRead formats the rows of a read, or their objects with --objects
+func formatRead(cmd *cobra.Command, rows []xlsx.Row, objectRows []xlsx.Object) ([]byte, error) {
+	objects, _ := cmd.Flags().GetBool("objects")
+	typed, _ := cmd.Flags().GetBool("typed")
+	nullRepr, _ := cmd.Flags().GetString("null-representation")
+	nullEmpty, err := output.ParseNullRepresentation(nullRepr)
+	if err != nil {
+		return nil, err
+	}
+	format := GetFormatFromCmd(cmd)
+
+	if objects && (typed || nullEmpty) {
+		return nil, fmt.Errorf("--objects cannot be combined with --typed or --null-representation null")
+	}
+	if typed || nullEmpty {
+		opts := output.TypedOptions{NativeTypes: typed, NullEmpty: nullEmpty}
+		return output.FormatTypedRows(format, xlsx.RowsToCells(rows), opts)
+	}
+	if objects {
+		switch output.Format(strings.ToLower(format)) {
+		case output.FormatJSON, output.FormatNDJSON, output.FormatYAML:
+		default:
+			return nil, fmt.Errorf("--objects requires json, ndjson or yaml format, got %s", format)
 		}
+		return output.FormatSingle(format, objectRows)
+	}
+	return output.FormatRows(format, xlsx.RowsToStringSlice(rows))
+}
+
+// sourceColumn is the column, or object key, naming the file each row of a
+// glob read came from
+const sourceColumn = "__source"
 
-		data := xlsx.RowsToStringSlice(rows)
-		out, err := output.FormatRows(GetFormatFromCmd(cmd), data)
+// isGlobPattern reports whether a file argument is a glob such as
+// 'data/*.xlsx' rather than a single file
+func isGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// readGlob reads the same sheet and range from every file matching a glob,
+// in name order, tagging each row with its file name in a leading __source
+// column, or a __source key with --objects. Every matched file must pass
+// the MCP server's path checks (XLQ_ALLOWED_PATHS, by default the working
+// directory, and the read extension allowlist); files that do not are
+// skipped with a warning. --limit applies to each file.
+func readGlob(cmd *cobra.Command, args []string) error {
+	pattern, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+	if err != nil {
+		return err
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid glob pattern %q: %w", args[0], err)
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("no files match %s", args[0])
+	}
+	if err := mcp.LoadAllowedPathsFromEnv(); err != nil {
+		return err
+	}
+
+	var rows []xlsx.Row
+	var objects []xlsx.Object
+	read := 0
+	for _, match := range matches {
+		validPath, err := mcp.ValidateFilePath(match)
 		if err != nil {
-			return err
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", match, err)
+			continue
 		}
+		result, err := readFile(cmd, validPath, args[1:])
+		if err != nil {
+			return fmt.Errorf("%s: %w", match, err)
+		}
+		source := filepath.Base(match)
+		result.warn(source)
 
-		fmt.Fprint(os.Stdout, string(out))
-		return nil
-	},
+		for _, row := range result.rows {
+			tag := xlsx.Cell{Value: source, Type: "string", Row: row.Number}
+			row.Cells = append([]xlsx.Cell{tag}, row.Cells...)
+			rows = append(rows, row)
+		}
+		for _, obj := range result.objects {
+			tag := xlsx.ObjectField{Key: sourceColumn, Value: source}
+			objects = append(objects, append(xlsx.Object{tag}, obj...))
+		}
+		read++
+	}
+	if read == 0 {
+		return fmt.Errorf("no readable files match %s", args[0])
+	}
+
+	out, err := formatRead(cmd, rows, objects)
+	if err != nil {
+		return err
+	}
+	return output.Emit(out, GetPrintOptionsFromCmd(cmd))
+}
+
+// readFile opens a workbook and reads a sheet from it as readSheet does
+func readFile(cmd *cobra.Command, path string, args []string) (*sheetRead, error) {
+	f, err := xlsx.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readSheet(cmd, f, args)
 }
 
 func init() {
 	readCmd.Flags().IntP("limit", "l", 1000, "Maximum rows when no range specified (0 = unlimited)")
+	readCmd.Flags().Bool("objects", false, "Emit rows as objects keyed by the header row (json only)")
+	readCmd.Flags().Bool("typed", false, "Emit numbers and booleans as native JSON values (json only)")
+	readCmd.Flags().String("null-representation", output.NullEmpty, "How empty cells are emitted: empty (\"\") or null (json only)")
+	readCmd.Flags().String("where", "", "Only rows where a column matches, e.g. 'Age>30', 'City==\"Boston\"', 'Name~=^A' (header row is kept)")
+	readCmd.Flags().Bool("merged-fill", false, "Fill every cell of a merged region with its top-left value")
+	readCmd.Flags().Bool("visible-only", false, "Skip hidden rows and columns")
+	readCmd.Flags().Bool("raw", false, "Read stored values without number formats (e.g. 0.5 instead of 50%)")
+	readCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
+	readCmd.Flags().Bool("skip-empty", false, skipEmptyFlagUsage)
+	readCmd.Flags().Bool("trim", false, trimFlagUsage)
+	readCmd.Flags().Int("max-columns", 0, "Keep only the first N columns of each row (0 = no limit)")
+	readCmd.Flags().Bool("rectangular", false, "Pad rows with empty cells to the widest row's column count")
+	addOutputFlag(readCmd)
 	rootCmd.AddCommand(readCmd)
 }
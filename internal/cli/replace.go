@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var replaceCmd = &cobra.Command{
+	Use:   "replace <file> <pattern> <replacement>",
+	Short: "Find and replace cell values",
+	Long: `Replace text in cell values across all sheets, or one sheet with --sheet.
+Formula cells are replaced in their formula text. With --regex the
+replacement may use $1-style capture group references.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := ResolveWritePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		pattern := args[1]
+		replacement := args[2]
+
+		sheet, err := cmd.Flags().GetString("sheet")
+		if err != nil {
+			return fmt.Errorf("failed to get sheet flag: %w", err)
+		}
+		ignoreCase, err := cmd.Flags().GetBool("ignore-case")
+		if err != nil {
+			return fmt.Errorf("failed to get ignore-case flag: %w", err)
+		}
+		regex, err := cmd.Flags().GetBool("regex")
+		if err != nil {
+			return fmt.Errorf("failed to get regex flag: %w", err)
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		opts := xlsx.ReplaceOptions{
+			SearchOptions: xlsx.SearchOptions{
+				CaseInsensitive: ignoreCase,
+				Regex:           regex,
+			},
+			DryRun: dryRun,
+		}
+
+		result, err := xlsx.Replace(file, sheet, pattern, replacement, opts)
+		if err != nil {
+			return err
+		}
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	replaceCmd.Flags().StringP("sheet", "s", "", "Replace only in specific sheet (default: all sheets)")
+	replaceCmd.Flags().BoolP("ignore-case", "i", false, "Case-insensitive matching")
+	replaceCmd.Flags().BoolP("regex", "r", false, "Treat pattern as regex")
+	replaceCmd.Flags().Bool("dry-run", false, "Report matches without writing the file")
+	rootCmd.AddCommand(replaceCmd)
+}
@@ -3,8 +3,11 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/charmbracelet/fang"
+	"github.com/fuabioo/xlq/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +16,24 @@ var rootCmd = &cobra.Command{
 	Use:   "xlq",
 	Short: "xlq - jq for Excel",
 	Long:  `xlq is a streaming xlsx CLI tool that provides efficient Excel file operations.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveOutputFlag(cmd); err != nil {
+			return err
+		}
+		color, _ := cmd.Flags().GetString("color")
+		enabled, err := colorFromFlag(color)
+		if err != nil {
+			return err
+		}
+		// auto looks at stdout, which a file written with -o bypasses
+		if outputPathFromCmd(cmd) != "" && strings.ToLower(color) != "always" {
+			enabled = false
+		}
+		output.SetColor(enabled)
+		pretty, _ := cmd.Flags().GetBool("pretty")
+		output.SetPrettyJSON(pretty)
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
 	},
@@ -39,10 +60,32 @@ func Execute(ctx context.Context, version, commit, date string) error {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringP("format", "f", "json", "Output format (json, csv, tsv)")
+	rootCmd.PersistentFlags().StringP("format", "f", "json", "Output format (json, ndjson, yaml, csv, tsv, markdown, html, table)")
+	rootCmd.PersistentFlags().String("color", "auto", "Color table output: auto (terminal only, unless NO_COLOR is set), always, never")
+	rootCmd.PersistentFlags().Bool("pretty", false, "Indent JSON output for reading")
+	rootCmd.PersistentFlags().Bool("no-trailing-newline", false, "Omit the final newline from output")
 	rootCmd.PersistentFlags().StringP("basepath", "b", "", "Base directory for relative file paths (env: XLQ_BASEPATH)")
 }
 
+// colorFromFlag resolves the --color flag: auto colors only when stdout is
+// a terminal and NO_COLOR is unset or empty
+func colorFromFlag(color string) (bool, error) {
+	switch strings.ToLower(color) {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto", "":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		info, err := os.Stdout.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("unknown color mode: %s (valid: auto, always, never)", color)
+	}
+}
+
 // GetFormatFromCmd returns the format flag value from the command
 func GetFormatFromCmd(cmd *cobra.Command) string {
 	format, _ := cmd.Flags().GetString("format")
@@ -51,3 +94,9 @@ func GetFormatFromCmd(cmd *cobra.Command) string {
 	}
 	return format
 }
+
+// GetPrintOptionsFromCmd returns output write options from the command's flags
+func GetPrintOptionsFromCmd(cmd *cobra.Command) output.PrintOptions {
+	noTrailingNewline, _ := cmd.Flags().GetBool("no-trailing-newline")
+	return output.PrintOptions{NoTrailingNewline: noTrailingNewline, Output: outputPathFromCmd(cmd)}
+}
@@ -3,47 +3,67 @@ package cli
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/fuabioo/xlq/internal/output"
 	"github.com/fuabioo/xlq/internal/xlsx"
 	"github.com/spf13/cobra"
 )
 
+// searchFileResult is printed when search results are streamed to a file
+type searchFileResult struct {
+	File    string `json:"file"`
+	Matches int    `json:"matches"`
+}
+
 var searchCmd = &cobra.Command{
 	Use:   "search <file.xlsx> <pattern>",
 	Short: "Search for cells matching pattern",
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
-		regex, _ := cmd.Flags().GetBool("regex")
-		sheet, _ := cmd.Flags().GetString("sheet")
 		max, _ := cmd.Flags().GetInt("max")
+		outputFile, _ := cmd.Flags().GetString("output-file")
+		if outputFile != "" && cmd.Flags().Changed("output") {
+			return fmt.Errorf("--output-file cannot be combined with -o/--output; use --output-file to stream matches as CSV, or -o to save the formatted results")
+		}
+		opts, err := searchOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		opts.MaxResults = max
+		if outputFile != "" {
+			if outputFile, err = validateOutputFile(cmd, outputFile); err != nil {
+				return err
+			}
+		}
 
-		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+		basepath := GetBasepathFromCmd(cmd)
+		filePath, err := ResolveFilePath(basepath, args[0])
 		if err != nil {
 			return err
 		}
-		f, err := xlsx.OpenFile(filePath)
+		f, err := openInput(cmd, filePath)
 		if err != nil {
 			return err
 		}
 		defer f.Close()
 
-		opts := xlsx.SearchOptions{
-			Sheet:           sheet,
-			CaseInsensitive: ignoreCase,
-			Regex:           regex,
-			MaxResults:      max,
-		}
-
-		ctx := context.Background()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
 		ch, err := xlsx.Search(ctx, f, args[1], opts)
 		if err != nil {
 			return err
 		}
 
+		if outputFile != "" {
+			matches, err := writeSearchResultsCSV(ch, outputFile)
+			if err != nil {
+				return err
+			}
+			return output.Print(searchFileResult{File: outputFile, Matches: matches}, GetFormatFromCmd(cmd), GetPrintOptionsFromCmd(cmd))
+		}
+
 		results, err := xlsx.CollectSearchResults(ch)
 		if err != nil {
 			return err
@@ -54,15 +74,119 @@ var searchCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Fprint(os.Stdout, string(out))
-		return nil
+		return output.Emit(out, GetPrintOptionsFromCmd(cmd))
 	},
 }
 
+// addSearchFlags registers the matching flags shared by search and count
+func addSearchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("ignore-case", "i", false, "Case-insensitive search")
+	cmd.Flags().BoolP("regex", "r", false, "Treat pattern as regex")
+	cmd.Flags().StringP("sheet", "s", "", "Search only in specific sheet")
+	cmd.Flags().BoolP("whole-word", "w", false, "Only match the pattern as a whole word")
+	cmd.Flags().Bool("fuzzy", false, "Match whole cells within --max-distance edits of pattern (slower than a literal search)")
+	cmd.Flags().Int("max-distance", xlsx.DefaultMaxDistance, "Maximum edits for --fuzzy matches")
+	cmd.Flags().Bool("numeric", false, "Treat pattern as a numeric comparison on stored values (>1000, <=0, 100..200)")
+	cmd.Flags().String("range", "", "Only search cells in this range (e.g. A2:A10000, B:B)")
+	cmd.Flags().String("column", "", "Only search this column (e.g. B)")
+}
+
+// searchOptionsFromFlags builds search options from the flags registered
+// by addSearchFlags
+func searchOptionsFromFlags(cmd *cobra.Command) (xlsx.SearchOptions, error) {
+	ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
+	regex, _ := cmd.Flags().GetBool("regex")
+	sheet, _ := cmd.Flags().GetString("sheet")
+	wholeWord, _ := cmd.Flags().GetBool("whole-word")
+	fuzzy, _ := cmd.Flags().GetBool("fuzzy")
+	maxDistance, _ := cmd.Flags().GetInt("max-distance")
+	numeric, _ := cmd.Flags().GetBool("numeric")
+	rangeStr, _ := cmd.Flags().GetString("range")
+	column, _ := cmd.Flags().GetString("column")
+
+	if cmd.Flags().Changed("max-distance") && !fuzzy {
+		return xlsx.SearchOptions{}, fmt.Errorf("--max-distance requires --fuzzy")
+	}
+	if fuzzy && maxDistance < 1 {
+		return xlsx.SearchOptions{}, fmt.Errorf("--max-distance must be at least 1")
+	}
+	searchRange, err := searchRangeFromFlags(rangeStr, column)
+	if err != nil {
+		return xlsx.SearchOptions{}, err
+	}
+
+	return xlsx.SearchOptions{
+		Sheet:           sheet,
+		CaseInsensitive: ignoreCase,
+		Regex:           regex,
+		WholeWord:       wholeWord,
+		Fuzzy:           fuzzy,
+		MaxDistance:     maxDistance,
+		Numeric:         numeric,
+		Range:           searchRange,
+	}, nil
+}
+
+// searchRangeFromFlags parses --range or --column, at most one of which
+// may be set, into the range to search. It returns nil when neither is.
+func searchRangeFromFlags(rangeStr, column string) (*xlsx.CellRange, error) {
+	switch {
+	case rangeStr != "" && column != "":
+		return nil, fmt.Errorf("--range cannot be combined with --column")
+	case column != "":
+		col, err := xlsx.ParseColumnName(column)
+		if err != nil {
+			return nil, err
+		}
+		return &xlsx.CellRange{StartCol: col, StartRow: 1, EndCol: col}, nil
+	case rangeStr != "":
+		return xlsx.ParseRange(rangeStr)
+	}
+	return nil, nil
+}
+
+// writeSearchResultsCSV writes each match to path as a CSV row of
+// sheet,address,value as soon as it is received, so memory stays
+// constant regardless of how many cells match. The rows go to a temp file
+// that replaces path once the search completes, so a failed search leaves
+// any previous file in place.
+func writeSearchResultsCSV(ch <-chan xlsx.SearchResultStream, path string) (int, error) {
+	matches := 0
+	err := xlsx.WriteFileAtomic(path, func(w io.Writer) error {
+		formatter := &output.CSVFormatter{}
+		line, err := formatter.FormatValue([]string{"sheet", "address", "value"})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+
+		for stream := range ch {
+			if stream.Err != nil {
+				return stream.Err
+			}
+			if stream.Result == nil {
+				continue
+			}
+			line, err := formatter.FormatValue([]string{stream.Result.Sheet, stream.Result.Address, stream.Result.Value})
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(line); err != nil {
+				return err
+			}
+			matches++
+		}
+		return nil
+	})
+	return matches, err
+}
+
 func init() {
-	searchCmd.Flags().BoolP("ignore-case", "i", false, "Case-insensitive search")
-	searchCmd.Flags().BoolP("regex", "r", false, "Treat pattern as regex")
-	searchCmd.Flags().StringP("sheet", "s", "", "Search only in specific sheet")
+	addSearchFlags(searchCmd)
 	searchCmd.Flags().IntP("max", "m", 0, "Maximum results (0 = unlimited)")
+	searchCmd.Flags().String("output-file", "", "Stream matches to a CSV file (sheet,address,value) as they are found and print only a summary; unlike -o, memory stays constant however many cells match")
+	addOutputFlag(searchCmd)
 	rootCmd.AddCommand(searchCmd)
 }
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var sortCmd = &cobra.Command{
+	Use:   "sort <file> [sheet]",
+	Short: "Sort rows by one or more columns",
+	Long: `Sort the rows of a sheet in place by one or more columns.
+Columns are named by header or letter, each with an optional direction:
+
+  xlq sort data.xlsx Sheet1 --by Age:desc,Name:asc
+
+The first row is kept in place as the header unless --no-header is given.
+Numbers sort numerically and empty cells always sort last.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := ResolveWritePath(GetBasepathFromCmd(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		sheet := ""
+		if len(args) > 1 {
+			sheet = args[1]
+		}
+
+		by, err := cmd.Flags().GetString("by")
+		if err != nil {
+			return fmt.Errorf("failed to get by flag: %w", err)
+		}
+		noHeader, err := cmd.Flags().GetBool("no-header")
+		if err != nil {
+			return fmt.Errorf("failed to get no-header flag: %w", err)
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		keys, err := xlsx.ParseSortKeys(by)
+		if err != nil {
+			return err
+		}
+
+		result, err := xlsx.Edit(file, dryRun, func(wb *xlsx.Workbook) (*xlsx.SortResult, error) {
+			return wb.SortRows(sheet, keys, !noHeader)
+		})
+		if err != nil {
+			return err
+		}
+		result.DryRun = dryRun
+
+		format := GetFormatFromCmd(cmd)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
+	},
+}
+
+func init() {
+	sortCmd.Flags().String("by", "", "Sort keys as column[:asc|desc], comma-separated (e.g., Age:desc,Name)")
+	sortCmd.Flags().Bool("no-header", false, "Sort the first row too instead of keeping it as the header")
+	sortCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
+	rootCmd.AddCommand(sortCmd)
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/spf13/cobra"
+	"github.com/xuri/excelize/v2"
+)
+
+// stdinPath is the file argument that reads the workbook from stdin, as in
+// 'cat report.xlsx | xlq sheets -'
+const stdinPath = "-"
+
+// openInput opens the workbook a read-only command was given: a file, or an
+// xlsx piped through stdin when the path is "-". Stdin has no path, so the
+// basepath does not apply to it.
+func openInput(cmd *cobra.Command, path string) (*excelize.File, error) {
+	if path == stdinPath {
+		return xlsx.OpenReader(cmd.InOrStdin())
+	}
+	return xlsx.OpenFile(path)
+}
+
+// ResolveWritePath is ResolveFilePath for a workbook a command modifies.
+// Stdin input cannot be written back, so "-" is rejected.
+func ResolveWritePath(basepath, file string) (string, error) {
+	if file == stdinPath {
+		return "", fmt.Errorf("cannot modify a workbook read from stdin; write commands need a file path")
+	}
+	return ResolveFilePath(basepath, file)
+}
+
+// checkSingleStdin rejects reading more than one input from stdin, which
+// can only be consumed once
+func checkSingleStdin(paths ...string) error {
+	count := 0
+	for _, path := range paths {
+		if path == stdinPath {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("only one input can be read from stdin")
+	}
+	return nil
+}
@@ -1,26 +1,47 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/fuabioo/xlq/internal/output"
 	"github.com/fuabioo/xlq/internal/xlsx"
 	"github.com/spf13/cobra"
 )
 
+// followPollInterval is how often tail --follow checks the file for changes
+const followPollInterval = time.Second
+
 var tailCmd = &cobra.Command{
 	Use:   "tail <file.xlsx> [sheet]",
 	Short: "Show last N rows",
-	Args:  cobra.RangeArgs(1, 2),
+	Long: `Show the last N rows of a sheet.
+
+With --follow, keep watching the file and print rows appended to the sheet
+by another process until interrupted. If the sheet shrinks, as when the file
+is rewritten from scratch, the last N rows are printed again.`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		n, _ := cmd.Flags().GetInt("number")
+		maxColumns, _ := cmd.Flags().GetInt("max-columns")
+		follow, _ := cmd.Flags().GetBool("follow")
+		if follow && outputPathFromCmd(cmd) != "" {
+			// Each batch would replace the file, keeping only the last one
+			return fmt.Errorf("--follow cannot be combined with -o/--output; redirect stdout to keep every followed row")
+		}
 
 		filePath, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
 		if err != nil {
 			return err
 		}
-		f, err := xlsx.OpenFile(filePath)
+		if follow && filePath == stdinPath {
+			return fmt.Errorf("--follow needs a file path, not stdin")
+		}
+		f, err := openInput(cmd, filePath)
 		if err != nil {
 			return err
 		}
@@ -37,23 +58,150 @@ var tailCmd = &cobra.Command{
 			}
 		}
 
-		rows, err := xlsx.StreamTail(f, sheet, n)
+		cols, err := columnSelection(cmd, f, sheet)
 		if err != nil {
 			return err
 		}
 
-		data := xlsx.RowsToStringSlice(rows)
-		out, err := output.FormatRows(GetFormatFromCmd(cmd), data)
+		opts := cleanupOptions(cmd)
+		rows, err := xlsx.StreamTailWithOptions(f, sheet, n, opts)
 		if err != nil {
 			return err
 		}
 
-		fmt.Fprint(os.Stdout, string(out))
-		return nil
+		printRows := func(rows []xlsx.Row) error {
+			rows = selectColumns(rows, cols)
+			rows, _ = xlsx.LimitColumns(rows, maxColumns)
+			out, err := output.FormatRows(GetFormatFromCmd(cmd), xlsx.RowsToStringSlice(rows))
+			if err != nil {
+				return err
+			}
+			return output.Emit(out, GetPrintOptionsFromCmd(cmd))
+		}
+		if err := printRows(rows); err != nil {
+			return err
+		}
+		if !follow {
+			return nil
+		}
+
+		follower, err := newTailFollower(filePath, sheet, n, opts, rows)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		ticker := time.NewTicker(followPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+			rows, truncated, err := follower.poll()
+			if err != nil {
+				return err
+			}
+			if truncated {
+				fmt.Fprintf(os.Stderr, "Warning: %s was truncated, showing its last rows again\n", filePath)
+			}
+			if len(rows) > 0 {
+				if err := printRows(rows); err != nil {
+					return err
+				}
+			}
+		}
 	},
 }
 
+// tailFollower tracks the rows of a sheet that tail --follow has printed
+type tailFollower struct {
+	path    string
+	sheet   string
+	n       int
+	opts    xlsx.StreamOptions
+	lastRow int // Number of the last row printed
+	modTime time.Time
+	size    int64
+}
+
+// newTailFollower starts following a sheet after the rows already printed
+func newTailFollower(path, sheet string, n int, opts xlsx.StreamOptions, printed []xlsx.Row) (*tailFollower, error) {
+	t := &tailFollower{path: path, sheet: sheet, n: n, opts: opts}
+	if len(printed) > 0 {
+		t.lastRow = printed[len(printed)-1].Number
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	t.modTime, t.size = info.ModTime(), info.Size()
+	return t, nil
+}
+
+// poll returns the rows appended since the last call when the file has
+// changed. When the sheet now ends before the last printed row, the file was
+// truncated or rewritten: poll starts over and returns its last n rows.
+// A file that cannot be read, e.g. while another process is still writing
+// it, is skipped until the next change.
+func (t *tailFollower) poll() ([]xlsx.Row, bool, error) {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to stat %s: %w", t.path, err)
+	}
+	if info.ModTime().Equal(t.modTime) && info.Size() == t.size {
+		return nil, false, nil
+	}
+
+	f, err := xlsx.OpenFile(t.path)
+	if err != nil {
+		return nil, false, nil
+	}
+	defer f.Close()
+
+	last, err := xlsx.StreamTail(f, t.sheet, 1)
+	if err != nil {
+		return nil, false, nil
+	}
+	t.modTime, t.size = info.ModTime(), info.Size()
+
+	lastRow := 0
+	if len(last) > 0 {
+		lastRow = last[0].Number
+	}
+
+	var rows []xlsx.Row
+	truncated := lastRow < t.lastRow
+	switch {
+	case truncated:
+		if rows, err = xlsx.StreamTailWithOptions(f, t.sheet, t.n, t.opts); err != nil {
+			return nil, false, err
+		}
+	case lastRow > t.lastRow:
+		ch, err := xlsx.StreamRowsWithOptions(context.Background(), f, t.sheet, t.lastRow+1, lastRow, t.opts)
+		if err != nil {
+			return nil, false, err
+		}
+		if rows, err = xlsx.CollectRows(ch); err != nil {
+			return nil, false, err
+		}
+	}
+	t.lastRow = lastRow
+	return rows, truncated, nil
+}
+
 func init() {
 	tailCmd.Flags().IntP("number", "n", 10, "Number of rows to show")
+	tailCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
+	tailCmd.Flags().Bool("skip-empty", false, skipEmptyFlagUsage)
+	tailCmd.Flags().Bool("trim", false, trimFlagUsage)
+	tailCmd.Flags().Int("max-columns", 0, "Keep only the first N columns of each row (0 = no limit)")
+	tailCmd.Flags().Bool("follow", false, "Keep watching the file and print appended rows")
+	addOutputFlag(tailCmd)
 	rootCmd.AddCommand(tailCmd)
 }
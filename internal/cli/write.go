@@ -14,7 +14,7 @@ var writeCmd = &cobra.Command{
 	Long:  "Write a value to a specific cell in an xlsx file. Use --sheet to specify sheet.",
 	Args:  cobra.ExactArgs(3),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		file, err := ResolveFilePath(GetBasepathFromCmd(cmd), args[0])
+		file, err := ResolveWritePath(GetBasepathFromCmd(cmd), args[0])
 		if err != nil {
 			return err
 		}
@@ -31,18 +31,48 @@ var writeCmd = &cobra.Command{
 			return fmt.Errorf("failed to get type flag: %w", err)
 		}
 
-		result, err := xlsx.WriteCell(file, sheet, cell, value, valueType)
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		if err := applySanitizeFlag(cmd); err != nil {
+			return err
+		}
+
+		result, err := xlsx.Edit(file, dryRun, func(wb *xlsx.Workbook) (*xlsx.WriteResult, error) {
+			return wb.WriteCell(sheet, cell, value, valueType)
+		})
 		if err != nil {
 			return err
 		}
+		result.DryRun = dryRun
 
 		format := GetFormatFromCmd(cmd)
-		return output.Print(result, format)
+		return output.Print(result, format, GetPrintOptionsFromCmd(cmd))
 	},
 }
 
 func init() {
 	writeCmd.Flags().StringP("sheet", "s", "", "Sheet name (default: first sheet)")
-	writeCmd.Flags().StringP("type", "t", "auto", "Value type: auto, string, number, bool, formula")
+	writeCmd.Flags().StringP("type", "t", "auto", "Value type: auto, string, number, bool, formula, date, datetime")
+	writeCmd.Flags().Bool("sanitize", false, sanitizeFlagUsage)
+	writeCmd.Flags().Bool("dry-run", false, dryRunFlagUsage)
 	rootCmd.AddCommand(writeCmd)
 }
+
+// sanitizeFlagUsage describes the --sanitize flag shared by write commands
+const sanitizeFlagUsage = "Mark string values starting with =, +, - or @ as text (Excel's quote prefix) so editing them does not turn them into formulas"
+
+// dryRunFlagUsage describes the --dry-run flag shared by write commands
+const dryRunFlagUsage = "Validate and report the result without writing the file"
+
+// applySanitizeFlag enables string sanitizing for writes from the --sanitize flag
+func applySanitizeFlag(cmd *cobra.Command) error {
+	sanitize, err := cmd.Flags().GetBool("sanitize")
+	if err != nil {
+		return fmt.Errorf("failed to get sanitize flag: %w", err)
+	}
+	xlsx.SetSanitizeStrings(sanitize)
+	return nil
+}
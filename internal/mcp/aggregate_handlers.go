@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleAggregate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	column := request.GetString("column", "")
+	op := request.GetString("op", "")
+
+	// Validate path
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := xlsx.Aggregate(validPath, sheet, column, op)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(result)
+}
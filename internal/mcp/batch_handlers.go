@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleWriteCells(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	valueType := request.GetString("type", "auto")
+
+	// Parse cells from request arguments
+	var args struct {
+		Cells map[string]any `json:"cells"`
+	}
+	if err := request.BindArguments(&args); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to parse cells: %v", err)), nil
+	}
+
+	// Validate cell count
+	if len(args.Cells) == 0 {
+		return mcp.NewToolResultError("no cells provided"), nil
+	}
+	if len(args.Cells) > xlsx.MaxWriteRangeCells {
+		return mcp.NewToolResultError(fmt.Sprintf("too many cells: %d exceeds limit of %d", len(args.Cells), xlsx.MaxWriteRangeCells)), nil
+	}
+
+	cells := make(map[string]xlsx.CellWrite, len(args.Cells))
+	for addr, value := range args.Cells {
+		cells[addr] = xlsx.CellWrite{Value: value, Type: valueType}
+	}
+
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.WriteCells
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.WriteCellsResult, error) {
+		return wb.WriteCells(sheet, cells)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Capabilities describes the server's version, limits and file access
+// rules so clients can size their requests up front
+type Capabilities struct {
+	Version            string   `json:"version"`
+	ReadOnly           bool     `json:"read_only"`
+	AllowedPaths       []string `json:"allowed_paths"`
+	ReadExtensions     []string `json:"read_extensions"`
+	WriteExtensions    []string `json:"write_extensions"`
+	DefaultRowLimit    int      `json:"default_row_limit"`
+	MaxRowLimit        int      `json:"max_row_limit"`
+	MaxHeadRows        int      `json:"max_head_rows"`
+	MaxTailRows        int      `json:"max_tail_rows"`
+	MaxSearchResults   int      `json:"max_search_results"`
+	MaxOutputBytes     int      `json:"max_output_bytes"`
+	MaxWriteFileSize   int64    `json:"max_write_file_size"`
+	MaxAppendRows      int      `json:"max_append_rows"`
+	MaxWriteRangeCells int      `json:"max_write_range_cells"`
+	MaxCreateFileRows  int      `json:"max_create_file_rows"`
+}
+
+func (s *Server) handleCapabilities(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return jsonResult(Capabilities{
+		Version:            ServerVersion,
+		ReadOnly:           s.readOnly,
+		AllowedPaths:       GetAllowedBasePaths(),
+		ReadExtensions:     GetAllowedReadExtensions(),
+		WriteExtensions:    GetAllowedWriteExtensions(),
+		DefaultRowLimit:    DefaultRowLimit,
+		MaxRowLimit:        MaxRowLimit,
+		MaxHeadRows:        MaxHeadRows,
+		MaxTailRows:        MaxTailRows,
+		MaxSearchResults:   MaxSearchResults,
+		MaxOutputBytes:     GetMaxOutputBytes(),
+		MaxWriteFileSize:   xlsx.MaxWriteFileSize,
+		MaxAppendRows:      xlsx.MaxAppendRows,
+		MaxWriteRangeCells: xlsx.MaxWriteRangeCells,
+		MaxCreateFileRows:  xlsx.MaxCreateFileRows,
+	})
+}
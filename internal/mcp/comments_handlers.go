@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleComments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+
+	// Validate path
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	f, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer f.Close()
+
+	comments, err := xlsx.GetComments(f, sheet)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(comments)
+}
+
+func (s *Server) handleAddComment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	cell := request.GetString("cell", "")
+	author := request.GetString("author", "")
+	text := request.GetString("text", "")
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.AddComment
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.CommentResult, error) {
+		return wb.AddComment(sheet, cell, author, text)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
+
+func (s *Server) handleDeleteComment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	cell := request.GetString("cell", "")
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.DeleteComment
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.CommentResult, error) {
+		return wb.DeleteComment(sheet, cell)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
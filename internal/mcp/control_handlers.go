@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleFindControlChars(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+
+	// Validate path
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	f, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer f.Close()
+
+	result, err := xlsx.FindControlChars(f, sheet)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(result)
+}
+
+func (s *Server) handleStripControlChars(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	keepNewlines := request.GetBool("keep_newlines", false)
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.StripControlChars
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.ReplaceResult, error) {
+		return wb.StripControlChars(sheet, keepNewlines)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
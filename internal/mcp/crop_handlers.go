@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleCrop(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	keepRange := request.GetString("range", "")
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.CropSheet
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.CropResult, error) {
+		return wb.CropSheet(sheet, keepRange)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleConvertDates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	column := request.GetString("column", "")
+	format := request.GetString("format", xlsx.DefaultDateFormat)
+	hasHeader := request.GetBool("has_header", false)
+	convertText := request.GetBool("convert_text", false)
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.ConvertSerialDates
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.ConvertDatesResult, error) {
+		return wb.ConvertSerialDates(sheet, column, format, hasHeader, convertText)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleDiff(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	otherFile, err := s.resolveFile(request.GetString("otherFile", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	otherSheet := request.GetString("otherSheet", sheet)
+	maxResults := request.GetInt("maxResults", DefaultSearchResults)
+
+	// Cap maxResults at MaxSearchResults and ensure it's at least 1
+	if maxResults <= 0 {
+		maxResults = DefaultSearchResults
+	}
+	maxResults = min(maxResults, MaxSearchResults)
+
+	// Validate paths
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	validOtherPath, err := ValidateFilePath(otherFile)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	oldF, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer oldF.Close()
+
+	newF, err := xlsx.OpenFile(validOtherPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer newF.Close()
+
+	result, err := xlsx.DiffSheets(ctx, oldF, sheet, newF, otherSheet, xlsx.DiffOptions{
+		IgnoreCase:       request.GetBool("ignoreCase", false),
+		IgnoreWhitespace: request.GetBool("ignoreWhitespace", false),
+		MaxResults:       maxResults,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResultWithMetadata(result, len(result.Differences), result.Truncated, maxResults)
+}
@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"context"
+	"io"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleExport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	outFile, err := s.resolveFile(request.GetString("output", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	format := request.GetString("format", "")
+	overwrite := request.GetBool("overwrite", false)
+	dryRun := request.GetBool("dry_run", false)
+
+	// Validate paths
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	validOut, err := ValidateExportPath(outFile, overwrite)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if format == "" {
+		format, err = output.ExportFormatForPath(validOut)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	f, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer f.Close()
+
+	var result *output.ExportResult
+	if dryRun {
+		result, err = output.WriteSheet(ctx, f, sheet, io.Discard, format)
+		if result != nil {
+			result.File = validOut
+			result.DryRun = true
+		}
+	} else {
+		result, err = output.ExportSheet(ctx, f, sheet, validOut, format)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(result)
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleFilter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	objects := request.GetBool("objects", false)
+
+	pred, err := xlsx.ParsePredicate(request.GetString("where", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate path
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	f, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer f.Close()
+
+	// Resolve sheet name
+	resolvedSheet, err := xlsx.ResolveSheetName(f, sheet)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	headers, err := xlsx.GetHeaderRow(ctx, f, resolvedSheet)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := pred.Resolve(headers); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Cancel on return so the row producer never outlives the request
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch, err := xlsx.StreamRows(streamCtx, f, resolvedSheet, 0, 0)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	rows, truncated, err := xlsx.CollectRowsAndCancel(xlsx.FilterRows(streamCtx, ch, pred, true), DefaultRowLimit, cancel)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if objects {
+		data := xlsx.RowsToObjects(headers, xlsx.DropHeaderRow(rows))
+		return jsonResultWithMetadata(data, len(data), truncated, DefaultRowLimit)
+	}
+
+	return jsonResultWithMetadata(
+		xlsx.RowsToStringSlice(rows),
+		len(rows),
+		truncated,
+		DefaultRowLimit,
+	)
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleImportCSV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	csvFile := request.GetString("csv_file", "")
+	csvText := request.GetString("csv", "")
+	delimiter := request.GetString("delimiter", ",")
+	header := request.GetBool("header", false)
+	dryRun := request.GetBool("dry_run", false)
+
+	if (csvFile == "") == (csvText == "") {
+		return mcp.NewToolResultError("provide exactly one of csv_file or csv"), nil
+	}
+	if utf8.RuneCountInString(delimiter) != 1 {
+		return mcp.NewToolResultError(fmt.Sprintf("delimiter must be a single character, got %q", delimiter)), nil
+	}
+	comma, _ := utf8.DecodeRuneInString(delimiter)
+
+	var source io.Reader = strings.NewReader(csvText)
+	if csvFile != "" {
+		csvFile, err = s.resolveFile(csvFile)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		validCSV, err := ValidateImportPath(csvFile)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		cf, err := os.Open(validCSV)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to open CSV file: %v", err)), nil
+		}
+		defer cf.Close()
+		source = cf
+	}
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.ImportCSV
+	opts := xlsx.ImportOptions{Delimiter: comma, Header: header}
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.ImportResult, error) {
+		return wb.ImportCSV(source, sheet, opts)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
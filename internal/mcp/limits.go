@@ -1,5 +1,16 @@
 package mcp
 
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+)
+
 const (
 	// DefaultRowLimit is applied when reading entire sheets without a range
 	DefaultRowLimit = 1000
@@ -28,3 +39,57 @@ const (
 	// MaxOutputBytes is the maximum size of JSON output (5MB)
 	MaxOutputBytes = 5 * 1024 * 1024
 )
+
+// maxOutputBytes is the output cap applied by the JSON result helpers.
+// It starts at MaxOutputBytes; protected by maxOutputMu.
+var maxOutputBytes = MaxOutputBytes
+
+// maxOutputMu protects concurrent access to maxOutputBytes.
+var maxOutputMu sync.RWMutex
+
+// SetMaxOutputBytes sets the output cap. Values <= 0 restore MaxOutputBytes.
+func SetMaxOutputBytes(n int) {
+	if n <= 0 {
+		n = MaxOutputBytes
+	}
+	maxOutputMu.Lock()
+	maxOutputBytes = n
+	maxOutputMu.Unlock()
+}
+
+// GetMaxOutputBytes returns the configured output cap
+func GetMaxOutputBytes() int {
+	maxOutputMu.RLock()
+	defer maxOutputMu.RUnlock()
+	return maxOutputBytes
+}
+
+// LoadLimitsFromEnv overrides the output cap and the xlsx write limits from
+// XLQ_MAX_OUTPUT_BYTES, XLQ_MAX_WRITE_FILE_SIZE, XLQ_MAX_APPEND_ROWS,
+// XLQ_MAX_WRITE_RANGE_CELLS and XLQ_MAX_CREATE_FILE_ROWS. Call it once at
+// server start. Unset variables leave a limit unchanged; values that are
+// not positive integers restore its default and are reported in the
+// returned error.
+func LoadLimitsFromEnv() error {
+	var errs []error
+	load := func(name string, def int, set func(int)) {
+		env := strings.TrimSpace(os.Getenv(name))
+		if env == "" {
+			return
+		}
+		n, err := strconv.Atoi(env)
+		if err != nil || n <= 0 {
+			errs = append(errs, fmt.Errorf("invalid %s %q (must be a positive integer), using default %d", name, env, def))
+			n = def
+		}
+		set(n)
+	}
+
+	load("XLQ_MAX_OUTPUT_BYTES", MaxOutputBytes, SetMaxOutputBytes)
+	load("XLQ_MAX_WRITE_FILE_SIZE", xlsx.DefaultMaxWriteFileSize, func(n int) { xlsx.MaxWriteFileSize = int64(n) })
+	load("XLQ_MAX_APPEND_ROWS", xlsx.DefaultMaxAppendRows, func(n int) { xlsx.MaxAppendRows = n })
+	load("XLQ_MAX_WRITE_RANGE_CELLS", xlsx.DefaultMaxWriteRangeCells, func(n int) { xlsx.MaxWriteRangeCells = n })
+	load("XLQ_MAX_CREATE_FILE_ROWS", xlsx.DefaultMaxCreateFileRows, func(n int) { xlsx.MaxCreateFileRows = n })
+
+	return errors.Join(errs...)
+}
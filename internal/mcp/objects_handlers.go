@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleWriteObjects(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	createSheet := request.GetBool("create_sheet", false)
+
+	// Parse objects from request arguments using BindArguments
+	var args struct {
+		Objects []map[string]any `json:"objects"`
+	}
+	if err := request.BindArguments(&args); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to parse objects: %v", err)), nil
+	}
+	if len(args.Objects) == 0 {
+		return mcp.NewToolResultError("no objects provided"), nil
+	}
+
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.AppendObjects
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.AppendObjectsResult, error) {
+		return wb.AppendObjects(sheet, args.Objects, xlsx.AppendObjectsOptions{
+			CreateSheet: createSheet,
+		})
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
@@ -0,0 +1,186 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fuabioo/xlq/internal/output"
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+// readOutput holds the read tool's options for shaping the rows it returns
+type readOutput struct {
+	objects     bool
+	rectangular bool
+	withTypes   bool
+	nullEmpty   bool
+	maxColumns  int
+}
+
+func (s *Server) handleRead(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	offset := max(request.GetInt("offset", 0), 0)
+	limit := min(request.GetInt("limit", 0), MaxRowLimit)
+	out, err := readOutputOptions(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate path
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	f, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer f.Close()
+
+	sheet, rangeStr, err := resolveReadTarget(f, request.GetString("sheet", ""), request.GetString("range", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// Whole-sheet reads get the default limit; range reads have none unless given
+	if rangeStr == "" && limit <= 0 {
+		limit = DefaultRowLimit
+	}
+	rows, scanned, truncated, err := readPage(ctx, f, sheet, rangeStr, offset, limit, readStreamOptions(request))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// Unlimited range reads report the default limit in the metadata
+	if limit <= 0 {
+		limit = DefaultRowLimit
+	}
+
+	rows, columnsTruncated := xlsx.LimitColumns(rows, out.maxColumns)
+	extra := pageMetadata(offset, len(rows), scanned, truncated)
+	for k, v := range columnLimitMetadata(out.maxColumns, columnsTruncated) {
+		extra[k] = v
+	}
+	return readResult(ctx, f, sheet, rows, out, truncated, limit, extra)
+}
+
+// readOutputOptions reads the read tool's output options, rejecting
+// combinations it cannot honour
+func readOutputOptions(request mcp.CallToolRequest) (readOutput, error) {
+	out := readOutput{
+		objects:     request.GetBool("objects", false),
+		rectangular: request.GetBool("rectangular", false),
+		withTypes:   request.GetBool("withTypes", false),
+		maxColumns:  request.GetInt("maxColumns", 0),
+	}
+	if out.objects && out.withTypes {
+		return out, errors.New("objects cannot be combined with withTypes")
+	}
+	nullEmpty, err := output.ParseNullRepresentation(request.GetString("nullRepresentation", output.NullEmpty))
+	if err != nil {
+		return out, err
+	}
+	out.nullEmpty = nullEmpty
+	return out, nil
+}
+
+// readStreamOptions reads the read tool's options for streaming cell values
+func readStreamOptions(request mcp.CallToolRequest) xlsx.StreamOptions {
+	return xlsx.StreamOptions{
+		MergedFill:  request.GetBool("mergedFill", false),
+		VisibleOnly: request.GetBool("visibleOnly", false),
+		RawValues:   request.GetBool("raw", false),
+		SkipEmpty:   request.GetBool("skipEmpty", false),
+		Trim:        request.GetBool("trim", false),
+	}
+}
+
+// resolveReadTarget resolves the sheet and bare range to read. The range
+// may be sheet-qualified or a defined name, whose sheet wins over the
+// sheet given.
+func resolveReadTarget(f *excelize.File, sheet, rangeStr string) (string, string, error) {
+	if rangeStr != "" {
+		refSheet, r, err := xlsx.ResolveRangeRef(f, rangeStr)
+		if err != nil {
+			return "", "", err
+		}
+		if refSheet != "" {
+			sheet = refSheet
+		}
+		rangeStr = r
+	}
+	resolved, err := xlsx.ResolveSheetName(f, sheet)
+	if err != nil {
+		return "", "", err
+	}
+	return resolved, rangeStr, nil
+}
+
+// readPage streams a range, or the whole sheet when rangeStr is empty, and
+// collects the page of rows after offset.
+// Returns: (rows, scanned, truncated, error) as CollectPageAndCancel does
+func readPage(ctx context.Context, f *excelize.File, sheet, rangeStr string, offset, limit int, opts xlsx.StreamOptions) ([]xlsx.Row, int, bool, error) {
+	// Cancel on return so the row producer never outlives the request
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var ch <-chan xlsx.RowResult
+	var err error
+	if rangeStr != "" {
+		ch, err = xlsx.StreamRangeWithOptions(streamCtx, f, sheet, rangeStr, opts)
+	} else {
+		ch, err = xlsx.StreamRowsWithOptions(streamCtx, f, sheet, 0, 0, opts)
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return xlsx.CollectPageAndCancel(ch, offset, limit, cancel)
+}
+
+// pageMetadata describes a page of a read. rows_scanned counts the rows
+// read to produce it: the skipped ones and, when more follow, the one read
+// ahead to tell. Only a page that reaches the end knows the total.
+func pageMetadata(offset, returned, scanned int, truncated bool) map[string]any {
+	extra := map[string]any{
+		"offset":       offset,
+		"rows_scanned": scanned,
+	}
+	if truncated {
+		extra["next_offset"] = offset + returned
+	} else {
+		extra["total_rows"] = scanned
+	}
+	return extra
+}
+
+// readResult formats a page of rows as the output options ask
+func readResult(ctx context.Context, f *excelize.File, sheet string, rows []xlsx.Row, out readOutput, truncated bool, limit int, extra map[string]any) (*mcp.CallToolResult, error) {
+	if out.rectangular {
+		rows = xlsx.PadRows(rows)
+	}
+
+	if out.objects {
+		headers, err := xlsx.GetHeaderRow(ctx, f, sheet)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		data := xlsx.RowsToObjects(headers, xlsx.DropHeaderRow(rows))
+		return jsonResultWithExtraMetadata(data, len(data), truncated, limit, extra)
+	}
+
+	if out.withTypes {
+		data := xlsx.RowsWithTypes(f, sheet, rows)
+		return jsonResultWithExtraMetadata(data, len(rows), truncated, limit, extra)
+	}
+
+	if out.nullEmpty {
+		data := output.TypedRows(xlsx.RowsToCells(rows), output.TypedOptions{NullEmpty: true})
+		return jsonResultWithExtraMetadata(data, len(rows), truncated, limit, extra)
+	}
+
+	return jsonResultWithExtraMetadata(xlsx.RowsToStringSlice(rows), len(rows), truncated, limit, extra)
+}
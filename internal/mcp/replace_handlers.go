@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleReplace(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	pattern := request.GetString("pattern", "")
+	replacement := request.GetString("replacement", "")
+	opts := xlsx.ReplaceOptions{
+		SearchOptions: xlsx.SearchOptions{
+			CaseInsensitive: request.GetBool("ignore_case", false),
+			Regex:           request.GetBool("regex", false),
+		},
+		DryRun: request.GetBool("dry_run", false),
+	}
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call xlsx.Replace
+	result, err := xlsx.Replace(validPath, sheet, pattern, replacement, opts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(result)
+}
+
+func (s *Server) handleSetWhere(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	pattern := request.GetString("pattern", "")
+	value := request.GetString("value", "")
+	opts := xlsx.SearchOptions{
+		CaseInsensitive: request.GetBool("ignore_case", false),
+		Regex:           request.GetBool("regex", false),
+		MaxResults:      request.GetInt("max_changes", 0),
+	}
+
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.SetWhereMatch
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.SetWhereResult, error) {
+		return wb.SetWhereMatch(sheet, pattern, value, opts)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -14,8 +15,42 @@ var (
 	ErrWriteDenied  = errors.New("write operation denied")
 	ErrFileTooLarge = errors.New("file exceeds size limit for write operations")
 	ErrFileExists   = errors.New("file already exists")
+	ErrUnsupported  = errors.New("unsupported file type")
+	ErrReadOnly     = errors.New("server is read-only")
 )
 
+// readOnly disables every write operation when set.
+// Protected by readOnlyMu for thread-safe access.
+var readOnly bool
+
+// readOnlyMu protects concurrent access to readOnly.
+var readOnlyMu sync.RWMutex
+
+// SetReadOnly enables or disables read-only mode. In read-only mode servers
+// created by New register no write tools and ValidateWritePath rejects
+// every path with ErrReadOnly.
+func SetReadOnly(enabled bool) {
+	readOnlyMu.Lock()
+	readOnly = enabled
+	readOnlyMu.Unlock()
+}
+
+// IsReadOnly reports whether read-only mode is enabled.
+func IsReadOnly() bool {
+	readOnlyMu.RLock()
+	defer readOnlyMu.RUnlock()
+	return readOnly
+}
+
+// LoadReadOnlyFromEnv enables read-only mode when the XLQ_READ_ONLY
+// environment variable holds a true value such as "1" or "true".
+// If unset or false, the mode is left unchanged.
+func LoadReadOnlyFromEnv() {
+	if enabled, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("XLQ_READ_ONLY"))); err == nil && enabled {
+		SetReadOnly(true)
+	}
+}
+
 // allowedBasePaths contains directories from which files can be accessed.
 // If empty, defaults to current working directory.
 // Protected by allowedPathsMu for thread-safe access.
@@ -125,8 +160,178 @@ func LoadAllowedPathsFromEnv() error {
 	return InitAllowedPaths(extra)
 }
 
+// DefaultReadExtensions are the file extensions readable by default.
+var DefaultReadExtensions = []string{".xlsx", ".xlsm", ".xltx"}
+
+// allowedReadExtensions holds the lowercase extensions accepted by
+// ValidateFilePath. A nil slice disables the check.
+// Protected by readExtensionsMu for thread-safe access.
+var allowedReadExtensions = normalizeExtensions(DefaultReadExtensions)
+
+// readExtensionsMu protects concurrent access to allowedReadExtensions.
+var readExtensionsMu sync.RWMutex
+
+// SetAllowedReadExtensions replaces the read extension allowlist.
+// Extensions are matched case-insensitively and may omit the leading dot.
+// Passing nil or an empty slice allows any extension.
+func SetAllowedReadExtensions(exts []string) {
+	readExtensionsMu.Lock()
+	allowedReadExtensions = normalizeExtensions(exts)
+	readExtensionsMu.Unlock()
+}
+
+// GetAllowedReadExtensions returns a copy of the read extension allowlist.
+func GetAllowedReadExtensions() []string {
+	readExtensionsMu.RLock()
+	defer readExtensionsMu.RUnlock()
+	if allowedReadExtensions == nil {
+		return nil
+	}
+	out := make([]string, len(allowedReadExtensions))
+	copy(out, allowedReadExtensions)
+	return out
+}
+
+// LoadReadExtensionsFromEnv reads the XLQ_READ_EXTENSIONS environment
+// variable, a comma-separated list such as ".xlsx,.xlsb". The value "*"
+// disables the extension check. If unset, the allowlist is left unchanged.
+func LoadReadExtensionsFromEnv() {
+	env := strings.TrimSpace(os.Getenv("XLQ_READ_EXTENSIONS"))
+	if env == "" {
+		return
+	}
+	if env == "*" {
+		SetAllowedReadExtensions(nil)
+		return
+	}
+	SetAllowedReadExtensions(strings.Split(env, ","))
+}
+
+// normalizeExtensions lowercases extensions and ensures a leading dot.
+// Returns nil when no usable extensions remain.
+func normalizeExtensions(exts []string) []string {
+	var out []string
+	for _, ext := range exts {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		out = append(out, ext)
+	}
+	return out
+}
+
+// checkReadExtension rejects files whose extension is not in the allowlist.
+func checkReadExtension(path string) error {
+	allowed := GetAllowedReadExtensions()
+	if allowed == nil {
+		return nil
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, a := range allowed {
+		if ext == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s (allowed: %s)", ErrUnsupported, filepath.Base(path), strings.Join(allowed, ", "))
+}
+
+// DefaultWriteExtensions are the file extensions writable by default.
+var DefaultWriteExtensions = []string{".xlsx"}
+
+// allowedWriteExtensions holds the lowercase extensions accepted by
+// ValidateWritePath. Unlike reads, writes always need a match.
+// Protected by writeExtensionsMu for thread-safe access.
+var allowedWriteExtensions = normalizeExtensions(DefaultWriteExtensions)
+
+// writeExtensionsMu protects concurrent access to allowedWriteExtensions.
+var writeExtensionsMu sync.RWMutex
+
+// SetAllowedWriteExtensions replaces the write extension allowlist, for
+// example to add ".xlsm". Extensions are matched case-insensitively and may
+// omit the leading dot. Passing nil or an empty slice restores
+// DefaultWriteExtensions, so writes can never target arbitrary files.
+func SetAllowedWriteExtensions(exts []string) {
+	normalized := normalizeExtensions(exts)
+	if normalized == nil {
+		normalized = normalizeExtensions(DefaultWriteExtensions)
+	}
+	writeExtensionsMu.Lock()
+	allowedWriteExtensions = normalized
+	writeExtensionsMu.Unlock()
+}
+
+// GetAllowedWriteExtensions returns a copy of the write extension allowlist.
+func GetAllowedWriteExtensions() []string {
+	writeExtensionsMu.RLock()
+	defer writeExtensionsMu.RUnlock()
+	out := make([]string, len(allowedWriteExtensions))
+	copy(out, allowedWriteExtensions)
+	return out
+}
+
+// LoadWriteExtensionsFromEnv reads the XLQ_WRITE_EXTENSIONS environment
+// variable, a comma-separated list such as ".xlsx,.xlsm". If unset, the
+// allowlist is left unchanged.
+func LoadWriteExtensionsFromEnv() {
+	env := strings.TrimSpace(os.Getenv("XLQ_WRITE_EXTENSIONS"))
+	if env == "" {
+		return
+	}
+	SetAllowedWriteExtensions(strings.Split(env, ","))
+}
+
+// checkWriteExtension rejects write targets whose extension is not in the
+// allowlist, so Excel bytes never land on a text or config file.
+func checkWriteExtension(path string) error {
+	allowed := GetAllowedWriteExtensions()
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, a := range allowed {
+		if ext == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not a writable file type (allowed: %s)", ErrWriteDenied, filepath.Base(path), strings.Join(allowed, ", "))
+}
+
 // ValidateFilePath ensures the path is safe to access.
 func ValidateFilePath(requestedPath string) (string, error) {
+	realPath, err := resolveAllowedPath(requestedPath)
+	if err != nil {
+		return "", err
+	}
+	if err := checkReadExtension(realPath); err != nil {
+		return "", err
+	}
+	return realPath, nil
+}
+
+// ImportExtensions are the file extensions accepted as CSV import sources.
+var ImportExtensions = []string{".csv", ".tsv", ".txt"}
+
+// ValidateImportPath ensures a CSV import source is safe to read. It applies
+// the same directory restrictions as ValidateFilePath but accepts
+// ImportExtensions instead of the read extension allowlist.
+func ValidateImportPath(requestedPath string) (string, error) {
+	realPath, err := resolveAllowedPath(requestedPath)
+	if err != nil {
+		return "", err
+	}
+	ext := strings.ToLower(filepath.Ext(realPath))
+	for _, a := range ImportExtensions {
+		if ext == a {
+			return realPath, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s (allowed: %s)", ErrUnsupported, filepath.Base(realPath), strings.Join(ImportExtensions, ", "))
+}
+
+// resolveAllowedPath resolves symlinks in requestedPath and checks that the
+// result lies within the allowed base paths.
+func resolveAllowedPath(requestedPath string) (string, error) {
 	if requestedPath == "" {
 		return "", fmt.Errorf("file path cannot be empty")
 	}
@@ -179,8 +384,11 @@ func ValidateFilePath(requestedPath string) (string, error) {
 	return "", fmt.Errorf("access denied: path outside allowed directories")
 }
 
-// blockedWritePatterns contains file patterns that should never be written to.
-var blockedWritePatterns = []string{
+// DefaultBlockedWritePatterns contains file patterns that are never written
+// to by default. A pattern ending in "/" matches any directory component, a
+// pattern containing "*" is a glob on the base name, and anything else must
+// equal the base name exactly.
+var DefaultBlockedWritePatterns = []string{
 	".git/",
 	".git",
 	"node_modules/",
@@ -195,6 +403,50 @@ var blockedWritePatterns = []string{
 	"*.db",
 }
 
+// blockedWritePatterns contains file patterns that should never be written to.
+// Protected by blockedPatternsMu for thread-safe access.
+var blockedWritePatterns = DefaultBlockedWritePatterns
+
+// blockedPatternsMu protects concurrent access to blockedWritePatterns.
+var blockedPatternsMu sync.RWMutex
+
+// SetBlockedWritePatterns replaces the blocked write patterns, defaults
+// included. Empty entries are ignored.
+func SetBlockedWritePatterns(patterns []string) {
+	var out []string
+	for _, p := range patterns {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	blockedPatternsMu.Lock()
+	blockedWritePatterns = out
+	blockedPatternsMu.Unlock()
+}
+
+// GetBlockedWritePatterns returns a copy of the blocked write patterns.
+func GetBlockedWritePatterns() []string {
+	blockedPatternsMu.RLock()
+	defer blockedPatternsMu.RUnlock()
+	out := make([]string, len(blockedWritePatterns))
+	copy(out, blockedWritePatterns)
+	return out
+}
+
+// LoadBlockedPatternsFromEnv reads the XLQ_BLOCKED_PATTERNS environment
+// variable and blocks its patterns on top of DefaultBlockedWritePatterns.
+// Patterns are separated by os.PathListSeparator (colon on Unix, semicolon
+// on Windows). If unset, the patterns are left unchanged.
+func LoadBlockedPatternsFromEnv() {
+	env := os.Getenv("XLQ_BLOCKED_PATTERNS")
+	if strings.TrimSpace(env) == "" {
+		return
+	}
+	patterns := append([]string{}, DefaultBlockedWritePatterns...)
+	patterns = append(patterns, strings.Split(env, string(os.PathListSeparator))...)
+	SetBlockedWritePatterns(patterns)
+}
+
 // isBlockedWritePath checks if a path matches any blocked write pattern.
 func isBlockedWritePath(path string) bool {
 	cleanPath := filepath.Clean(path)
@@ -203,7 +455,7 @@ func isBlockedWritePath(path string) bool {
 	// Split path into components for exact matching
 	pathComponents := strings.Split(cleanPath, string(os.PathSeparator))
 
-	for _, pattern := range blockedWritePatterns {
+	for _, pattern := range GetBlockedWritePatterns() {
 		// Check if pattern is a directory pattern (ends with /)
 		if strings.HasSuffix(pattern, "/") {
 			dirPattern := strings.TrimSuffix(pattern, "/")
@@ -231,11 +483,76 @@ func isBlockedWritePath(path string) bool {
 }
 
 // ValidateWritePath validates a path for write operations.
-// It performs all read validations plus:
+// It fails with ErrReadOnly in read-only mode, and performs all read
+// validations plus:
 // - Checks parent directory is writable
 // - Blocks sensitive file patterns
+// - Only accepts extensions in the write allowlist
 // - Handles overwrite flag
 func ValidateWritePath(path string, allowOverwrite bool) (string, error) {
+	return validateWritePath(path, allowOverwrite, checkWriteExtension)
+}
+
+// ValidateBackupPath checks the backup copy a destructive write makes
+// against the blocked write patterns, so a file whose own name is allowed
+// cannot have its backup land on a sensitive one.
+func ValidateBackupPath(backupPath string) error {
+	if isBlockedWritePath(backupPath) {
+		return fmt.Errorf("%w: cannot write backup to sensitive path %s", ErrWriteDenied, backupPath)
+	}
+	return nil
+}
+
+// ExportExtensions are the file extensions the export tool may write.
+var ExportExtensions = []string{".csv", ".tsv", ".json", ".ndjson", ".jsonl"}
+
+// ValidateExportPath validates the target of a sheet export. It applies every
+// ValidateWritePath check, but accepts ExportExtensions instead of the
+// spreadsheet write allowlist.
+func ValidateExportPath(path string, allowOverwrite bool) (string, error) {
+	return validateWritePath(path, allowOverwrite, checkExportExtension)
+}
+
+// checkExportExtension rejects export targets outside ExportExtensions.
+func checkExportExtension(path string) error {
+	return checkExtensionIn(path, ExportExtensions, "an export")
+}
+
+// OutputExtensions are the file extensions the CLI's --output flag may
+// write, one or more for each output format.
+var OutputExtensions = []string{".json", ".ndjson", ".jsonl", ".yaml", ".yml", ".csv", ".tsv", ".md", ".html", ".txt"}
+
+// ValidateOutputPath validates a file the CLI writes formatted output to in
+// place of stdout. It applies every ValidateWritePath check, but accepts
+// OutputExtensions instead of the spreadsheet write allowlist; an existing
+// file is replaced, as with shell redirection.
+func ValidateOutputPath(path string) (string, error) {
+	return validateWritePath(path, true, checkOutputExtension)
+}
+
+// checkOutputExtension rejects output files outside OutputExtensions.
+func checkOutputExtension(path string) error {
+	return checkExtensionIn(path, OutputExtensions, "an output")
+}
+
+// checkExtensionIn rejects path unless its extension is in allowed. kind
+// names the file type in the error, e.g. "an export".
+func checkExtensionIn(path string, allowed []string, kind string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, a := range allowed {
+		if ext == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not %s file type (allowed: %s)", ErrWriteDenied, filepath.Base(path), kind, strings.Join(allowed, ", "))
+}
+
+// validateWritePath implements ValidateWritePath with checkExt deciding
+// which file extensions may be written.
+func validateWritePath(path string, allowOverwrite bool, checkExt func(string) error) (string, error) {
+	if IsReadOnly() {
+		return "", ErrReadOnly
+	}
 	if path == "" {
 		return "", fmt.Errorf("file path cannot be empty")
 	}
@@ -245,6 +562,11 @@ func ValidateWritePath(path string, allowOverwrite bool) (string, error) {
 		return "", fmt.Errorf("%w: cannot write to sensitive path %s", ErrWriteDenied, path)
 	}
 
+	// Only allowed file types may be written
+	if err := checkExt(path); err != nil {
+		return "", err
+	}
+
 	// Get absolute path
 	absPath, err := filepath.Abs(path)
 	if err != nil {
@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -165,6 +166,16 @@ func TestAllHandlersPathTraversal(t *testing.T) {
 			handler: srv.handleSearch,
 			params:  map[string]any{"file": tmpFile, "pattern": "test"},
 		},
+		{
+			name:    "count",
+			handler: srv.handleCount,
+			params:  map[string]any{"file": tmpFile, "pattern": "test"},
+		},
+		{
+			name:    "sheet_visibility",
+			handler: srv.handleSheetVisibility,
+			params:  map[string]any{"file": tmpFile, "sheet": "Sheet1"},
+		},
 		{
 			name:    "cell",
 			handler: srv.handleCell,
@@ -345,6 +356,49 @@ func TestIsBlockedWritePath(t *testing.T) {
 	}
 }
 
+func TestLoadBlockedPatternsFromEnv(t *testing.T) {
+	t.Cleanup(func() { SetBlockedWritePatterns(DefaultBlockedWritePatterns) })
+	t.Setenv("XLQ_BLOCKED_PATTERNS", strings.Join([]string{"*.csv", "secrets/", ""}, string(os.PathListSeparator)))
+
+	LoadBlockedPatternsFromEnv()
+
+	tests := []struct {
+		path    string
+		blocked bool
+	}{
+		{"/data/export.csv", true},
+		{"/data/secrets/report.xlsx", true},
+		{"/project/.env", true},
+		{"/certs/server.pem", true},
+		{"/data/report.xlsx", false},
+	}
+	for _, tt := range tests {
+		if got := isBlockedWritePath(tt.path); got != tt.blocked {
+			t.Errorf("isBlockedWritePath(%q) = %v, want %v", tt.path, got, tt.blocked)
+		}
+	}
+
+	if _, err := ValidateWritePath(filepath.Join(t.TempDir(), "out.csv"), true); !errors.Is(err, ErrWriteDenied) {
+		t.Errorf("expected ErrWriteDenied for a custom pattern, got %v", err)
+	}
+}
+
+func TestSetBlockedWritePatternsOverrides(t *testing.T) {
+	t.Cleanup(func() { SetBlockedWritePatterns(DefaultBlockedWritePatterns) })
+
+	SetBlockedWritePatterns([]string{"*.csv"})
+
+	if !isBlockedWritePath("/data/export.csv") {
+		t.Error("expected custom pattern to block")
+	}
+	if isBlockedWritePath("/certs/server.pem") {
+		t.Error("expected overridden default pattern to no longer block")
+	}
+	if got := GetBlockedWritePatterns(); len(got) != 1 || got[0] != "*.csv" {
+		t.Errorf("GetBlockedWritePatterns() = %v", got)
+	}
+}
+
 // TestValidateWritePath tests write path validation
 func TestValidateWritePath(t *testing.T) {
 	// Setup test directory structure
@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -585,3 +586,99 @@ func TestValidateFilePathSymlinks(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFilePathExtensions(t *testing.T) {
+	allowedPathsMu.RLock()
+	originalPaths := make([]string, len(allowedBasePaths))
+	copy(originalPaths, allowedBasePaths)
+	allowedPathsMu.RUnlock()
+	originalExts := GetAllowedReadExtensions()
+	defer func() {
+		allowedPathsMu.Lock()
+		allowedBasePaths = originalPaths
+		allowedPathsMu.Unlock()
+		SetAllowedReadExtensions(originalExts)
+	}()
+
+	tmpDir := t.TempDir()
+	allowedPathsMu.Lock()
+	allowedBasePaths = []string{tmpDir}
+	allowedPathsMu.Unlock()
+
+	docx := filepath.Join(tmpDir, "report.docx")
+	upper := filepath.Join(tmpDir, "DATA.XLSM")
+	for _, p := range []string{docx, upper} {
+		if err := os.WriteFile(p, []byte("test"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	SetAllowedReadExtensions(DefaultReadExtensions)
+
+	_, err := ValidateFilePath(docx)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("Expected ErrUnsupported for .docx, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "unsupported file type") || !strings.Contains(err.Error(), "report.docx") {
+		t.Errorf("Expected clear unsupported file type message, got: %v", err)
+	}
+
+	if _, err := ValidateFilePath(upper); err != nil {
+		t.Errorf("Expected uppercase .XLSM to be allowed, got: %v", err)
+	}
+
+	t.Setenv("XLQ_READ_EXTENSIONS", "docx, xlsx")
+	LoadReadExtensionsFromEnv()
+	if _, err := ValidateFilePath(docx); err != nil {
+		t.Errorf("Expected .docx allowed via XLQ_READ_EXTENSIONS, got: %v", err)
+	}
+
+	t.Setenv("XLQ_READ_EXTENSIONS", "*")
+	LoadReadExtensionsFromEnv()
+	if GetAllowedReadExtensions() != nil {
+		t.Errorf("Expected \"*\" to disable the extension check, got %v", GetAllowedReadExtensions())
+	}
+}
+
+func TestValidateWritePathExtensions(t *testing.T) {
+	allowedPathsMu.RLock()
+	originalPaths := make([]string, len(allowedBasePaths))
+	copy(originalPaths, allowedBasePaths)
+	allowedPathsMu.RUnlock()
+	originalExts := GetAllowedWriteExtensions()
+	defer func() {
+		allowedPathsMu.Lock()
+		allowedBasePaths = originalPaths
+		allowedPathsMu.Unlock()
+		SetAllowedWriteExtensions(originalExts)
+	}()
+
+	tmpDir := t.TempDir()
+	allowedPathsMu.Lock()
+	allowedBasePaths = []string{tmpDir}
+	allowedPathsMu.Unlock()
+
+	SetAllowedWriteExtensions(DefaultWriteExtensions)
+
+	if _, err := ValidateWritePath(filepath.Join(tmpDir, "report.XLSX"), false); err != nil {
+		t.Errorf("Expected .xlsx to be writable, got: %v", err)
+	}
+
+	for _, name := range []string{"config.json", "notes.txt", "macros.xlsm", "noext"} {
+		_, err := ValidateWritePath(filepath.Join(tmpDir, name), true)
+		if !errors.Is(err, ErrWriteDenied) {
+			t.Errorf("Expected ErrWriteDenied for %s, got: %v", name, err)
+		}
+	}
+
+	t.Setenv("XLQ_WRITE_EXTENSIONS", ".xlsx, xlsm")
+	LoadWriteExtensionsFromEnv()
+	if _, err := ValidateWritePath(filepath.Join(tmpDir, "macros.xlsm"), false); err != nil {
+		t.Errorf("Expected .xlsm allowed via XLQ_WRITE_EXTENSIONS, got: %v", err)
+	}
+
+	SetAllowedWriteExtensions(nil)
+	if got := GetAllowedWriteExtensions(); len(got) != 1 || got[0] != ".xlsx" {
+		t.Errorf("Expected an empty allowlist to fall back to the defaults, got %v", got)
+	}
+}
@@ -3,7 +3,10 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,22 +16,27 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// ServerVersion is the version the server reports to clients
+const ServerVersion = "1.0.0"
+
 // Server wraps the MCP server
 type Server struct {
 	mcpServer *server.MCPServer
 	basepath  string
+	readOnly  bool
 }
 
 // New creates a new MCP server with all tools registered.
 // basepath sets the default base directory for resolving relative file paths.
+// When IsReadOnly is set, write tools are left out entirely.
 func New(basepath string) *Server {
 	s := server.NewMCPServer(
 		"xlq",
-		"1.0.0",
+		ServerVersion,
 		server.WithToolCapabilities(true),
 	)
 
-	srv := &Server{mcpServer: s, basepath: basepath}
+	srv := &Server{mcpServer: s, basepath: basepath, readOnly: IsReadOnly()}
 	srv.registerTools()
 
 	return srv
@@ -39,34 +47,171 @@ func (s *Server) Run() error {
 	return server.ServeStdio(s.mcpServer)
 }
 
+// RunHTTP starts the MCP server on addr using the HTTP/SSE transport.
+// Clients connect to /sse and post messages to /message. The same allowed
+// paths and write restrictions apply as on stdio.
+func (s *Server) RunHTTP(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return s.serveHTTP(ln)
+}
+
+// serveHTTP serves the HTTP/SSE transport on ln until it fails
+func (s *Server) serveHTTP(ln net.Listener) error {
+	httpSrv := &http.Server{Handler: server.NewSSEServer(s.mcpServer)}
+	if err := httpSrv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("HTTP server failed: %w", err)
+	}
+	return nil
+}
+
+// addWriteTool registers a tool that modifies files. Read-only servers skip
+// it, so clients never see write tools they cannot use. Every write tool
+// takes a dry_run flag that runs all checks without saving.
+func (s *Server) addWriteTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if s.readOnly {
+		return
+	}
+	if _, ok := tool.InputSchema.Properties["dry_run"]; !ok {
+		mcp.WithBoolean("dry_run", mcp.Description("Validate and report the result without writing the file (default: false)"))(&tool)
+	}
+	s.mcpServer.AddTool(tool, handler)
+}
+
 func (s *Server) registerTools() {
+	// capabilities tool - Server version, limits and allowed paths
+	s.mcpServer.AddTool(mcp.NewTool("capabilities",
+		mcp.WithDescription("Get the server version, row/cell/output limits and the allowed base paths, to size requests before making them"),
+	), s.handleCapabilities)
+
 	// sheets tool - List all sheets in workbook
 	s.mcpServer.AddTool(mcp.NewTool("sheets",
 		mcp.WithDescription("List all sheets in an Excel workbook"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithBoolean("withSize", mcp.Description("Return sheets with their row counts, sorted largest first (default: false)")),
 	), s.handleSheets)
 
+	// named_ranges tool - List defined names
+	s.mcpServer.AddTool(mcp.NewTool("named_ranges",
+		mcp.WithDescription("List the workbook's defined names and the ranges they refer to. Sheet-scoped names are keyed as Sheet!Name; any name can be passed as the range of the read tool"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+	), s.handleNamedRanges)
+
+	// comments tool - List cell comments
+	s.mcpServer.AddTool(mcp.NewTool("comments",
+		mcp.WithDescription("List the comments (notes) on a sheet with the cell each is attached to, its author and its text"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+	), s.handleComments)
+
 	// info tool - Get sheet metadata
 	s.mcpServer.AddTool(mcp.NewTool("info",
-		mcp.WithDescription("Get metadata about a sheet (rows, columns, headers)"),
+		mcp.WithDescription("Get metadata about a sheet (rows, columns, used dimension, merged cells, hidden state, headers). The size comes from the stored dimension when the file has one, avoiding a row scan"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
 	), s.handleInfo)
 
+	// tree tool - Workbook structure overview
+	s.mcpServer.AddTool(mcp.NewTool("tree",
+		mcp.WithDescription("Get the workbook structure: each sheet with its dimension, header count, charts, images and protection status"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+	), s.handleTree)
+
+	// visible_range tool - Used range without hidden rows and columns
+	s.mcpServer.AddTool(mcp.NewTool("visible_range",
+		mcp.WithDescription("Get the used range of a sheet minus hidden rows and columns, listing which rows and columns are hidden"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+	), s.handleVisibleRange)
+
+	// sheet_visibility tool - Whether a sheet is visible, hidden or very hidden
+	s.mcpServer.AddTool(mcp.NewTool("sheet_visibility",
+		mcp.WithDescription("Get whether a sheet is visible, hidden or veryHidden (hidden and not unhideable from Excel's interface)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+	), s.handleSheetVisibility)
+
+	// calc_props tool - Calculation mode and formula cache state
+	s.mcpServer.AddTool(mcp.NewTool("calc_props",
+		mcp.WithDescription("Get the workbook calculation mode (auto/manual) and whether cached formula values may be stale"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+	), s.handleCalcProps)
+
+	// legend tool - Column letter to header map
+	s.mcpServer.AddTool(mcp.NewTool("legend",
+		mcp.WithDescription("Map column letters to header names from the first row (e.g. {\"A\":\"Name\",\"B\":\"Age\"})"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+	), s.handleLegend)
+
+	// all_headers tool - Header row of every sheet
+	s.mcpServer.AddTool(mcp.NewTool("all_headers",
+		mcp.WithDescription("Get the header row (row 1) of every sheet in one call, keyed by sheet name"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+	), s.handleAllHeaders)
+
 	// read tool - Read cells from a range
 	s.mcpServer.AddTool(mcp.NewTool("read",
-		mcp.WithDescription("Read cells from a range or entire sheet. If no range specified, reads first 1000 rows (configurable via limit)"),
+		mcp.WithDescription("Read cells from a range or entire sheet. If no range specified, reads first 1000 rows (configurable via limit). Page through large sheets with offset and limit, following next_offset in the metadata; the last page reports total_rows"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
-		mcp.WithString("range", mcp.Description("Cell range (e.g., A1:C10). If not specified, reads entire sheet with limit")),
+		mcp.WithString("range", mcp.Description("Cell range (e.g., A1:C10, A:C, 2:5 or A5:C to the last used row), optionally sheet-qualified, or a defined name. If not specified, reads entire sheet with limit")),
+		mcp.WithBoolean("objects", mcp.Description("Return rows as objects keyed by the header row (default: false)")),
+		mcp.WithBoolean("rectangular", mcp.Description("Pad rows with empty cells to the widest row's column count (default: false)")),
+		mcp.WithString("nullRepresentation", mcp.Description("How empty cells are returned in row arrays: empty (\"\") or null (default: empty)")),
+		mcp.WithBoolean("mergedFill", mcp.Description("Fill every cell of a merged region with its top-left value (default: false)")),
+		mcp.WithNumber("maxColumns", mcp.Description("Keep only the first N columns of each row (default: no limit)")),
+		mcp.WithBoolean("visibleOnly", mcp.Description("Skip hidden rows and columns (default: false)")),
+		mcp.WithBoolean("raw", mcp.Description("Return stored values without number formats, e.g. 0.5 instead of 50% or 1234.5 instead of $1,234.50 (default: false, formatted as displayed)")),
+		mcp.WithBoolean("withTypes", mcp.Description("Return each cell as {value, type} with its detected type: string, number, bool, formula, error or empty (default: false)")),
+		mcp.WithBoolean("skipEmpty", mcp.Description("Drop rows in which every cell is empty; the others keep their row numbers, and offset and limit count only them (default: false)")),
+		mcp.WithBoolean("trim", mcp.Description("Strip leading and trailing whitespace from every value (default: false)")),
+		mcp.WithNumber("offset", mcp.Description("Skip this many rows before returning any (default: 0)")),
+		mcp.WithNumber("limit", mcp.Description("Maximum rows to return (default: 1000 for whole sheets, no limit for ranges; max: 10000)")),
 	), s.handleRead)
 
+	// filter tool - Read rows matching a column condition
+	s.mcpServer.AddTool(mcp.NewTool("filter",
+		mcp.WithDescription("Read only the rows where a column matches a condition, e.g. Age>30, City==\"Boston\", Name~=^A. The header row is always included (max 1000 rows)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("where", mcp.Required(), mcp.Description("Condition as column op value; column is a header name or letter; op is ==, !=, >, <, >=, <= or ~= (regex)")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithBoolean("objects", mcp.Description("Return rows as objects keyed by the header row (default: false)")),
+	), s.handleFilter)
+
+	// aggregate tool - Sum/avg/min/max/count of a column
+	s.mcpServer.AddTool(mcp.NewTool("aggregate",
+		mcp.WithDescription("Compute sum, avg, min, max or count over the numeric cells of a column. Non-numeric cells are skipped and reported as ignored"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("column", mcp.Required(), mcp.Description("Column letter (e.g., B) or header label (e.g., Age); a label skips the header row")),
+		mcp.WithString("op", mcp.Required(), mcp.Description("Operation: sum, avg, min, max, count")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+	), s.handleAggregate)
+
+	// data_dictionary tool - Per-column header, type, fill ratio and samples
+	s.mcpServer.AddTool(mcp.NewTool("data_dictionary",
+		mcp.WithDescription("Describe every column of a sheet: header, inferred type, fill ratio, distinct-value count and sample values. Row 1 is the header"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithNumber("sampleRows", mcp.Description("Data rows to scan (default: 1000, max: 50000)")),
+	), s.handleDataDictionary)
+
+	// find_control_chars tool - Cells with control or invisible characters
+	s.mcpServer.AddTool(mcp.NewTool("find_control_chars",
+		mcp.WithDescription("Find cells containing control or invisible characters such as embedded CR/LF, tabs or null bytes (max 1000 cells)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+	), s.handleFindControlChars)
+
 	// head tool - Get first N rows
 	s.mcpServer.AddTool(mcp.NewTool("head",
 		mcp.WithDescription("Get first N rows of a sheet (max 5000 rows)"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
 		mcp.WithNumber("n", mcp.Description("Number of rows (default: 10, max: 5000)")),
+		mcp.WithNumber("maxColumns", mcp.Description("Keep only the first N columns of each row (default: no limit)")),
 	), s.handleHead)
 
 	// tail tool - Get last N rows
@@ -75,6 +220,7 @@ func (s *Server) registerTools() {
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
 		mcp.WithNumber("n", mcp.Description("Number of rows (default: 10, max: 5000)")),
+		mcp.WithNumber("maxColumns", mcp.Description("Keep only the first N columns of each row (default: no limit)")),
 	), s.handleTail)
 
 	// search tool - Search for cells matching a pattern
@@ -85,46 +231,108 @@ func (s *Server) registerTools() {
 		mcp.WithString("sheet", mcp.Description("Sheet to search (default: all sheets)")),
 		mcp.WithBoolean("ignoreCase", mcp.Description("Case-insensitive search (default: false)")),
 		mcp.WithBoolean("regex", mcp.Description("Treat pattern as regex (default: false)")),
+		mcp.WithBoolean("wholeWord", mcp.Description("Only match a literal pattern as a whole word, so cat does not match category (default: false)")),
+		mcp.WithBoolean("fuzzy", mcp.Description("Match whole cells within maxDistance edits of pattern, for near-matches of names; slower than a literal search (default: false)")),
+		mcp.WithNumber("maxDistance", mcp.Description("Maximum edits for fuzzy matches (default: 2)")),
+		mcp.WithBoolean("withRow", mcp.Description("Include each match's row values as context (default: false; adds to output size)")),
+		mcp.WithNumber("rowWindow", mcp.Description("With withRow, only include this many columns on each side of the match (default: 0 = whole row)")),
 		mcp.WithNumber("maxResults", mcp.Description("Maximum results to return (default: 100, max: 1000)")),
 	), s.handleSearch)
 
+	// count tool - Count cells matching a pattern
+	s.mcpServer.AddTool(mcp.NewTool("count",
+		mcp.WithDescription("Count cells matching a pattern, per sheet and in total, without returning them"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("pattern", mcp.Required(), mcp.Description("Search pattern (string or regex)")),
+		mcp.WithString("sheet", mcp.Description("Sheet to search (default: all sheets)")),
+		mcp.WithBoolean("ignoreCase", mcp.Description("Case-insensitive search (default: false)")),
+		mcp.WithBoolean("regex", mcp.Description("Treat pattern as regex (default: false)")),
+		mcp.WithBoolean("wholeWord", mcp.Description("Only match a literal pattern as a whole word (default: false)")),
+		mcp.WithBoolean("fuzzy", mcp.Description("Match whole cells within maxDistance edits of pattern (default: false)")),
+		mcp.WithNumber("maxDistance", mcp.Description("Maximum edits for fuzzy matches (default: 2)")),
+	), s.handleCount)
+
+	// diff tool - Compare two sheets cell by cell
+	s.mcpServer.AddTool(mcp.NewTool("diff",
+		mcp.WithDescription("Compare a sheet with a sheet in another (or the same) file cell by cell and list the cells that changed, were added or were removed as {address, old, new, change} (max 1000 results)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to the old xlsx file")),
+		mcp.WithString("otherFile", mcp.Required(), mcp.Description("Path to the new xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet in the old file (default: first sheet)")),
+		mcp.WithString("otherSheet", mcp.Description("Sheet in the new file (default: same as sheet)")),
+		mcp.WithBoolean("ignoreCase", mcp.Description("Treat values differing only in case as equal (default: false)")),
+		mcp.WithBoolean("ignoreWhitespace", mcp.Description("Ignore leading, trailing and repeated whitespace (default: false)")),
+		mcp.WithNumber("maxResults", mcp.Description("Maximum differences to return (default: 100, max: 1000)")),
+	), s.handleDiff)
+
 	// cell tool - Get single cell value
 	s.mcpServer.AddTool(mcp.NewTool("cell",
 		mcp.WithDescription("Get a single cell value"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("address", mcp.Required(), mcp.Description("Cell address (e.g., A1, B23)")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithBoolean("mergedFill", mcp.Description("For a cell inside a merged region, return the region's top-left value (default: false)")),
+		mcp.WithBoolean("calc", mcp.Description("Evaluate a formula cell now, returning its formula and computed result alongside the cached value (default: false)")),
+		mcp.WithBoolean("raw", mcp.Description("Return the stored value without its number format, e.g. 0.5 instead of 50% (default: false, formatted as displayed)")),
 	), s.handleCell)
 
 	// write_cell tool - Write to a specific cell
-	s.mcpServer.AddTool(mcp.NewTool("write_cell",
+	s.addWriteTool(mcp.NewTool("write_cell",
 		mcp.WithDescription("Write a value to a specific cell in an Excel file"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
 		mcp.WithString("cell", mcp.Required(), mcp.Description("Cell address (e.g., A1, B23)")),
 		mcp.WithString("value", mcp.Required(), mcp.Description("Value to write")),
-		mcp.WithString("type", mcp.Description("Value type: auto, string, number, bool, formula (default: auto)")),
+		mcp.WithString("type", mcp.Description("Value type: auto, string, number, bool, formula, date, datetime (default: auto). Dates use YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC3339")),
 	), s.handleWriteCell)
 
+	// write_cells tool - Write several scattered cells at once
+	s.addWriteTool(mcp.NewTool("write_cells",
+		mcp.WithDescription("Write values to several cells in one save (max 10000 cells)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithObject("cells", mcp.Required(), mcp.Description("Object mapping cell address to value (e.g., {\"A1\": \"Name\", \"B7\": 42})")),
+		mcp.WithString("type", mcp.Description("Value type for all cells: auto, string, number, bool, formula, date, datetime (default: auto)")),
+	), s.handleWriteCells)
+
 	// append_rows tool - Append rows to sheet
-	s.mcpServer.AddTool(mcp.NewTool("append_rows",
+	s.addWriteTool(mcp.NewTool("append_rows",
 		mcp.WithDescription("Append rows to the end of a sheet (max 1000 rows per call)"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
 		// rows parameter will be passed as JSON array via BindArguments
 	), s.handleAppendRows)
 
+	// append_cols tool - Append columns to the right of existing data
+	s.addWriteTool(mcp.NewTool("append_cols",
+		mcp.WithDescription("Append columns to the right of a sheet's data, starting at row 1. Each inner array is one column's values top to bottom (max 10000 cells per call)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		// cols parameter will be passed as JSON array via BindArguments
+	), s.handleAppendCols)
+
+	// write_objects tool - Append objects as rows under matching headers
+	s.addWriteTool(mcp.NewTool("write_objects",
+		mcp.WithDescription("Append objects as rows, placing each value under the header matching its key; unknown keys become new header columns (max 1000 rows per call)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithBoolean("create_sheet", mcp.Description("Create the sheet if it does not exist, with headers from the objects' keys in alphabetical order (default: false)")),
+		// objects will be passed as JSON array via BindArguments
+	), s.handleWriteObjects)
+
 	// create_file tool - Create new Excel file
-	s.mcpServer.AddTool(mcp.NewTool("create_file",
+	s.addWriteTool(mcp.NewTool("create_file",
 		mcp.WithDescription("Create a new Excel file with optional initial data"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path for new xlsx file")),
 		mcp.WithString("sheet_name", mcp.Description("Name of first sheet (default: Sheet1)")),
 		mcp.WithBoolean("overwrite", mcp.Description("Allow overwriting existing file (default: false)")),
+		mcp.WithBoolean("backup", mcp.Description("Copy an existing file to <file>.bak before overwriting it (default: false)")),
+		mcp.WithBoolean("freeze_header", mcp.Description("Freeze the header row when headers are given (default: false)")),
+		mcp.WithBoolean("style_headers", mcp.Description("Make the header row bold with a light fill (default: false)")),
 		// headers and rows will be passed as JSON arrays via BindArguments
 	), s.handleCreateFile)
 
 	// write_range tool - Write to a range of cells
-	s.mcpServer.AddTool(mcp.NewTool("write_range",
+	s.addWriteTool(mcp.NewTool("write_range",
 		mcp.WithDescription("Write a 2D array of values to a range of cells starting at start_cell (max 10000 cells)"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
@@ -132,31 +340,184 @@ func (s *Server) registerTools() {
 		// data will be passed as JSON array via BindArguments
 	), s.handleWriteRange)
 
+	// clear_range tool - Remove values from a range of cells
+	s.addWriteTool(mcp.NewTool("clear_range",
+		mcp.WithDescription("Clear values and formulas from a range of cells, keeping styles (max 10000 cells)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("range", mcp.Required(), mcp.Description("Cell range to clear (e.g., A2:C10)")),
+	), s.handleClearRange)
+
+	// set_cell_style tool - Style a range of cells
+	s.addWriteTool(mcp.NewTool("set_cell_style",
+		mcp.WithDescription("Set font, fill, alignment or number format on a range of cells, keeping other existing style properties (max 10000 cells)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("range", mcp.Required(), mcp.Description("Cell range to style (e.g., A1:D1)")),
+		mcp.WithObject("style", mcp.Required(), mcp.Description("Style properties: bold, italic (bool), font_color, fill (hex like #1F4E79), horizontal (left, center, right, ...), vertical (top, center, bottom, ...), number_format (e.g. #,##0.00)")),
+	), s.handleSetCellStyle)
+
+	// crop tool - Keep only a range of a sheet
+	s.addWriteTool(mcp.NewTool("crop",
+		mcp.WithDescription("Crop a sheet to a range: the range moves to A1 and everything else is cleared (max 10000 cells)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("range", mcp.Required(), mcp.Description("Cell range to keep (e.g., C3:E5)")),
+	), s.handleCrop)
+
+	// swap_rows tool - Exchange the contents of two rows
+	s.addWriteTool(mcp.NewTool("swap_rows",
+		mcp.WithDescription("Swap the contents of two rows across the sheet's used columns"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithNumber("row_a", mcp.Required(), mcp.Description("First row number (1-based)")),
+		mcp.WithNumber("row_b", mcp.Required(), mcp.Description("Second row number (1-based)")),
+		mcp.WithBoolean("styles", mcp.Description("Move cell styles with the values (default: false)")),
+	), s.handleSwapRows)
+
+	// swap_columns tool - Exchange the contents of two columns
+	s.addWriteTool(mcp.NewTool("swap_columns",
+		mcp.WithDescription("Swap the contents of two columns across the sheet's used rows"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("column_a", mcp.Required(), mcp.Description("First column letter (e.g., A)")),
+		mcp.WithString("column_b", mcp.Required(), mcp.Description("Second column letter (e.g., C)")),
+		mcp.WithBoolean("styles", mcp.Description("Move cell styles with the values (default: false)")),
+	), s.handleSwapColumns)
+
+	// merge_cells tool - Merge a range into one cell
+	s.addWriteTool(mcp.NewTool("merge_cells",
+		mcp.WithDescription("Merge a range of at least two cells; only the top-left value is shown. Fails if the range overlaps existing merged cells"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("range", mcp.Required(), mcp.Description("Cell range to merge (e.g., A1:C1)")),
+	), s.handleMergeCells)
+
+	// unmerge_cells tool - Remove merged regions
+	s.addWriteTool(mcp.NewTool("unmerge_cells",
+		mcp.WithDescription("Unmerge every merged region that overlaps a range"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("range", mcp.Required(), mcp.Description("Cell range to unmerge (e.g., A1:C1)")),
+	), s.handleUnmergeCells)
+
+	// freeze_panes tool - Keep top rows and left columns visible
+	s.addWriteTool(mcp.NewTool("freeze_panes",
+		mcp.WithDescription("Freeze the top rows and left columns of a sheet; use rows=0 and cols=0 to unfreeze"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithNumber("rows", mcp.Description("Number of rows to freeze at the top (default: 0)")),
+		mcp.WithNumber("cols", mcp.Description("Number of columns to freeze at the left (default: 0)")),
+	), s.handleFreezePanes)
+
+	// replace tool - Find and replace cell values
+	s.addWriteTool(mcp.NewTool("replace",
+		mcp.WithDescription("Find and replace text in cell values across a sheet or the whole workbook (max 10000 cells). Formula cells are replaced in their formula text"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("pattern", mcp.Required(), mcp.Description("Text or regex to find")),
+		mcp.WithString("replacement", mcp.Required(), mcp.Description("Replacement text; in regex mode $1 refers to capture groups")),
+		mcp.WithString("sheet", mcp.Description("Sheet to replace in (default: all sheets)")),
+		mcp.WithBoolean("ignore_case", mcp.Description("Case-insensitive matching (default: false)")),
+		mcp.WithBoolean("regex", mcp.Description("Treat pattern as regex (default: false)")),
+		mcp.WithBoolean("dry_run", mcp.Description("Report matches without writing the file (default: false)")),
+	), s.handleReplace)
+
+	// set_where tool - Overwrite every cell matching a pattern
+	s.addWriteTool(mcp.NewTool("set_where",
+		mcp.WithDescription("Set every cell whose value matches a pattern to a new value, in a sheet or the whole workbook (max 10000 cells)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("pattern", mcp.Required(), mcp.Description("Text or regex to match, as in search (use ^TODO$ with regex for exact matches)")),
+		mcp.WithString("value", mcp.Required(), mcp.Description("New value for matching cells (type detected automatically)")),
+		mcp.WithString("sheet", mcp.Description("Sheet to update (default: all sheets)")),
+		mcp.WithBoolean("ignore_case", mcp.Description("Case-insensitive matching (default: false)")),
+		mcp.WithBoolean("regex", mcp.Description("Treat pattern as regex (default: false)")),
+		mcp.WithNumber("max_changes", mcp.Description("Stop after this many cells (default: error if more than 10000 match)")),
+	), s.handleSetWhere)
+
+	// strip_control_chars tool - Remove control characters from cells
+	s.addWriteTool(mcp.NewTool("strip_control_chars",
+		mcp.WithDescription("Remove control and invisible characters from cell values; line breaks and tabs become spaces (max 10000 cells)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet to clean (default: all sheets)")),
+		mcp.WithBoolean("keep_newlines", mcp.Description("Keep line breaks, normalizing CRLF and CR to LF (default: false)")),
+	), s.handleStripControlChars)
+
 	// create_sheet tool - Create a new sheet
-	s.mcpServer.AddTool(mcp.NewTool("create_sheet",
+	s.addWriteTool(mcp.NewTool("create_sheet",
 		mcp.WithDescription("Create a new sheet in an existing workbook with optional headers"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the new sheet")),
+		mcp.WithBoolean("style_headers", mcp.Description("Make the header row bold with a light fill (default: false)")),
 		// headers will be passed as JSON array via BindArguments
 	), s.handleCreateSheet)
 
 	// delete_sheet tool - Delete a sheet
-	s.mcpServer.AddTool(mcp.NewTool("delete_sheet",
+	s.addWriteTool(mcp.NewTool("delete_sheet",
 		mcp.WithDescription("Delete a sheet from the workbook (cannot delete the last sheet)"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Required(), mcp.Description("Name of sheet to delete")),
+		mcp.WithBoolean("backup", mcp.Description("Copy the file to <file>.bak before changing it (default: false)")),
 	), s.handleDeleteSheet)
 
 	// rename_sheet tool - Rename a sheet
-	s.mcpServer.AddTool(mcp.NewTool("rename_sheet",
+	s.addWriteTool(mcp.NewTool("rename_sheet",
 		mcp.WithDescription("Rename a sheet in the workbook"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("old_name", mcp.Required(), mcp.Description("Current name of the sheet")),
 		mcp.WithString("new_name", mcp.Required(), mcp.Description("New name for the sheet")),
 	), s.handleRenameSheet)
 
+	// set_sheet_visibility tool - Show or hide a sheet
+	s.addWriteTool(mcp.NewTool("set_sheet_visibility",
+		mcp.WithDescription("Show, hide or very-hide a sheet. At least one sheet must stay visible; hiding the active sheet activates the first visible one"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Required(), mcp.Description("Name of the sheet")),
+		mcp.WithString("visibility", mcp.Required(), mcp.Description("visible, hidden or veryHidden")),
+	), s.handleSetSheetVisibility)
+
+	// add_named_range tool - Define a workbook-level name
+	s.addWriteTool(mcp.NewTool("add_named_range",
+		mcp.WithDescription("Define a workbook-level name for a range. Names start with a letter or underscore, contain no spaces, and must not look like a cell reference"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name to define (e.g., SalesData)")),
+		mcp.WithString("range", mcp.Required(), mcp.Description("Range it refers to, optionally sheet-qualified (e.g., Sheet1!A1:C10; default sheet: first)")),
+	), s.handleAddNamedRange)
+
+	// delete_named_range tool - Remove a defined name
+	s.addWriteTool(mcp.NewTool("delete_named_range",
+		mcp.WithDescription("Remove a defined name. Formulas using it are left unchanged"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name to remove; use Sheet!Name for a sheet-scoped name")),
+	), s.handleDeleteNamedRange)
+
+	// add_comment tool - Attach a comment to a cell
+	s.addWriteTool(mcp.NewTool("add_comment",
+		mcp.WithDescription("Attach a comment (note) to a cell, replacing any comment the cell already has"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("cell", mcp.Required(), mcp.Description("Cell address (e.g., B3)")),
+		mcp.WithString("author", mcp.Required(), mcp.Description("Comment author (max 255 characters)")),
+		mcp.WithString("text", mcp.Required(), mcp.Description("Comment text (max 32512 characters)")),
+	), s.handleAddComment)
+
+	// delete_comment tool - Remove a cell comment
+	s.addWriteTool(mcp.NewTool("delete_comment",
+		mcp.WithDescription("Remove the comment from a cell. Deleting from a cell without a comment is a no-op"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("cell", mcp.Required(), mcp.Description("Cell address (e.g., B3)")),
+	), s.handleDeleteComment)
+
+	// copy_sheet tool - Duplicate a sheet
+	s.addWriteTool(mcp.NewTool("copy_sheet",
+		mcp.WithDescription("Duplicate a sheet with its values, styles and merged cells into a new sheet, e.g. to fill in a copy of a formatted template"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("source", mcp.Required(), mcp.Description("Name of the sheet to copy")),
+		mcp.WithString("destination", mcp.Required(), mcp.Description("Name of the new sheet; must not exist")),
+	), s.handleCopySheet)
+
 	// insert_rows tool - Insert rows at a specific position
-	s.mcpServer.AddTool(mcp.NewTool("insert_rows",
+	s.addWriteTool(mcp.NewTool("insert_rows",
 		mcp.WithDescription("Insert rows at a specific position, shifting existing rows down (max 1000 rows)"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
@@ -164,14 +525,83 @@ func (s *Server) registerTools() {
 		// data will be passed as JSON array via BindArguments
 	), s.handleInsertRows)
 
+	// insert_blank_rows tool - Insert empty rows at a specific position
+	s.addWriteTool(mcp.NewTool("insert_blank_rows",
+		mcp.WithDescription("Insert empty rows at a specific position, shifting existing rows down (max 1000 rows)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithNumber("row", mcp.Required(), mcp.Description("Row number to insert at (1-based)")),
+		mcp.WithNumber("count", mcp.Required(), mcp.Description("Number of blank rows to insert")),
+	), s.handleInsertBlankRows)
+
 	// delete_rows tool - Delete rows from sheet
-	s.mcpServer.AddTool(mcp.NewTool("delete_rows",
+	s.addWriteTool(mcp.NewTool("delete_rows",
 		mcp.WithDescription("Delete rows from sheet (max 1000 rows)"),
 		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
 		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
 		mcp.WithNumber("start_row", mcp.Required(), mcp.Description("First row to delete (1-based)")),
 		mcp.WithNumber("count", mcp.Required(), mcp.Description("Number of rows to delete")),
+		mcp.WithBoolean("backup", mcp.Description("Copy the file to <file>.bak before changing it (default: false)")),
 	), s.handleDeleteRows)
+
+	// dedup tool - Remove duplicate rows
+	s.addWriteTool(mcp.NewTool("dedup",
+		mcp.WithDescription("Remove duplicate rows from a sheet, keeping the first (or last) occurrence of each key and closing the gaps (max 100000 rows)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("columns", mcp.Description("Comma-separated key columns by header or letter (default: the whole row)")),
+		mcp.WithString("keep", mcp.Description("Occurrence to keep: first or last (default: first)")),
+		mcp.WithBoolean("header", mcp.Description("Pin the first row as a header and exclude it from comparison (default: true)")),
+	), s.handleDedup)
+
+	// convert_dates tool - Format serial numbers in a column as dates
+	s.addWriteTool(mcp.NewTool("convert_dates",
+		mcp.WithDescription("Apply a date number format to Excel date serials (e.g. 45000) stored as numbers in a column"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("column", mcp.Required(), mcp.Description("Column letter (e.g., A, C)")),
+		mcp.WithString("format", mcp.Description("Excel number format (default: yyyy-mm-dd)")),
+		mcp.WithBoolean("has_header", mcp.Description("Skip the first row as a header (default: false)")),
+		mcp.WithBoolean("convert_text", mcp.Description("Also convert serials stored as text (default: false)")),
+	), s.handleConvertDates)
+
+	// add_dropdown tool - List validation sourced from another range
+	s.addWriteTool(mcp.NewTool("add_dropdown",
+		mcp.WithDescription("Add an in-cell dropdown to a range whose allowed values come from another range (e.g. Sheet2!$A$1:$A$10)"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet containing the target range (default: first sheet)")),
+		mcp.WithString("target_range", mcp.Required(), mcp.Description("Cells that get the dropdown (e.g., B2:B100)")),
+		mcp.WithString("source_range", mcp.Required(), mcp.Description("Range holding the allowed values, optionally sheet-qualified (e.g., Lists!A1:A10)")),
+	), s.handleAddDropdown)
+
+	// import_csv tool - Write CSV records into a new or existing sheet
+	s.addWriteTool(mcp.NewTool("import_csv",
+		mcp.WithDescription("Import CSV records into a sheet after its last row, creating the sheet if it does not exist. Numeric fields become numbers and short rows are padded"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Target sheet, created if missing (default: first sheet)")),
+		mcp.WithString("csv_file", mcp.Description("Path to a .csv, .tsv or .txt file to import (or use csv)")),
+		mcp.WithString("csv", mcp.Description("Inline CSV text to import (or use csv_file)")),
+		mcp.WithString("delimiter", mcp.Description("Field delimiter (default: ,)")),
+		mcp.WithBoolean("header", mcp.Description("First record is a header; skipped when the sheet already has rows (default: false)")),
+	), s.handleImportCSV)
+
+	// export tool - Stream a sheet to a CSV, TSV or JSON file
+	s.addWriteTool(mcp.NewTool("export",
+		mcp.WithDescription("Stream a sheet to a .csv, .tsv, .json or .ndjson file on disk without loading it into memory. Returns the row count, not the data"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("output", mcp.Required(), mcp.Description("Path of the file to write (.csv, .tsv, .json, .ndjson or .jsonl)")),
+		mcp.WithString("format", mcp.Description("Export format: csv, tsv, json or ndjson (default: from the output extension)")),
+		mcp.WithBoolean("overwrite", mcp.Description("Allow overwriting an existing output file (default: false)")),
+	), s.handleExport)
+
+	// trace tool - Formula precedents and dependents of a cell
+	s.mcpServer.AddTool(mcp.NewTool("trace",
+		mcp.WithDescription("List the cells a cell's formula references (precedents) and the cells whose formulas reference it (dependents), within the sheet"),
+		mcp.WithString("file", mcp.Required(), mcp.Description("Path to xlsx file")),
+		mcp.WithString("sheet", mcp.Description("Sheet name (default: first sheet)")),
+		mcp.WithString("cell", mcp.Required(), mcp.Description("Cell address (e.g., A1, B23)")),
+	), s.handleTrace)
 }
 
 // resolveFile resolves a file path using the server-level basepath.
@@ -213,6 +643,14 @@ func (s *Server) handleSheets(ctx context.Context, request mcp.CallToolRequest)
 	}
 	defer f.Close()
 
+	if request.GetBool("withSize", false) {
+		sizes, err := xlsx.GetSheetsBySize(f)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return jsonResult(sizes)
+	}
+
 	sheets, err := xlsx.GetSheets(f)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
@@ -254,13 +692,38 @@ func (s *Server) handleInfo(ctx context.Context, request mcp.CallToolRequest) (*
 	return jsonResult(info)
 }
 
-func (s *Server) handleRead(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (s *Server) handleCalcProps(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate path
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	f, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer f.Close()
+
+	props, err := xlsx.GetCalcProps(f)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(props)
+}
+
+func (s *Server) handleLegend(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	file, err := s.resolveFile(request.GetString("file", ""))
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 	sheet := request.GetString("sheet", "")
-	rangeStr := request.GetString("range", "")
 
 	// Validate path
 	validPath, err := ValidateFilePath(file)
@@ -274,46 +737,50 @@ func (s *Server) handleRead(ctx context.Context, request mcp.CallToolRequest) (*
 	}
 	defer f.Close()
 
-	// Resolve sheet name
-	resolvedSheet, err := xlsx.ResolveSheetName(f, sheet)
+	legend, err := xlsx.GetLegend(ctx, f, sheet)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	var rows []xlsx.Row
-	var truncated bool
+	return jsonResult(legend)
+}
 
-	if rangeStr != "" {
-		// Read specific range - no limit needed
-		ch, err := xlsx.StreamRange(ctx, f, resolvedSheet, rangeStr)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-		rows, err = xlsx.CollectRows(ch)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-		truncated = false
-	} else {
-		// Read entire sheet with default limit
-		ch, err := xlsx.StreamRows(ctx, f, resolvedSheet, 0, 0)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-		var totalScanned int
-		rows, totalScanned, truncated, err = xlsx.CollectRowsWithLimit(ch, DefaultRowLimit)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-		_ = totalScanned // Used by CollectRowsWithLimit for metadata
+func (s *Server) handleAllHeaders(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return jsonResultWithMetadata(
-		xlsx.RowsToStringSlice(rows),
-		len(rows),
-		truncated,
-		DefaultRowLimit,
-	)
+	// Validate path
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	f, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer f.Close()
+
+	headers, err := xlsx.GetAllHeaders(ctx, f)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(headers)
+}
+
+// columnLimitMetadata returns the metadata for a maxColumns read option,
+// or nil when no column limit was requested
+func columnLimitMetadata(maxColumns int, truncated bool) map[string]any {
+	if maxColumns <= 0 {
+		return nil
+	}
+	return map[string]any{
+		"max_columns":       maxColumns,
+		"columns_truncated": truncated,
+	}
 }
 
 func (s *Server) handleHead(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -323,6 +790,7 @@ func (s *Server) handleHead(ctx context.Context, request mcp.CallToolRequest) (*
 	}
 	sheet := request.GetString("sheet", "")
 	n := request.GetInt("n", DefaultHeadRows)
+	maxColumns := request.GetInt("maxColumns", 0)
 
 	// Cap n at MaxHeadRows and ensure it's at least 1
 	if n <= 0 {
@@ -348,6 +816,9 @@ func (s *Server) handleHead(ctx context.Context, request mcp.CallToolRequest) (*
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	ch, err := xlsx.StreamHead(ctx, f, resolvedSheet, n)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
@@ -357,12 +828,14 @@ func (s *Server) handleHead(ctx context.Context, request mcp.CallToolRequest) (*
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	rows, columnsTruncated := xlsx.LimitColumns(rows, maxColumns)
 
-	return jsonResultWithMetadata(
+	return jsonResultWithExtraMetadata(
 		xlsx.RowsToStringSlice(rows),
 		len(rows),
 		false, // head never truncates - it's a hard limit
 		n,
+		columnLimitMetadata(maxColumns, columnsTruncated),
 	)
 }
 
@@ -373,6 +846,7 @@ func (s *Server) handleTail(ctx context.Context, request mcp.CallToolRequest) (*
 	}
 	sheet := request.GetString("sheet", "")
 	n := request.GetInt("n", DefaultTailRows)
+	maxColumns := request.GetInt("maxColumns", 0)
 
 	// Cap n at MaxTailRows and ensure it's at least 1
 	if n <= 0 {
@@ -402,12 +876,14 @@ func (s *Server) handleTail(ctx context.Context, request mcp.CallToolRequest) (*
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	rows, columnsTruncated := xlsx.LimitColumns(rows, maxColumns)
 
-	return jsonResultWithMetadata(
+	return jsonResultWithExtraMetadata(
 		xlsx.RowsToStringSlice(rows),
 		len(rows),
 		false, // tail never truncates - it's a hard limit
 		n,
+		columnLimitMetadata(maxColumns, columnsTruncated),
 	)
 }
 
@@ -420,6 +896,11 @@ func (s *Server) handleSearch(ctx context.Context, request mcp.CallToolRequest)
 	sheet := request.GetString("sheet", "")
 	ignoreCase := request.GetBool("ignoreCase", false)
 	regex := request.GetBool("regex", false)
+	wholeWord := request.GetBool("wholeWord", false)
+	fuzzy := request.GetBool("fuzzy", false)
+	maxDistance := request.GetInt("maxDistance", xlsx.DefaultMaxDistance)
+	withRow := request.GetBool("withRow", false)
+	rowWindow := request.GetInt("rowWindow", 0)
 	maxResults := request.GetInt("maxResults", DefaultSearchResults)
 
 	// Cap maxResults at MaxSearchResults and ensure it's at least 1
@@ -454,21 +935,29 @@ func (s *Server) handleSearch(ctx context.Context, request mcp.CallToolRequest)
 		CaseInsensitive: ignoreCase,
 		Regex:           regex,
 		MaxResults:      maxResults,
+		WholeWord:       wholeWord,
+		Fuzzy:           fuzzy,
+		MaxDistance:     maxDistance,
+		WithRow:         withRow,
+		RowWindow:       rowWindow,
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	ch, err := xlsx.Search(ctx, f, pattern, opts)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	results, err := xlsx.CollectSearchResults(ch)
+	results, bounds, err := xlsx.CollectSearchResultsWithBounds(ch)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	truncated := len(results) >= maxResults
 
-	return jsonResultWithMetadata(
+	return jsonResultWithExtraMetadata(
 		map[string]any{
 			"pattern": pattern,
 			"results": results,
@@ -476,9 +965,45 @@ func (s *Server) handleSearch(ctx context.Context, request mcp.CallToolRequest)
 		len(results),
 		truncated,
 		maxResults,
+		map[string]any{"boundingRange": bounds},
 	)
 }
 
+func (s *Server) handleCount(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	pattern := request.GetString("pattern", "")
+	sheet := request.GetString("sheet", "")
+
+	// Validate path
+	validPath, err := ValidateFilePath(file)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	f, err := xlsx.OpenFile(validPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer f.Close()
+
+	result, err := xlsx.Count(ctx, f, pattern, xlsx.SearchOptions{
+		Sheet:           sheet,
+		CaseInsensitive: request.GetBool("ignoreCase", false),
+		Regex:           request.GetBool("regex", false),
+		WholeWord:       request.GetBool("wholeWord", false),
+		Fuzzy:           request.GetBool("fuzzy", false),
+		MaxDistance:     request.GetInt("maxDistance", xlsx.DefaultMaxDistance),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(result)
+}
+
 func (s *Server) handleCell(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	file, err := s.resolveFile(request.GetString("file", ""))
 	if err != nil {
@@ -505,7 +1030,12 @@ func (s *Server) handleCell(ctx context.Context, request mcp.CallToolRequest) (*
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	cell, err := xlsx.GetCell(f, resolvedSheet, address)
+	opts := xlsx.StreamOptions{
+		MergedFill: request.GetBool("mergedFill", false),
+		Calc:       request.GetBool("calc", false),
+		RawValues:  request.GetBool("raw", false),
+	}
+	cell, err := xlsx.GetCellWithOptions(f, resolvedSheet, address, opts)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
@@ -522,6 +1052,7 @@ func (s *Server) handleWriteCell(ctx context.Context, request mcp.CallToolReques
 	cell := request.GetString("cell", "")
 	value := request.GetString("value", "")
 	valueType := request.GetString("type", "auto")
+	dryRun := request.GetBool("dry_run", false)
 
 	// 1. Validate write path - allow overwrite for existing files
 	validPath, err := ValidateWritePath(file, true)
@@ -534,11 +1065,14 @@ func (s *Server) handleWriteCell(ctx context.Context, request mcp.CallToolReques
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// 3. Call xlsx.WriteCell
-	result, err := xlsx.WriteCell(validPath, sheet, cell, value, valueType)
+	// 3. Call Workbook.WriteCell
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.WriteResult, error) {
+		return wb.WriteCell(sheet, cell, value, valueType)
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	result.DryRun = dryRun
 
 	return jsonResult(result)
 }
@@ -566,6 +1100,8 @@ func (s *Server) handleAppendRows(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(fmt.Sprintf("too many rows: %d exceeds limit of %d", len(args.Rows), xlsx.MaxAppendRows)), nil
 	}
 
+	dryRun := request.GetBool("dry_run", false)
+
 	// 1. Validate write path
 	validPath, err := ValidateWritePath(file, true)
 	if err != nil {
@@ -577,11 +1113,58 @@ func (s *Server) handleAppendRows(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// 3. Call xlsx.AppendRows
-	result, err := xlsx.AppendRows(validPath, sheet, args.Rows)
+	// 3. Call Workbook.AppendRows
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.AppendResult, error) {
+		return wb.AppendRows(sheet, args.Rows)
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
+
+func (s *Server) handleAppendCols(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+
+	// Parse cols from request arguments using BindArguments
+	var args struct {
+		Cols [][]any `json:"cols"`
+	}
+	if err := request.BindArguments(&args); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to parse cols: %v", err)), nil
+	}
+
+	if len(args.Cols) == 0 {
+		return mcp.NewToolResultError("no columns provided"), nil
+	}
+
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.AppendCols
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.AppendColsResult, error) {
+		return wb.AppendCols(sheet, args.Cols)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
 
 	return jsonResult(result)
 }
@@ -593,6 +1176,10 @@ func (s *Server) handleCreateFile(ctx context.Context, request mcp.CallToolReque
 	}
 	sheetName := request.GetString("sheet_name", "Sheet1")
 	overwrite := request.GetBool("overwrite", false)
+	backup := request.GetBool("backup", false)
+	freezeHeader := request.GetBool("freeze_header", false)
+	styleHeaders := request.GetBool("style_headers", false)
+	dryRun := request.GetBool("dry_run", false)
 
 	// Parse headers and rows from request arguments
 	var args struct {
@@ -616,8 +1203,23 @@ func (s *Server) handleCreateFile(ctx context.Context, request mcp.CallToolReque
 
 	// 2. No need to check file size for new files
 
-	// 3. Call xlsx.CreateFile
-	result, err := xlsx.CreateFile(validPath, sheetName, args.Headers, args.Rows, overwrite)
+	// Back up a file about to be overwritten once the new workbook is
+	// built; new files are skipped
+	backup = backup && overwrite
+	if backup {
+		if err := ValidateBackupPath(validPath + xlsx.BackupSuffix); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	// 3. Call xlsx.CreateFileWithOptions
+	result, err := xlsx.CreateFileWithOptions(validPath, sheetName, args.Headers, args.Rows, xlsx.CreateFileOptions{
+		Overwrite:    overwrite,
+		FreezeHeader: freezeHeader,
+		StyleHeaders: styleHeaders,
+		DryRun:       dryRun,
+		Backup:       backup,
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
@@ -658,6 +1260,8 @@ func (s *Server) handleWriteRange(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(fmt.Sprintf("too many cells: %d exceeds limit of %d", totalCells, xlsx.MaxWriteRangeCells)), nil
 	}
 
+	dryRun := request.GetBool("dry_run", false)
+
 	// 1. Validate write path
 	validPath, err := ValidateWritePath(file, true)
 	if err != nil {
@@ -669,11 +1273,46 @@ func (s *Server) handleWriteRange(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// 3. Call xlsx.WriteRange
-	result, err := xlsx.WriteRange(validPath, sheet, startCell, args.Data)
+	// 3. Call Workbook.WriteRange
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.WriteResult, error) {
+		return wb.WriteRange(sheet, startCell, args.Data)
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
+
+func (s *Server) handleClearRange(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	rangeStr := request.GetString("range", "")
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.ClearRange
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.ClearRangeResult, error) {
+		return wb.ClearRange(sheet, rangeStr)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
 
 	return jsonResult(result)
 }
@@ -684,6 +1323,7 @@ func (s *Server) handleCreateSheet(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 	name := request.GetString("name", "")
+	styleHeaders := request.GetBool("style_headers", false)
 
 	// Parse headers from request arguments
 	var args struct {
@@ -693,6 +1333,8 @@ func (s *Server) handleCreateSheet(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(fmt.Sprintf("failed to parse headers: %v", err)), nil
 	}
 
+	dryRun := request.GetBool("dry_run", false)
+
 	// 1. Validate write path
 	validPath, err := ValidateWritePath(file, true)
 	if err != nil {
@@ -704,11 +1346,16 @@ func (s *Server) handleCreateSheet(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// 3. Call xlsx.CreateSheet
-	result, err := xlsx.CreateSheet(validPath, name, args.Headers)
+	// 3. Call Workbook.CreateSheetWithOptions
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.SheetResult, error) {
+		return wb.CreateSheetWithOptions(name, args.Headers, xlsx.CreateSheetOptions{
+			StyleHeaders: styleHeaders,
+		})
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	result.DryRun = dryRun
 
 	return jsonResult(result)
 }
@@ -719,6 +1366,7 @@ func (s *Server) handleDeleteSheet(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 	sheet := request.GetString("sheet", "")
+	dryRun := request.GetBool("dry_run", false)
 
 	// 1. Validate write path
 	validPath, err := ValidateWritePath(file, true)
@@ -731,11 +1379,29 @@ func (s *Server) handleDeleteSheet(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// 3. Call xlsx.DeleteSheet
-	result, err := xlsx.DeleteSheet(validPath, sheet)
+	backup := request.GetBool("backup", false) && !dryRun
+	if backup {
+		if err := ValidateBackupPath(validPath + xlsx.BackupSuffix); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	// 3. Call Workbook.DeleteSheet, backing up the file once the change is known
+	// to succeed and before it is saved
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.SheetResult, error) {
+		result, err := wb.DeleteSheet(sheet)
+		if err != nil || !backup {
+			return result, err
+		}
+		if result.Backup, err = xlsx.BackupFile(validPath); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	result.DryRun = dryRun
 
 	return jsonResult(result)
 }
@@ -747,6 +1413,43 @@ func (s *Server) handleRenameSheet(ctx context.Context, request mcp.CallToolRequ
 	}
 	oldName := request.GetString("old_name", "")
 	newName := request.GetString("new_name", "")
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call Workbook.RenameSheet
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.SheetResult, error) {
+		return wb.RenameSheet(oldName, newName)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
+
+func (s *Server) handleCopySheet(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	source := request.GetString("source", "")
+	destination := request.GetString("destination", "")
+	dryRun := request.GetBool("dry_run", false)
+
+	if source == "" || destination == "" {
+		return mcp.NewToolResultError("source and destination are required"), nil
+	}
 
 	// 1. Validate write path
 	validPath, err := ValidateWritePath(file, true)
@@ -759,11 +1462,14 @@ func (s *Server) handleRenameSheet(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// 3. Call xlsx.RenameSheet
-	result, err := xlsx.RenameSheet(validPath, oldName, newName)
+	// 3. Call Workbook.CopySheet
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.SheetResult, error) {
+		return wb.CopySheet(source, destination)
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	result.DryRun = dryRun
 
 	return jsonResult(result)
 }
@@ -777,21 +1483,31 @@ func jsonResult(v any) (*mcp.CallToolResult, error) {
 	}
 
 	// Check output size limit
-	if len(data) > MaxOutputBytes {
-		return mcp.NewToolResultError(fmt.Sprintf("Output too large (%d bytes, max %d bytes). Try reducing the range or limit.", len(data), MaxOutputBytes)), nil
+	if maxBytes := GetMaxOutputBytes(); len(data) > maxBytes {
+		return mcp.NewToolResultError(fmt.Sprintf("Output too large (%d bytes, max %d bytes). Try reducing the range or limit.", len(data), maxBytes)), nil
 	}
 
 	return mcp.NewToolResultText(string(data)), nil
 }
 
 func jsonResultWithMetadata(data any, rowsReturned int, truncated bool, limit int) (*mcp.CallToolResult, error) {
+	return jsonResultWithExtraMetadata(data, rowsReturned, truncated, limit, nil)
+}
+
+// jsonResultWithExtraMetadata is jsonResultWithMetadata with additional
+// tool-specific metadata fields
+func jsonResultWithExtraMetadata(data any, rowsReturned int, truncated bool, limit int, extra map[string]any) (*mcp.CallToolResult, error) {
+	metadata := map[string]any{
+		"rows_returned": rowsReturned,
+		"truncated":     truncated,
+		"limit":         limit,
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
 	result := map[string]any{
-		"data": data,
-		"metadata": map[string]any{
-			"rows_returned": rowsReturned,
-			"truncated":     truncated,
-			"limit":         limit,
-		},
+		"data":     data,
+		"metadata": metadata,
 	}
 
 	jsonData, err := json.Marshal(result)
@@ -800,8 +1516,8 @@ func jsonResultWithMetadata(data any, rowsReturned int, truncated bool, limit in
 	}
 
 	// Check output size limit
-	if len(jsonData) > MaxOutputBytes {
-		return mcp.NewToolResultError(fmt.Sprintf("Output too large (%d bytes, max %d bytes). Try reducing the range or limit.", len(jsonData), MaxOutputBytes)), nil
+	if maxBytes := GetMaxOutputBytes(); len(jsonData) > maxBytes {
+		return mcp.NewToolResultError(fmt.Sprintf("Output too large (%d bytes, max %d bytes). Try reducing the range or limit.", len(jsonData), maxBytes)), nil
 	}
 
 	return mcp.NewToolResultText(string(jsonData)), nil
@@ -836,6 +1552,8 @@ func (s *Server) handleInsertRows(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(fmt.Sprintf("invalid row number: %d (must be >= 1)", row)), nil
 	}
 
+	dryRun := request.GetBool("dry_run", false)
+
 	// 1. Validate write path
 	validPath, err := ValidateWritePath(file, true)
 	if err != nil {
@@ -847,11 +1565,14 @@ func (s *Server) handleInsertRows(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// 3. Call xlsx.InsertRows
-	result, err := xlsx.InsertRows(validPath, sheet, row, args.Data)
+	// 3. Call Workbook.InsertRows
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.AppendResult, error) {
+		return wb.InsertRows(sheet, row, args.Data)
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	result.DryRun = dryRun
 
 	return jsonResult(result)
 }
@@ -876,6 +1597,8 @@ func (s *Server) handleDeleteRows(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(fmt.Sprintf("too many rows to delete: %d exceeds limit of %d", count, xlsx.MaxAppendRows)), nil
 	}
 
+	dryRun := request.GetBool("dry_run", false)
+
 	// 1. Validate write path
 	validPath, err := ValidateWritePath(file, true)
 	if err != nil {
@@ -887,11 +1610,29 @@ func (s *Server) handleDeleteRows(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// 3. Call xlsx.DeleteRows
-	result, err := xlsx.DeleteRows(validPath, sheet, startRow, count)
+	backup := request.GetBool("backup", false) && !dryRun
+	if backup {
+		if err := ValidateBackupPath(validPath + xlsx.BackupSuffix); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	// 3. Call Workbook.DeleteRows, backing up the file once the change is known
+	// to succeed and before it is saved
+	result, err := xlsx.Edit(validPath, dryRun, func(wb *xlsx.Workbook) (*xlsx.DeleteRowsResult, error) {
+		result, err := wb.DeleteRows(sheet, startRow, count)
+		if err != nil || !backup {
+			return result, err
+		}
+		if result.Backup, err = xlsx.BackupFile(validPath); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	result.DryRun = dryRun
 
 	return jsonResult(result)
 }
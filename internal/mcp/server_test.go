@@ -1,11 +1,21 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/xuri/excelize/v2"
 )
 
 func TestNewServer(t *testing.T) {
@@ -19,6 +29,77 @@ func TestNewServer(t *testing.T) {
 	}
 }
 
+func TestServeHTTPListsTools(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go New("").serveHTTP(ln)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c, err := client.NewSSEMCPClient("http://" + ln.Addr().String() + "/sse")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer c.Close()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("failed to start client: %v", err)
+	}
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "xlq-test", Version: "1.0.0"}
+	if _, err := c.Initialize(ctx, initRequest); err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+
+	tools, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		t.Fatalf("failed to list tools: %v", err)
+	}
+	found := false
+	for _, tool := range tools.Tools {
+		if tool.Name == "read" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected read tool over HTTP, got %d tools", len(tools.Tools))
+	}
+}
+
+func TestHandleCapabilities(t *testing.T) {
+	origBasePaths := allowedBasePaths
+	defer func() { allowedBasePaths = origBasePaths }()
+	allowedBasePaths = []string{t.TempDir()}
+
+	result, err := New("").handleCapabilities(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handleCapabilities returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+
+	var caps Capabilities
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &caps); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if caps.Version != ServerVersion {
+		t.Errorf("expected version %s, got %s", ServerVersion, caps.Version)
+	}
+	if caps.MaxAppendRows != xlsx.MaxAppendRows || caps.MaxOutputBytes != MaxOutputBytes || caps.DefaultRowLimit != DefaultRowLimit {
+		t.Errorf("limits do not match the constants: %+v", caps)
+	}
+	if len(caps.AllowedPaths) != 1 || caps.AllowedPaths[0] != allowedBasePaths[0] {
+		t.Errorf("expected allowed paths %v, got %v", allowedBasePaths, caps.AllowedPaths)
+	}
+}
+
 func TestJsonResult(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -212,3 +293,235 @@ func TestLimitsConstants(t *testing.T) {
 		t.Errorf("MaxOutputBytes should be 5MB, got %d", MaxOutputBytes)
 	}
 }
+
+func TestLoadLimitsFromEnv(t *testing.T) {
+	defer func() {
+		SetMaxOutputBytes(MaxOutputBytes)
+		xlsx.MaxAppendRows = xlsx.DefaultMaxAppendRows
+	}()
+
+	// A raised value is applied and enforced by the result helpers
+	t.Setenv("XLQ_MAX_OUTPUT_BYTES", "10")
+	t.Setenv("XLQ_MAX_APPEND_ROWS", "5000")
+	if err := LoadLimitsFromEnv(); err != nil {
+		t.Fatalf("LoadLimitsFromEnv failed: %v", err)
+	}
+	if got := GetMaxOutputBytes(); got != 10 {
+		t.Errorf("expected output cap 10, got %d", got)
+	}
+	if xlsx.MaxAppendRows != 5000 {
+		t.Errorf("expected MaxAppendRows 5000, got %d", xlsx.MaxAppendRows)
+	}
+	result, err := jsonResult(strings.Repeat("x", 20))
+	if err != nil {
+		t.Fatalf("jsonResult returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected output over the configured cap to be rejected")
+	}
+
+	// Invalid values are reported and fall back to the defaults
+	t.Setenv("XLQ_MAX_OUTPUT_BYTES", "-1")
+	t.Setenv("XLQ_MAX_APPEND_ROWS", "lots")
+	if err := LoadLimitsFromEnv(); err == nil {
+		t.Error("expected an error for invalid limits")
+	}
+	if got := GetMaxOutputBytes(); got != MaxOutputBytes {
+		t.Errorf("expected default output cap %d, got %d", MaxOutputBytes, got)
+	}
+	if xlsx.MaxAppendRows != xlsx.DefaultMaxAppendRows {
+		t.Errorf("expected default MaxAppendRows %d, got %d", xlsx.DefaultMaxAppendRows, xlsx.MaxAppendRows)
+	}
+}
+
+func TestHandleReadPagination(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_read_page_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_page.xlsx")
+	rows := make([][]any, 5)
+	for i := range rows {
+		rows[i] = []any{fmt.Sprintf("row%d", i+2)}
+	}
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Name"}, rows, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	read := func(offset, limit int) (data [][]string, metadata map[string]any) {
+		t.Helper()
+		result, err := srv.handleRead(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name:      "read",
+				Arguments: map[string]any{"file": testFile, "offset": offset, "limit": limit},
+			},
+		})
+		if err != nil {
+			t.Fatalf("handleRead returned error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("expected success, got error: %+v", result)
+		}
+		var parsed struct {
+			Data     [][]string     `json:"data"`
+			Metadata map[string]any `json:"metadata"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &parsed); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		return parsed.Data, parsed.Metadata
+	}
+
+	data, metadata := read(2, 2)
+	if len(data) != 2 || data[0][0] != "row3" {
+		t.Errorf("expected rows row3 and row4, got %v", data)
+	}
+	if metadata["truncated"] != true || metadata["next_offset"] != float64(4) {
+		t.Errorf("expected truncated page with next_offset 4, got %v", metadata)
+	}
+	if metadata["rows_scanned"] != float64(5) {
+		t.Errorf("expected 5 rows scanned, got %v", metadata["rows_scanned"])
+	}
+	if _, ok := metadata["total_rows"]; ok {
+		t.Errorf("expected no total_rows before the end, got %v", metadata)
+	}
+
+	data, metadata = read(4, 2)
+	if len(data) != 2 || metadata["truncated"] != false {
+		t.Errorf("expected a final page of 2 rows, got %v %v", data, metadata)
+	}
+	if _, ok := metadata["next_offset"]; ok {
+		t.Errorf("expected no next_offset on the last page, got %v", metadata)
+	}
+	if metadata["total_rows"] != float64(6) {
+		t.Errorf("expected 6 total rows on the last page, got %v", metadata["total_rows"])
+	}
+
+	data, metadata = read(100, 2)
+	if data == nil || len(data) != 0 || metadata["truncated"] != false {
+		t.Errorf("expected an empty page past the end, got %v %v", data, metadata)
+	}
+}
+
+func TestHandleReadObjectsMaxColumnsOffsetRange(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_read_objects_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "objects.xlsx")
+	headers := []string{"A1h", "B1h", "C1h", "D1h", "E1h"}
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", headers, [][]any{{"a", "b", "c", "d", "e"}}, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	result, err := srv.handleRead(context.Background(), createMockRequest("read", map[string]any{
+		"file": testFile, "range": "C1:E2", "objects": true, "maxColumns": 2,
+	}))
+	if err != nil {
+		t.Fatalf("handleRead returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+	var parsed struct {
+		Data []map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &parsed); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	want := []map[string]string{{"C1h": "c", "D1h": "d"}}
+	if !reflect.DeepEqual(parsed.Data, want) {
+		t.Errorf("expected %v, got %v", want, parsed.Data)
+	}
+}
+
+func TestHandleCellRaw(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_cell_raw_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "percent.xlsx")
+	f := excelize.NewFile()
+	percent, err := f.NewStyle(&excelize.Style{NumFmt: 9}) // 0%
+	if err != nil {
+		t.Fatalf("failed to create style: %v", err)
+	}
+	if err := f.SetCellValue("Sheet1", "A1", 0.5); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := f.SetCellStyle("Sheet1", "A1", "A1", percent); err != nil {
+		t.Fatalf("failed to set style: %v", err)
+	}
+	if err := f.SaveAs(testFile); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	srv := New("")
+	for raw, want := range map[bool]string{false: "50%", true: "0.5"} {
+		result, err := srv.handleCell(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"file": testFile, "address": "A1", "raw": raw}},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("handleCell failed: %v %+v", err, result)
+		}
+		var cell xlsx.Cell
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &cell); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		if cell.Value != want {
+			t.Errorf("raw=%v: value = %q, want %q", raw, cell.Value, want)
+		}
+	}
+}
+
+func TestHandleDiff(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_diff_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldFile := filepath.Join(tmpDir, "old.xlsx")
+	newFile := filepath.Join(tmpDir, "new.xlsx")
+	if _, err := xlsx.CreateFile(oldFile, "Data", []string{"Name"}, [][]any{{"alpha"}, {"beta"}}, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	if _, err := xlsx.CreateFile(newFile, "Data", []string{"Name"}, [][]any{{"alpha"}, {"gamma"}}, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	result, err := srv.handleDiff(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"file": oldFile, "otherFile": newFile, "sheet": "Data"}},
+	})
+	if err != nil {
+		t.Fatalf("handleDiff returned error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("expected success, got error: %s", text)
+	}
+	var parsed struct {
+		Data     xlsx.DiffResult `json:"data"`
+		Metadata map[string]any  `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(text), &parsed); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	want := xlsx.CellDiff{Address: "A3", Old: "beta", New: "gamma", Change: xlsx.DiffChanged}
+	if len(parsed.Data.Differences) != 1 || parsed.Data.Differences[0] != want {
+		t.Errorf("expected %+v, got %+v", want, parsed.Data.Differences)
+	}
+	if parsed.Metadata["truncated"] != false {
+		t.Errorf("expected untruncated result, got %v", parsed.Metadata)
+	}
+}
@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) handleAddDropdown(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	file, err := s.resolveFile(request.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sheet := request.GetString("sheet", "")
+	targetRange := request.GetString("target_range", "")
+	sourceRange := request.GetString("source_range", "")
+	dryRun := request.GetBool("dry_run", false)
+
+	// 1. Validate write path
+	validPath, err := ValidateWritePath(file, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 2. Check file size
+	if err := CheckFileSize(validPath, xlsx.MaxWriteFileSize); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 3. Call xlsx.AddDropdownFromRange, which also reads the saved
+	// validation back; dry runs only preview it
+	var result *xlsx.DropdownResult
+	if dryRun {
+		result, err = xlsx.Edit(validPath, true, func(wb *xlsx.Workbook) (*xlsx.DropdownResult, error) {
+			return wb.AddDropdownFromRange(sheet, targetRange, sourceRange)
+		})
+	} else {
+		result, err = xlsx.AddDropdownFromRange(validPath, sheet, targetRange, sourceRange)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result.DryRun = dryRun
+
+	return jsonResult(result)
+}
@@ -5,12 +5,71 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/fuabioo/xlq/internal/output"
 	"github.com/fuabioo/xlq/internal/xlsx"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+func TestReadOnlyServer(t *testing.T) {
+	SetReadOnly(true)
+	t.Cleanup(func() { SetReadOnly(false) })
+
+	tmpDir := filepath.Join("testdata", "tmp_read_only_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_read_only.xlsx")
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", nil, nil, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+
+	for _, name := range []string{"write_cell", "append_rows", "create_file", "write_range", "create_sheet", "delete_sheet", "rename_sheet", "insert_rows", "delete_rows"} {
+		if srv.mcpServer.GetTool(name) != nil {
+			t.Errorf("write tool %s registered on a read-only server", name)
+		}
+	}
+	if srv.mcpServer.GetTool("read") == nil {
+		t.Error("read tool missing on a read-only server")
+	}
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "write_cell",
+			Arguments: map[string]any{
+				"file":  testFile,
+				"cell":  "A1",
+				"value": "changed",
+			},
+		},
+	}
+	result, err := srv.handleWriteCell(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handleWriteCell returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected an error result from a read-only server")
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; text != ErrReadOnly.Error() {
+		t.Errorf("expected %q, got %q", ErrReadOnly.Error(), text)
+	}
+
+	f, err := xlsx.OpenFile(testFile)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+	if value, _ := f.GetCellValue("Sheet1", "A1"); value != "" {
+		t.Errorf("file was modified: A1 = %q", value)
+	}
+}
+
 func TestHandleWriteCell(t *testing.T) {
 	// Create a temporary test directory in current working directory
 	tmpDir := filepath.Join("testdata", "tmp_write_cell_test")
@@ -177,6 +236,45 @@ func TestHandleAppendRows(t *testing.T) {
 	}
 }
 
+func TestHandleAppendCols(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_append_cols_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_append_cols.xlsx")
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Name"}, [][]any{{"a"}, {"b"}}, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	result, err := srv.handleAppendCols(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "append_cols",
+			Arguments: map[string]any{
+				"file": testFile,
+				"cols": []any{[]any{"Score", 1, 2}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("handleAppendCols returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+	var colsResult xlsx.AppendColsResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &colsResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if colsResult.StartingColumn != "B" || colsResult.EndingColumn != "B" {
+		t.Errorf("expected column B, got %+v", colsResult)
+	}
+	assertCell(t, testFile, "B1", "Score")
+	assertCell(t, testFile, "B3", "2")
+}
+
 func TestHandleCreateFile(t *testing.T) {
 	// Create a temporary test directory in current working directory
 	tmpDir := filepath.Join("testdata", "tmp_create_file_test")
@@ -380,3 +478,541 @@ func TestHandleAppendRowsErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleWriteCells(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_write_cells_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_write_cells.xlsx")
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", nil, nil, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "write_cells",
+			Arguments: map[string]any{
+				"file":  testFile,
+				"cells": map[string]any{"A1": "Total", "D9": 12.5},
+			},
+		},
+	}
+
+	result, err := srv.handleWriteCells(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handleWriteCells returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+
+	textContent, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("content is not TextContent type")
+	}
+	var writeResult xlsx.WriteCellsResult
+	if err := json.Unmarshal([]byte(textContent.Text), &writeResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if writeResult.CellsWritten != 2 {
+		t.Errorf("expected 2 cells written, got %d", writeResult.CellsWritten)
+	}
+
+	f, err := xlsx.OpenFile(testFile)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	cell, err := xlsx.GetCell(f, "Sheet1", "D9")
+	if err != nil {
+		t.Fatalf("failed to get cell: %v", err)
+	}
+	if cell.Value != "12.5" {
+		t.Errorf("expected D9 = 12.5, got %s", cell.Value)
+	}
+}
+
+func TestHandleDeleteRowsBackup(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_backup_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_backup.xlsx")
+	rows := [][]any{{"a"}, {"b"}, {"c"}}
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Name"}, rows, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	request := func() mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "delete_rows",
+				Arguments: map[string]any{
+					"file":      testFile,
+					"start_row": 2,
+					"count":     1,
+					"backup":    true,
+				},
+			},
+		}
+	}
+
+	// A failed backup must leave the file untouched
+	backupPath := testFile + xlsx.BackupSuffix
+	if err := os.Mkdir(backupPath+".tmp", 0755); err != nil {
+		t.Fatalf("failed to block backup: %v", err)
+	}
+	result, err := srv.handleDeleteRows(context.Background(), request())
+	if err != nil {
+		t.Fatalf("handleDeleteRows returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected an error result when the backup fails")
+	}
+	assertCell(t, testFile, "A2", "a")
+	if err := os.Remove(backupPath + ".tmp"); err != nil {
+		t.Fatalf("failed to unblock backup: %v", err)
+	}
+
+	result, err = srv.handleDeleteRows(context.Background(), request())
+	if err != nil {
+		t.Fatalf("handleDeleteRows returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+
+	var deleteResult xlsx.DeleteRowsResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &deleteResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if !strings.HasSuffix(deleteResult.Backup, filepath.Base(backupPath)) {
+		t.Errorf("expected backup %s, got %s", backupPath, deleteResult.Backup)
+	}
+	assertCell(t, testFile, "A2", "b")
+	assertCell(t, backupPath, "A2", "a")
+
+	// A request that fails must not replace the last good backup
+	failing := request()
+	failing.Params.Arguments.(map[string]any)["sheet"] = "Missing"
+	if result, err = srv.handleDeleteRows(context.Background(), failing); err != nil {
+		t.Fatalf("handleDeleteRows returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected an error result for a missing sheet")
+	}
+	assertCell(t, backupPath, "A2", "a")
+
+	// Nor may the backup land on a blocked name
+	t.Cleanup(func() { SetBlockedWritePatterns(DefaultBlockedWritePatterns) })
+	SetBlockedWritePatterns([]string{"*.bak"})
+	if result, err = srv.handleDeleteRows(context.Background(), request()); err != nil {
+		t.Fatalf("handleDeleteRows returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected an error result for a blocked backup path")
+	}
+	assertCell(t, testFile, "A2", "b")
+}
+
+func TestHandleWriteDryRun(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_dry_run_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_dry_run.xlsx")
+	rows := [][]any{{"a"}, {"b"}}
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Name"}, rows, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+
+	result, err := srv.handleWriteRange(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "write_range",
+			Arguments: map[string]any{
+				"file":       testFile,
+				"start_cell": "B2",
+				"data":       []any{[]any{1, 2, 3}, []any{4, 5, 6}, []any{7, 8, 9}},
+				"dry_run":    true,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("handleWriteRange returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+	var writeResult xlsx.WriteResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &writeResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if !writeResult.DryRun {
+		t.Error("expected dry_run marker in result")
+	}
+	if writeResult.Cell != "B2:D4" {
+		t.Errorf("expected range B2:D4, got %s", writeResult.Cell)
+	}
+	assertCell(t, testFile, "B2", "")
+
+	result, err = srv.handleDeleteRows(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "delete_rows",
+			Arguments: map[string]any{
+				"file":      testFile,
+				"start_row": 2,
+				"count":     10,
+				"backup":    true,
+				"dry_run":   true,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("handleDeleteRows returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+	var deleteResult xlsx.DeleteRowsResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &deleteResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if !deleteResult.DryRun || deleteResult.RowsWithData != 2 {
+		t.Errorf("expected dry run with 2 rows with data, got %+v", deleteResult)
+	}
+	assertCell(t, testFile, "A2", "a")
+	if _, err := os.Stat(testFile + xlsx.BackupSuffix); !os.IsNotExist(err) {
+		t.Errorf("expected no backup on a dry run, got %v", err)
+	}
+}
+
+func TestHandleImportCSV(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_import_csv_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_import.xlsx")
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Name", "Qty"}, [][]any{{"a", 1}}, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	csvFile := filepath.Join(tmpDir, "rows.tsv")
+	if err := os.WriteFile(csvFile, []byte("Name\tQty\nb\t2\nc\n"), 0644); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+
+	srv := New("")
+
+	result, err := srv.handleImportCSV(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "import_csv",
+			Arguments: map[string]any{
+				"file":      testFile,
+				"csv_file":  csvFile,
+				"delimiter": "\t",
+				"header":    true,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("handleImportCSV returned error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+	var importResult xlsx.ImportResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &importResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if importResult.RowsAdded != 2 || importResult.StartingRow != 3 || !importResult.HeaderSkipped {
+		t.Errorf("expected 2 rows from row 3 with header skipped, got %+v", importResult)
+	}
+	assertCell(t, testFile, "A3", "b")
+	assertCell(t, testFile, "B3", "2")
+	assertCell(t, testFile, "A4", "c")
+
+	for name, args := range map[string]map[string]any{
+		"no source":      {"file": testFile},
+		"both sources":   {"file": testFile, "csv_file": csvFile, "csv": "x"},
+		"bad delimiter":  {"file": testFile, "csv": "x", "delimiter": ";;"},
+		"xlsx as source": {"file": testFile, "csv_file": testFile},
+	} {
+		result, err := srv.handleImportCSV(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "import_csv", Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("%s: handleImportCSV returned error: %v", name, err)
+		}
+		if !result.IsError {
+			t.Errorf("%s: expected error result", name)
+		}
+	}
+}
+
+func TestHandleExport(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_export_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_export.xlsx")
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Name"}, [][]any{{"a"}, {"b"}}, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	outFile := filepath.Join(tmpDir, "out.tsv")
+
+	srv := New("")
+	call := func(args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := srv.handleExport(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "export", Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("handleExport returned error: %v", err)
+		}
+		return result
+	}
+
+	result := call(map[string]any{"file": testFile, "output": outFile, "dry_run": true})
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+	if _, err := os.Stat(outFile); !os.IsNotExist(err) {
+		t.Errorf("expected no output on a dry run, got %v", err)
+	}
+
+	result = call(map[string]any{"file": testFile, "output": outFile})
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result)
+	}
+	var exportResult output.ExportResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &exportResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if exportResult.Rows != 3 || exportResult.Format != "tsv" {
+		t.Errorf("expected 3 tsv rows, got %+v", exportResult)
+	}
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if string(data) != "Name\na\nb\n" {
+		t.Errorf("unexpected export content %q", string(data))
+	}
+
+	if result := call(map[string]any{"file": testFile, "output": outFile}); !result.IsError {
+		t.Error("expected error when output exists without overwrite")
+	}
+	if result := call(map[string]any{"file": testFile, "output": filepath.Join(tmpDir, "out.xlsx")}); !result.IsError {
+		t.Error("expected error for a non-export extension")
+	}
+}
+
+func TestHandleNamedRanges(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_named_ranges_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_names.xlsx")
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Name"}, [][]any{{"alpha"}, {"beta"}}, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) string {
+		t.Helper()
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		if err != nil {
+			t.Fatalf("handler returned error: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("expected success, got error: %s", text)
+		}
+		return text
+	}
+
+	call(srv.handleAddNamedRange, map[string]any{"file": testFile, "name": "Values", "range": "A2:A3"})
+
+	text := call(srv.handleNamedRanges, map[string]any{"file": testFile})
+	if !strings.Contains(text, `"Values":"Sheet1!$A$2:$A$3"`) {
+		t.Errorf("expected Values in named ranges, got %s", text)
+	}
+
+	text = call(srv.handleRead, map[string]any{"file": testFile, "range": "Values"})
+	if !strings.Contains(text, "beta") || strings.Contains(text, `"Name"`) {
+		t.Errorf("expected read of the named range only, got %s", text)
+	}
+
+	call(srv.handleDeleteNamedRange, map[string]any{"file": testFile, "name": "values"})
+
+	result, err := srv.handleAddNamedRange(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"file": testFile, "name": "B2", "range": "A1"}},
+	})
+	if err != nil {
+		t.Fatalf("handleAddNamedRange returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected error for a name that looks like a cell reference")
+	}
+}
+
+func TestHandleSheetVisibility(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_sheet_visibility_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_visibility.xlsx")
+	if _, err := xlsx.CreateFile(testFile, "Report", []string{"Total"}, nil, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	if _, err := xlsx.CreateSheet(testFile, "Lookup", nil); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+
+	srv := New("")
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) (string, bool) {
+		t.Helper()
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		if err != nil {
+			t.Fatalf("handler returned error: %v", err)
+		}
+		return result.Content[0].(mcp.TextContent).Text, result.IsError
+	}
+
+	text, isErr := call(srv.handleSetSheetVisibility, map[string]any{"file": testFile, "sheet": "Lookup", "visibility": "veryHidden"})
+	if isErr || !strings.Contains(text, `"previous":"visible"`) {
+		t.Fatalf("expected Lookup to be very hidden, got %s", text)
+	}
+
+	text, _ = call(srv.handleSheetVisibility, map[string]any{"file": testFile, "sheet": "Lookup"})
+	if text != `{"sheet":"Lookup","visibility":"veryHidden"}` {
+		t.Errorf("unexpected visibility: %s", text)
+	}
+	text, _ = call(srv.handleInfo, map[string]any{"file": testFile, "sheet": "Lookup"})
+	if !strings.Contains(text, `"hidden":true,"visibility":"veryHidden"`) {
+		t.Errorf("expected info to report the sheet very hidden, got %s", text)
+	}
+
+	text, isErr = call(srv.handleSetSheetVisibility, map[string]any{"file": testFile, "sheet": "Report", "visibility": "hidden"})
+	if !isErr || !strings.Contains(text, xlsx.ErrNoVisibleSheet.Error()) {
+		t.Errorf("expected an error hiding the last visible sheet, got %s", text)
+	}
+	_, isErr = call(srv.handleSetSheetVisibility, map[string]any{"file": testFile, "sheet": "Report", "visibility": "gone"})
+	if !isErr {
+		t.Error("expected an error for an unknown visibility")
+	}
+}
+
+func TestHandleComments(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_comments_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_comments.xlsx")
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Name"}, [][]any{{"alpha"}}, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) string {
+		t.Helper()
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		if err != nil {
+			t.Fatalf("handler returned error: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("expected success, got error: %s", text)
+		}
+		return text
+	}
+
+	call(srv.handleAddComment, map[string]any{"file": testFile, "cell": "A2", "author": "Ana", "text": "check spelling"})
+
+	text := call(srv.handleComments, map[string]any{"file": testFile})
+	if !strings.Contains(text, `"cell":"A2"`) || !strings.Contains(text, `"text":"check spelling"`) {
+		t.Errorf("expected the A2 comment, got %s", text)
+	}
+
+	text = call(srv.handleDeleteComment, map[string]any{"file": testFile, "cell": "A2", "dry_run": true})
+	if !strings.Contains(text, `"deleted":true`) {
+		t.Errorf("expected dry run to report deleted, got %s", text)
+	}
+	call(srv.handleDeleteComment, map[string]any{"file": testFile, "cell": "A2"})
+
+	text = call(srv.handleComments, map[string]any{"file": testFile})
+	if text != "[]" {
+		t.Errorf("expected no comments after delete, got %s", text)
+	}
+
+	text = call(srv.handleDeleteComment, map[string]any{"file": testFile, "cell": "A2"})
+	if strings.Contains(text, `"deleted"`) {
+		t.Errorf("expected deleting a missing comment to be a no-op, got %s", text)
+	}
+}
+
+func TestHandleDedup(t *testing.T) {
+	tmpDir := filepath.Join("testdata", "tmp_dedup_test")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "test_dedup.xlsx")
+	rows := [][]any{{"a", 1}, {"b", 2}, {"a", 3}}
+	if _, err := xlsx.CreateFile(testFile, "Sheet1", []string{"Key", "Value"}, rows, false); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	srv := New("")
+	result, err := srv.handleDedup(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"file": testFile, "columns": "Key", "keep": "last"}},
+	})
+	if err != nil {
+		t.Fatalf("handleDedup returned error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("expected success, got error: %s", text)
+	}
+	if !strings.Contains(text, `"duplicates_removed":1`) {
+		t.Errorf("expected 1 duplicate removed, got %s", text)
+	}
+	assertCell(t, testFile, "B2", "2")
+	assertCell(t, testFile, "B3", "3")
+	assertCell(t, testFile, "A4", "")
+}
+
+// assertCell checks a cell value in a saved file
+func assertCell(t *testing.T, path, cell, want string) {
+	t.Helper()
+	f, err := xlsx.OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+	if got, _ := f.GetCellValue("Sheet1", cell); got != want {
+		t.Errorf("%s %s: expected %q, got %q", filepath.Base(path), cell, want, got)
+	}
+}
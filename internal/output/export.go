@@ -0,0 +1,224 @@
+package output
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"github.com/xuri/excelize/v2"
+)
+
+// ExportResult describes a sheet written to a file by ExportSheet
+type ExportResult struct {
+	Success bool   `json:"success"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+	File    string `json:"file"`
+	Sheet   string `json:"sheet"`
+	Format  string `json:"format"`
+	Rows    int    `json:"rows"`
+}
+
+// ExportAllResult lists the files written by export-all, one per sheet
+type ExportAllResult struct {
+	Success bool           `json:"success"`
+	Dir     string         `json:"dir"`
+	Files   []ExportResult `json:"files"`
+}
+
+// reservedFileNames are device names Windows refuses as file names,
+// whatever their extension
+var reservedFileNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// ExportFileNames returns a file name ending in ext for each sheet, in
+// order. Path separators, characters reserved on Windows and control
+// characters become "_", and names that would still collide, ignoring
+// case, get a "-2", "-3", ... suffix.
+func ExportFileNames(sheets []string, ext string) []string {
+	names := make([]string, len(sheets))
+	used := make(map[string]bool, len(sheets))
+	for i, sheet := range sheets {
+		base := safeFileName(sheet)
+		name := base + ext
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+// safeFileName makes a sheet name usable as a file name on any platform
+func safeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	// Windows drops trailing dots and spaces, and a leading dot hides the file
+	name = strings.TrimRight(name, ". ")
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		return "sheet"
+	}
+	if reservedFileNames[strings.ToUpper(name)] {
+		name += "_"
+	}
+	return name
+}
+
+// ExportFormatForPath returns the export format implied by the extension of
+// path: csv, tsv, json, or ndjson for .ndjson and .jsonl.
+func ExportFormatForPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return string(FormatCSV), nil
+	case ".tsv":
+		return string(FormatTSV), nil
+	case ".json":
+		return string(FormatJSON), nil
+	case ".ndjson", ".jsonl":
+		return string(FormatNDJSON), nil
+	default:
+		return "", fmt.Errorf("cannot infer export format from %s (use .csv, .tsv, .json or .ndjson, or set the format)", filepath.Base(path))
+	}
+}
+
+// RowWriter writes rows one at a time through a Formatter, using its
+// WriteHeader, WriteSeparator and WriteFooter hooks so that large sheets
+// never have to be held in memory.
+type RowWriter struct {
+	w       io.Writer
+	f       Formatter
+	started bool
+}
+
+// NewRowWriter creates a RowWriter for format writing to w
+func NewRowWriter(w io.Writer, format string) (*RowWriter, error) {
+	f, err := NewFormatter(format)
+	if err != nil {
+		return nil, err
+	}
+	return &RowWriter{w: w, f: f}, nil
+}
+
+// WriteRow writes a single row, preceded by the format header on the
+// first call.
+func (rw *RowWriter) WriteRow(row []string) error {
+	if err := rw.start(); err != nil {
+		return err
+	}
+	if err := rw.f.WriteSeparator(rw.w); err != nil {
+		return err
+	}
+	data, err := rw.f.FormatValue(row)
+	if err != nil {
+		return err
+	}
+	if _, err := rw.w.Write(data); err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
+	}
+	return nil
+}
+
+// Close writes the format footer. A writer with no rows still produces a
+// complete, empty document.
+func (rw *RowWriter) Close() error {
+	if err := rw.start(); err != nil {
+		return err
+	}
+	return rw.f.WriteFooter(rw.w)
+}
+
+func (rw *RowWriter) start() error {
+	if rw.started {
+		return nil
+	}
+	rw.started = true
+	return rw.f.WriteHeader(rw.w)
+}
+
+// ExportSheet streams sheet from f and writes it to path in format (csv,
+// tsv, json or ndjson), one row at a time. The file is replaced atomically, so a
+// failed export leaves no partial output behind.
+func ExportSheet(ctx context.Context, f *excelize.File, sheet, path, format string) (*ExportResult, error) {
+	var result *ExportResult
+	err := xlsx.WriteFileAtomic(path, func(w io.Writer) error {
+		var err error
+		result, err = WriteSheet(ctx, f, sheet, w, format)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.File = path
+	return result, nil
+}
+
+// WriteSheet streams sheet from f to w in format (csv, tsv, json or ndjson) and
+// reports how many rows were written. Output is buffered and flushed before
+// it returns.
+func WriteSheet(ctx context.Context, f *excelize.File, sheet string, w io.Writer, format string) (*ExportResult, error) {
+	switch Format(strings.ToLower(format)) {
+	case FormatCSV, FormatTSV, FormatJSON, FormatNDJSON:
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s (valid: csv, tsv, json, ndjson)", format)
+	}
+
+	resolvedSheet, err := xlsx.ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch, err := xlsx.StreamRows(ctx, f, resolvedSheet, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	bw := bufio.NewWriter(w)
+	rw, err := NewRowWriter(bw, format)
+	if err != nil {
+		return nil, err
+	}
+	rows := 0
+	for result := range ch {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		row := make([]string, len(result.Row.Cells))
+		for i, cell := range result.Row.Cells {
+			row[i] = cell.Value
+		}
+		if err := rw.WriteRow(row); err != nil {
+			return nil, err
+		}
+		rows++
+	}
+	if err := rw.Close(); err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to flush export: %w", err)
+	}
+
+	return &ExportResult{
+		Success: true,
+		Sheet:   resolvedSheet,
+		Format:  strings.ToLower(format),
+		Rows:    rows,
+	}, nil
+}
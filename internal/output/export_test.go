@@ -0,0 +1,96 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestRowWriter(t *testing.T) {
+	tests := []struct {
+		format string
+		rows   [][]string
+		want   string
+	}{
+		{"json", [][]string{{"a", "b"}, {"c"}}, `[["a","b"],["c"]]` + "\n"},
+		{"json", nil, "[]\n"},
+		{"csv", [][]string{{"a", "b,c"}, {"d"}}, "a,\"b,c\"\nd\n"},
+		{"tsv", [][]string{{"a", "b"}}, "a\tb\n"},
+		{"ndjson", [][]string{{"a", "b"}, {"c"}}, `["a","b"]` + "\n" + `["c"]` + "\n"},
+		{"ndjson", nil, ""},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		rw, err := NewRowWriter(&buf, tt.format)
+		if err != nil {
+			t.Fatalf("NewRowWriter(%s) failed: %v", tt.format, err)
+		}
+		for _, row := range tt.rows {
+			if err := rw.WriteRow(row); err != nil {
+				t.Fatalf("WriteRow failed: %v", err)
+			}
+		}
+		if err := rw.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.format, tt.want, buf.String())
+		}
+	}
+}
+
+func TestExportSheet(t *testing.T) {
+	dir := t.TempDir()
+	f := excelize.NewFile()
+	defer f.Close()
+	for addr, v := range map[string]any{"A1": "Name", "B1": "Qty", "A2": "x", "B2": 3, "A4": "gap"} {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set %s: %v", addr, err)
+		}
+	}
+
+	outPath := filepath.Join(dir, "out.csv")
+	format, err := ExportFormatForPath(outPath)
+	if err != nil {
+		t.Fatalf("ExportFormatForPath failed: %v", err)
+	}
+	result, err := ExportSheet(context.Background(), f, "", outPath, format)
+	if err != nil {
+		t.Fatalf("ExportSheet failed: %v", err)
+	}
+	if result.Rows != 4 || result.Sheet != "Sheet1" || result.Format != "csv" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if want := "Name,Qty\nx,3\n\ngap\n"; string(data) != want {
+		t.Errorf("expected %q, got %q", want, string(data))
+	}
+
+	if _, err := ExportFormatForPath(filepath.Join(dir, "out.xlsx")); err == nil {
+		t.Error("expected error for unknown export extension")
+	}
+	if _, err := ExportSheet(context.Background(), f, "", filepath.Join(dir, "out.md"), "markdown"); err == nil {
+		t.Error("expected error for unsupported export format")
+	}
+}
+
+func TestExportFileNames(t *testing.T) {
+	sheets := []string{"Sales", "Q1/Q2", "Q1_Q2", "a:b*c", "CON", "..", "sales"}
+	want := []string{"Sales.csv", "Q1_Q2.csv", "Q1_Q2-2.csv", "a_b_c.csv", "CON_.csv", "sheet.csv", "sales-2.csv"}
+
+	got := ExportFileNames(sheets, ".csv")
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sheet %q: expected %q, got %q", sheets[i], want[i], got[i])
+		}
+	}
+}
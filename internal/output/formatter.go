@@ -6,15 +6,21 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync/atomic"
 )
 
 // Format represents output format options
 type Format string
 
 const (
-	FormatJSON Format = "json"
-	FormatCSV  Format = "csv"
-	FormatTSV  Format = "tsv"
+	FormatJSON     Format = "json"
+	FormatNDJSON   Format = "ndjson"
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatTable    Format = "table"
+	FormatYAML     Format = "yaml"
 )
 
 // Formatter interface for outputting data in various formats
@@ -35,27 +41,61 @@ type Formatter interface {
 	WriteSeparator(w io.Writer) error
 }
 
+// keyValueFormatter is implemented by table formats that render a single
+// object as key/value rows
+type keyValueFormatter interface {
+	FormatKeyValue(v interface{}) ([]byte, error)
+}
+
 // NewFormatter creates a formatter for the specified format
 func NewFormatter(format string) (Formatter, error) {
 	switch Format(strings.ToLower(format)) {
 	case FormatJSON, "":
-		return &JSONFormatter{}, nil
+		return &JSONFormatter{Indent: prettyJSON.Load()}, nil
+	case FormatNDJSON:
+		return &NDJSONFormatter{}, nil
 	case FormatCSV:
 		return &CSVFormatter{}, nil
 	case FormatTSV:
 		return &TSVFormatter{}, nil
+	case FormatMarkdown:
+		return &MarkdownFormatter{}, nil
+	case FormatHTML:
+		return &HTMLFormatter{}, nil
+	case FormatTable:
+		return &TableFormatter{Color: colorEnabled.Load()}, nil
+	case FormatYAML:
+		return &YAMLFormatter{}, nil
 	default:
-		return nil, fmt.Errorf("unknown format: %s (valid: json, csv, tsv)", format)
+		return nil, fmt.Errorf("unknown format: %s (valid: json, ndjson, yaml, csv, tsv, markdown, html, table)", format)
 	}
 }
 
-// JSONFormatter outputs JSON format
+// prettyJSON controls whether NewFormatter indents JSON output. It is off by
+// default so MCP responses stay compact within their size budget.
+var prettyJSON atomic.Bool
+
+// SetPrettyJSON enables or disables indented JSON output
+func SetPrettyJSON(enabled bool) {
+	prettyJSON.Store(enabled)
+}
+
+// JSONFormatter outputs JSON format, compact unless Indent is set
 type JSONFormatter struct {
+	Indent    bool
 	itemCount int
 }
 
+// marshal encodes v, indented by two spaces when f.Indent is set
+func (f *JSONFormatter) marshal(v interface{}) ([]byte, error) {
+	if f.Indent {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	return json.Marshal(v)
+}
+
 func (f *JSONFormatter) FormatValue(v interface{}) ([]byte, error) {
-	data, err := json.Marshal(v)
+	data, err := f.marshal(v)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal JSON value: %w", err)
 	}
@@ -63,7 +103,7 @@ func (f *JSONFormatter) FormatValue(v interface{}) ([]byte, error) {
 }
 
 func (f *JSONFormatter) FormatSlice(v interface{}) ([]byte, error) {
-	data, err := json.Marshal(v)
+	data, err := f.marshal(v)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal JSON slice: %w", err)
 	}
@@ -254,15 +294,39 @@ func FormatSingle(format string, v interface{}) ([]byte, error) {
 		return nil, fmt.Errorf("failed to create formatter: %w", err)
 	}
 
-	if format == "" || Format(format) == FormatJSON {
+	if jf, ok := f.(*JSONFormatter); ok {
 		// For JSON, format as single object, not array
-		data, err := json.Marshal(v)
+		data, err := jf.marshal(v)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal JSON: %w", err)
 		}
 		return append(data, '\n'), nil
 	}
 
+	if nf, ok := f.(*NDJSONFormatter); ok {
+		// Slices such as search results become one line per element
+		data, err := nf.FormatSlice(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format value: %w", err)
+		}
+		return data, nil
+	}
+
+	if yf, ok := f.(*YAMLFormatter); ok {
+		data, err := yf.FormatSlice(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format value: %w", err)
+		}
+		return data, nil
+	}
+
+	if kf, ok := f.(keyValueFormatter); ok {
+		// Objects render as a key/value table; anything else falls through
+		if data, err := kf.FormatKeyValue(v); err == nil {
+			return data, nil
+		}
+	}
+
 	data, err := f.FormatValue(v)
 	if err != nil {
 		return nil, fmt.Errorf("failed to format value: %w", err)
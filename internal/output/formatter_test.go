@@ -2,6 +2,7 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -32,6 +33,31 @@ func TestNewFormatter(t *testing.T) {
 			format:  "tsv",
 			wantErr: false,
 		},
+		{
+			name:    "markdown",
+			format:  "markdown",
+			wantErr: false,
+		},
+		{
+			name:    "html",
+			format:  "html",
+			wantErr: false,
+		},
+		{
+			name:    "ndjson",
+			format:  "ndjson",
+			wantErr: false,
+		},
+		{
+			name:    "table",
+			format:  "table",
+			wantErr: false,
+		},
+		{
+			name:    "yaml",
+			format:  "yaml",
+			wantErr: false,
+		},
 		{
 			name:    "empty defaults to json",
 			format:  "",
@@ -134,6 +160,37 @@ func TestJSONFormatter_FormatSlice(t *testing.T) {
 	}
 }
 
+func TestJSONFormatter_Indent(t *testing.T) {
+	f := &JSONFormatter{Indent: true}
+	out, err := f.FormatSlice([][]string{{"Name", "Age"}, {"Alice", "30"}})
+	if err != nil {
+		t.Fatalf("FormatSlice() error = %v", err)
+	}
+	if !strings.Contains(string(out), "\n  [\n    \"Name\",") {
+		t.Errorf("expected indented output, got %s", out)
+	}
+	var rows [][]string
+	if err := json.Unmarshal(out, &rows); err != nil {
+		t.Errorf("indented output does not parse: %v", err)
+	}
+
+	SetPrettyJSON(true)
+	t.Cleanup(func() { SetPrettyJSON(false) })
+	single, err := FormatSingle("json", map[string]int{"rows": 3})
+	if err != nil {
+		t.Fatalf("FormatSingle() error = %v", err)
+	}
+	if want := "{\n  \"rows\": 3\n}\n"; string(single) != want {
+		t.Errorf("expected %q, got %q", want, single)
+	}
+
+	SetPrettyJSON(false)
+	compact, _ := FormatSingle("json", map[string]int{"rows": 3})
+	if want := "{\"rows\":3}\n"; string(compact) != want {
+		t.Errorf("expected compact %q by default, got %q", want, compact)
+	}
+}
+
 func TestJSONFormatter_Streaming(t *testing.T) {
 	var buf bytes.Buffer
 	f := &JSONFormatter{}
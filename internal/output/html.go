@@ -0,0 +1,128 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// HTMLFormatter outputs HTML tables
+type HTMLFormatter struct{}
+
+// FormatValue renders a single row as a <tr>, for streaming between
+// WriteHeader and WriteFooter
+func (f *HTMLFormatter) FormatValue(v interface{}) ([]byte, error) {
+	row, err := toStringSlice(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert value to string slice: %w", err)
+	}
+	return []byte(htmlRow(row, "td") + "\n"), nil
+}
+
+// FormatSlice renders rows as a complete table, using the first row as the header
+func (f *HTMLFormatter) FormatSlice(v interface{}) ([]byte, error) {
+	rows, err := toStringSliceSlice(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert slice to string slice slice: %w", err)
+	}
+	if len(rows) == 0 {
+		return []byte{}, nil
+	}
+
+	var b strings.Builder
+	b.WriteString("<table>\n<thead>\n")
+	b.WriteString(htmlRow(rows[0], "th") + "\n")
+	b.WriteString("</thead>\n<tbody>\n")
+	for _, row := range rows[1:] {
+		b.WriteString(htmlRow(row, "td") + "\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return []byte(b.String()), nil
+}
+
+func (f *HTMLFormatter) WriteHeader(w io.Writer) error {
+	_, err := w.Write([]byte("<table>\n"))
+	if err != nil {
+		return fmt.Errorf("failed to write HTML header: %w", err)
+	}
+	return nil
+}
+
+func (f *HTMLFormatter) WriteFooter(w io.Writer) error {
+	_, err := w.Write([]byte("</table>\n"))
+	if err != nil {
+		return fmt.Errorf("failed to write HTML footer: %w", err)
+	}
+	return nil
+}
+
+func (f *HTMLFormatter) WriteSeparator(w io.Writer) error {
+	return nil // Rows are self-delimiting <tr> elements
+}
+
+// FormatKeyValue renders a map, or any value that marshals to a JSON
+// object, as a two-column key/value table sorted by key
+func (f *HTMLFormatter) FormatKeyValue(v interface{}) ([]byte, error) {
+	fields, err := toFieldMap(v)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("<table>\n<thead>\n")
+	b.WriteString(htmlRow([]string{"Key", "Value"}, "th") + "\n")
+	b.WriteString("</thead>\n<tbody>\n")
+	for _, k := range keys {
+		b.WriteString(htmlRow([]string{k, fields[k]}, "td") + "\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return []byte(b.String()), nil
+}
+
+// toFieldMap flattens a map or struct into string values keyed by field name.
+// Non-string values are rendered as JSON.
+func toFieldMap(v interface{}) (map[string]string, error) {
+	if m, ok := v.(map[string]string); ok {
+		return m, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("value is not an object: %w", err)
+	}
+
+	fields := make(map[string]string, len(raw))
+	for k, msg := range raw {
+		var s string
+		if err := json.Unmarshal(msg, &s); err == nil {
+			fields[k] = s
+		} else {
+			fields[k] = string(msg)
+		}
+	}
+	return fields, nil
+}
+
+// htmlRow renders one <tr> with escaped cells wrapped in the given tag
+func htmlRow(row []string, tag string) string {
+	var b strings.Builder
+	b.WriteString("<tr>")
+	for _, cell := range row {
+		fmt.Fprintf(&b, "<%s>%s</%s>", tag, html.EscapeString(cell), tag)
+	}
+	b.WriteString("</tr>")
+	return b.String()
+}
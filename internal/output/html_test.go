@@ -0,0 +1,76 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHTMLFormatter_FormatSlice(t *testing.T) {
+	f := &HTMLFormatter{}
+	out, err := f.FormatSlice([][]string{{"Name", "Note"}, {"Alice", "<b>a & b</b>"}})
+	if err != nil {
+		t.Fatalf("FormatSlice failed: %v", err)
+	}
+
+	want := "<table>\n<thead>\n<tr><th>Name</th><th>Note</th></tr>\n</thead>\n<tbody>\n" +
+		"<tr><td>Alice</td><td>&lt;b&gt;a &amp; b&lt;/b&gt;</td></tr>\n</tbody>\n</table>\n"
+	if string(out) != want {
+		t.Errorf("FormatSlice() = %q, want %q", out, want)
+	}
+}
+
+func TestHTMLFormatter_FormatSliceEmpty(t *testing.T) {
+	f := &HTMLFormatter{}
+	out, err := f.FormatSlice([][]string{})
+	if err != nil {
+		t.Fatalf("FormatSlice failed: %v", err)
+	}
+	if len(out) != 0 {
+		t.Errorf("expected empty output, got %q", out)
+	}
+}
+
+func TestHTMLFormatter_Streaming(t *testing.T) {
+	f := &HTMLFormatter{}
+	var buf bytes.Buffer
+
+	if err := f.WriteHeader(&buf); err != nil {
+		t.Fatalf("WriteHeader failed: %v", err)
+	}
+	for _, row := range [][]string{{"a", "b"}, {"c", "d"}} {
+		if err := f.WriteSeparator(&buf); err != nil {
+			t.Fatalf("WriteSeparator failed: %v", err)
+		}
+		data, err := f.FormatValue(row)
+		if err != nil {
+			t.Fatalf("FormatValue failed: %v", err)
+		}
+		buf.Write(data)
+	}
+	if err := f.WriteFooter(&buf); err != nil {
+		t.Fatalf("WriteFooter failed: %v", err)
+	}
+
+	want := "<table>\n<tr><td>a</td><td>b</td></tr>\n<tr><td>c</td><td>d</td></tr>\n</table>\n"
+	if buf.String() != want {
+		t.Errorf("streamed output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFormatSingleHTML(t *testing.T) {
+	out, err := FormatSingle("html", map[string]interface{}{"rows": 10, "name": "Sheet<1>"})
+	if err != nil {
+		t.Fatalf("FormatSingle failed: %v", err)
+	}
+
+	s := string(out)
+	if !strings.Contains(s, "<tr><th>Key</th><th>Value</th></tr>") {
+		t.Errorf("missing key/value header: %s", s)
+	}
+	nameIdx := strings.Index(s, "<tr><td>name</td><td>Sheet&lt;1&gt;</td></tr>")
+	rowsIdx := strings.Index(s, "<tr><td>rows</td><td>10</td></tr>")
+	if nameIdx < 0 || rowsIdx < 0 || nameIdx > rowsIdx {
+		t.Errorf("expected sorted, escaped key/value rows: %s", s)
+	}
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownFormatter outputs GitHub-flavored Markdown tables
+type MarkdownFormatter struct{}
+
+func (f *MarkdownFormatter) FormatValue(v interface{}) ([]byte, error) {
+	row, err := toStringSlice(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert value to string slice: %w", err)
+	}
+	return []byte(markdownRow(row, len(row)) + "\n"), nil
+}
+
+// FormatSlice renders rows as a table, using the first row as the header.
+// Rows shorter than the widest row are padded with empty cells.
+func (f *MarkdownFormatter) FormatSlice(v interface{}) ([]byte, error) {
+	rows, err := toStringSliceSlice(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert slice to string slice slice: %w", err)
+	}
+	if len(rows) == 0 {
+		return []byte{}, nil
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return []byte{}, nil
+	}
+
+	separator := make([]string, width)
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	lines = append(lines, markdownRow(rows[0], width), "| "+strings.Join(separator, " | ")+" |")
+	for _, row := range rows[1:] {
+		lines = append(lines, markdownRow(row, width))
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+func (f *MarkdownFormatter) WriteHeader(w io.Writer) error {
+	return nil
+}
+
+func (f *MarkdownFormatter) WriteFooter(w io.Writer) error {
+	return nil
+}
+
+func (f *MarkdownFormatter) WriteSeparator(w io.Writer) error {
+	return nil
+}
+
+// markdownRow renders one table row padded to width cells
+func markdownRow(row []string, width int) string {
+	cells := make([]string, width)
+	for i := range cells {
+		if i < len(row) {
+			cells[i] = escapeMarkdownCell(row[i])
+		}
+	}
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+// escapeMarkdownCell escapes pipes and flattens newlines, which would
+// otherwise break the table structure
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestMarkdownFormatter_FormatSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]string
+		want string
+	}{
+		{
+			name: "header and body",
+			rows: [][]string{{"Name", "Age"}, {"Alice", "30"}, {"Bob", "25"}},
+			want: "| Name | Age |\n| --- | --- |\n| Alice | 30 |\n| Bob | 25 |\n",
+		},
+		{
+			name: "single row renders header only",
+			rows: [][]string{{"Name", "Age"}},
+			want: "| Name | Age |\n| --- | --- |\n",
+		},
+		{
+			name: "empty input",
+			rows: [][]string{},
+			want: "",
+		},
+		{
+			name: "pipes are escaped",
+			rows: [][]string{{"Expr"}, {"a|b"}},
+			want: "| Expr |\n| --- |\n| a\\|b |\n",
+		},
+		{
+			name: "ragged rows are padded",
+			rows: [][]string{{"A", "B", "C"}, {"1"}},
+			want: "| A | B | C |\n| --- | --- | --- |\n| 1 |  |  |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &MarkdownFormatter{}
+			out, err := f.FormatSlice(tt.rows)
+			if err != nil {
+				t.Fatalf("FormatSlice failed: %v", err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("FormatSlice() = %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownFormatter_FormatValue(t *testing.T) {
+	f := &MarkdownFormatter{}
+	out, err := f.FormatValue([]string{"x", "y|z"})
+	if err != nil {
+		t.Fatalf("FormatValue failed: %v", err)
+	}
+	if want := "| x | y\\|z |\n"; string(out) != want {
+		t.Errorf("FormatValue() = %q, want %q", out, want)
+	}
+}
+
+func TestFormatRowsMarkdown(t *testing.T) {
+	out, err := FormatRows("markdown", [][]string{{"h1"}, {"v1"}})
+	if err != nil {
+		t.Fatalf("FormatRows failed: %v", err)
+	}
+	if want := "| h1 |\n| --- |\n| v1 |\n"; string(out) != want {
+		t.Errorf("FormatRows() = %q, want %q", out, want)
+	}
+}
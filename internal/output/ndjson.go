@@ -0,0 +1,61 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+)
+
+// NDJSONFormatter outputs newline-delimited JSON: one JSON value per line
+// with no enclosing array, so each line can be parsed on its own
+type NDJSONFormatter struct{}
+
+// FormatValue renders a single value as one line
+func (f *NDJSONFormatter) FormatValue(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal NDJSON value: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// FormatSlice renders each element of a slice as its own line. Any other
+// value is rendered as a single line.
+func (f *NDJSONFormatter) FormatSlice(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return f.FormatValue(v)
+	}
+
+	var b strings.Builder
+	for i := range rv.Len() {
+		data, err := json.Marshal(rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal NDJSON row %d: %w", i, err)
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	return []byte(b.String()), nil
+}
+
+// FormatTypedRows formats cells one row per line according to opts
+func (f *NDJSONFormatter) FormatTypedRows(rows [][]xlsx.Cell, opts TypedOptions) ([]byte, error) {
+	return f.FormatSlice(TypedRows(rows, opts))
+}
+
+func (f *NDJSONFormatter) WriteHeader(w io.Writer) error {
+	return nil // No enclosing array
+}
+
+func (f *NDJSONFormatter) WriteFooter(w io.Writer) error {
+	return nil
+}
+
+func (f *NDJSONFormatter) WriteSeparator(w io.Writer) error {
+	return nil // Each value already ends with a newline
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+)
+
+func TestNDJSONFormatter_FormatSlice(t *testing.T) {
+	rows := [][]string{{"Name", "Age"}, {"Alice", "30"}, {"Bob", "25"}}
+
+	out, err := FormatRows("ndjson", rows)
+	if err != nil {
+		t.Fatalf("FormatRows failed: %v", err)
+	}
+	if !strings.HasSuffix(string(out), "\n") {
+		t.Fatalf("expected newline-terminated output, got %q", out)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(lines) != len(rows) {
+		t.Fatalf("expected %d lines, got %d: %q", len(rows), len(lines), out)
+	}
+	for i, line := range lines {
+		var row []string
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			t.Fatalf("line %d is not valid JSON: %q: %v", i, line, err)
+		}
+		if strings.Join(row, ",") != strings.Join(rows[i], ",") {
+			t.Errorf("line %d: expected %v, got %v", i, rows[i], row)
+		}
+	}
+
+	if out, _ := FormatRows("ndjson", [][]string{}); len(out) != 0 {
+		t.Errorf("expected no output for no rows, got %q", out)
+	}
+}
+
+func TestNDJSONFormatSingle(t *testing.T) {
+	objects := []map[string]string{{"Name": "Alice"}, {"Name": "Bob"}}
+	out, err := FormatSingle("ndjson", objects)
+	if err != nil {
+		t.Fatalf("FormatSingle failed: %v", err)
+	}
+	if want := "{\"Name\":\"Alice\"}\n{\"Name\":\"Bob\"}\n"; string(out) != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+
+	out, err = FormatSingle("ndjson", map[string]int{"rows": 3})
+	if err != nil {
+		t.Fatalf("FormatSingle failed: %v", err)
+	}
+	if want := "{\"rows\":3}\n"; string(out) != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+func TestNDJSONTypedRows(t *testing.T) {
+	rows := [][]xlsx.Cell{
+		{{Value: "Qty", Type: "string"}},
+		{{Value: "7", Type: "number"}},
+	}
+	out, err := FormatTypedRows("ndjson", rows, TypedOptions{NativeTypes: true})
+	if err != nil {
+		t.Fatalf("FormatTypedRows failed: %v", err)
+	}
+	if want := "[\"Qty\"]\n[7]\n"; string(out) != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
@@ -1,18 +1,55 @@
 package output
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
 )
 
-// Print outputs any result in the specified format to stdout.
-// This is a convenience function for CLI commands.
-func Print(result any, format string) error {
+// PrintOptions controls how formatted output is written
+type PrintOptions struct {
+	NoTrailingNewline bool   // Trim the final newline from the output
+	Output            string // File to write to instead of stdout; "" or "-" is stdout
+}
+
+// Print outputs any result in the specified format to stdout, or to
+// opts.Output. This is a convenience function for CLI commands.
+func Print(result any, format string, opts PrintOptions) error {
 	out, err := FormatSingle(format, result)
 	if err != nil {
 		return fmt.Errorf("failed to format output: %w", err)
 	}
 
-	fmt.Fprint(os.Stdout, string(out))
+	return Emit(out, opts)
+}
+
+// Emit writes formatted output to stdout, or replaces the file opts.Output
+// with it atomically, so a failed write leaves no partial file behind
+func Emit(out []byte, opts PrintOptions) error {
+	if opts.Output == "" || opts.Output == "-" {
+		return Write(os.Stdout, out, opts)
+	}
+	return xlsx.WriteFileAtomic(opts.Output, func(w io.Writer) error {
+		return Write(w, out, opts)
+	})
+}
+
+// Write writes formatted output to w. With NoTrailingNewline, exactly one
+// trailing newline ("\n" or "\r\n") is removed, whatever the format.
+func Write(w io.Writer, out []byte, opts PrintOptions) error {
+	if opts.NoTrailingNewline {
+		if bytes.HasSuffix(out, []byte("\r\n")) {
+			out = out[:len(out)-2]
+		} else {
+			out = bytes.TrimSuffix(out, []byte("\n"))
+		}
+	}
+
+	if _, err := w.Write(out); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
 	return nil
 }
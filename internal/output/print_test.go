@@ -0,0 +1,34 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		trim bool
+		want string
+	}{
+		{"keeps newline by default", "[1]\n", false, "[1]\n"},
+		{"trims one newline", "[1]\n", true, "[1]"},
+		{"trims only the last newline", "a,b\nc,d\n\n", true, "a,b\nc,d\n"},
+		{"trims CRLF as one newline", "a,b\r\n", true, "a,b"},
+		{"no newline to trim", "[1]", true, "[1]"},
+		{"empty output", "", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, []byte(tt.in), PrintOptions{NoTrailingNewline: tt.trim}); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+)
+
+// ANSI escape codes used by the table format when color is enabled
+const (
+	ansiBold  = "\x1b[1m"
+	ansiShade = "\x1b[48;5;236m" // Dark grey background for alternate rows
+	ansiReset = "\x1b[0m"
+)
+
+// tableColumnGap separates the columns of a table
+const tableColumnGap = "  "
+
+// colorEnabled controls whether the table format emits ANSI color codes.
+// No other format reads it.
+var colorEnabled atomic.Bool
+
+// SetColor enables or disables color in table output
+func SetColor(enabled bool) {
+	colorEnabled.Store(enabled)
+}
+
+// TableFormatter outputs columns aligned for reading in a terminal. Numeric
+// columns are right-aligned; with color, the header row is bold and every other
+// row is shaded.
+type TableFormatter struct {
+	Color bool
+}
+
+// FormatValue renders a single row, separated by the column gap
+func (f *TableFormatter) FormatValue(v interface{}) ([]byte, error) {
+	row, err := toStringSlice(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert value to string slice: %w", err)
+	}
+	return []byte(strings.Join(flattenTableRow(row), tableColumnGap) + "\n"), nil
+}
+
+// FormatSlice renders rows as an aligned table, using the first row as the
+// header. Rows shorter than the widest row are padded with empty cells.
+func (f *TableFormatter) FormatSlice(v interface{}) ([]byte, error) {
+	rows, err := toStringSliceSlice(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert slice to string slice slice: %w", err)
+	}
+	return f.formatTable(rows), nil
+}
+
+func (f *TableFormatter) WriteHeader(w io.Writer) error {
+	return nil
+}
+
+func (f *TableFormatter) WriteFooter(w io.Writer) error {
+	return nil
+}
+
+func (f *TableFormatter) WriteSeparator(w io.Writer) error {
+	return nil
+}
+
+// FormatKeyValue renders a map, or any value that marshals to a JSON
+// object, as a two-column key/value table sorted by key
+func (f *TableFormatter) FormatKeyValue(v interface{}) ([]byte, error) {
+	fields, err := toFieldMap(v)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([][]string, 0, len(keys)+1)
+	rows = append(rows, []string{"Key", "Value"})
+	for _, k := range keys {
+		rows = append(rows, []string{k, fields[k]})
+	}
+	return f.formatTable(rows), nil
+}
+
+// formatTable pads every cell to its column's width
+func (f *TableFormatter) formatTable(in [][]string) []byte {
+	rows := make([][]string, len(in))
+	width := 0
+	for i, row := range in {
+		rows[i] = flattenTableRow(row)
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return []byte{}
+	}
+
+	// A column is numeric when every non-empty data cell is a number
+	widths := make([]int, width)
+	numeric := make([]bool, width)
+	for i := range numeric {
+		numeric[i] = len(rows) > 1
+	}
+	for r, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			if r > 0 && cell != "" && xlsx.InferCellType(cell) != "number" {
+				numeric[i] = false
+			}
+		}
+	}
+
+	var b strings.Builder
+	cells := make([]string, width)
+	for r, row := range rows {
+		for i := range cells {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			// Numeric columns, header included, line up on the last digit
+			if numeric[i] {
+				cells[i] = pad + cell
+			} else {
+				cells[i] = cell + pad
+			}
+		}
+		line := strings.Join(cells, tableColumnGap)
+		if !f.Color {
+			line = strings.TrimRight(line, " ")
+		}
+
+		switch {
+		case f.Color && r == 0:
+			line = ansiBold + line + ansiReset
+		case f.Color && r%2 == 0:
+			line = ansiShade + line + ansiReset
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return []byte(b.String())
+}
+
+// tableCellReplacer flattens line breaks and tabs, which would otherwise
+// break the alignment
+var tableCellReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ")
+
+// flattenTableRow returns row with tableCellReplacer applied to each cell
+func flattenTableRow(row []string) []string {
+	flat := make([]string, len(row))
+	for i, cell := range row {
+		flat[i] = tableCellReplacer.Replace(cell)
+	}
+	return flat
+}
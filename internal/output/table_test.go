@@ -0,0 +1,92 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableFormatter_FormatSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]string
+		want string
+	}{
+		{
+			name: "numeric columns are right-aligned",
+			rows: [][]string{{"Name", "Age"}, {"Alice", "30"}, {"Bob", "7"}},
+			want: "Name   Age\nAlice   30\nBob      7\n",
+		},
+		{
+			name: "mixed columns are left-aligned",
+			rows: [][]string{{"Key", "Value"}, {"rows", "11"}, {"name", "Sheet1"}},
+			want: "Key   Value\nrows  11\nname  Sheet1\n",
+		},
+		{
+			name: "ragged rows are padded",
+			rows: [][]string{{"A", "B", "C"}, {"x"}},
+			want: "A  B  C\nx\n",
+		},
+		{
+			name: "line breaks are flattened",
+			rows: [][]string{{"Note"}, {"a\nb"}},
+			want: "Note\na b\n",
+		},
+		{
+			name: "empty input",
+			rows: [][]string{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &TableFormatter{}
+			out, err := f.FormatSlice(tt.rows)
+			if err != nil {
+				t.Fatalf("FormatSlice failed: %v", err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("FormatSlice() = %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableFormatter_Color(t *testing.T) {
+	f := &TableFormatter{Color: true}
+	out, err := f.FormatSlice([][]string{{"Name"}, {"Ann"}, {"Bob"}, {"Cy"}})
+	if err != nil {
+		t.Fatalf("FormatSlice failed: %v", err)
+	}
+	want := ansiBold + "Name" + ansiReset + "\n" +
+		"Ann \n" +
+		ansiShade + "Bob " + ansiReset + "\n" +
+		"Cy  \n"
+	if string(out) != want {
+		t.Errorf("FormatSlice() = %q, want %q", out, want)
+	}
+}
+
+func TestSetColorOnlyAffectsTable(t *testing.T) {
+	SetColor(true)
+	t.Cleanup(func() { SetColor(false) })
+
+	rows := [][]string{{"Name", "Age"}, {"Alice", "30"}, {"Bob", "25"}}
+	for _, format := range []string{"json", "csv", "tsv", "markdown", "html"} {
+		out, err := FormatRows(format, rows)
+		if err != nil {
+			t.Fatalf("FormatRows(%s) failed: %v", format, err)
+		}
+		if strings.Contains(string(out), "\x1b[") {
+			t.Errorf("%s output contains color codes: %q", format, out)
+		}
+	}
+
+	out, err := FormatRows("table", rows)
+	if err != nil {
+		t.Fatalf("FormatRows(table) failed: %v", err)
+	}
+	if !strings.Contains(string(out), ansiBold) {
+		t.Errorf("expected colored table output, got %q", out)
+	}
+}
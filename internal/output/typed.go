@@ -0,0 +1,119 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+)
+
+// jsonNumberRegex matches numbers that are valid JSON literals as written
+var jsonNumberRegex = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// Null representations for empty cells in typed output
+const (
+	NullEmpty = "empty" // Empty cells are emitted as ""
+	NullNull  = "null"  // Empty cells are emitted as JSON null
+)
+
+// TypedOptions controls how cells are converted for typed output
+type TypedOptions struct {
+	NativeTypes bool // Emit numbers and booleans as native JSON values
+	NullEmpty   bool // Emit empty cells as null instead of ""
+}
+
+// ParseNullRepresentation validates a null representation name and reports
+// whether empty cells should become null. An empty name means NullEmpty.
+func ParseNullRepresentation(name string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case NullEmpty, "":
+		return false, nil
+	case NullNull:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown null representation: %s (valid: empty, null)", name)
+	}
+}
+
+// TypedRows converts cells into values for JSON encoding according to opts
+func TypedRows(rows [][]xlsx.Cell, opts TypedOptions) [][]any {
+	typed := make([][]any, len(rows))
+	for i, row := range rows {
+		typed[i] = make([]any, len(row))
+		for j, cell := range row {
+			switch {
+			case opts.NullEmpty && cell.Value == "":
+				typed[i][j] = nil
+			case opts.NativeTypes:
+				typed[i][j] = TypedValue(cell)
+			default:
+				typed[i][j] = cell.Value
+			}
+		}
+	}
+	return typed
+}
+
+// FormatTypedRows formats cells as a JSON array of arrays according to opts,
+// e.g. emitting numbers and booleans as native JSON values
+func (f *JSONFormatter) FormatTypedRows(rows [][]xlsx.Cell, opts TypedOptions) ([]byte, error) {
+	data, err := f.marshal(TypedRows(rows, opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal typed JSON rows: %w", err)
+	}
+	return data, nil
+}
+
+// TypedValue converts a cell to its native Go value based on Cell.Type.
+// Numbers keep their written form when it is a valid JSON number.
+// Values that do not parse as their detected type fall back to strings.
+func TypedValue(cell xlsx.Cell) any {
+	switch cell.Type {
+	case "number":
+		if jsonNumberRegex.MatchString(cell.Value) {
+			return json.Number(cell.Value)
+		}
+		if n, err := strconv.ParseFloat(cell.Value, 64); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+			return n
+		}
+	case "bool":
+		if strings.EqualFold(cell.Value, "true") {
+			return true
+		}
+		if strings.EqualFold(cell.Value, "false") {
+			return false
+		}
+	}
+	return cell.Value
+}
+
+// FormatTypedRows is a convenience function for typed row output.
+// Only JSON, NDJSON and YAML support native types and nulls, so other
+// formats are rejected.
+func FormatTypedRows(format string, rows [][]xlsx.Cell, opts TypedOptions) ([]byte, error) {
+	f, err := NewFormatter(format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create formatter: %w", err)
+	}
+
+	tf, ok := f.(typedRowsFormatter)
+	if !ok {
+		return nil, fmt.Errorf("typed output requires json, ndjson or yaml format, got %s", format)
+	}
+
+	data, err := tf.FormatTypedRows(rows, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format typed rows: %w", err)
+	}
+	return data, nil
+}
+
+// typedRowsFormatter is implemented by the formats that can keep native
+// cell types
+type typedRowsFormatter interface {
+	FormatTypedRows(rows [][]xlsx.Cell, opts TypedOptions) ([]byte, error)
+}
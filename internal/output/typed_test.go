@@ -0,0 +1,90 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+)
+
+func TestJSONFormatter_FormatTypedRows(t *testing.T) {
+	rows := [][]xlsx.Cell{
+		{
+			{Value: "Alice", Type: "string"},
+			{Value: "42", Type: "number"},
+			{Value: "1.50", Type: "number"},
+			{Value: "TRUE", Type: "bool"},
+			{Value: "", Type: "empty"},
+		},
+		{
+			{Value: "+5", Type: "number"},
+			{Value: "yes", Type: "bool"},
+		},
+	}
+
+	f := &JSONFormatter{}
+	out, err := f.FormatTypedRows(rows, TypedOptions{NativeTypes: true})
+	if err != nil {
+		t.Fatalf("FormatTypedRows failed: %v", err)
+	}
+
+	want := `[["Alice",42,1.50,true,""],[5,"yes"]]`
+	if string(out) != want {
+		t.Errorf("FormatTypedRows() = %s, want %s", out, want)
+	}
+}
+
+func TestFormatTypedRowsRequiresJSON(t *testing.T) {
+	rows := [][]xlsx.Cell{{{Value: "1", Type: "number"}}}
+
+	if _, err := FormatTypedRows("csv", rows, TypedOptions{NativeTypes: true}); err == nil {
+		t.Error("expected error for typed csv output")
+	}
+	if _, err := FormatTypedRows("json", rows, TypedOptions{NativeTypes: true}); err != nil {
+		t.Errorf("FormatTypedRows(json) failed: %v", err)
+	}
+}
+
+func TestTypedRowsNullEmpty(t *testing.T) {
+	rows := [][]xlsx.Cell{{
+		{Value: "a", Type: "string"},
+		{Value: "", Type: "empty"},
+		{Value: "7", Type: "number"},
+	}}
+
+	f := &JSONFormatter{}
+	out, err := f.FormatTypedRows(rows, TypedOptions{NullEmpty: true})
+	if err != nil {
+		t.Fatalf("FormatTypedRows failed: %v", err)
+	}
+	if want := `[["a",null,"7"]]`; string(out) != want {
+		t.Errorf("FormatTypedRows() = %s, want %s", out, want)
+	}
+
+	out, err = f.FormatTypedRows(rows, TypedOptions{NativeTypes: true, NullEmpty: true})
+	if err != nil {
+		t.Fatalf("FormatTypedRows failed: %v", err)
+	}
+	if want := `[["a",null,7]]`; string(out) != want {
+		t.Errorf("FormatTypedRows() = %s, want %s", out, want)
+	}
+}
+
+func TestParseNullRepresentation(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+	}{
+		{"", false, false},
+		{"empty", false, false},
+		{"NULL", true, false},
+		{"none", false, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseNullRepresentation(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseNullRepresentation(%q) = %v, %v; want %v, err=%v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
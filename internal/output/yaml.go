@@ -0,0 +1,85 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlIndent is the indentation of nested YAML blocks
+const yamlIndent = 2
+
+// YAMLFormatter outputs YAML. Values are encoded through their JSON form,
+// so field names and omitted fields match the json format. Strings that
+// would read back as numbers, booleans or null are quoted, and multi-line
+// values use block scalars.
+type YAMLFormatter struct{}
+
+// FormatValue renders a single row as one item of a YAML sequence, for
+// streaming
+func (f *YAMLFormatter) FormatValue(v interface{}) ([]byte, error) {
+	return marshalYAML([]interface{}{v})
+}
+
+// FormatSlice renders rows as a list of lists, or objects as a list of
+// mappings
+func (f *YAMLFormatter) FormatSlice(v interface{}) ([]byte, error) {
+	return marshalYAML(v)
+}
+
+// FormatTypedRows formats cells as a list of lists according to opts, with
+// numbers and booleans as native YAML scalars
+func (f *YAMLFormatter) FormatTypedRows(rows [][]xlsx.Cell, opts TypedOptions) ([]byte, error) {
+	return marshalYAML(TypedRows(rows, opts))
+}
+
+func (f *YAMLFormatter) WriteHeader(w io.Writer) error {
+	return nil
+}
+
+func (f *YAMLFormatter) WriteFooter(w io.Writer) error {
+	return nil
+}
+
+func (f *YAMLFormatter) WriteSeparator(w io.Writer) error {
+	return nil // Each row is a complete sequence item
+}
+
+// marshalYAML encodes v as YAML by way of its JSON encoding. JSON is valid
+// YAML, so decoding it gives a node tree that keeps key order and whether
+// each scalar is a string; clearing the flow and quoting styles JSON
+// implies lets the encoder pick plain, quoted or block styles.
+func marshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to convert value to YAML: %w", err)
+	}
+	clearYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(yamlIndent)
+	if err := enc.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// clearYAMLStyle resets the style of node and its children
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
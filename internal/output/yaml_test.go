@@ -0,0 +1,77 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/fuabioo/xlq/internal/xlsx"
+)
+
+func TestYAMLFormatter_FormatSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{
+			name: "numeric-looking strings stay strings",
+			v:    [][]string{{"Name", "Age"}, {"Alice", "30"}, {"Bob", "true"}},
+			want: "- - Name\n  - Age\n- - Alice\n  - \"30\"\n- - Bob\n  - \"true\"\n",
+		},
+		{
+			name: "special characters are quoted",
+			v:    [][]string{{"key: value", "#tag", ""}},
+			want: "- - 'key: value'\n  - '#tag'\n  - \"\"\n",
+		},
+		{
+			name: "multi-line values use block scalars",
+			v:    [][]string{{"line 1\nline 2"}},
+			want: "- - |-\n    line 1\n    line 2\n",
+		},
+		{
+			name: "objects become mappings",
+			v:    []map[string]string{{"Age": "30", "Name": "Alice"}},
+			want: "- Age: \"30\"\n  Name: Alice\n",
+		},
+		{
+			name: "empty input",
+			v:    [][]string{},
+			want: "[]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &YAMLFormatter{}
+			out, err := f.FormatSlice(tt.v)
+			if err != nil {
+				t.Fatalf("FormatSlice failed: %v", err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("FormatSlice() = %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLFormatSingle(t *testing.T) {
+	// Field names and omitempty follow the json tags
+	out, err := FormatSingle("yaml", xlsx.SearchResult{Sheet: "Sheet1", Address: "B2", Value: "007", Row: 2, Col: 2})
+	if err != nil {
+		t.Fatalf("FormatSingle failed: %v", err)
+	}
+	want := "sheet: Sheet1\naddress: B2\nvalue: \"007\"\nrow: 2\ncol: 2\n"
+	if string(out) != want {
+		t.Errorf("FormatSingle() = %q, want %q", out, want)
+	}
+}
+
+func TestYAMLTypedRows(t *testing.T) {
+	rows := [][]xlsx.Cell{{{Value: "7", Type: "number"}, {Value: "TRUE", Type: "bool"}, {Value: "", Type: "empty"}}}
+	out, err := FormatTypedRows("yaml", rows, TypedOptions{NativeTypes: true, NullEmpty: true})
+	if err != nil {
+		t.Fatalf("FormatTypedRows failed: %v", err)
+	}
+	if want := "- - 7\n  - true\n  - null\n"; string(out) != want {
+		t.Errorf("FormatTypedRows() = %q, want %q", out, want)
+	}
+}
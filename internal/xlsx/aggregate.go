@@ -0,0 +1,187 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Aggregate operations
+const (
+	AggregateSum   = "sum"
+	AggregateAvg   = "avg"
+	AggregateMin   = "min"
+	AggregateMax   = "max"
+	AggregateCount = "count"
+)
+
+// Aggregate streams a sheet and computes sum, avg, min, max or count over
+// the numeric cells of one column. The column is a header label or a
+// letter; with a label the header row is skipped. Stored values are used,
+// so number formats such as currency do not affect parsing. Non-numeric
+// cells are skipped and reported in Ignored; empty cells are not counted.
+func Aggregate(path, sheet, column, op string) (*AggregateResult, error) {
+	if _, err := normalizeAggregateOp(op); err != nil {
+		return nil, err
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return AggregateSheet(f, sheet, column, op)
+}
+
+// AggregateSheet is Aggregate on an open workbook
+func AggregateSheet(f *excelize.File, sheet, column, op string) (*AggregateResult, error) {
+	op, err := normalizeAggregateOp(op)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedSheet, err := ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	headers, err := GetHeaderRow(ctx, f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	col, byHeader, err := resolveAggregateColumn(headers, column)
+	if err != nil {
+		return nil, err
+	}
+
+	startRow := 1
+	if byHeader {
+		startRow = 2
+	}
+	ch, err := StreamRowsWithOptions(ctx, f, resolvedSheet, startRow, 0,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AggregateResult{
+		Sheet:  resolvedSheet,
+		Column: ColumnNumberToName(col),
+		Op:     op,
+	}
+	var acc accumulator
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, rowResult.Err
+		}
+		if col > len(rowResult.Row.Cells) {
+			continue
+		}
+		acc.add(rowResult.Row.Cells[col-1].Value)
+	}
+
+	result.Value = acc.result(op)
+	result.Count = acc.count
+	result.Ignored = acc.ignored
+	return result, nil
+}
+
+// normalizeAggregateOp validates an aggregate operation name, accepting
+// "average" and "mean" for avg
+func normalizeAggregateOp(op string) (string, error) {
+	op = strings.ToLower(strings.TrimSpace(op))
+	switch op {
+	case AggregateSum, AggregateAvg, AggregateMin, AggregateMax, AggregateCount:
+		return op, nil
+	case "average", "mean":
+		return AggregateAvg, nil
+	}
+	return "", fmt.Errorf("unknown aggregate operation: %s (valid: sum, avg, min, max, count)", op)
+}
+
+// accumulator gathers the running totals for an aggregate operation from
+// stored cell values
+type accumulator struct {
+	sum, min, max float64
+	count         int // Numeric cells added
+	ignored       int // Non-empty cells skipped as non-numeric
+}
+
+// add folds a stored cell value into the totals. Empty cells are skipped
+// and non-numeric ones counted as ignored.
+func (a *accumulator) add(value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	if InferCellType(value) != "number" {
+		a.ignored++
+		return
+	}
+	n, _ := strconv.ParseFloat(value, 64)
+
+	if a.count == 0 || n < a.min {
+		a.min = n
+	}
+	if a.count == 0 || n > a.max {
+		a.max = n
+	}
+	a.sum += n
+	a.count++
+}
+
+// result returns the value of op over the added cells: nil for avg, min
+// and max when no cell was numeric
+func (a *accumulator) result(op string) *float64 {
+	var v float64
+	switch op {
+	case AggregateSum:
+		v = a.sum
+	case AggregateCount:
+		v = float64(a.count)
+	case AggregateAvg, AggregateMin, AggregateMax:
+		if a.count == 0 {
+			return nil
+		}
+		switch op {
+		case AggregateAvg:
+			v = a.sum / float64(a.count)
+		case AggregateMin:
+			v = a.min
+		default:
+			v = a.max
+		}
+	}
+	return &v
+}
+
+// summary is result for a group or pivot cell: a sum over no numeric
+// cells is nil as well, so it reads as blank rather than as a real zero
+func (a *accumulator) summary(op string) *float64 {
+	if op == AggregateSum && a.count == 0 {
+		return nil
+	}
+	return a.result(op)
+}
+
+// resolveAggregateColumn finds a column by header label (case-insensitive)
+// or letter, reporting whether the header label was used
+func resolveAggregateColumn(headers []string, column string) (int, bool, error) {
+	for i, h := range headers {
+		if h != "" && strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(column)) {
+			return i + 1, true, nil
+		}
+	}
+	col, err := ParseColumnName(column)
+	if err != nil {
+		return 0, false, fmt.Errorf("unknown column %q: not a header or column letter", column)
+	}
+	return col, false, nil
+}
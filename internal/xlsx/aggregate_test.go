@@ -0,0 +1,120 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createAggregateTestFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "aggregate.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+
+	values := map[string]any{
+		"A1": "Item", "B1": "Amount",
+		"A2": "a", "B2": 10,
+		"A3": "b", "B3": 20.5,
+		"A4": "c", "B4": "n/a",
+		"A5": "d", // B5 empty
+		"A6": "e", "B6": 1500,
+	}
+	for addr, v := range values {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set cell: %v", err)
+		}
+	}
+
+	// A thousands separator format must not stop B6 being parsed
+	style, err := f.NewStyle(&excelize.Style{NumFmt: 4}) // #,##0.00
+	if err != nil {
+		t.Fatalf("failed to create style: %v", err)
+	}
+	if err := f.SetCellStyle("Sheet1", "B6", "B6", style); err != nil {
+		t.Fatalf("failed to set style: %v", err)
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
+func TestAggregate(t *testing.T) {
+	path := createAggregateTestFile(t)
+
+	tests := []struct {
+		op   string
+		want float64
+	}{
+		{"sum", 1530.5},
+		{"avg", 510.1666666666667},
+		{"min", 10},
+		{"max", 1500},
+		{"count", 3},
+		{"AVERAGE", 510.1666666666667},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			result, err := Aggregate(path, "Sheet1", "Amount", tt.op)
+			if err != nil {
+				t.Fatalf("Aggregate failed: %v", err)
+			}
+			if result.Value == nil || *result.Value != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, result.Value)
+			}
+			if result.Column != "B" {
+				t.Errorf("expected column B, got %s", result.Column)
+			}
+			if result.Count != 3 || result.Ignored != 1 {
+				t.Errorf("expected 3 numeric and 1 ignored, got %d and %d", result.Count, result.Ignored)
+			}
+		})
+	}
+}
+
+func TestAggregateByLetter(t *testing.T) {
+	path := createAggregateTestFile(t)
+
+	// By letter the header row is included and ignored as text
+	result, err := Aggregate(path, "", "b", "sum")
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if *result.Value != 1530.5 || result.Ignored != 2 {
+		t.Errorf("expected sum 1530.5 with 2 ignored, got %v with %d", *result.Value, result.Ignored)
+	}
+}
+
+func TestAggregateNoNumbers(t *testing.T) {
+	path := createAggregateTestFile(t)
+
+	result, err := Aggregate(path, "Sheet1", "Item", "max")
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if result.Value != nil {
+		t.Errorf("expected nil value, got %v", *result.Value)
+	}
+	if result.Ignored != 5 {
+		t.Errorf("expected 5 ignored, got %d", result.Ignored)
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	path := createAggregateTestFile(t)
+
+	if _, err := Aggregate(path, "Sheet1", "Amount", "median"); err == nil {
+		t.Error("expected error for unknown operation")
+	}
+	if _, err := Aggregate(path, "Sheet1", "No Such Header", "sum"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+	if _, err := Aggregate(path, "NoSuchSheet", "B", "sum"); err == nil {
+		t.Error("expected error for nonexistent sheet")
+	}
+}
@@ -0,0 +1,126 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AppendObjectsOptions configures AppendObjects
+type AppendObjectsOptions struct {
+	// CreateSheet creates the sheet when it does not exist, writing headers
+	// inferred from the first object's keys
+	CreateSheet bool
+}
+
+// AppendObjects appends one row per object, placing each value under the
+// header matching its key (case-insensitive), and saves atomically. Keys
+// without a matching header are added as new header columns. An empty
+// sheet gets a header row from the objects' keys. Keys are sorted
+// alphabetically when inferring headers, since JSON objects are unordered.
+// Enforces MaxAppendRows limit.
+func AppendObjects(path, sheet string, objects []map[string]any, opts AppendObjectsOptions) (*AppendObjectsResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*AppendObjectsResult, error) {
+		return wb.AppendObjects(sheet, objects, opts)
+	})
+}
+
+// AppendObjects appends one row per object under matching headers.
+// Enforces MaxAppendRows limit.
+func (wb *Workbook) AppendObjects(sheet string, objects []map[string]any, opts AppendObjectsOptions) (*AppendObjectsResult, error) {
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no objects provided")
+	}
+	if len(objects) > MaxAppendRows {
+		return nil, fmt.Errorf("%w: attempting to append %d rows, limit is %d",
+			ErrRowLimitExceeded, len(objects), MaxAppendRows)
+	}
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	result := &AppendObjectsResult{Success: true}
+
+	if opts.CreateSheet && sheet != "" && !SheetExists(wb.f, sheet) {
+		if _, err := wb.CreateSheet(sheet, nil); err != nil {
+			return nil, err
+		}
+		result.SheetCreated = true
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+	result.Sheet = resolvedSheet
+
+	headers, err := GetHeaderRow(context.Background(), wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	for len(headers) > 0 && strings.TrimSpace(headers[len(headers)-1]) == "" {
+		headers = headers[:len(headers)-1]
+	}
+
+	// Map keys to columns, adding headers for keys not seen yet
+	columns := map[string]int{}
+	for i, h := range headers {
+		key := strings.ToLower(strings.TrimSpace(h))
+		if _, exists := columns[key]; key != "" && !exists {
+			columns[key] = i
+		}
+	}
+	var newHeaders []string
+	for _, obj := range objects {
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key := strings.ToLower(strings.TrimSpace(k))
+			if _, exists := columns[key]; !exists {
+				columns[key] = len(headers) + len(newHeaders)
+				newHeaders = append(newHeaders, k)
+			}
+		}
+	}
+
+	if len(newHeaders) > 0 {
+		cells := make([]any, len(newHeaders))
+		for i, h := range newHeaders {
+			cells[i] = h
+		}
+		start := FormatCellAddress(len(headers)+1, 1)
+		if err := wb.f.SetSheetRow(resolvedSheet, start, &cells); err != nil {
+			return nil, fmt.Errorf("failed to write headers: %w", err)
+		}
+		headers = append(headers, newHeaders...)
+	}
+	result.Headers = headers
+	result.HeadersAdded = len(newHeaders)
+
+	rows := make([][]any, len(objects))
+	for i, obj := range objects {
+		row := make([]any, len(headers))
+		for k, v := range obj {
+			row[columns[strings.ToLower(strings.TrimSpace(k))]] = v
+		}
+		rows[i] = row
+	}
+
+	lastRow, err := getLastRow(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last row: %w", err)
+	}
+	startingRow := max(lastRow+1, 2)
+	if err := wb.setRows(resolvedSheet, startingRow, rows); err != nil {
+		return nil, err
+	}
+
+	result.RowsAdded = len(rows)
+	result.StartingRow = startingRow
+	result.EndingRow = startingRow + len(rows) - 1
+	return result, nil
+}
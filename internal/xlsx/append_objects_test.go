@@ -0,0 +1,98 @@
+package xlsx
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAppendObjectsCreatesSheet(t *testing.T) {
+	path := createTestFile(t)
+
+	objects := []map[string]any{
+		{"name": "Alice", "age": 30},
+		{"name": "Bob", "age": 25, "city": "Boston"},
+	}
+	result, err := AppendObjects(path, "People", objects, AppendObjectsOptions{CreateSheet: true})
+	if err != nil {
+		t.Fatalf("AppendObjects failed: %v", err)
+	}
+	if !result.SheetCreated {
+		t.Error("expected sheet_created=true")
+	}
+	if want := []string{"age", "name", "city"}; !reflect.DeepEqual(result.Headers, want) {
+		t.Errorf("expected headers %v, got %v", want, result.Headers)
+	}
+	if result.RowsAdded != 2 || result.StartingRow != 2 || result.EndingRow != 3 {
+		t.Errorf("expected rows 2-3 added, got %+v", result)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("People")
+	if err != nil {
+		t.Fatalf("GetRows failed: %v", err)
+	}
+	want := [][]string{
+		{"age", "name", "city"},
+		{"30", "Alice"},
+		{"25", "Bob", "Boston"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("expected rows %v, got %v", want, rows)
+	}
+}
+
+func TestAppendObjectsExistingSheet(t *testing.T) {
+	path := createTestFile(t)
+
+	objects := []map[string]any{
+		{"header2": 7, "Header1": "Value4"},
+	}
+	result, err := AppendObjects(path, "Sheet1", objects, AppendObjectsOptions{})
+	if err != nil {
+		t.Fatalf("AppendObjects failed: %v", err)
+	}
+	if result.SheetCreated || result.HeadersAdded != 0 {
+		t.Errorf("expected no sheet or headers created, got %+v", result)
+	}
+	if result.StartingRow != 4 {
+		t.Errorf("expected starting row 4, got %d", result.StartingRow)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+	cell, err := GetCell(f, "Sheet1", "B4")
+	if err != nil {
+		t.Fatalf("GetCell failed: %v", err)
+	}
+	if cell.Value != "7" {
+		t.Errorf("expected B4=7, got %q", cell.Value)
+	}
+}
+
+func TestAppendObjectsMissingSheet(t *testing.T) {
+	path := createTestFile(t)
+
+	_, err := AppendObjects(path, "Nope", []map[string]any{{"a": 1}}, AppendObjectsOptions{})
+	if err == nil {
+		t.Fatal("expected error for missing sheet")
+	}
+
+	_, err = AppendObjects(path, "Sheet1", nil, AppendObjectsOptions{})
+	if err == nil {
+		t.Fatal("expected error for no objects")
+	}
+
+	objects := make([]map[string]any, MaxAppendRows+1)
+	_, err = AppendObjects(path, "Sheet1", objects, AppendObjectsOptions{})
+	if !errors.Is(err, ErrRowLimitExceeded) {
+		t.Errorf("expected ErrRowLimitExceeded, got %v", err)
+	}
+}
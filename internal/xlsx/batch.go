@@ -0,0 +1,89 @@
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WriteCells writes several cells in one open/save cycle.
+// cells maps addresses (e.g. "B7") to the value and type to write; an empty
+// type means "auto". Enforces MaxWriteRangeCells across the batch.
+// Results are ordered by row, then column.
+func WriteCells(path, sheet string, cells map[string]CellWrite) (*WriteCellsResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*WriteCellsResult, error) {
+		return wb.WriteCells(sheet, cells)
+	})
+}
+
+// WriteCells writes several cells, recording each previous value.
+// See the package-level WriteCells for details.
+func (wb *Workbook) WriteCells(sheet string, cells map[string]CellWrite) (*WriteCellsResult, error) {
+	// 1. Validate batch size and addresses before touching the file
+	if len(cells) == 0 {
+		return nil, fmt.Errorf("no cells provided")
+	}
+	if len(cells) > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to write %d cells, limit is %d",
+			ErrCellLimitExceeded, len(cells), MaxWriteRangeCells)
+	}
+
+	type target struct {
+		addr     string
+		col, row int
+		write    CellWrite
+	}
+	targets := make([]target, 0, len(cells))
+	seen := make(map[string]bool, len(cells))
+	for addr, write := range cells {
+		col, row, err := ParseCellAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		normalized := FormatCellAddress(col, row)
+		if seen[normalized] {
+			return nil, fmt.Errorf("%w: duplicate cell %s", ErrInvalidAddress, normalized)
+		}
+		seen[normalized] = true
+		targets = append(targets, target{addr: normalized, col: col, row: row, write: write})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].row != targets[j].row {
+			return targets[i].row < targets[j].row
+		}
+		return targets[i].col < targets[j].col
+	})
+
+	// 2. Resolve sheet
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Record previous values and write each cell
+	result := &WriteCellsResult{Success: true, Cells: make([]WriteResult, 0, len(targets))}
+	for _, t := range targets {
+		previousValue, err := wb.f.GetCellValue(resolvedSheet, t.addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get previous value of %s: %w", t.addr, err)
+		}
+
+		valueType := strings.TrimSpace(t.write.Type)
+		if valueType == "" {
+			valueType = "auto"
+		}
+		if err := setCellWithType(wb.f, resolvedSheet, t.addr, t.write.Value, valueType); err != nil {
+			return nil, fmt.Errorf("failed to write cell: %w", err)
+		}
+
+		result.Cells = append(result.Cells, WriteResult{
+			Success:       true,
+			Cell:          t.addr,
+			PreviousValue: previousValue,
+			NewValue:      t.write.Value,
+		})
+	}
+	result.CellsWritten = len(result.Cells)
+
+	return result, nil
+}
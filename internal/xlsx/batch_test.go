@@ -0,0 +1,90 @@
+package xlsx
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteCells(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.xlsx")
+	if _, err := CreateFile(path, "Sheet1", []string{"Name"}, nil, false); err != nil {
+		t.Fatalf("CreateFile failed: %v", err)
+	}
+
+	result, err := WriteCells(path, "Sheet1", map[string]CellWrite{
+		"c3": {Value: 42.0},
+		"A1": {Value: "Renamed"},
+		"B2": {Value: "SUM(1,2)", Type: "formula"},
+	})
+	if err != nil {
+		t.Fatalf("WriteCells failed: %v", err)
+	}
+
+	if result.CellsWritten != 3 || len(result.Cells) != 3 {
+		t.Fatalf("expected 3 cells written, got %+v", result)
+	}
+	wantOrder := []string{"A1", "B2", "C3"}
+	for i, cell := range result.Cells {
+		if cell.Cell != wantOrder[i] {
+			t.Errorf("result %d: expected cell %s, got %s", i, wantOrder[i], cell.Cell)
+		}
+	}
+	if result.Cells[0].PreviousValue != "Name" || result.Cells[0].NewValue != "Renamed" {
+		t.Errorf("unexpected A1 result: %+v", result.Cells[0])
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	if v, _ := f.GetCellValue("Sheet1", "C3"); v != "42" {
+		t.Errorf("expected C3 = 42, got %q", v)
+	}
+	if formula, _ := f.GetCellFormula("Sheet1", "B2"); formula != "=SUM(1,2)" {
+		t.Errorf("expected B2 formula =SUM(1,2), got %q", formula)
+	}
+}
+
+func TestWriteCellsLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.xlsx")
+	if _, err := CreateFile(path, "Sheet1", nil, nil, false); err != nil {
+		t.Fatalf("CreateFile failed: %v", err)
+	}
+
+	cells := make(map[string]CellWrite, MaxWriteRangeCells+1)
+	for i := 1; i <= MaxWriteRangeCells+1; i++ {
+		cells[FormatCellAddress(1, i)] = CellWrite{Value: i}
+	}
+
+	_, err := WriteCells(path, "Sheet1", cells)
+	if !errors.Is(err, ErrCellLimitExceeded) {
+		t.Errorf("expected ErrCellLimitExceeded, got %v", err)
+	}
+}
+
+func TestWriteCellsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.xlsx")
+	if _, err := CreateFile(path, "Sheet1", nil, nil, false); err != nil {
+		t.Fatalf("CreateFile failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		cells map[string]CellWrite
+	}{
+		{"empty batch", map[string]CellWrite{}},
+		{"invalid address", map[string]CellWrite{"1A": {Value: "x"}}},
+		{"duplicate address", map[string]CellWrite{"a1": {Value: "x"}, "A1": {Value: "y"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := WriteCells(path, "Sheet1", tt.cells); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
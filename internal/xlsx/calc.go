@@ -0,0 +1,46 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Calculation modes as stored in the workbook's calcPr element
+const (
+	CalcModeAuto        = "auto"
+	CalcModeManual      = "manual"
+	CalcModeAutoNoTable = "autoNoTable"
+)
+
+// GetCalcProps reads the workbook calculation properties: whether Excel
+// recalculates automatically or manually, and whether the cached formula
+// values may be stale. Cached values are flagged as possibly stale when
+// calculation is manual, when the last calculation did not complete, or
+// when the file asks for a full recalculation on load (as files edited
+// without a calculation engine often do).
+func GetCalcProps(f *excelize.File) (*CalcProps, error) {
+	opts, err := f.GetCalcProps()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calculation properties: %w", err)
+	}
+
+	props := &CalcProps{Mode: CalcModeAuto, StaleReasons: []string{}}
+	if opts.CalcMode != nil && *opts.CalcMode != "" {
+		props.Mode = *opts.CalcMode
+	}
+	props.FullCalcOnLoad = opts.FullCalcOnLoad != nil && *opts.FullCalcOnLoad
+	props.ForceFullCalc = opts.ForceFullCalc != nil && *opts.ForceFullCalc
+
+	if props.Mode == CalcModeManual {
+		props.StaleReasons = append(props.StaleReasons, "calculation mode is manual")
+	}
+	if opts.CalcCompleted != nil && !*opts.CalcCompleted {
+		props.StaleReasons = append(props.StaleReasons, "last calculation did not complete")
+	}
+	if props.FullCalcOnLoad {
+		props.StaleReasons = append(props.StaleReasons, "workbook requests full recalculation on load")
+	}
+	props.CacheMayBeStale = len(props.StaleReasons) > 0
+	return props, nil
+}
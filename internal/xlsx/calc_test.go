@@ -0,0 +1,57 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestGetCalcProps(t *testing.T) {
+	f, err := OpenFile(createTestFile(t))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	props, err := GetCalcProps(f)
+	if err != nil {
+		t.Fatalf("GetCalcProps failed: %v", err)
+	}
+	if props.Mode != CalcModeAuto {
+		t.Errorf("expected auto mode by default, got %q", props.Mode)
+	}
+	if props.CacheMayBeStale {
+		t.Errorf("expected cache not stale by default, got reasons %v", props.StaleReasons)
+	}
+}
+
+func TestGetCalcPropsManual(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manual.xlsx")
+	f := excelize.NewFile()
+	mode := CalcModeManual
+	if err := f.SetCalcProps(&excelize.CalcPropsOptions{CalcMode: &mode}); err != nil {
+		t.Fatalf("SetCalcProps failed: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	props, err := GetCalcProps(f)
+	if err != nil {
+		t.Fatalf("GetCalcProps failed: %v", err)
+	}
+	if props.Mode != CalcModeManual {
+		t.Errorf("expected manual mode, got %q", props.Mode)
+	}
+	if !props.CacheMayBeStale || len(props.StaleReasons) == 0 {
+		t.Errorf("expected cache flagged as possibly stale, got %+v", props)
+	}
+}
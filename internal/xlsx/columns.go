@@ -0,0 +1,59 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveColumns maps column references, each a header label
+// (case-insensitive) or a column letter, to column numbers in the order
+// given. headers is row 1, read once by the caller.
+func ResolveColumns(headers []string, refs []string) ([]int, error) {
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	cols := make([]int, len(refs))
+	for i, ref := range refs {
+		if strings.TrimSpace(ref) == "" {
+			return nil, fmt.Errorf("empty column in selection")
+		}
+		col, _, err := resolveAggregateColumn(headers, ref)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// SelectColumns projects each row down to cols, in that order. Cells keep
+// their address and column number; a selected column a row has no cell in
+// becomes an empty cell, so every row has len(cols) cells.
+func SelectColumns(rows []Row, cols []int) []Row {
+	for i := range rows {
+		rows[i].Cells = selectRowColumns(rows[i], cols)
+	}
+	return rows
+}
+
+// selectRowColumns returns the cells of row at cols
+func selectRowColumns(row Row, cols []int) []Cell {
+	byCol := make(map[int]Cell, len(row.Cells))
+	for _, cell := range row.Cells {
+		byCol[cell.Col] = cell
+	}
+	cells := make([]Cell, len(cols))
+	for i, col := range cols {
+		cell, ok := byCol[col]
+		if !ok {
+			cell = Cell{
+				Address: FormatCellAddress(col, row.Number),
+				Type:    "empty",
+				Row:     row.Number,
+				Col:     col,
+			}
+		}
+		cells[i] = cell
+	}
+	return cells
+}
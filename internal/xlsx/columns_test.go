@@ -0,0 +1,30 @@
+package xlsx
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSelectColumns(t *testing.T) {
+	headers := []string{"Name", "Age", "City"}
+	cols, err := ResolveColumns(headers, []string{"city", "A", "E"})
+	if err != nil {
+		t.Fatalf("ResolveColumns failed: %v", err)
+	}
+	if want := []int{3, 1, 5}; !slices.Equal(cols, want) {
+		t.Fatalf("expected %v, got %v", want, cols)
+	}
+	if _, err := ResolveColumns(headers, []string{"Country"}); err == nil {
+		t.Error("expected error for an unknown column")
+	}
+
+	// A range read from B holds cells for columns 2 and 3 only
+	rows := []Row{{Number: 4, Cells: []Cell{
+		{Address: "B4", Value: "30", Row: 4, Col: 2},
+		{Address: "C4", Value: "Boston", Row: 4, Col: 3},
+	}}}
+	got := SelectColumns(rows, cols)[0].Cells
+	if len(got) != 3 || got[0].Value != "Boston" || got[1].Address != "A4" || got[1].Value != "" || got[2].Address != "E4" {
+		t.Errorf("unexpected projection: %+v", got)
+	}
+}
@@ -0,0 +1,159 @@
+package xlsx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxSheetNameLength is the longest sheet name Excel accepts
+const maxSheetNameLength = 31
+
+// CombineCSV creates a new xlsx file with one sheet per CSV file, in order,
+// each named after its file without the extension. Fields are typed as in
+// ImportCSV. Names Excel would reject are made valid, and a name already
+// taken, ignoring case, gets a " (2)", " (3)", ... suffix. Each file is
+// limited to MaxCreateFileRows rows. Nothing is written unless every file
+// is read successfully.
+func CombineCSV(path string, csvPaths []string, opts CombineOptions) (*CombineResult, error) {
+	if len(csvPaths) == 0 {
+		return nil, fmt.Errorf("no CSV files to combine")
+	}
+
+	// 1. Check if file exists
+	if _, err := os.Stat(path); err == nil {
+		if !opts.Overwrite {
+			return nil, fmt.Errorf("%w: %s", ErrFileExists, path)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to check if file exists: %w", err)
+	}
+
+	// 2. Create new file
+	f := excelize.NewFile()
+	defer f.Close()
+
+	result := &CombineResult{Success: true, DryRun: opts.DryRun, File: path}
+	used := make(map[string]bool, len(csvPaths))
+	for i, csvPath := range csvPaths {
+		// 3. Read the file, then add its sheet; the first reuses Sheet1
+		records, err := readCSVFile(csvPath, opts.Delimiter)
+		if err != nil {
+			return nil, err
+		}
+
+		sheet := uniqueSheetName(csvSheetName(csvPath), used)
+		if i == 0 {
+			err = f.SetSheetName("Sheet1", sheet)
+		} else {
+			_, err = f.NewSheet(sheet)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sheet %s: %w", sheet, err)
+		}
+
+		// 4. Write the records
+		width := 0
+		for _, record := range records {
+			width = max(width, len(record))
+		}
+		rows := make([][]any, len(records))
+		for j, record := range records {
+			row := make([]any, width)
+			for k, field := range record {
+				row[k] = csvValue(field)
+			}
+			rows[j] = row
+		}
+		if err := checkBlockBounds(1, 1, rows); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(csvPath), err)
+		}
+		for j, row := range rows {
+			if err := f.SetSheetRow(sheet, FormatCellAddress(1, j+1), &row); err != nil {
+				return nil, fmt.Errorf("failed to write row %d of sheet %s: %w", j+1, sheet, err)
+			}
+			if err := quoteFormulaLike(f, sheet, row, func(k int) string { return FormatCellAddress(k+1, j+1) }); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Sheets = append(result.Sheets, CombinedSheet{
+			Sheet:   sheet,
+			Source:  csvPath,
+			Rows:    len(rows),
+			Columns: width,
+		})
+	}
+
+	// 5. Save atomically, unless this is a dry run
+	if !opts.DryRun {
+		if err := SaveFileAtomic(f, path); err != nil {
+			return nil, fmt.Errorf("failed to save file: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// readCSVFile reads every record of a CSV file, failing when it has more
+// than MaxCreateFileRows rows
+func readCSVFile(csvPath string, delimiter rune) ([][]string, error) {
+	cf, err := os.Open(csvPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer cf.Close()
+
+	records, err := readCSVRecords(cf, delimiter, MaxCreateFileRows+1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(csvPath), err)
+	}
+	if len(records) > MaxCreateFileRows {
+		return nil, fmt.Errorf("%w: %s has more than %d rows",
+			ErrRowLimitExceeded, filepath.Base(csvPath), MaxCreateFileRows)
+	}
+	return records, nil
+}
+
+// csvSheetName derives a sheet name from a file name: the base name without
+// its extension, with the characters Excel forbids replaced by "_" and cut
+// to maxSheetNameLength
+func csvSheetName(csvPath string) string {
+	base := filepath.Base(csvPath)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	// Excel also rejects a leading or trailing apostrophe
+	name = truncateRunes(strings.Trim(name, "'"), maxSheetNameLength)
+	if strings.TrimSpace(name) == "" {
+		return "Sheet"
+	}
+	return name
+}
+
+// uniqueSheetName returns name, or name with a " (n)" suffix when used
+// already holds it ignoring case, and records the result in used
+func uniqueSheetName(name string, used map[string]bool) string {
+	candidate := name
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		candidate = truncateRunes(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// truncateRunes cuts s to at most n runes
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
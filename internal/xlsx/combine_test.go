@@ -0,0 +1,106 @@
+package xlsx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCombineCSV(t *testing.T) {
+	dir := t.TempDir()
+	sales := filepath.Join(dir, "sales.csv")
+	if err := os.WriteFile(sales, []byte("Name,Qty\nAda,3\nBob,12.5\n"), 0644); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+	// writeCSVFile names every file data.csv, so these two collide
+	first := writeCSVFile(t, "Zip\n02134\n")
+	second := writeCSVFile(t, "a,b,c\n")
+
+	path := filepath.Join(dir, "combined.xlsx")
+	result, err := CombineCSV(path, []string{sales, first, second}, CombineOptions{})
+	if err != nil {
+		t.Fatalf("CombineCSV failed: %v", err)
+	}
+	var sheets []string
+	for _, s := range result.Sheets {
+		sheets = append(sheets, s.Sheet)
+	}
+	if want := []string{"sales", "data", "data (2)"}; !reflect.DeepEqual(sheets, want) {
+		t.Errorf("expected sheets %v, got %v", want, sheets)
+	}
+	if result.Sheets[0].Rows != 3 || result.Sheets[0].Columns != 2 {
+		t.Errorf("unexpected result for sales.csv: %+v", result.Sheets[0])
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	if got := f.GetSheetList(); !reflect.DeepEqual(got, sheets) {
+		t.Errorf("expected workbook sheets %v, got %v", sheets, got)
+	}
+	rows, err := f.GetRows("sales")
+	if err != nil {
+		t.Fatalf("failed to read rows: %v", err)
+	}
+	if want := [][]string{{"Name", "Qty"}, {"Ada", "3"}, {"Bob", "12.5"}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("expected %v, got %v", want, rows)
+	}
+	if cellType, _ := f.GetCellType("sales", "B3"); cellType == excelize.CellTypeSharedString {
+		t.Error("expected B3 stored as a number, got text")
+	}
+	if v, _ := f.GetCellValue("data", "A2"); v != "02134" {
+		t.Errorf("expected leading zero kept, got %q", v)
+	}
+	if v, _ := f.GetCellValue("data (2)", "C1"); v != "c" {
+		t.Errorf("expected c in data (2)!C1, got %q", v)
+	}
+
+	if _, err := CombineCSV(path, []string{sales}, CombineOptions{}); !errors.Is(err, ErrFileExists) {
+		t.Errorf("expected ErrFileExists, got %v", err)
+	}
+}
+
+func TestCombineCSVRowLimit(t *testing.T) {
+	orig := MaxCreateFileRows
+	MaxCreateFileRows = 2
+	t.Cleanup(func() { MaxCreateFileRows = orig })
+
+	path := filepath.Join(t.TempDir(), "combined.xlsx")
+	csvPath := writeCSVFile(t, "a\nb\nc\n")
+	if _, err := CombineCSV(path, []string{csvPath}, CombineOptions{}); !errors.Is(err, ErrRowLimitExceeded) {
+		t.Errorf("expected ErrRowLimitExceeded, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no file to be written, got %v", err)
+	}
+}
+
+func TestCSVSheetName(t *testing.T) {
+	tests := map[string]string{
+		"/tmp/q1 sales.csv": "q1 sales",
+		"/tmp/a[1]?.csv":    "a_1__",
+		"/tmp/'quoted'.csv": "quoted",
+		"/tmp/.csv":         "Sheet",
+		"/tmp/an extremely long file name here.csv": "an extremely long file name her",
+	}
+	for input, want := range tests {
+		if got := csvSheetName(input); got != want {
+			t.Errorf("csvSheetName(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	used := map[string]bool{}
+	long := "an extremely long file name her"
+	for _, want := range []string{long, "an extremely long file name (2)", "an extremely long file name (3)"} {
+		if got := uniqueSheetName(long, used); got != want {
+			t.Errorf("uniqueSheetName = %q, want %q", got, want)
+		}
+	}
+}
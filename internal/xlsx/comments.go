@@ -0,0 +1,169 @@
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxCommentAuthorLength and maxCommentTextLength are the longest comment
+// author and text Excel accepts
+const (
+	maxCommentAuthorLength = 255
+	maxCommentTextLength   = 32512
+)
+
+// CellComment is a comment (note) attached to a cell
+type CellComment struct {
+	Cell   string `json:"cell"`
+	Author string `json:"author"`
+	Text   string `json:"text"`
+}
+
+// GetComments returns every comment on a sheet with the cell it is anchored
+// to, ordered by row and then column.
+func GetComments(f *excelize.File, sheet string) ([]CellComment, error) {
+	resolvedSheet, err := ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := f.GetComments(resolvedSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comments: %w", err)
+	}
+
+	result := make([]CellComment, 0, len(comments))
+	for _, c := range comments {
+		text := c.Text
+		for _, run := range c.Paragraph {
+			text += run.Text
+		}
+		result = append(result, CellComment{Cell: c.Cell, Author: c.Author, Text: text})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		ci, ri, _ := ParseCellAddress(result[i].Cell)
+		cj, rj, _ := ParseCellAddress(result[j].Cell)
+		if ri != rj {
+			return ri < rj
+		}
+		return ci < cj
+	})
+	return result, nil
+}
+
+// AddComment attaches a comment to a cell. See Workbook.AddComment.
+func AddComment(path, sheet, cell, author, text string) (*CommentResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*CommentResult, error) {
+		return wb.AddComment(sheet, cell, author, text)
+	})
+}
+
+// DeleteComment removes the comment from a cell. See Workbook.DeleteComment.
+func DeleteComment(path, sheet, cell string) (*CommentResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*CommentResult, error) {
+		return wb.DeleteComment(sheet, cell)
+	})
+}
+
+// AddComment attaches a comment with the given author and text to a cell.
+// A comment already on the cell is replaced, since Excel shows only one
+// comment per cell.
+func (wb *Workbook) AddComment(sheet, cell, author, text string) (*CommentResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+	if author == "" || text == "" {
+		return nil, fmt.Errorf("a comment needs both an author and text")
+	}
+	if utf8.RuneCountInString(author) > maxCommentAuthorLength {
+		return nil, fmt.Errorf("comment author is longer than %d characters", maxCommentAuthorLength)
+	}
+	if utf8.RuneCountInString(text) > maxCommentTextLength {
+		return nil, fmt.Errorf("comment text is longer than %d characters", maxCommentTextLength)
+	}
+
+	resolvedSheet, addr, err := wb.resolveCommentCell(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+
+	existed, err := hasComment(wb.f, resolvedSheet, addr)
+	if err != nil {
+		return nil, err
+	}
+	if existed {
+		if err := wb.f.DeleteComment(resolvedSheet, addr); err != nil {
+			return nil, fmt.Errorf("failed to replace comment on %s: %w", addr, err)
+		}
+	}
+
+	comment := excelize.Comment{Cell: addr, Author: author, Text: text}
+	if err := wb.f.AddComment(resolvedSheet, comment); err != nil {
+		return nil, fmt.Errorf("failed to add comment to %s: %w", addr, err)
+	}
+
+	return &CommentResult{
+		Success:  true,
+		Sheet:    resolvedSheet,
+		Cell:     addr,
+		Replaced: existed,
+	}, nil
+}
+
+// DeleteComment removes the comment from a cell. Deleting from a cell
+// without a comment is a no-op, reported with Deleted false.
+func (wb *Workbook) DeleteComment(sheet, cell string) (*CommentResult, error) {
+	resolvedSheet, addr, err := wb.resolveCommentCell(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+
+	existed, err := hasComment(wb.f, resolvedSheet, addr)
+	if err != nil {
+		return nil, err
+	}
+	if existed {
+		if err := wb.f.DeleteComment(resolvedSheet, addr); err != nil {
+			return nil, fmt.Errorf("failed to delete comment from %s: %w", addr, err)
+		}
+	}
+
+	return &CommentResult{
+		Success: true,
+		Sheet:   resolvedSheet,
+		Cell:    addr,
+		Deleted: existed,
+	}, nil
+}
+
+// resolveCommentCell resolves the sheet and normalizes the cell address to
+// the form excelize stores comment anchors in, e.g. "$b$3" to "B3"
+func (wb *Workbook) resolveCommentCell(sheet, cell string) (string, string, error) {
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return "", "", err
+	}
+	col, row, err := ParseCellAddress(strings.ReplaceAll(cell, "$", ""))
+	if err != nil {
+		return "", "", err
+	}
+	return resolvedSheet, FormatCellAddress(col, row), nil
+}
+
+// hasComment reports whether the cell at addr has a comment
+func hasComment(f *excelize.File, sheet, addr string) (bool, error) {
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return false, fmt.Errorf("failed to read comments: %w", err)
+	}
+	for _, c := range comments {
+		if c.Cell == addr {
+			return true, nil
+		}
+	}
+	return false, nil
+}
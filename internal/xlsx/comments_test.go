@@ -0,0 +1,79 @@
+package xlsx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestComments(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := AddComment(path, "", "B2", "Ana", "double-check"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := AddComment(path, "", "$a$3", "Ben", "from the old sheet"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	result, err := AddComment(path, "", "B2", "Ana", "verified")
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if !result.Replaced {
+		t.Error("expected replacing a comment to report Replaced")
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	comments, err := GetComments(f, "Sheet1")
+	f.Close()
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	want := []CellComment{
+		{Cell: "B2", Author: "Ana", Text: "verified"},
+		{Cell: "A3", Author: "Ben", Text: "from the old sheet"},
+	}
+	if len(comments) != len(want) {
+		t.Fatalf("expected %d comments, got %+v", len(want), comments)
+	}
+	for i := range want {
+		if comments[i] != want[i] {
+			t.Errorf("comment %d: expected %+v, got %+v", i, want[i], comments[i])
+		}
+	}
+
+	result, err = DeleteComment(path, "", "B2")
+	if err != nil {
+		t.Fatalf("DeleteComment failed: %v", err)
+	}
+	if !result.Deleted {
+		t.Error("expected Deleted for a cell with a comment")
+	}
+	result, err = DeleteComment(path, "", "B2")
+	if err != nil {
+		t.Fatalf("DeleteComment on a cell without a comment failed: %v", err)
+	}
+	if result.Deleted {
+		t.Error("expected Deleted false for a cell without a comment")
+	}
+}
+
+func TestCommentErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := AddComment(path, "", "B2", "", "text"); err == nil {
+		t.Error("expected error for a missing author")
+	}
+	if _, err := AddComment(path, "", "B2", strings.Repeat("a", 256), "text"); err == nil {
+		t.Error("expected error for an author over 255 characters")
+	}
+	if _, err := AddComment(path, "", "XFE1", "Ana", "text"); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("expected ErrInvalidAddress, got: %v", err)
+	}
+	if _, err := DeleteComment(path, "Missing", "A1"); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("expected ErrSheetNotFound, got: %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// RemoveEmptyRows deletes the rows of a sheet in which every cell is empty
+// and saves atomically. See Workbook.RemoveEmptyRows.
+func RemoveEmptyRows(path, sheet string) (*CompactResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*CompactResult, error) {
+		return wb.RemoveEmptyRows(sheet)
+	})
+}
+
+// RemoveEmptyCols deletes the columns of a sheet in which every cell is
+// empty and saves atomically. See Workbook.RemoveEmptyCols.
+func RemoveEmptyCols(path, sheet string) (*CompactResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*CompactResult, error) {
+		return wb.RemoveEmptyCols(sheet)
+	})
+}
+
+// RemoveEmptyRows deletes every row within the sheet's used range, leading
+// blank rows included, that has no value or formula, shifting the rows
+// below up. A row with a single non-empty cell is kept. Rows are removed
+// with excelize's RemoveRow, so formulas, merged cells and defined names
+// referring to the rows below are adjusted.
+func (wb *Workbook) RemoveEmptyRows(sheet string) (*CompactResult, error) {
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CompactResult{Success: true, Sheet: resolvedSheet}
+	usedRows, _, bounds, err := scanUsedCells(wb.f, resolvedSheet)
+	if err != nil || bounds == nil {
+		return result, err
+	}
+
+	// Remove bottom-up so the remaining row numbers stay valid
+	for row := bounds.EndRow; row >= 1; row-- {
+		if usedRows[row] {
+			continue
+		}
+		used, err := rangeHasFormula(wb.f, resolvedSheet, &CellRange{StartCol: 1, StartRow: row, EndCol: bounds.EndCol, EndRow: row})
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			continue
+		}
+		// As in DeleteRows, references to the removed row become #REF!
+		// instead of shifting onto the row below
+		if _, err := adjustRefsForDeletion(wb.f, resolvedSheet, row, row); err != nil {
+			return nil, err
+		}
+		if err := wb.f.RemoveRow(resolvedSheet, row); err != nil {
+			return nil, fmt.Errorf("failed to remove row %d: %w", row, err)
+		}
+		result.RowsRemoved++
+	}
+	return result, nil
+}
+
+// RemoveEmptyCols deletes every column within the sheet's used range,
+// leading blank columns included, that has no value or formula, shifting
+// the columns to the right left. Columns are removed with excelize's
+// RemoveCol, which shifts formula references to the columns on the right.
+func (wb *Workbook) RemoveEmptyCols(sheet string) (*CompactResult, error) {
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CompactResult{Success: true, Sheet: resolvedSheet}
+	_, usedCols, bounds, err := scanUsedCells(wb.f, resolvedSheet)
+	if err != nil || bounds == nil {
+		return result, err
+	}
+
+	// Remove right to left so the remaining column numbers stay valid
+	for col := bounds.EndCol; col >= 1; col-- {
+		if usedCols[col] {
+			continue
+		}
+		used, err := rangeHasFormula(wb.f, resolvedSheet, &CellRange{StartCol: col, StartRow: 1, EndCol: col, EndRow: bounds.EndRow})
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			continue
+		}
+		name := ColumnNumberToName(col)
+		if err := wb.f.RemoveCol(resolvedSheet, name); err != nil {
+			return nil, fmt.Errorf("failed to remove column %s: %w", name, err)
+		}
+		result.ColsRemoved++
+	}
+	return result, nil
+}
+
+// scanUsedCells streams a sheet and reports which rows and columns hold a
+// value, along with the used range, which is nil for an empty sheet.
+// Formulas with an empty cached value are not seen; see rangeHasFormula.
+func scanUsedCells(f *excelize.File, sheet string) (rows, cols map[int]bool, bounds *CellRange, err error) {
+	bounds, err = sheetBounds(f, sheet)
+	if err != nil || bounds == nil {
+		return nil, nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := StreamRowsWithOptions(ctx, f, sheet, 1, bounds.EndRow,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	rows, cols = make(map[int]bool), make(map[int]bool)
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, nil, nil, rowResult.Err
+		}
+		for _, cell := range rowResult.Row.Cells {
+			if cell.Value != "" {
+				rows[cell.Row] = true
+				cols[cell.Col] = true
+			}
+		}
+	}
+	return rows, cols, bounds, nil
+}
+
+// rangeHasFormula reports whether any cell in r holds a formula
+func rangeHasFormula(f *excelize.File, sheet string, r *CellRange) (bool, error) {
+	for row := r.StartRow; row <= r.EndRow; row++ {
+		for col := r.StartCol; col <= r.EndCol; col++ {
+			addr := FormatCellAddress(col, row)
+			formula, err := f.GetCellFormula(sheet, addr)
+			if err != nil {
+				return false, fmt.Errorf("failed to get formula for %s: %w", addr, err)
+			}
+			if formula != "" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
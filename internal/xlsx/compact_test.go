@@ -0,0 +1,112 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createCompactTestFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "compact.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+	cells := map[string]any{
+		"A1": "Name", "C1": "Age",
+		"A2": "Ann", "C2": 30,
+		"C4": 41,
+		"A5": "Cy", "C5": 25,
+	}
+	for cell, value := range cells {
+		if err := f.SetCellValue("Sheet1", cell, value); err != nil {
+			t.Fatalf("failed to set %s: %v", cell, err)
+		}
+	}
+	// Row 3 and column B are empty; row 4 has a single value
+	if err := f.SetCellFormula("Sheet1", "D5", "C5*2"); err != nil {
+		t.Fatalf("failed to set formula: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
+func TestRemoveEmptyRows(t *testing.T) {
+	path := createCompactTestFile(t)
+
+	result, err := RemoveEmptyRows(path, "")
+	if err != nil {
+		t.Fatalf("RemoveEmptyRows failed: %v", err)
+	}
+	if result.RowsRemoved != 1 || result.Sheet != "Sheet1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	got := readDedupRows(t, path)
+	want := [][]string{
+		{"Name", "", "Age"},
+		{"Ann", "", "30"},
+		{"", "", "41"},
+		{"Cy", "", "25", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+	assertFormula(t, path, "D4", "C4*2")
+}
+
+func TestRemoveEmptyCols(t *testing.T) {
+	path := createCompactTestFile(t)
+
+	result, err := RemoveEmptyCols(path, "")
+	if err != nil {
+		t.Fatalf("RemoveEmptyCols failed: %v", err)
+	}
+	if result.ColsRemoved != 1 {
+		t.Errorf("ColsRemoved = %d, want 1", result.ColsRemoved)
+	}
+
+	got := readDedupRows(t, path)
+	if len(got) < 2 || !reflect.DeepEqual(got[1], []string{"Ann", "30"}) {
+		t.Errorf("rows = %q, want column B removed", got)
+	}
+	// The formula-only column D is kept and moves to C
+	assertFormula(t, path, "C5", "B5*2")
+}
+
+func TestRemoveEmptyRows_EmptySheet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.xlsx")
+	f := excelize.NewFile()
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	result, err := RemoveEmptyRows(path, "")
+	if err != nil {
+		t.Fatalf("RemoveEmptyRows failed: %v", err)
+	}
+	if result.RowsRemoved != 0 {
+		t.Errorf("RowsRemoved = %d, want 0", result.RowsRemoved)
+	}
+}
+
+func assertFormula(t *testing.T, path, cell, want string) {
+	t.Helper()
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+	got, err := f.GetCellFormula("Sheet1", cell)
+	if err != nil {
+		t.Fatalf("failed to get formula: %v", err)
+	}
+	if got != want {
+		t.Errorf("formula %s = %q, want %q", cell, got, want)
+	}
+}
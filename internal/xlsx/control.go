@@ -0,0 +1,162 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxControlCharResults is the maximum number of cells FindControlChars reports
+const MaxControlCharResults = 1000
+
+// isControlChar reports whether r is a control or invisible format character
+// (e.g. NUL, tab, CR, LF, zero-width space)
+func isControlChar(r rune) bool {
+	return unicode.IsControl(r) || unicode.Is(unicode.Cf, r)
+}
+
+// controlCharName returns a readable name for a control character
+func controlCharName(r rune) string {
+	switch r {
+	case 0:
+		return "NUL"
+	case '\t':
+		return "TAB"
+	case '\n':
+		return "LF"
+	case '\r':
+		return "CR"
+	}
+	return fmt.Sprintf("U+%04X", r)
+}
+
+// FindControlChars scans a sheet for cells containing control or invisible
+// format characters such as embedded CR/LF, tabs or null bytes, which often
+// come from imported data and break downstream parsing. Stored values are
+// scanned. At most MaxControlCharResults cells are reported; Truncated is
+// set when there are more.
+func FindControlChars(f *excelize.File, sheet string) (*ControlCharsResult, error) {
+	resolvedSheet, err := ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := StreamRowsWithOptions(ctx, f, resolvedSheet, 0, 0,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ControlCharsResult{Sheet: resolvedSheet, Matches: []ControlCharMatch{}}
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, rowResult.Err
+		}
+		for _, cell := range rowResult.Row.Cells {
+			chars := findControlChars(cell.Value)
+			if len(chars) == 0 {
+				continue
+			}
+			if len(result.Matches) >= MaxControlCharResults {
+				result.Truncated = true
+				return result, nil
+			}
+			result.Matches = append(result.Matches, ControlCharMatch{
+				Address: cell.Address,
+				Value:   cell.Value,
+				Chars:   chars,
+			})
+		}
+	}
+	return result, nil
+}
+
+// findControlChars returns the distinct control characters in a value by name
+func findControlChars(value string) []string {
+	var chars []string
+	seen := map[rune]bool{}
+	for _, r := range value {
+		if isControlChar(r) && !seen[r] {
+			seen[r] = true
+			chars = append(chars, controlCharName(r))
+		}
+	}
+	return chars
+}
+
+// StripControlChars removes control and invisible format characters from
+// the cells of one sheet, or every sheet when sheet is empty, and saves
+// atomically. Line breaks and tabs become a single space so words do not
+// run together; with keepNewlines, CRLF and CR are normalized to LF and
+// kept instead. Formula text is cleaned too.
+// Enforces MaxReplaceCells limit.
+func StripControlChars(path, sheet string, keepNewlines bool) (*ReplaceResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*ReplaceResult, error) {
+		return wb.StripControlChars(sheet, keepNewlines)
+	})
+}
+
+// StripControlChars removes control and invisible format characters from
+// cell values. Enforces MaxReplaceCells limit.
+func (wb *Workbook) StripControlChars(sheet string, keepNewlines bool) (*ReplaceResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	sheets := wb.f.GetSheetList()
+	if sheet != "" {
+		resolvedSheet, err := wb.resolveSheet(sheet)
+		if err != nil {
+			return nil, err
+		}
+		sheets = []string{resolvedSheet}
+	}
+
+	strip := func(value string) (string, bool) {
+		return stripControlChars(value, keepNewlines), true
+	}
+
+	result := &ReplaceResult{Success: true, Changes: []ReplaceChange{}}
+	for _, sheetName := range sheets {
+		changes, err := replaceInSheet(wb.f, sheetName, strip, MaxReplaceCells-len(result.Changes), false)
+		if err != nil {
+			return nil, err
+		}
+		result.Changes = append(result.Changes, changes...)
+	}
+
+	result.CellsChanged = len(result.Changes)
+	return result, nil
+}
+
+// stripControlChars cleans a value as described on StripControlChars
+func stripControlChars(value string, keepNewlines bool) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	value = strings.ReplaceAll(value, "\r", "\n")
+
+	var b strings.Builder
+	space := false // Last written rune was a space from a line break or tab
+	for _, r := range value {
+		switch {
+		case r == '\n' && keepNewlines:
+			b.WriteRune(r)
+			space = false
+		case r == '\n' || r == '\t':
+			if !space {
+				b.WriteRune(' ')
+				space = true
+			}
+		case isControlChar(r):
+		default:
+			b.WriteRune(r)
+			space = false
+		}
+	}
+	return b.String()
+}
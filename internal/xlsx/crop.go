@@ -0,0 +1,237 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// copiedCell is a cell captured for copying: its value, write type and style
+type copiedCell struct {
+	col, row  int // Offset from the top-left of the copied range
+	value     string
+	valueType string // string, number, bool, formula (as used by setCellWithType)
+	style     int
+}
+
+// CropSheet keeps only the cells within keepRange, moving them to A1 and
+// clearing everything else in the sheet. Values, formulas and cell styles
+// are kept; formula references are copied as written, not adjusted.
+// Enforces MaxWriteRangeCells limit.
+func CropSheet(path, sheet, keepRange string) (*CropResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*CropResult, error) {
+		return wb.CropSheet(sheet, keepRange)
+	})
+}
+
+// CropSheet keeps only the cells within keepRange, moving them to A1.
+// Enforces MaxWriteRangeCells limit.
+func (wb *Workbook) CropSheet(sheet, keepRange string) (*CropResult, error) {
+	keep, err := parseClosedRange(keepRange)
+	if err != nil {
+		return nil, err
+	}
+	totalCells := (keep.EndCol - keep.StartCol + 1) * (keep.EndRow - keep.StartRow + 1)
+	if totalCells > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to keep %d cells, limit is %d",
+			ErrCellLimitExceeded, totalCells, MaxWriteRangeCells)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Read the kept region
+	cells, err := copyRange(wb.f, resolvedSheet, keep)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Clear the sheet
+	if err := clearSheet(wb.f, resolvedSheet); err != nil {
+		return nil, err
+	}
+
+	// 3. Write the region back at A1
+	if err := pasteRange(wb.f, resolvedSheet, 1, 1, cells); err != nil {
+		return nil, err
+	}
+
+	newRange := &CellRange{
+		StartCol: 1,
+		StartRow: 1,
+		EndCol:   keep.EndCol - keep.StartCol + 1,
+		EndRow:   keep.EndRow - keep.StartRow + 1,
+	}
+
+	return &CropResult{
+		Success:   true,
+		Sheet:     resolvedSheet,
+		Kept:      keep.String(),
+		Range:     newRange.String(),
+		CellsKept: len(cells),
+	}, nil
+}
+
+// copyRange captures the non-empty or styled cells of a range
+func copyRange(f *excelize.File, sheet string, r *CellRange) ([]copiedCell, error) {
+	var cells []copiedCell
+	for row := r.StartRow; row <= r.EndRow; row++ {
+		for col := r.StartCol; col <= r.EndCol; col++ {
+			addr := FormatCellAddress(col, row)
+
+			style, err := f.GetCellStyle(sheet, addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get style for %s: %w", addr, err)
+			}
+			formula, err := f.GetCellFormula(sheet, addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get formula for %s: %w", addr, err)
+			}
+			value, err := f.GetCellValue(sheet, addr, excelize.Options{RawCellValue: true})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cell %s: %w", addr, err)
+			}
+			if formula == "" && value == "" && style == 0 {
+				continue
+			}
+
+			cell := copiedCell{
+				col:   col - r.StartCol,
+				row:   row - r.StartRow,
+				value: value,
+				style: style,
+			}
+			switch {
+			case formula != "":
+				cell.value = formula
+				cell.valueType = "formula"
+			case value != "":
+				cell.valueType, err = copiedValueType(f, sheet, addr, value)
+				if err != nil {
+					return nil, err
+				}
+			}
+			cells = append(cells, cell)
+		}
+	}
+	return cells, nil
+}
+
+// copiedValueType maps a stored cell type to a setCellWithType type
+func copiedValueType(f *excelize.File, sheet, addr, value string) (string, error) {
+	cellType, err := f.GetCellType(sheet, addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cell type for %s: %w", addr, err)
+	}
+	switch cellType {
+	case excelize.CellTypeBool:
+		return "bool", nil
+	case excelize.CellTypeNumber, excelize.CellTypeUnset:
+		if InferCellType(value) == "number" {
+			return "number", nil
+		}
+	}
+	return "string", nil
+}
+
+// clearSheet removes values, formulas and styles from every cell in the
+// sheet's used range
+func clearSheet(f *excelize.File, sheet string) error {
+	bounds, err := sheetBounds(f, sheet)
+	if err != nil {
+		return err
+	}
+	if bounds == nil {
+		return nil
+	}
+	return clearCells(f, sheet, bounds)
+}
+
+// clearCells removes values, formulas and styles from the cells of a range
+func clearCells(f *excelize.File, sheet string, r *CellRange) error {
+	for row := r.StartRow; row <= r.EndRow; row++ {
+		for col := r.StartCol; col <= r.EndCol; col++ {
+			addr := FormatCellAddress(col, row)
+			value, err := f.GetCellValue(sheet, addr, excelize.Options{RawCellValue: true})
+			if err != nil {
+				return fmt.Errorf("failed to get cell %s: %w", addr, err)
+			}
+			formula, err := f.GetCellFormula(sheet, addr)
+			if err != nil {
+				return fmt.Errorf("failed to get formula for %s: %w", addr, err)
+			}
+			style, err := f.GetCellStyle(sheet, addr)
+			if err != nil {
+				return fmt.Errorf("failed to get style for %s: %w", addr, err)
+			}
+			if value == "" && formula == "" && style == 0 {
+				continue // Avoid creating records for cells that do not exist
+			}
+
+			if err := f.SetCellValue(sheet, addr, nil); err != nil {
+				return fmt.Errorf("failed to clear cell %s: %w", addr, err)
+			}
+			if err := f.SetCellStyle(sheet, addr, addr, 0); err != nil {
+				return fmt.Errorf("failed to clear style of %s: %w", addr, err)
+			}
+		}
+	}
+	return nil
+}
+
+// clearValues removes values and formulas from the cells of a range,
+// keeping styles, and returns how many cells were cleared
+func clearValues(f *excelize.File, sheet string, r *CellRange) (int, error) {
+	cleared := 0
+	for row := r.StartRow; row <= r.EndRow; row++ {
+		for col := r.StartCol; col <= r.EndCol; col++ {
+			addr := FormatCellAddress(col, row)
+			value, err := f.GetCellValue(sheet, addr, excelize.Options{RawCellValue: true})
+			if err != nil {
+				return 0, fmt.Errorf("failed to get cell %s: %w", addr, err)
+			}
+			formula, err := f.GetCellFormula(sheet, addr)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get formula for %s: %w", addr, err)
+			}
+			if value == "" && formula == "" {
+				continue // Avoid creating records for cells that do not exist
+			}
+
+			// A nil value empties the cell and drops its formula
+			if err := f.SetCellValue(sheet, addr, nil); err != nil {
+				return 0, fmt.Errorf("failed to clear cell %s: %w", addr, err)
+			}
+			cleared++
+		}
+	}
+	return cleared, nil
+}
+
+// pasteRange writes copied cells with their top-left at startCol, startRow
+func pasteRange(f *excelize.File, sheet string, startCol, startRow int, cells []copiedCell) error {
+	for _, cell := range cells {
+		addr := FormatCellAddress(startCol+cell.col, startRow+cell.row)
+		if cell.style != 0 {
+			if err := f.SetCellStyle(sheet, addr, addr, cell.style); err != nil {
+				return fmt.Errorf("failed to set style of %s: %w", addr, err)
+			}
+		}
+		switch cell.valueType {
+		case "":
+			continue
+		case "string":
+			// Copied text is written as-is, without sanitizing
+			if err := f.SetCellStr(sheet, addr, cell.value); err != nil {
+				return fmt.Errorf("failed to set cell %s as string: %w", addr, err)
+			}
+			continue
+		}
+		if err := setCellWithType(f, sheet, addr, cell.value, cell.valueType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
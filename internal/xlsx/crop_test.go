@@ -0,0 +1,96 @@
+package xlsx
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCropSheet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crop.xlsx")
+
+	f := excelize.NewFile()
+	// Fill A1:F7 with "R<row>C<col>" so every cell outside C3:E5 has data
+	for row := 1; row <= 7; row++ {
+		for col := 1; col <= 6; col++ {
+			addr := FormatCellAddress(col, row)
+			if err := f.SetCellValue("Sheet1", addr, addr); err != nil {
+				t.Fatalf("failed to set cell: %v", err)
+			}
+		}
+	}
+	if err := f.SetCellValue("Sheet1", "D4", 42); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	result, err := CropSheet(path, "Sheet1", "C3:E5")
+	if err != nil {
+		t.Fatalf("CropSheet failed: %v", err)
+	}
+	if result.Kept != "C3:E5" || result.Range != "A1:C3" {
+		t.Errorf("expected C3:E5 moved to A1:C3, got %s -> %s", result.Kept, result.Range)
+	}
+	if result.CellsKept != 9 {
+		t.Errorf("expected 9 cells kept, got %d", result.CellsKept)
+	}
+
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatalf("GetRows failed: %v", err)
+	}
+	expected := [][]string{
+		{"C3", "D3", "E3"},
+		{"C4", "42", "E4"},
+		{"C5", "D5", "E5"},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("expected %d rows, got %d: %v", len(expected), len(rows), rows)
+	}
+	for i, want := range expected {
+		if len(rows[i]) != len(want) {
+			t.Fatalf("row %d: expected %v, got %v", i+1, want, rows[i])
+		}
+		for j := range want {
+			if rows[i][j] != want[j] {
+				t.Errorf("row %d col %d: expected %q, got %q", i+1, j+1, want[j], rows[i][j])
+			}
+		}
+	}
+
+	cellType, err := f.GetCellType("Sheet1", "B2")
+	if err != nil {
+		t.Fatalf("GetCellType failed: %v", err)
+	}
+	if cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+		t.Errorf("expected moved number to stay numeric, got type %v", cellType)
+	}
+}
+
+func TestCropSheetErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	_, err := CropSheet(path, "Sheet1", "A1:A20000")
+	if !errors.Is(err, ErrCellLimitExceeded) {
+		t.Errorf("expected ErrCellLimitExceeded, got: %v", err)
+	}
+
+	if _, err := CropSheet(path, "Sheet1", "bogus"); err == nil {
+		t.Error("expected error for invalid range")
+	}
+
+	if _, err := CropSheet(path, "NoSuchSheet", "A1:B2"); err == nil {
+		t.Error("expected error for nonexistent sheet")
+	}
+}
@@ -0,0 +1,176 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// DefaultDateFormat is the number format applied by ConvertSerialDates
+// when no format is given
+const DefaultDateFormat = "yyyy-mm-dd"
+
+// maxDateSerial is the serial for 9999-12-31, the last date Excel supports
+const maxDateSerial = 2958465
+
+// maxDateSerial1904 is the serial for 9999-12-31 in the 1904 date system
+const maxDateSerial1904 = 2957003
+
+// ConvertSerialDates applies a date number format to every numeric cell in
+// a column so Excel date serials (e.g. 45000) display as dates.
+// If convertText is true, text cells holding a serial are rewritten as
+// numbers first. Cells outside the valid serial range are skipped.
+func ConvertSerialDates(path, sheet, col, format string, hasHeader, convertText bool) (*ConvertDatesResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*ConvertDatesResult, error) {
+		return wb.ConvertSerialDates(sheet, col, format, hasHeader, convertText)
+	})
+}
+
+// ConvertSerialDates applies a date number format to the serials in a column
+func (wb *Workbook) ConvertSerialDates(sheet, col, format string, hasHeader, convertText bool) (*ConvertDatesResult, error) {
+	colNum, err := ParseColumnName(col)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = DefaultDateFormat
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+	f := wb.f
+
+	date1904, err := IsDate1904(f)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRow, err := getLastRow(f, resolvedSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last row: %w", err)
+	}
+
+	startRow := 1
+	if hasHeader {
+		startRow = 2
+	}
+
+	result := &ConvertDatesResult{Success: true, Column: ColumnNumberToName(colNum), Date1904: date1904}
+	styles := make(map[int]int) // original style ID -> date style ID
+
+	for row := startRow; row <= lastRow; row++ {
+		addr := FormatCellAddress(colNum, row)
+		serial, ok, err := readDateSerial(f, resolvedSheet, addr, convertText, date1904)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if v, _ := f.GetCellValue(resolvedSheet, addr); v != "" {
+				result.CellsSkipped++
+			}
+			continue
+		}
+
+		if err := applyDateFormat(f, resolvedSheet, addr, format, styles); err != nil {
+			return nil, err
+		}
+		if result.CellsConverted == 0 {
+			result.FirstDate = formatSerialDate(serial, date1904)
+		}
+		result.CellsConverted++
+	}
+
+	return result, nil
+}
+
+// IsDate1904 reports whether the workbook uses the 1904 date system, where
+// serial 0 is 1904-01-01 instead of 1900-01-00
+func IsDate1904(f *excelize.File) (bool, error) {
+	props, err := f.GetWorkbookProps()
+	if err != nil {
+		return false, fmt.Errorf("failed to read workbook properties: %w", err)
+	}
+	return props.Date1904 != nil && *props.Date1904, nil
+}
+
+// readDateSerial returns the numeric serial held in a cell. Text cells are
+// only accepted (and rewritten as numbers) when convertText is set.
+// The valid serial range depends on the workbook's date system.
+func readDateSerial(f *excelize.File, sheet, addr string, convertText, date1904 bool) (float64, bool, error) {
+	raw, err := f.GetCellValue(sheet, addr, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get cell %s: %w", addr, err)
+	}
+	if raw == "" {
+		return 0, false, nil
+	}
+
+	serial, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	minSerial, maxSerial := 1.0, float64(maxDateSerial)
+	if date1904 {
+		minSerial, maxSerial = 0, maxDateSerial1904
+	}
+	if err != nil || serial < minSerial || serial > maxSerial {
+		return 0, false, nil
+	}
+
+	cellType, err := f.GetCellType(sheet, addr)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get cell type %s: %w", addr, err)
+	}
+	switch cellType {
+	case excelize.CellTypeSharedString, excelize.CellTypeInlineString:
+		if !convertText {
+			return 0, false, nil
+		}
+		if err := f.SetCellFloat(sheet, addr, serial, -1, 64); err != nil {
+			return 0, false, fmt.Errorf("failed to set cell %s as number: %w", addr, err)
+		}
+	case excelize.CellTypeBool, excelize.CellTypeError, excelize.CellTypeFormula:
+		return 0, false, nil
+	}
+
+	return serial, true, nil
+}
+
+// applyDateFormat sets the cell's number format while keeping the rest of
+// its existing style. Derived style IDs are cached per original style.
+func applyDateFormat(f *excelize.File, sheet, addr, format string, cache map[int]int) error {
+	styleID, err := f.GetCellStyle(sheet, addr)
+	if err != nil {
+		return fmt.Errorf("failed to get style for %s: %w", addr, err)
+	}
+
+	dateStyle, ok := cache[styleID]
+	if !ok {
+		style, err := f.GetStyle(styleID)
+		if err != nil {
+			return fmt.Errorf("failed to read style %d: %w", styleID, err)
+		}
+		style.NumFmt = 0
+		style.CustomNumFmt = &format
+		dateStyle, err = f.NewStyle(style)
+		if err != nil {
+			return fmt.Errorf("failed to create date style: %w", err)
+		}
+		cache[styleID] = dateStyle
+	}
+
+	if err := f.SetCellStyle(sheet, addr, addr, dateStyle); err != nil {
+		return fmt.Errorf("failed to set style for %s: %w", addr, err)
+	}
+	return nil
+}
+
+// formatSerialDate renders an Excel serial as an ISO date string
+func formatSerialDate(serial float64, date1904 bool) string {
+	t, err := excelize.ExcelDateToTime(serial, date1904)
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,144 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createDateSerialFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dates.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+
+	cells := map[string]any{
+		"A1": "Date",
+		"A2": 45000,
+		"A3": 45001.5,
+		"A4": "45002",
+		"A5": "not a date",
+	}
+	for addr, v := range cells {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set %s: %v", addr, err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	return path
+}
+
+func TestConvertSerialDates(t *testing.T) {
+	path := createDateSerialFile(t)
+
+	result, err := ConvertSerialDates(path, "Sheet1", "A", "", true, false)
+	if err != nil {
+		t.Fatalf("ConvertSerialDates failed: %v", err)
+	}
+	if result.CellsConverted != 2 {
+		t.Errorf("expected 2 cells converted, got %d", result.CellsConverted)
+	}
+	if result.CellsSkipped != 2 {
+		t.Errorf("expected 2 cells skipped, got %d", result.CellsSkipped)
+	}
+	if result.FirstDate != "2023-03-15" {
+		t.Errorf("expected first date 2023-03-15, got %q", result.FirstDate)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	value, err := f.GetCellValue("Sheet1", "A2")
+	if err != nil {
+		t.Fatalf("GetCellValue failed: %v", err)
+	}
+	if value != "2023-03-15" {
+		t.Errorf("expected A2 to display 2023-03-15, got %q", value)
+	}
+
+	// Text serial untouched without convertText
+	value, _ = f.GetCellValue("Sheet1", "A4")
+	if value != "45002" {
+		t.Errorf("expected text serial to stay 45002, got %q", value)
+	}
+}
+
+func TestConvertSerialDatesText(t *testing.T) {
+	path := createDateSerialFile(t)
+
+	result, err := ConvertSerialDates(path, "Sheet1", "A", "dd/mm/yyyy", true, true)
+	if err != nil {
+		t.Fatalf("ConvertSerialDates failed: %v", err)
+	}
+	if result.CellsConverted != 3 {
+		t.Errorf("expected 3 cells converted, got %d", result.CellsConverted)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	value, _ := f.GetCellValue("Sheet1", "A4")
+	if value != "17/03/2023" {
+		t.Errorf("expected text serial to display 17/03/2023, got %q", value)
+	}
+}
+
+func TestConvertSerialDatesInvalidColumn(t *testing.T) {
+	path := createDateSerialFile(t)
+
+	if _, err := ConvertSerialDates(path, "Sheet1", "A1", "", false, false); err == nil {
+		t.Error("expected error for invalid column")
+	}
+}
+
+func TestConvertSerialDates1904(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dates1904.xlsx")
+	f := excelize.NewFile()
+	date1904 := true
+	if err := f.SetWorkbookProps(&excelize.WorkbookPropsOptions{Date1904: &date1904}); err != nil {
+		t.Fatalf("failed to set workbook props: %v", err)
+	}
+	if err := f.SetCellValue("Sheet1", "A1", 45000); err != nil {
+		t.Fatalf("failed to set A1: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	f.Close()
+
+	result, err := ConvertSerialDates(path, "Sheet1", "A", "", false, false)
+	if err != nil {
+		t.Fatalf("ConvertSerialDates failed: %v", err)
+	}
+	if !result.Date1904 {
+		t.Error("expected Date1904 to be reported")
+	}
+	// Same serial is 1462 days later than in the 1900 system (2023-03-15)
+	if result.FirstDate != "2027-03-16" {
+		t.Errorf("expected first date 2027-03-16, got %q", result.FirstDate)
+	}
+
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	value, err := f.GetCellValue("Sheet1", "A1")
+	if err != nil {
+		t.Fatalf("GetCellValue failed: %v", err)
+	}
+	if value != "2027-03-16" {
+		t.Errorf("expected A1 to display 2027-03-16, got %q", value)
+	}
+}
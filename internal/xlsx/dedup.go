@@ -0,0 +1,202 @@
+package xlsx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// MaxDedupRows is the maximum number of rows Dedup will compare
+const MaxDedupRows = 100000
+
+// Which occurrence of a duplicated row Dedup keeps
+const (
+	KeepFirst = "first"
+	KeepLast  = "last"
+)
+
+// DedupOptions configures Dedup
+type DedupOptions struct {
+	Columns []string // Key columns by header label or letter (default: the whole row)
+	Keep    string   // KeepFirst (default) or KeepLast
+	Header  bool     // Pin the first row as a header, excluded from comparison
+}
+
+// Dedup removes duplicate rows from a sheet and saves atomically.
+// See Workbook.Dedup.
+func Dedup(path, sheet string, opts DedupOptions) (*DedupResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*DedupResult, error) {
+		return wb.Dedup(sheet, opts)
+	})
+}
+
+// Dedup removes rows whose key repeats an earlier row, keeping the first
+// occurrence, or the last with KeepLast, and closing the gaps so the kept
+// rows stay in their original order. The rows left over at the bottom are
+// removed. The key is the stored values of opts.Columns, or of the whole
+// row; rows whose key cells are all empty are never treated as duplicates.
+// Finding the duplicates streams the rows and holds only a hash of each
+// key, but closing the gaps loads every cell of the region into memory,
+// which MaxDedupRows bounds. Kept rows keep their styles; formulas move with
+// their row but their references are not adjusted.
+func (wb *Workbook) Dedup(sheet string, opts DedupOptions) (*DedupResult, error) {
+	keep := strings.ToLower(strings.TrimSpace(opts.Keep))
+	switch keep {
+	case "":
+		keep = KeepFirst
+	case KeepFirst, KeepLast:
+	default:
+		return nil, fmt.Errorf("unknown keep option: %s (valid: first, last)", opts.Keep)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DedupResult{Success: true, Sheet: resolvedSheet}
+
+	bounds, err := sheetBounds(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	if bounds == nil {
+		return result, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var headers []string
+	if opts.Header {
+		if headers, err = GetHeaderRow(ctx, wb.f, resolvedSheet); err != nil {
+			return nil, err
+		}
+	}
+	var cols []int
+	for _, column := range opts.Columns {
+		col, _, err := resolveAggregateColumn(headers, column)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	if len(cols) == 0 {
+		for col := bounds.StartCol; col <= bounds.EndCol; col++ {
+			cols = append(cols, col)
+		}
+	}
+
+	region := *bounds
+	if opts.Header {
+		region.StartRow++
+	}
+	rowCount := region.EndRow - region.StartRow + 1
+	if rowCount <= 1 {
+		result.RowsScanned = max(rowCount, 0)
+		return result, nil
+	}
+	if rowCount > MaxDedupRows {
+		return nil, fmt.Errorf("%w: attempting to deduplicate %d rows, limit is %d",
+			ErrRowLimitExceeded, rowCount, MaxDedupRows)
+	}
+
+	// 1. Stream the rows, hashing each key and recording the row that keeps it
+	ch, err := StreamRowsWithOptions(ctx, wb.f, resolvedSheet, region.StartRow, region.EndRow,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*[sha256.Size]byte, rowCount) // nil for rows with an empty key
+	owner := make(map[[sha256.Size]byte]int)
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, rowResult.Err
+		}
+		i := rowResult.Row.Number - region.StartRow
+		key, ok := dedupKey(rowResult.Row.Cells, cols)
+		if !ok {
+			continue
+		}
+		keys[i] = &key
+		if _, seen := owner[key]; !seen || keep == KeepLast {
+			owner[key] = i
+		}
+	}
+
+	kept := make([]bool, rowCount)
+	for i, key := range keys {
+		kept[i] = key == nil || owner[*key] == i
+		if !kept[i] {
+			result.DuplicatesRemoved++
+		}
+	}
+	result.RowsScanned = rowCount
+	if result.DuplicatesRemoved == 0 {
+		return result, nil
+	}
+
+	// 2. Clear and write the kept rows back without gaps
+	cells, err := copyRange(wb.f, resolvedSheet, &region)
+	if err != nil {
+		return nil, err
+	}
+	if err := clearCells(wb.f, resolvedSheet, &region); err != nil {
+		return nil, err
+	}
+	newRow := make([]int, rowCount)
+	next := 0
+	for i := range kept {
+		newRow[i] = next
+		if kept[i] {
+			next++
+		}
+	}
+	compacted := make([]copiedCell, 0, len(cells))
+	for _, cell := range cells {
+		if kept[cell.row] {
+			cell.row = newRow[cell.row]
+			compacted = append(compacted, cell)
+		}
+	}
+	if err := pasteRange(wb.f, resolvedSheet, region.StartCol, region.StartRow, compacted); err != nil {
+		return nil, err
+	}
+
+	// 3. Remove the vacated rows at the bottom, bottom-up, so the used
+	// range shrinks with them
+	for row := region.EndRow; row >= region.StartRow+next; row-- {
+		if err := wb.f.RemoveRow(resolvedSheet, row); err != nil {
+			return nil, fmt.Errorf("failed to remove row %d: %w", row, err)
+		}
+	}
+
+	result.Range = region.String()
+	return result, nil
+}
+
+// dedupKey hashes the values of a row's key columns, each prefixed with
+// its length so ("ab", "c") and ("a", "bc") differ. It reports false when
+// every key cell is empty.
+func dedupKey(cells []Cell, cols []int) ([sha256.Size]byte, bool) {
+	h := sha256.New()
+	empty := true
+	var size [8]byte
+	for _, col := range cols {
+		value := cellValueAt(cells, col)
+		if value != "" {
+			empty = false
+		}
+		binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+		h.Write(size[:])
+		h.Write([]byte(value))
+	}
+	var key [sha256.Size]byte
+	if empty {
+		return key, false
+	}
+	copy(key[:], h.Sum(nil))
+	return key, true
+}
@@ -0,0 +1,80 @@
+package xlsx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func createDedupTestFile(t *testing.T) string {
+	t.Helper()
+	return writeRowsFile(t, [][]any{
+		{"Email", "Name"},
+		{"a@x.com", "Ann"},
+		{"b@x.com", "Bob"},
+		{"a@x.com", "Ann"},
+		{},
+		{"b@x.com", "Robert"},
+		{},
+		{"c@x.com", "Cy"},
+	})
+}
+
+func readDedupRows(t *testing.T, path string) [][]string {
+	t.Helper()
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatalf("failed to read rows: %v", err)
+	}
+	return rows
+}
+
+func TestDedup(t *testing.T) {
+	path := createDedupTestFile(t)
+
+	result, err := Dedup(path, "", DedupOptions{Header: true})
+	if err != nil {
+		t.Fatalf("Dedup failed: %v", err)
+	}
+	if result.DuplicatesRemoved != 1 || result.RowsScanned != 7 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	want := [][]string{{"Email", "Name"}, {"a@x.com", "Ann"}, {"b@x.com", "Bob"}, nil, {"b@x.com", "Robert"}, nil, {"c@x.com", "Cy"}}
+	if got := readDedupRows(t, path); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	result, err = Dedup(path, "", DedupOptions{Columns: []string{"email"}, Keep: KeepLast, Header: true})
+	if err != nil {
+		t.Fatalf("Dedup failed: %v", err)
+	}
+	if result.DuplicatesRemoved != 1 {
+		t.Errorf("expected 1 duplicate removed, got %+v", result)
+	}
+	want = [][]string{{"Email", "Name"}, {"a@x.com", "Ann"}, nil, {"b@x.com", "Robert"}, nil, {"c@x.com", "Cy"}}
+	if got := readDedupRows(t, path); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// The vacated rows are gone, not left behind as empty cells
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	info, err := GetSheetInfo(f, "Sheet1")
+	f.Close()
+	if err != nil {
+		t.Fatalf("GetSheetInfo failed: %v", err)
+	}
+	if info.Rows != 6 || info.Dimension != "A1:B6" {
+		t.Errorf("expected 6 rows in A1:B6 after dedup, got %d rows in %s", info.Rows, info.Dimension)
+	}
+
+	if _, err := Dedup(path, "", DedupOptions{Keep: "middle"}); err == nil {
+		t.Error("expected error for an unknown keep option")
+	}
+}
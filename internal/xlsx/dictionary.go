@@ -0,0 +1,154 @@
+package xlsx
+
+import (
+	"context"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Data dictionary limits
+const (
+	DefaultDictionaryRows = 1000  // Data rows scanned when sampleRows is 0
+	MaxDictionaryRows     = 50000 // Upper bound on data rows scanned
+	MaxDictionaryColumns  = 200   // Columns described; wider sheets are truncated
+	MaxDistinctValues     = 1000  // Distinct values counted per column
+	DictionarySamples     = 3     // Sample values kept per column
+)
+
+// DataDictionary describes each column of a sheet in one bounded streaming
+// pass: its header, inferred type, fill ratio, distinct-value count and a
+// few sample values. Row 1 is the header row; up to sampleRows data rows
+// after it are scanned (0 = DefaultDictionaryRows, capped at
+// MaxDictionaryRows). Types are inferred from displayed values as in read,
+// so a currency-formatted number is reported as a string.
+func DataDictionary(f *excelize.File, sheet string, sampleRows int) (*DataDictionaryResult, error) {
+	if sampleRows <= 0 {
+		sampleRows = DefaultDictionaryRows
+	}
+	sampleRows = min(sampleRows, MaxDictionaryRows)
+
+	resolvedSheet, err := ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	headers, err := GetHeaderRow(ctx, f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scan one row past the sample to tell whether more rows exist
+	ch, err := StreamRows(ctx, f, resolvedSheet, 2, sampleRows+2)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DataDictionaryResult{Sheet: resolvedSheet, Columns: []ColumnProfile{}}
+	var stats []*columnStats
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, rowResult.Err
+		}
+		if result.RowsScanned == sampleRows {
+			result.Truncated = true
+			cancel()
+			break
+		}
+		result.RowsScanned++
+
+		for _, cell := range rowResult.Row.Cells {
+			if cell.Col > MaxDictionaryColumns {
+				result.ColumnsTruncated = true
+				break
+			}
+			for len(stats) < cell.Col {
+				stats = append(stats, newColumnStats())
+			}
+			stats[cell.Col-1].add(cell)
+		}
+	}
+
+	width := max(len(headers), len(stats))
+	if width > MaxDictionaryColumns {
+		width = MaxDictionaryColumns
+		result.ColumnsTruncated = true
+	}
+	for len(stats) < width {
+		stats = append(stats, newColumnStats())
+	}
+
+	for i := 0; i < width; i++ {
+		header := ""
+		if i < len(headers) {
+			header = headers[i]
+		}
+		result.Columns = append(result.Columns, stats[i].profile(i+1, header, result.RowsScanned))
+	}
+	return result, nil
+}
+
+// columnStats accumulates a column's values during a DataDictionary pass
+type columnStats struct {
+	nonEmpty int
+	types    map[string]int
+	distinct map[string]bool
+	capped   bool
+	samples  []string
+}
+
+func newColumnStats() *columnStats {
+	return &columnStats{types: map[string]int{}, distinct: map[string]bool{}}
+}
+
+// add records one cell's value
+func (s *columnStats) add(cell Cell) {
+	if cell.Value == "" {
+		return
+	}
+	s.nonEmpty++
+	s.types[cell.Type]++
+
+	if s.distinct[cell.Value] {
+		return
+	}
+	if len(s.distinct) >= MaxDistinctValues {
+		s.capped = true
+		return
+	}
+	s.distinct[cell.Value] = true
+	if len(s.samples) < DictionarySamples {
+		s.samples = append(s.samples, cell.Value)
+	}
+}
+
+// profile summarizes the column. The type is the single type seen, "mixed"
+// for several, or "empty" when no value was found.
+func (s *columnStats) profile(col int, header string, rows int) ColumnProfile {
+	p := ColumnProfile{
+		Column:         ColumnNumberToName(col),
+		Header:         header,
+		Type:           "empty",
+		Types:          s.types,
+		NonEmpty:       s.nonEmpty,
+		Distinct:       len(s.distinct),
+		DistinctCapped: s.capped,
+		Samples:        s.samples,
+	}
+	if p.Samples == nil {
+		p.Samples = []string{}
+	}
+	if rows > 0 {
+		p.FillRatio = float64(s.nonEmpty) / float64(rows)
+	}
+	for t := range s.types {
+		if p.Type == "empty" {
+			p.Type = t
+		} else {
+			p.Type = "mixed"
+		}
+	}
+	return p
+}
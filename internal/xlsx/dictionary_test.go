@@ -0,0 +1,66 @@
+package xlsx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func createDictionaryTestFile(t *testing.T) string {
+	t.Helper()
+	return writeRowsFile(t, [][]any{
+		{"Name", "Age", "Active", "Note"},
+		{"Alice", 30, true},
+		{"Bob", 25, false, "x"},
+		{"Alice", "n/a", true},
+		{"Dana", 28, true},
+	})
+}
+
+func TestDataDictionary(t *testing.T) {
+	f, err := OpenFile(createDictionaryTestFile(t))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	result, err := DataDictionary(f, "", 0)
+	if err != nil {
+		t.Fatalf("DataDictionary failed: %v", err)
+	}
+	if result.RowsScanned != 4 || result.Truncated {
+		t.Errorf("expected 4 rows scanned without truncation, got %+v", result)
+	}
+
+	want := []ColumnProfile{
+		{Column: "A", Header: "Name", Type: "string", Types: map[string]int{"string": 4},
+			FillRatio: 1, NonEmpty: 4, Distinct: 3, Samples: []string{"Alice", "Bob", "Dana"}},
+		{Column: "B", Header: "Age", Type: "mixed", Types: map[string]int{"number": 3, "string": 1},
+			FillRatio: 1, NonEmpty: 4, Distinct: 4, Samples: []string{"30", "25", "n/a"}},
+		{Column: "C", Header: "Active", Type: "bool", Types: map[string]int{"bool": 4},
+			FillRatio: 1, NonEmpty: 4, Distinct: 2, Samples: []string{"TRUE", "FALSE"}},
+		{Column: "D", Header: "Note", Type: "string", Types: map[string]int{"string": 1},
+			FillRatio: 0.25, NonEmpty: 1, Distinct: 1, Samples: []string{"x"}},
+	}
+	if !reflect.DeepEqual(result.Columns, want) {
+		t.Errorf("unexpected columns:\n got %+v\nwant %+v", result.Columns, want)
+	}
+}
+
+func TestDataDictionarySampleRows(t *testing.T) {
+	f, err := OpenFile(createDictionaryTestFile(t))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	result, err := DataDictionary(f, "Sheet1", 2)
+	if err != nil {
+		t.Fatalf("DataDictionary failed: %v", err)
+	}
+	if result.RowsScanned != 2 || !result.Truncated {
+		t.Errorf("expected 2 rows scanned with truncation, got %+v", result)
+	}
+	if len(result.Columns) != 4 {
+		t.Errorf("expected an entry per header, got %d", len(result.Columns))
+	}
+}
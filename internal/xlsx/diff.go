@@ -0,0 +1,174 @@
+package xlsx
+
+import (
+	"context"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Kinds of CellDiff change
+const (
+	DiffChanged = "changed"
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+)
+
+// DiffOptions configures how two sheets are compared
+type DiffOptions struct {
+	IgnoreCase       bool // Treat values that differ only in case as equal
+	IgnoreWhitespace bool // Trim values and collapse inner runs of whitespace before comparing
+	MaxResults       int  // Maximum differences to report (0 = unlimited)
+}
+
+// CellDiff is a cell whose value differs between two sheets. Added cells
+// are empty in the old sheet and removed cells are empty in the new one.
+type CellDiff struct {
+	Address string `json:"address"`
+	Old     string `json:"old"`
+	New     string `json:"new"`
+	Change  string `json:"change"` // changed, added or removed
+}
+
+// DiffResult lists the cells that differ between two sheets in row-major order
+type DiffResult struct {
+	OldSheet    string     `json:"old_sheet"`
+	NewSheet    string     `json:"new_sheet"`
+	Differences []CellDiff `json:"differences"`
+	Truncated   bool       `json:"truncated,omitempty"`
+}
+
+// DiffSheets compares oldSheet in oldF with newSheet in newF cell by cell
+// over the union of both sheets' used areas. Both sheets are streamed in
+// lockstep, so memory stays constant apart from the differences found.
+// Empty sheet names mean the first sheet of each file. Formatted values are
+// compared, as read shows them. Truncated is set when more than
+// opts.MaxResults cells differ.
+func DiffSheets(ctx context.Context, oldF *excelize.File, oldSheet string, newF *excelize.File, newSheet string, opts DiffOptions) (*DiffResult, error) {
+	oldSheet, err := ResolveSheetName(oldF, oldSheet)
+	if err != nil {
+		return nil, err
+	}
+	newSheet, err = ResolveSheetName(newF, newSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	oldCh, err := StreamRows(ctx, oldF, oldSheet, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	newCh, err := StreamRows(ctx, newF, newSheet, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DiffResult{
+		OldSheet:    oldSheet,
+		NewSheet:    newSheet,
+		Differences: []CellDiff{},
+	}
+
+	oldRow, err := nextDiffRow(oldCh)
+	if err != nil {
+		return nil, err
+	}
+	newRow, err := nextDiffRow(newCh)
+	if err != nil {
+		return nil, err
+	}
+
+	for oldRow != nil || newRow != nil {
+		advanceOld := newRow == nil || (oldRow != nil && oldRow.Number <= newRow.Number)
+		advanceNew := oldRow == nil || (newRow != nil && newRow.Number <= oldRow.Number)
+
+		var oldCells, newCells []Cell
+		rowNum := 0
+		if advanceOld {
+			rowNum, oldCells = oldRow.Number, oldRow.Cells
+		}
+		if advanceNew {
+			rowNum, newCells = newRow.Number, newRow.Cells
+		}
+
+		if !diffRow(result, rowNum, oldCells, newCells, opts) {
+			return result, nil
+		}
+
+		if advanceOld {
+			if oldRow, err = nextDiffRow(oldCh); err != nil {
+				return nil, err
+			}
+		}
+		if advanceNew {
+			if newRow, err = nextDiffRow(newCh); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// diffRow appends the differing cells of one row to result. It returns
+// false once the result is full and further differences were found.
+func diffRow(result *DiffResult, rowNum int, oldCells, newCells []Cell, opts DiffOptions) bool {
+	for i := 0; i < max(len(oldCells), len(newCells)); i++ {
+		var oldValue, newValue string
+		if i < len(oldCells) {
+			oldValue = oldCells[i].Value
+		}
+		if i < len(newCells) {
+			newValue = newCells[i].Value
+		}
+		if normalizeDiffValue(oldValue, opts) == normalizeDiffValue(newValue, opts) {
+			continue
+		}
+
+		if opts.MaxResults > 0 && len(result.Differences) >= opts.MaxResults {
+			result.Truncated = true
+			return false
+		}
+
+		change := DiffChanged
+		switch {
+		case oldValue == "":
+			change = DiffAdded
+		case newValue == "":
+			change = DiffRemoved
+		}
+		result.Differences = append(result.Differences, CellDiff{
+			Address: FormatCellAddress(i+1, rowNum),
+			Old:     oldValue,
+			New:     newValue,
+			Change:  change,
+		})
+	}
+	return true
+}
+
+// normalizeDiffValue applies the comparison options to a cell value
+func normalizeDiffValue(value string, opts DiffOptions) string {
+	if opts.IgnoreWhitespace {
+		value = strings.Join(strings.Fields(value), " ")
+	}
+	if opts.IgnoreCase {
+		value = strings.ToLower(value)
+	}
+	return value
+}
+
+// nextDiffRow receives the next row from a stream, or nil at its end
+func nextDiffRow(ch <-chan RowResult) (*Row, error) {
+	res, ok := <-ch
+	if !ok {
+		return nil, nil
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	return res.Row, nil
+}
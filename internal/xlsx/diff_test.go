@@ -0,0 +1,53 @@
+package xlsx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestDiffSheets(t *testing.T) {
+	oldF := excelize.NewFile()
+	defer oldF.Close()
+	newF := excelize.NewFile()
+	defer newF.Close()
+
+	for addr, v := range map[string]string{"A1": "Name", "B1": "Qty", "A2": "apple", "B2": "3", "A3": "pear", "C3": "old note"} {
+		oldF.SetCellValue("Sheet1", addr, v)
+	}
+	for addr, v := range map[string]string{"A1": "name ", "B1": "Qty", "A2": "apple", "B2": "4", "A3": "pear", "A5": "plum"} {
+		newF.SetCellValue("Sheet1", addr, v)
+	}
+
+	result, err := DiffSheets(context.Background(), oldF, "", newF, "", DiffOptions{})
+	if err != nil {
+		t.Fatalf("DiffSheets failed: %v", err)
+	}
+	want := []CellDiff{
+		{Address: "A1", Old: "Name", New: "name ", Change: DiffChanged},
+		{Address: "B2", Old: "3", New: "4", Change: DiffChanged},
+		{Address: "C3", Old: "old note", New: "", Change: DiffRemoved},
+		{Address: "A5", Old: "", New: "plum", Change: DiffAdded},
+	}
+	if len(result.Differences) != len(want) {
+		t.Fatalf("expected %d differences, got %+v", len(want), result.Differences)
+	}
+	for i := range want {
+		if result.Differences[i] != want[i] {
+			t.Errorf("difference %d: expected %+v, got %+v", i, want[i], result.Differences[i])
+		}
+	}
+
+	result, err = DiffSheets(context.Background(), oldF, "", newF, "", DiffOptions{IgnoreCase: true, IgnoreWhitespace: true, MaxResults: 2})
+	if err != nil {
+		t.Fatalf("DiffSheets failed: %v", err)
+	}
+	if len(result.Differences) != 2 || result.Differences[0].Address != "B2" || !result.Truncated {
+		t.Errorf("expected 2 differences from B2, truncated; got %+v", result)
+	}
+
+	if _, err := DiffSheets(context.Background(), oldF, "Missing", newF, "", DiffOptions{}); err == nil {
+		t.Error("expected error for a missing sheet")
+	}
+}
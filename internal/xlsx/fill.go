@@ -0,0 +1,160 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Fill directions
+const (
+	FillDown = "down"
+	FillUp   = "up"
+)
+
+// FillOptions configures Fill
+type FillOptions struct {
+	Column    string // Header label or column letter
+	Rows      string // Optional row range such as "2:500" (default: the used rows)
+	Direction string // FillDown (default) or FillUp
+}
+
+// Fill forward-fills the blank cells of a column and saves atomically.
+// See Workbook.Fill.
+func Fill(path, sheet string, opts FillOptions) (*FillResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*FillResult, error) {
+		return wb.Fill(sheet, opts)
+	})
+}
+
+// Fill writes into each empty cell of a column the last non-empty value
+// above it, or below it with FillUp, as when cleaning exports where a value
+// is only given on the first row of its group. Blank cells before the first
+// value stay blank. When the column is named by header, the header row is
+// left out. The column is streamed and the filled cells written in one
+// batch, keeping each source value's type; blanks keep their own style and
+// formulas are never overwritten. Enforces MaxWriteRangeCells limit.
+func (wb *Workbook) Fill(sheet string, opts FillOptions) (*FillResult, error) {
+	direction := strings.ToLower(strings.TrimSpace(opts.Direction))
+	switch direction {
+	case "":
+		direction = FillDown
+	case FillDown, FillUp:
+	default:
+		return nil, fmt.Errorf("unknown fill direction: %s (valid: down, up)", opts.Direction)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	headers, err := GetHeaderRow(ctx, wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	col, byHeader, err := resolveAggregateColumn(headers, opts.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FillResult{Success: true, Sheet: resolvedSheet, Column: ColumnNumberToName(col), Direction: direction}
+
+	// 1. Work out the rows to fill: the given range, or the used rows
+	bounds, err := sheetBounds(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	if bounds == nil {
+		return result, nil
+	}
+	startRow, endRow := 1, bounds.EndRow
+	if byHeader {
+		startRow = 2
+	}
+	if opts.Rows != "" {
+		r, err := ParseRange(opts.Rows)
+		if err != nil {
+			return nil, err
+		}
+		startRow = r.StartRow
+		if r.EndRow != 0 {
+			endRow = r.EndRow
+		}
+	}
+	if startRow > endRow {
+		return result, nil
+	}
+	result.Range = fmt.Sprintf("%s%d:%s%d", result.Column, startRow, result.Column, endRow)
+
+	// 2. Stream the column's values
+	ch, err := StreamRowsWithOptions(ctx, wb.f, resolvedSheet, startRow, endRow,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, endRow-startRow+1)
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, rowResult.Err
+		}
+		values[rowResult.Row.Number-startRow] = cellValueAt(rowResult.Row.Cells, col)
+	}
+
+	// 3. Pair each blank with the row whose value it takes
+	type fill struct{ row, source int }
+	var fills []fill
+	source := 0
+	visit := func(i int) {
+		if values[i] != "" {
+			source = startRow + i
+		} else if source != 0 {
+			fills = append(fills, fill{row: startRow + i, source: source})
+		}
+	}
+	if direction == FillDown {
+		for i := range values {
+			visit(i)
+		}
+	} else {
+		for i := len(values) - 1; i >= 0; i-- {
+			visit(i)
+		}
+	}
+	if len(fills) > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to fill %d cells, limit is %d",
+			ErrCellLimitExceeded, len(fills), MaxWriteRangeCells)
+	}
+
+	// 4. Write the fills in one batch, typed like their source
+	types := make(map[int]string)
+	cells := make([]copiedCell, 0, len(fills))
+	for _, fl := range fills {
+		addr := FormatCellAddress(col, fl.row)
+		formula, err := wb.f.GetCellFormula(resolvedSheet, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get formula for %s: %w", addr, err)
+		}
+		if formula != "" {
+			continue
+		}
+		value := values[fl.source-startRow]
+		valueType, ok := types[fl.source]
+		if !ok {
+			if valueType, err = copiedValueType(wb.f, resolvedSheet, FormatCellAddress(col, fl.source), value); err != nil {
+				return nil, err
+			}
+			types[fl.source] = valueType
+		}
+		cells = append(cells, copiedCell{row: fl.row - 1, value: value, valueType: valueType})
+	}
+	if err := pasteRange(wb.f, resolvedSheet, col, 1, cells); err != nil {
+		return nil, err
+	}
+
+	result.CellsFilled = len(cells)
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createFillTestFile(t *testing.T) string {
+	t.Helper()
+	return writeRowsFile(t, [][]any{
+		{"Region", "Amount"},
+		{nil, 5},
+		{"East", 10},
+		{nil, 20},
+		{nil, 30},
+		{"West", 40},
+		{nil, 50},
+	})
+}
+
+func readFillColumn(t *testing.T, path, col string) []string {
+	t.Helper()
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+	var values []string
+	for row := 1; row <= 7; row++ {
+		v, err := f.GetCellValue("Sheet1", FormatCellAddress(ColumnNameToNumber(col), row))
+		if err != nil {
+			t.Fatalf("failed to read cell: %v", err)
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestFill_Down(t *testing.T) {
+	path := createFillTestFile(t)
+
+	result, err := Fill(path, "", FillOptions{Column: "Region"})
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if result.CellsFilled != 3 || result.Column != "A" || result.Range != "A2:A7" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// The blank before the first value stays blank
+	want := []string{"Region", "", "East", "East", "East", "West", "West"}
+	if got := readFillColumn(t, path, "A"); !reflect.DeepEqual(got, want) {
+		t.Errorf("column A = %q, want %q", got, want)
+	}
+}
+
+func TestFill_Up(t *testing.T) {
+	path := createFillTestFile(t)
+
+	result, err := Fill(path, "", FillOptions{Column: "A", Rows: "2:7", Direction: FillUp})
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if result.CellsFilled != 3 {
+		t.Errorf("CellsFilled = %d, want 3", result.CellsFilled)
+	}
+
+	want := []string{"Region", "East", "East", "West", "West", "West", ""}
+	if got := readFillColumn(t, path, "A"); !reflect.DeepEqual(got, want) {
+		t.Errorf("column A = %q, want %q", got, want)
+	}
+}
+
+func TestFill_KeepsNumberType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "numbers.xlsx")
+	f := excelize.NewFile()
+	f.SetCellValue("Sheet1", "B1", 42)
+	f.SetCellValue("Sheet1", "B3", 7)
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	if _, err := Fill(path, "", FillOptions{Column: "B"}); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+	value, _ := f.GetCellValue("Sheet1", "B2")
+	cellType, _ := f.GetCellType("Sheet1", "B2")
+	if value != "42" || cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+		t.Errorf("B2 = %q (type %v), want the number 42", value, cellType)
+	}
+}
+
+func TestFill_InvalidDirection(t *testing.T) {
+	path := createFillTestFile(t)
+
+	if _, err := Fill(path, "", FillOptions{Column: "A", Direction: "sideways"}); err == nil {
+		t.Error("expected error for unknown direction")
+	}
+}
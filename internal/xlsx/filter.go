@@ -0,0 +1,247 @@
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFilter is returned for filter expressions that cannot be parsed
+// or refer to unknown columns
+var ErrInvalidFilter = errors.New("invalid filter expression")
+
+// filterOperators lists supported operators, two-character ones first so
+// ">=" is not read as ">"
+var filterOperators = []string{"==", "!=", ">=", "<=", "~=", ">", "<"}
+
+// Predicate is a parsed "column op value" filter such as Age>30,
+// City=="Boston" or Name~=^A. Call Resolve before Match.
+type Predicate struct {
+	Column string // Header name or column letter
+	Op     string // One of ==, !=, >, <, >=, <=, ~=
+	Value  string
+
+	quoted bool           // Value was quoted, so compare as text
+	re     *regexp.Regexp // Compiled pattern for ~=
+	col    int            // Resolved 1-based column
+}
+
+// ParsePredicate parses a filter expression of the form "column op value".
+// The value may be wrapped in double or single quotes to force a text
+// comparison; unquoted values compare numerically when both sides are numbers.
+func ParsePredicate(expr string) (*Predicate, error) {
+	idx, op := -1, ""
+	for i := 0; i < len(expr) && idx < 0; i++ {
+		for _, candidate := range filterOperators {
+			if strings.HasPrefix(expr[i:], candidate) {
+				idx, op = i, candidate
+				break
+			}
+		}
+	}
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %q has no operator (valid: %s)",
+			ErrInvalidFilter, expr, strings.Join(filterOperators, ", "))
+	}
+
+	p := &Predicate{
+		Column: strings.TrimSpace(expr[:idx]),
+		Op:     op,
+		Value:  strings.TrimSpace(expr[idx+len(op):]),
+	}
+	if p.Column == "" {
+		return nil, fmt.Errorf("%w: %q has no column", ErrInvalidFilter, expr)
+	}
+	if strings.HasPrefix(p.Value, "=") {
+		return nil, fmt.Errorf("%w: unknown operator in %q (valid: %s)",
+			ErrInvalidFilter, expr, strings.Join(filterOperators, ", "))
+	}
+	if n := len(p.Value); n >= 2 && (p.Value[0] == '"' || p.Value[0] == '\'') && p.Value[n-1] == p.Value[0] {
+		p.Value = p.Value[1 : n-1]
+		p.quoted = true
+	}
+
+	if op == "~=" {
+		re, err := regexp.Compile(p.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid regex %q: %v", ErrInvalidFilter, p.Value, err)
+		}
+		p.re = re
+	}
+	return p, nil
+}
+
+// Resolve binds the predicate's column to a column number, matching a
+// header name first (case-insensitive) and then a column letter
+func (p *Predicate) Resolve(headers []string) error {
+	for i, h := range headers {
+		if strings.EqualFold(strings.TrimSpace(h), p.Column) {
+			p.col = i + 1
+			return nil
+		}
+	}
+	col, err := ParseColumnName(p.Column)
+	if err != nil {
+		return fmt.Errorf("%w: unknown column %q: not a header or column letter", ErrInvalidFilter, p.Column)
+	}
+	p.col = col
+	return nil
+}
+
+// Match reports whether a row satisfies the predicate. Missing cells are
+// treated as empty.
+func (p *Predicate) Match(row Row) bool {
+	value := ""
+	for _, cell := range row.Cells {
+		if cell.Col == p.col {
+			value = cell.Value
+			break
+		}
+	}
+
+	if p.re != nil {
+		return p.re.MatchString(value)
+	}
+
+	c := p.compare(value)
+	switch p.Op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case "<":
+		return c < 0
+	case ">=":
+		return c >= 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+// compare orders a cell value against the predicate value, numerically
+// when both are numbers and the value was not quoted
+func (p *Predicate) compare(value string) int {
+	if !p.quoted && InferCellType(value) == "number" && InferCellType(p.Value) == "number" {
+		x, _ := strconv.ParseFloat(value, 64)
+		y, _ := strconv.ParseFloat(p.Value, 64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(value, p.Value)
+}
+
+// FilterRows forwards only the rows matching pred, keeping memory bounded
+// to one row at a time. With keepHeader, row 1 is passed through unfiltered.
+// Errors are always forwarded. As with StreamRows, callers that stop
+// reading early must cancel ctx.
+func FilterRows(ctx context.Context, in <-chan RowResult, pred *Predicate, keepHeader bool) <-chan RowResult {
+	out := make(chan RowResult)
+
+	go func() {
+		defer close(out)
+		for result := range in {
+			if result.Err == nil && result.Row != nil {
+				isHeader := keepHeader && result.Row.Number == 1
+				if !isHeader && !pred.Match(*result.Row) {
+					continue
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- result:
+			}
+		}
+	}()
+
+	return out
+}
+
+// NumericQuery is a parsed numeric search such as >1000, <=0 or 100..200.
+// It uses the comparison operators of Predicate without a column; a bare
+// number means ==.
+type NumericQuery struct {
+	Op    string  // One of ==, !=, >, <, >=, <=, or .. for an inclusive range
+	Value float64 // Operand, or the low end of a range
+	High  float64 // High end of a range
+}
+
+// ParseNumericQuery parses a numeric search query
+func ParseNumericQuery(query string) (*NumericQuery, error) {
+	query = strings.TrimSpace(query)
+	if low, high, ok := strings.Cut(query, ".."); ok {
+		x, errLow := parseQueryNumber(low)
+		y, errHigh := parseQueryNumber(high)
+		if errLow != nil || errHigh != nil {
+			return nil, fmt.Errorf("%w: range %q needs a number on each side", ErrInvalidFilter, query)
+		}
+		if x > y {
+			return nil, fmt.Errorf("%w: range %q is empty", ErrInvalidFilter, query)
+		}
+		return &NumericQuery{Op: "..", Value: x, High: y}, nil
+	}
+
+	q := &NumericQuery{Op: "=="}
+	for _, op := range filterOperators {
+		if op != "~=" && strings.HasPrefix(query, op) {
+			q.Op = op
+			query = query[len(op):]
+			break
+		}
+	}
+	value, err := parseQueryNumber(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is not a number (use e.g. >1000, <=0 or 100..200)", ErrInvalidFilter, query)
+	}
+	q.Value = value
+	return q, nil
+}
+
+// parseQueryNumber parses one operand of a numeric query
+func parseQueryNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if InferCellType(s) != "number" {
+		return 0, fmt.Errorf("not a number: %q", s)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// Match reports whether value is a number satisfying the query. Values
+// that are not numbers never match.
+func (q *NumericQuery) Match(value string) (float64, bool) {
+	if InferCellType(value) != "number" {
+		return 0, false
+	}
+	x, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch q.Op {
+	case "==":
+		return x, x == q.Value
+	case "!=":
+		return x, x != q.Value
+	case ">":
+		return x, x > q.Value
+	case "<":
+		return x, x < q.Value
+	case ">=":
+		return x, x >= q.Value
+	case "<=":
+		return x, x <= q.Value
+	case "..":
+		return x, x >= q.Value && x <= q.High
+	}
+	return x, false
+}
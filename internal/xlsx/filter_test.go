@@ -0,0 +1,180 @@
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParsePredicate(t *testing.T) {
+	tests := []struct {
+		expr   string
+		column string
+		op     string
+		value  string
+	}{
+		{"Age>30", "Age", ">", "30"},
+		{"Age >= 30", "Age", ">=", "30"},
+		{`City=="Boston"`, "City", "==", "Boston"},
+		{"City != 'New York'", "City", "!=", "New York"},
+		{"Name~=^A", "Name", "~=", "^A"},
+		{"B<=5", "B", "<=", "5"},
+		{"First Name<M", "First Name", "<", "M"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			p, err := ParsePredicate(tt.expr)
+			if err != nil {
+				t.Fatalf("ParsePredicate failed: %v", err)
+			}
+			if p.Column != tt.column || p.Op != tt.op || p.Value != tt.value {
+				t.Errorf("got (%q, %q, %q), want (%q, %q, %q)",
+					p.Column, p.Op, p.Value, tt.column, tt.op, tt.value)
+			}
+		})
+	}
+}
+
+func TestParsePredicateErrors(t *testing.T) {
+	for _, expr := range []string{"Age", "Age=30", "Age===30", ">30", "Name~=(["} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParsePredicate(expr)
+			if !errors.Is(err, ErrInvalidFilter) {
+				t.Errorf("expected ErrInvalidFilter, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestPredicateResolve(t *testing.T) {
+	headers := []string{"Name", "Age"}
+
+	p, _ := ParsePredicate("age>1")
+	if err := p.Resolve(headers); err != nil || p.col != 2 {
+		t.Errorf("expected header match on column 2, got %d (err %v)", p.col, err)
+	}
+
+	p, _ = ParsePredicate("C==x")
+	if err := p.Resolve(headers); err != nil || p.col != 3 {
+		t.Errorf("expected letter match on column 3, got %d (err %v)", p.col, err)
+	}
+
+	p, _ = ParsePredicate("Missing Column==x")
+	if err := p.Resolve(headers); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter, got: %v", err)
+	}
+}
+
+func TestPredicateMatch(t *testing.T) {
+	row := Row{Number: 2, Cells: []Cell{
+		{Col: 1, Value: "Alice"},
+		{Col: 2, Value: "9"},
+		{Col: 3, Value: "Boston"},
+	}}
+	headers := []string{"Name", "Age", "City"}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"Age>30", false},
+		{"Age<30", true},    // numeric, not lexical ("9" > "30")
+		{`Age<"30"`, false}, // quoted compares as text
+		{"Age==9.0", true},
+		{`City=="Boston"`, true},
+		{"City!=Boston", false},
+		{"Name~=^A", true},
+		{"Name~=^B", false},
+		{"D==", true}, // missing cell is empty
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			p, err := ParsePredicate(tt.expr)
+			if err != nil {
+				t.Fatalf("ParsePredicate failed: %v", err)
+			}
+			if err := p.Resolve(headers); err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			if got := p.Match(row); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRows(t *testing.T) {
+	path := createTestFile(t)
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	p, err := ParsePredicate("B>40")
+	if err != nil {
+		t.Fatalf("ParsePredicate failed: %v", err)
+	}
+	if err := p.Resolve(nil); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := StreamRows(ctx, f, "Sheet1", 0, 0)
+	if err != nil {
+		t.Fatalf("StreamRows failed: %v", err)
+	}
+	rows, err := CollectRows(FilterRows(ctx, ch, p, true))
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+
+	// Header row is kept; only row 2 (B2=42) matches
+	if len(rows) != 2 || rows[0].Number != 1 || rows[1].Number != 2 {
+		t.Errorf("expected rows 1 and 2, got %+v", rows)
+	}
+}
+
+func TestParseNumericQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		matches []string
+		misses  []string
+	}{
+		{">1000", []string{"1000.5", "2e3"}, []string{"1000", "abc", ""}},
+		{"<=0", []string{"0", "-3"}, []string{"0.1", "true"}},
+		{"100..200", []string{"100", "150", "200"}, []string{"99.9", "201"}},
+		{"-5..-1", []string{"-5", "-1"}, []string{"0"}},
+		{"42", []string{"42", "42.0"}, []string{"43"}},
+		{"!= 0", []string{"1"}, []string{"0", "zero"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseNumericQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseNumericQuery failed: %v", err)
+			}
+			for _, v := range tt.matches {
+				if _, ok := q.Match(v); !ok {
+					t.Errorf("expected %q to match", v)
+				}
+			}
+			for _, v := range tt.misses {
+				if _, ok := q.Match(v); ok {
+					t.Errorf("expected %q not to match", v)
+				}
+			}
+		})
+	}
+
+	for _, query := range []string{"", ">abc", "~=5", "1..", "200..100", "=5"} {
+		if _, err := ParseNumericQuery(query); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("ParseNumericQuery(%q): expected ErrInvalidFilter, got %v", query, err)
+		}
+	}
+}
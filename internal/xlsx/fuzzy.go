@@ -0,0 +1,42 @@
+package xlsx
+
+// DefaultMaxDistance is the edit distance fuzzy search allows when none is set
+const DefaultMaxDistance = 2
+
+// withinDistance reports whether the Levenshtein distance between a and b,
+// counted in runes, is at most maxDist. It gives up as soon as every entry
+// of a row exceeds maxDist, so far-apart strings cost little more than
+// their length.
+func withinDistance(a, b string, maxDist int) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(ra)-len(rb) > maxDist {
+		return false
+	}
+
+	// prev and cur are rows of the edit matrix over rb
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > maxDist {
+			return false
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)] <= maxDist
+}
@@ -0,0 +1,28 @@
+package xlsx
+
+import "testing"
+
+func TestWithinDistance(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		maxDist int
+		want    bool
+	}{
+		{"kitten", "kitten", 0, true},
+		{"kitten", "sitting", 3, true},
+		{"kitten", "sitting", 2, false},
+		{"Jon", "John", 1, true},
+		{"", "ab", 2, true},
+		{"", "abc", 2, false},
+		{"Müller", "Muller", 1, true},
+		{"abcdef", "uvwxyz", 2, false},
+	}
+	for _, tt := range tests {
+		if got := withinDistance(tt.a, tt.b, tt.maxDist); got != tt.want {
+			t.Errorf("withinDistance(%q, %q, %d) = %v, want %v", tt.a, tt.b, tt.maxDist, got, tt.want)
+		}
+		if got := withinDistance(tt.b, tt.a, tt.maxDist); got != tt.want {
+			t.Errorf("withinDistance(%q, %q, %d) = %v, want %v", tt.b, tt.a, tt.maxDist, got, tt.want)
+		}
+	}
+}
@@ -252,6 +252,53 @@ func TestGoroutineLeakTiming(t *testing.T) {
 	}
 }
 
+// TestGoroutineNoLeakCollectRowsAndCancel verifies that collecting only the
+// first few rows via CollectRowsAndCancel releases the producer goroutine
+// without the caller having to cancel the context itself.
+func TestGoroutineNoLeakCollectRowsAndCancel(t *testing.T) {
+	path := createLargeTestFile(t, 1000)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	runtime.GC()
+	time.Sleep(100 * time.Millisecond)
+	baselineGoroutines := runtime.NumGoroutine()
+
+	const attempts = 10
+
+	for range attempts {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		ch, err := StreamRows(ctx, f, "Sheet1", 1, 0)
+		if err != nil {
+			t.Fatalf("StreamRows failed: %v", err)
+		}
+
+		// Collect 5 of 1000 rows; remaining rows are abandoned
+		rows, truncated, err := CollectRowsAndCancel(ch, 5, cancel)
+		if err != nil {
+			t.Fatalf("CollectRowsAndCancel failed: %v", err)
+		}
+		if len(rows) != 5 || !truncated {
+			t.Fatalf("expected 5 truncated rows, got %d (truncated=%v)", len(rows), truncated)
+		}
+	}
+
+	runtime.GC()
+	time.Sleep(500 * time.Millisecond)
+
+	leaked := runtime.NumGoroutine() - baselineGoroutines
+	t.Logf("Goroutines delta: %d", leaked)
+
+	if leaked > 2 {
+		t.Errorf("LEAK DETECTED: %d goroutines remain after %d early-stopped collections", leaked, attempts)
+	}
+}
+
 // BenchmarkGoroutineLeakMemory checks if leaked goroutines consume memory over time.
 // This benchmark demonstrates the practical impact of the leak.
 func BenchmarkGoroutineLeakMemory(b *testing.B) {
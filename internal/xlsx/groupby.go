@@ -0,0 +1,184 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// GroupAgg is one aggregation of a group-by, such as the sum of Amount
+type GroupAgg struct {
+	Column string // Header label or column letter
+	Op     string // sum, avg, min, max or count
+}
+
+// ParseGroupAgg parses an aggregation written as "column:op", such as
+// "Amount:sum". The last colon separates the op, so labels may contain one.
+func ParseGroupAgg(spec string) (GroupAgg, error) {
+	idx := strings.LastIndex(spec, ":")
+	if idx <= 0 {
+		return GroupAgg{}, fmt.Errorf("invalid aggregation %q: expected column:op, e.g. Amount:sum", spec)
+	}
+	op, err := normalizeAggregateOp(spec[idx+1:])
+	if err != nil {
+		return GroupAgg{}, err
+	}
+	return GroupAgg{Column: strings.TrimSpace(spec[:idx]), Op: op}, nil
+}
+
+// GroupByOptions configures GroupBy
+type GroupByOptions struct {
+	Sheet string     // Default: first sheet
+	Group string     // Column to group by: header label or letter
+	Aggs  []GroupAgg // At least one aggregation
+	Where *Predicate // Optional filter; only matching rows are grouped
+}
+
+// GroupByResult is a small table with one row per group: the group value
+// followed by one column per aggregation. Ignored counts, per aggregation,
+// the non-empty cells skipped as non-numeric.
+type GroupByResult struct {
+	Sheet   string     `json:"sheet"`
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+	Ignored []int      `json:"ignored"`
+}
+
+// GroupBy streams a sheet whose first row is a header and aggregates
+// columns per distinct value of the group column. Only one accumulator per
+// group and aggregation is held in memory. Stored values are used, as in
+// Aggregate. Groups are sorted by value, numbers first, with the blank
+// group last. Empty aggregate results, such as the sum or avg of a group
+// with no numbers, are left blank.
+func GroupBy(ctx context.Context, f *excelize.File, opts GroupByOptions) (*GroupByResult, error) {
+	if len(opts.Aggs) == 0 {
+		return nil, fmt.Errorf("at least one aggregation is required, e.g. Amount:sum")
+	}
+
+	sheet, err := ResolveSheetName(f, opts.Sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	headers, err := GetHeaderRow(ctx, f, sheet)
+	if err != nil {
+		return nil, err
+	}
+	groupCol, _, err := resolveAggregateColumn(headers, opts.Group)
+	if err != nil {
+		return nil, err
+	}
+	aggCols := make([]int, len(opts.Aggs))
+	for i, agg := range opts.Aggs {
+		if aggCols[i], _, err = resolveAggregateColumn(headers, agg.Column); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Where != nil {
+		if err := opts.Where.Resolve(headers); err != nil {
+			return nil, err
+		}
+	}
+
+	ch, err := StreamRowsWithOptions(ctx, f, sheet, 2, 0,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, err
+	}
+	if opts.Where != nil {
+		ch = FilterRows(ctx, ch, opts.Where, false)
+	}
+
+	groups := make(map[string][]accumulator)
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, rowResult.Err
+		}
+		cells := rowResult.Row.Cells
+		if len(cells) == 0 {
+			continue
+		}
+
+		key := trimmedCellValueAt(cells, groupCol)
+		accs, ok := groups[key]
+		if !ok {
+			accs = make([]accumulator, len(opts.Aggs))
+			groups[key] = accs
+		}
+		for i, col := range aggCols {
+			accs[i].add(cellValueAt(cells, col))
+		}
+	}
+
+	result := &GroupByResult{
+		Sheet:   sheet,
+		Headers: []string{columnLabel(headers, groupCol)},
+		Rows:    make([][]string, 0, len(groups)),
+		Ignored: make([]int, len(opts.Aggs)),
+	}
+	for i, agg := range opts.Aggs {
+		result.Headers = append(result.Headers, fmt.Sprintf("%s(%s)", agg.Op, columnLabel(headers, aggCols[i])))
+	}
+
+	for _, key := range sortedGroupKeys(groups) {
+		row := []string{key}
+		for i, acc := range groups[key] {
+			row = append(row, formatAggregate(acc.summary(opts.Aggs[i].Op)))
+			result.Ignored[i] += acc.ignored
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+// cellValueAt returns the value in a 1-based column of a streamed row, or
+// "" past its last cell
+func cellValueAt(cells []Cell, col int) string {
+	if col > len(cells) {
+		return ""
+	}
+	return cells[col-1].Value
+}
+
+// columnLabel returns a column's header label, or its letter when the
+// header cell is blank
+func columnLabel(headers []string, col int) string {
+	if col <= len(headers) {
+		if label := strings.TrimSpace(headers[col-1]); label != "" {
+			return label
+		}
+	}
+	return ColumnNumberToName(col)
+}
+
+// sortedGroupKeys returns the keys of a group map in the order
+// compareSortValues gives: numbers first, then text, then blank
+func sortedGroupKeys[V any](groups map[string]V) []string {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if c := compareSortValues(keys[i], keys[j], false); c != 0 {
+			return c < 0
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// formatAggregate formats an aggregate value without trailing zeros, or
+// "" when there is none
+func formatAggregate(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
@@ -0,0 +1,92 @@
+package xlsx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	f, err := OpenFile(writeRowsFile(t, [][]any{
+		{"Region", "Amount", "Year"},
+		{"West", 10, 2024},
+		{"East", 5, 2024},
+		{"West", "n/a", 2024},
+		{"East", 7.5, 2023},
+		{nil, 1, 2024},
+		{"West", 30, 2024},
+	}))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	result, err := GroupBy(context.Background(), f, GroupByOptions{
+		Group: "region",
+		Aggs:  []GroupAgg{{Column: "Amount", Op: AggregateSum}, {Column: "B", Op: AggregateAvg}},
+	})
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+	if want := []string{"Region", "sum(Amount)", "avg(Amount)"}; !reflect.DeepEqual(result.Headers, want) {
+		t.Errorf("expected headers %v, got %v", want, result.Headers)
+	}
+	want := [][]string{{"East", "12.5", "6.25"}, {"West", "40", "20"}, {"", "1", "1"}}
+	if !reflect.DeepEqual(result.Rows, want) {
+		t.Errorf("expected rows %v, got %v", want, result.Rows)
+	}
+	if !reflect.DeepEqual(result.Ignored, []int{1, 1}) {
+		t.Errorf("expected 1 ignored cell per aggregation, got %v", result.Ignored)
+	}
+
+	pred, err := ParsePredicate("Year==2023")
+	if err != nil {
+		t.Fatalf("ParsePredicate failed: %v", err)
+	}
+	result, err = GroupBy(context.Background(), f, GroupByOptions{
+		Group: "A",
+		Aggs:  []GroupAgg{{Column: "Amount", Op: AggregateCount}},
+		Where: pred,
+	})
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+	if want := [][]string{{"East", "1"}}; !reflect.DeepEqual(result.Rows, want) {
+		t.Errorf("expected rows %v, got %v", want, result.Rows)
+	}
+
+	// A group without a single number has no sum, not a sum of 0
+	if err := f.SetSheetRow("Sheet1", "A8", &[]any{"South", "n/a", 2024}); err != nil {
+		t.Fatalf("failed to set row: %v", err)
+	}
+	result, err = GroupBy(context.Background(), f, GroupByOptions{
+		Group: "Region",
+		Aggs:  []GroupAgg{{Column: "Amount", Op: AggregateSum}},
+	})
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+	want = [][]string{{"East", "12.5"}, {"South", ""}, {"West", "40"}, {"", "1"}}
+	if !reflect.DeepEqual(result.Rows, want) {
+		t.Errorf("expected rows %v, got %v", want, result.Rows)
+	}
+
+	if _, err := GroupBy(context.Background(), f, GroupByOptions{Group: "Region"}); err == nil {
+		t.Error("expected error without aggregations")
+	}
+}
+
+func TestParseGroupAgg(t *testing.T) {
+	agg, err := ParseGroupAgg("Time: start:MEAN")
+	if err != nil {
+		t.Fatalf("ParseGroupAgg failed: %v", err)
+	}
+	if agg.Column != "Time: start" || agg.Op != AggregateAvg {
+		t.Errorf("unexpected aggregation: %+v", agg)
+	}
+	for _, spec := range []string{"Amount", ":sum", "Amount:median"} {
+		if _, err := ParseGroupAgg(spec); err == nil {
+			t.Errorf("ParseGroupAgg(%q): expected error", spec)
+		}
+	}
+}
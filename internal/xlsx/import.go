@@ -0,0 +1,206 @@
+package xlsx
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrEmptyImport is returned when a CSV source has no records
+var ErrEmptyImport = errors.New("no records to import")
+
+// maxExactDigits is the number of significant digits Excel keeps for
+// numbers. Longer digit strings such as account numbers are imported as text
+// so they survive unchanged.
+const maxExactDigits = 15
+
+// ImportCSV reads CSV records from csvPath, or from stdin when csvPath is
+// "-", and writes them to sheet after its last row with data. The sheet is
+// created when it does not exist.
+func ImportCSV(path, csvPath, sheet string, opts ImportOptions) (*ImportResult, error) {
+	var r io.Reader = os.Stdin
+	if csvPath != "-" {
+		cf, err := os.Open(csvPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open CSV file: %w", err)
+		}
+		defer cf.Close()
+		r = cf
+	}
+
+	return withWorkbook(path, func(wb *Workbook) (*ImportResult, error) {
+		return wb.ImportCSV(r, sheet, opts)
+	})
+}
+
+// ImportCSV writes the CSV records read from r to sheet after its last row
+// with data, creating the sheet when it does not exist. Fields that parse as
+// numbers are written as numbers and everything else as text. Short rows are
+// padded to the width of the widest record. When opts.Header is set and the
+// sheet already has rows, the first record is dropped instead of being
+// appended as data. Importing into an empty sheet is limited to
+// MaxCreateFileRows rows, appending to one with data to MaxAppendRows.
+func (wb *Workbook) ImportCSV(r io.Reader, sheet string, opts ImportOptions) (*ImportResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	maxRows := max(MaxCreateFileRows, MaxAppendRows)
+	records, err := readCSVRecords(r, opts.Delimiter, maxRows+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	created := false
+	if sheet != "" && !SheetExists(wb.f, sheet) {
+		if _, err := wb.CreateSheet(sheet, nil); err != nil {
+			return nil, err
+		}
+		created = true
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRow := 0
+	if !created {
+		if lastRow, err = getLastRow(wb.f, resolvedSheet); err != nil {
+			return nil, fmt.Errorf("failed to get last row: %w", err)
+		}
+	}
+
+	headerSkipped := false
+	limit := MaxCreateFileRows
+	if lastRow > 0 {
+		limit = MaxAppendRows
+		if opts.Header {
+			records = records[1:]
+			headerSkipped = true
+		}
+	}
+	if len(records) > limit {
+		return nil, fmt.Errorf("%w: CSV has more than %d rows", ErrRowLimitExceeded, limit)
+	}
+
+	width := 0
+	for _, record := range records {
+		width = max(width, len(record))
+	}
+
+	rows := make([][]any, len(records))
+	for i, record := range records {
+		row := make([]any, width)
+		for j, field := range record {
+			row[j] = csvValue(field)
+		}
+		rows[i] = row
+	}
+
+	startingRow := lastRow + 1
+	if err := wb.setRows(resolvedSheet, startingRow, rows); err != nil {
+		return nil, err
+	}
+
+	return &ImportResult{
+		Success:       true,
+		Sheet:         resolvedSheet,
+		SheetCreated:  created,
+		HeaderSkipped: headerSkipped,
+		RowsAdded:     len(rows),
+		Columns:       width,
+		StartingRow:   startingRow,
+		EndingRow:     startingRow + len(rows) - 1,
+	}, nil
+}
+
+// readCSVRecords reads at most limit records from r. Records may have
+// different lengths, and a UTF-8 byte order mark before the first field is
+// removed.
+func readCSVRecords(r io.Reader, delimiter rune, limit int) ([][]string, error) {
+	cr := csv.NewReader(r)
+	if delimiter != 0 {
+		cr.Comma = delimiter
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	var records [][]string
+	for len(records) < limit {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		if len(records) == 0 && len(record) > 0 {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// csvValue converts a CSV field to a cell value. Numbers become int64 or
+// float64; values that would not survive a round trip as a number, such as
+// ZIP codes with leading zeros or long identifiers, stay text. Empty fields
+// become nil and leave the cell blank.
+func csvValue(field string) any {
+	if field == "" {
+		return nil
+	}
+	if !looksNumeric(field) {
+		return field
+	}
+	if n, err := strconv.ParseInt(field, 10, 64); err == nil {
+		return n
+	}
+	n, err := strconv.ParseFloat(field, 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return field
+	}
+	return n
+}
+
+// looksNumeric reports whether s is a plain decimal number: an optional
+// sign, digits with at most one decimal point, and an optional exponent.
+// A leading zero is only allowed directly before the decimal point.
+func looksNumeric(s string) bool {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	mantissa, exp, hasExp := strings.Cut(strings.ToLower(s), "e")
+	intPart, frac, _ := strings.Cut(mantissa, ".")
+	if intPart == "" && frac == "" {
+		return false
+	}
+	if len(intPart) > 1 && intPart[0] == '0' {
+		return false
+	}
+	if !allDigits(intPart) || !allDigits(frac) || len(intPart)+len(frac) > maxExactDigits {
+		return false
+	}
+	if hasExp {
+		exp = strings.TrimPrefix(strings.TrimPrefix(exp, "-"), "+")
+		return exp != "" && allDigits(exp)
+	}
+	return true
+}
+
+// allDigits reports whether s contains only ASCII digits
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package xlsx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func writeCSVFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+	return path
+}
+
+func TestImportCSVNewSheet(t *testing.T) {
+	path := createTestFile(t)
+	csvPath := writeCSVFile(t, "\ufeffName,Zip,Amount\nAda,02134,12.5\nBob,90210\n")
+
+	result, err := ImportCSV(path, csvPath, "Imported", ImportOptions{Header: true})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if !result.SheetCreated || result.HeaderSkipped {
+		t.Errorf("expected new sheet with header kept, got %+v", result)
+	}
+	if result.RowsAdded != 3 || result.Columns != 3 || result.StartingRow != 1 || result.EndingRow != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	if v, _ := f.GetCellValue("Imported", "A1"); v != "Name" {
+		t.Errorf("expected A1 Name without BOM, got %q", v)
+	}
+	if v, _ := f.GetCellValue("Imported", "B2"); v != "02134" {
+		t.Errorf("expected leading zero kept in B2, got %q", v)
+	}
+	if typ, _ := f.GetCellType("Imported", "C2"); typ == excelize.CellTypeSharedString || typ == excelize.CellTypeInlineString {
+		t.Errorf("expected C2 to be numeric, got type %v", typ)
+	}
+	if v, _ := f.GetCellValue("Imported", "C3"); v != "" {
+		t.Errorf("expected padded C3 to be empty, got %q", v)
+	}
+}
+
+func TestImportCSVAppendSkipsHeader(t *testing.T) {
+	path := createTestFile(t)
+	csvPath := writeCSVFile(t, "Header1;Header2\nx;7\n")
+
+	result, err := ImportCSV(path, csvPath, "Sheet1", ImportOptions{Delimiter: ';', Header: true})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if result.SheetCreated || !result.HeaderSkipped {
+		t.Errorf("expected header skipped on existing sheet, got %+v", result)
+	}
+	if result.RowsAdded != 1 || result.StartingRow != 4 {
+		t.Errorf("expected 1 row at row 4, got %+v", result)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	if v, _ := f.GetCellValue("Sheet1", "B4"); v != "7" {
+		t.Errorf("expected B4 7, got %q", v)
+	}
+}
+
+func TestImportCSVLimits(t *testing.T) {
+	path := createTestFile(t)
+
+	orig := MaxAppendRows
+	MaxAppendRows = 2
+	t.Cleanup(func() { MaxAppendRows = orig })
+
+	csvPath := writeCSVFile(t, "a\nb\nc\n")
+	if _, err := ImportCSV(path, csvPath, "Sheet1", ImportOptions{}); !errors.Is(err, ErrRowLimitExceeded) {
+		t.Errorf("expected ErrRowLimitExceeded, got %v", err)
+	}
+
+	emptyPath := writeCSVFile(t, "")
+	if _, err := ImportCSV(path, emptyPath, "Sheet1", ImportOptions{}); !errors.Is(err, ErrEmptyImport) {
+		t.Errorf("expected ErrEmptyImport, got %v", err)
+	}
+}
+
+func TestCSVValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want any
+	}{
+		{"42", int64(42)},
+		{"-3.5", -3.5},
+		{"0.25", 0.25},
+		{"1e3", 1000.0},
+		{"007", "007"},
+		{"1234567890123456789", "1234567890123456789"},
+		{"NaN", "NaN"},
+		{"0x1F", "0x1F"},
+		{"12 apples", "12 apples"},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := csvValue(tt.in); got != tt.want {
+			t.Errorf("csvValue(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWorkbookImportCSVReader(t *testing.T) {
+	path := createTestFile(t)
+
+	result, err := Edit(path, false, func(wb *Workbook) (*ImportResult, error) {
+		return wb.ImportCSV(strings.NewReader("p,q\n"), "", ImportOptions{})
+	})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if result.Sheet != "Sheet1" || result.StartingRow != 4 {
+		t.Errorf("expected default sheet at row 4, got %+v", result)
+	}
+}
@@ -0,0 +1,279 @@
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Join types
+const (
+	JoinLeft  = "left"
+	JoinInner = "inner"
+)
+
+// ErrDuplicateKey is returned when the right sheet repeats a join key and
+// JoinOptions.ErrorOnDuplicate is set
+var ErrDuplicateKey = errors.New("duplicate join key")
+
+// JoinOptions configures JoinSheets. Key columns are header labels
+// (case-insensitive) or column letters; RightKey defaults to LeftKey.
+type JoinOptions struct {
+	LeftSheet        string // Default: first sheet
+	RightSheet       string // Default: first sheet
+	LeftKey          string
+	RightKey         string
+	Type             string // JoinLeft (default) or JoinInner
+	ErrorOnDuplicate bool   // Fail on a repeated right key instead of using its first row
+	DryRun           bool   // Report the result without writing the output file
+}
+
+// JoinResult represents the result of joining two sheets into an output file
+type JoinResult struct {
+	Success   bool   `json:"success"`
+	DryRun    bool   `json:"dry_run,omitempty"`
+	File      string `json:"file"`
+	Sheet     string `json:"sheet"`
+	Type      string `json:"type"`
+	Rows      int    `json:"rows"`
+	Matched   int    `json:"matched"`
+	Unmatched int    `json:"unmatched"`
+	Columns   int    `json:"columns"`
+}
+
+// JoinSheets merges the rows of a right sheet into a left sheet by matching
+// key columns, VLOOKUP-style, and writes the combined rows to a new xlsx
+// file at outPath. Both sheets must start with a header row. Each left row
+// gets the right row's columns, minus its key, appended; a left join keeps
+// every left row without a match, including those with a blank key, with
+// those cells blank, an inner join drops them. The right sheet is indexed
+// in memory and the left sheet streamed. Blank left rows are dropped.
+// Values are copied with their stored type, so numbers stay numbers and
+// text stays text even when it looks numeric; styles, including date
+// formats, are not copied. The output is saved atomically.
+func JoinSheets(ctx context.Context, left, right *excelize.File, outPath string, opts JoinOptions) (*JoinResult, error) {
+	joinType := strings.ToLower(strings.TrimSpace(opts.Type))
+	switch joinType {
+	case "":
+		joinType = JoinLeft
+	case JoinLeft, JoinInner:
+	default:
+		return nil, fmt.Errorf("unknown join type: %s (valid: left, inner)", opts.Type)
+	}
+	if opts.LeftKey == "" {
+		return nil, fmt.Errorf("a join key column is required")
+	}
+	rightKey := opts.RightKey
+	if rightKey == "" {
+		rightKey = opts.LeftKey
+	}
+
+	leftSheet, err := ResolveSheetName(left, opts.LeftSheet)
+	if err != nil {
+		return nil, err
+	}
+	rightSheet, err := ResolveSheetName(right, opts.RightSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	leftHeaders, err := GetHeaderRow(ctx, left, leftSheet)
+	if err != nil {
+		return nil, err
+	}
+	leftCol, _, err := resolveAggregateColumn(leftHeaders, opts.LeftKey)
+	if err != nil {
+		return nil, fmt.Errorf("left sheet %s: %w", leftSheet, err)
+	}
+
+	rightHeaders, index, err := indexJoinSheet(ctx, right, rightSheet, rightKey, opts.ErrorOnDuplicate)
+	if err != nil {
+		return nil, err
+	}
+
+	out := excelize.NewFile()
+	defer out.Close()
+	sw, err := out.NewStreamWriter(out.GetSheetName(0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream writer: %w", err)
+	}
+
+	// The right columns start after the widest left row, measured from the
+	// rows since a stale stored dimension would misalign them
+	leftWidth := len(leftHeaders)
+	bounds, err := sheetBounds(left, leftSheet)
+	if err != nil {
+		return nil, err
+	}
+	if bounds != nil {
+		leftWidth = max(leftWidth, bounds.EndCol)
+	}
+
+	result := &JoinResult{
+		Success: true,
+		DryRun:  opts.DryRun,
+		File:    outPath,
+		Sheet:   leftSheet,
+		Type:    joinType,
+		Columns: leftWidth + len(rightHeaders),
+	}
+
+	leftTypes, err := openStoredTypes(left, leftSheet)
+	if err != nil {
+		return nil, err
+	}
+	defer leftTypes.Close()
+
+	ch, err := StreamRowsWithOptions(ctx, left, leftSheet, 0, 0,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, err
+	}
+
+	outRow := 0
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, rowResult.Err
+		}
+		cells := rowResult.Row.Cells
+		if len(cells) == 0 {
+			continue
+		}
+
+		var matched []any
+		if rowResult.Row.Number == 1 {
+			matched = make([]any, len(rightHeaders))
+			for i, label := range rightHeaders {
+				matched[i] = label
+			}
+		} else {
+			key := ""
+			if leftCol <= len(cells) {
+				key = strings.TrimSpace(cells[leftCol-1].Value)
+			}
+			var ok bool
+			if matched, ok = index[key]; ok && key != "" {
+				result.Matched++
+			} else {
+				result.Unmatched++
+				if joinType == JoinInner {
+					continue
+				}
+			}
+		}
+
+		values := make([]any, leftWidth+len(rightHeaders))
+		for i, cell := range cells {
+			if values[i], err = leftTypes.value(cell.Col, cell.Row, cell.Value); err != nil {
+				return nil, err
+			}
+		}
+		copy(values[leftWidth:], matched)
+
+		outRow++
+		if err := sw.SetRow(FormatCellAddress(1, outRow), values); err != nil {
+			return nil, fmt.Errorf("failed to write row %d: %w", outRow, err)
+		}
+	}
+	if err := sw.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to flush output: %w", err)
+	}
+	if err := out.SetSheetName(out.GetSheetName(0), leftSheet); err != nil {
+		return nil, fmt.Errorf("failed to name output sheet: %w", err)
+	}
+
+	result.Rows = max(outRow-1, 0)
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if err := WriteFileAtomic(outPath, func(w io.Writer) error {
+		_, err := out.WriteTo(w)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// indexJoinSheet reads the right sheet of a join into memory, keyed by the
+// trimmed value of its key column. It returns the header labels and the
+// row values, with their stored types, of every column but the key. Rows with an empty key are
+// skipped; a repeated key keeps its first row unless errorOnDuplicate.
+func indexJoinSheet(ctx context.Context, f *excelize.File, sheet, key string, errorOnDuplicate bool) ([]string, map[string][]any, error) {
+	headers, err := GetHeaderRow(ctx, f, sheet)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyCol, _, err := resolveAggregateColumn(headers, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("right sheet %s: %w", sheet, err)
+	}
+
+	types, err := openStoredTypes(f, sheet)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer types.Close()
+
+	ch, err := StreamRowsWithOptions(ctx, f, sheet, 2, 0,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	width := len(headers)
+	index := make(map[string][]any)
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, nil, rowResult.Err
+		}
+		cells := rowResult.Row.Cells
+		if keyCol > len(cells) {
+			continue
+		}
+		k := strings.TrimSpace(cells[keyCol-1].Value)
+		if k == "" {
+			continue
+		}
+		if _, exists := index[k]; exists {
+			if errorOnDuplicate {
+				return nil, nil, fmt.Errorf("%w: %q repeats in row %d of %s", ErrDuplicateKey, k, rowResult.Row.Number, sheet)
+			}
+			continue
+		}
+		width = max(width, len(cells))
+		values := make([]any, 0, len(cells))
+		for i, cell := range cells {
+			if i == keyCol-1 {
+				continue
+			}
+			v, err := types.value(cell.Col, cell.Row, cell.Value)
+			if err != nil {
+				return nil, nil, err
+			}
+			values = append(values, v)
+		}
+		index[k] = values
+	}
+
+	labels := make([]string, 0, width)
+	for col := 1; col <= width; col++ {
+		if col == keyCol {
+			continue
+		}
+		label := ""
+		if col <= len(headers) {
+			label = headers[col-1]
+		}
+		labels = append(labels, label)
+	}
+	return labels, index, nil
+}
@@ -0,0 +1,145 @@
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestJoinSheets(t *testing.T) {
+	left := excelize.NewFile()
+	defer left.Close()
+	left.SetSheetRow("Sheet1", "A1", &[]any{"ID", "Name"})
+	left.SetSheetRow("Sheet1", "A2", &[]any{1, "alpha"})
+	left.SetSheetRow("Sheet1", "A3", &[]any{2, "beta"})
+	left.SetSheetRow("Sheet1", "A4", &[]any{3, "gamma"})
+	left.SetSheetRow("Sheet1", "B5", &[]any{"delta"})
+
+	right := excelize.NewFile()
+	defer right.Close()
+	right.SetSheetName("Sheet1", "Prices")
+	right.SetSheetRow("Prices", "A1", &[]any{"Price", "id"})
+	right.SetSheetRow("Prices", "A2", &[]any{9.5, 2})
+	right.SetSheetRow("Prices", "A3", &[]any{4, 1})
+	right.SetSheetRow("Prices", "A4", &[]any{7, 2})
+
+	outPath := filepath.Join(t.TempDir(), "joined.xlsx")
+	result, err := JoinSheets(context.Background(), left, right, outPath, JoinOptions{LeftKey: "id"})
+	if err != nil {
+		t.Fatalf("JoinSheets failed: %v", err)
+	}
+	if result.Rows != 4 || result.Matched != 2 || result.Unmatched != 2 || result.Columns != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	out, err := excelize.OpenFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to open output: %v", err)
+	}
+	rows, err := out.GetRows("Sheet1")
+	out.Close()
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	want := [][]string{{"ID", "Name", "Price"}, {"1", "alpha", "4"}, {"2", "beta", "9.5"}, {"3", "gamma"}, {"", "delta"}}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %v, got %v", want, rows)
+	}
+	for i := range want {
+		if len(rows[i]) != len(want[i]) {
+			t.Errorf("row %d: expected %v, got %v", i+1, want[i], rows[i])
+			continue
+		}
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Errorf("row %d: expected %v, got %v", i+1, want[i], rows[i])
+				break
+			}
+		}
+	}
+
+	result, err = JoinSheets(context.Background(), left, right, outPath, JoinOptions{LeftKey: "A", RightKey: "B", Type: JoinInner, DryRun: true})
+	if err != nil {
+		t.Fatalf("JoinSheets failed: %v", err)
+	}
+	if result.Rows != 2 {
+		t.Errorf("expected 2 rows from an inner join, got %+v", result)
+	}
+
+	if _, err := JoinSheets(context.Background(), left, right, outPath, JoinOptions{LeftKey: "ID", ErrorOnDuplicate: true}); !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("expected ErrDuplicateKey, got: %v", err)
+	}
+	if _, err := JoinSheets(context.Background(), left, right, outPath, JoinOptions{LeftKey: "ID", Type: "outer"}); err == nil {
+		t.Error("expected error for an unknown join type")
+	}
+}
+
+func TestJoinSheetsKeepsStoredTypes(t *testing.T) {
+	dir := t.TempDir()
+	leftPath := filepath.Join(dir, "left.xlsx")
+	rightPath := filepath.Join(dir, "right.xlsx")
+
+	left := excelize.NewFile()
+	left.SetSheetRow("Sheet1", "A1", &[]any{"ID", "Count", "Active"})
+	left.SetCellStr("Sheet1", "A2", "123")
+	left.SetSheetRow("Sheet1", "B2", &[]any{5, true})
+	if err := left.SaveAs(leftPath); err != nil {
+		t.Fatalf("failed to save left: %v", err)
+	}
+	left.Close()
+
+	right := excelize.NewFile()
+	right.SetSheetRow("Sheet1", "A1", &[]any{"ID", "Code", "Qty"})
+	right.SetCellStr("Sheet1", "A2", "123")
+	right.SetCellStr("Sheet1", "B2", "0456")
+	right.SetCellStr("Sheet1", "C2", "789")
+	if err := right.SaveAs(rightPath); err != nil {
+		t.Fatalf("failed to save right: %v", err)
+	}
+	right.Close()
+
+	// Files opened from disk stream their types; ones read into memory ask
+	// excelize
+	for _, onDisk := range []bool{true, false} {
+		l, err := OpenFile(leftPath)
+		if err != nil {
+			t.Fatalf("OpenFile failed: %v", err)
+		}
+		r, err := OpenFile(rightPath)
+		if err != nil {
+			t.Fatalf("OpenFile failed: %v", err)
+		}
+		if !onDisk {
+			l.Path, r.Path = "", ""
+		}
+
+		outPath := filepath.Join(dir, "joined.xlsx")
+		_, err = JoinSheets(context.Background(), l, r, outPath, JoinOptions{LeftKey: "ID"})
+		l.Close()
+		r.Close()
+		if err != nil {
+			t.Fatalf("JoinSheets failed: %v", err)
+		}
+
+		out, err := excelize.OpenFile(outPath)
+		if err != nil {
+			t.Fatalf("failed to open output: %v", err)
+		}
+		for addr, want := range map[string]string{"A2": "string", "B2": "number", "C2": "bool", "D2": "string", "E2": "string"} {
+			got, err := copiedValueType(out, "Sheet1", addr, "1")
+			if err != nil {
+				t.Fatalf("failed to get type of %s: %v", addr, err)
+			}
+			if got != want {
+				t.Errorf("onDisk=%v: %s type = %s, want %s", onDisk, addr, got, want)
+			}
+		}
+		if v, _ := out.GetCellValue("Sheet1", "D2"); v != "0456" {
+			t.Errorf("onDisk=%v: expected D2 0456, got %q", onDisk, v)
+		}
+		out.Close()
+	}
+}
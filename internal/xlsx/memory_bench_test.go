@@ -112,6 +112,32 @@ func BenchmarkStreamTailMemoryVaryingTailSize(b *testing.B) {
 	}
 }
 
+// BenchmarkStreamTailDimension measures StreamTail on a file whose stored
+// dimension lets it skip straight to the last rows
+func BenchmarkStreamTailDimension(b *testing.B) {
+	path := createDimensionTestFile(b, 10000, 0)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		b.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for b.Loop() {
+		rows, err := StreamTail(f, "Sheet1", 10)
+		if err != nil {
+			b.Fatalf("StreamTail failed: %v", err)
+		}
+
+		if len(rows) != 10 || rows[9].Number != 10000 {
+			b.Errorf("expected rows ending at 10000, got %d rows", len(rows))
+		}
+	}
+}
+
 // BenchmarkStreamHeadMemory provides a comparison point
 // StreamHead should only allocate for the requested rows
 func BenchmarkStreamHeadMemory(b *testing.B) {
@@ -288,3 +314,26 @@ func BenchmarkStreamTailMemoryWideRows(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkCreateFileMemory measures CreateFile allocations for a large
+// dataset, near the sizes the streamed path is meant for
+func BenchmarkCreateFileMemory(b *testing.B) {
+	const n = 100000
+	defer func(limit int) { MaxCreateFileRows = limit }(MaxCreateFileRows)
+	MaxCreateFileRows = n
+
+	rows := make([][]any, n)
+	for i := range rows {
+		rows[i] = []any{fmt.Sprintf("name%d", i), float64(i), i%2 == 0, "City"}
+	}
+	path := filepath.Join(b.TempDir(), "create.xlsx")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for b.Loop() {
+		if _, err := CreateFile(path, "Data", []string{"Name", "Value", "Flag", "City"}, rows, true); err != nil {
+			b.Fatalf("CreateFile failed: %v", err)
+		}
+	}
+}
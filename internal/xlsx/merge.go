@@ -0,0 +1,99 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// mergeRegion is a merged cell range and the value of its top-left anchor
+type mergeRegion struct {
+	bounds *CellRange
+	anchor string
+	value  string
+}
+
+// mergeIndex holds the merged regions of a sheet for filling read cells
+type mergeIndex []mergeRegion
+
+// loadMergeIndex reads a sheet's merged ranges and their anchor values
+func loadMergeIndex(f *excelize.File, sheet string, opts StreamOptions) (mergeIndex, error) {
+	merged, err := mergedRanges(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(mergeIndex, 0, len(merged))
+	for _, bounds := range merged {
+		anchor := FormatCellAddress(bounds.StartCol, bounds.StartRow)
+		value, err := f.GetCellValue(sheet, anchor, opts.columnOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get merged cell %s: %w", anchor, err)
+		}
+		index = append(index, mergeRegion{bounds: bounds, anchor: anchor, value: value})
+	}
+	return index, nil
+}
+
+// mergedRanges returns the merged regions of a sheet
+func mergedRanges(f *excelize.File, sheet string) ([]*CellRange, error) {
+	merged, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged cells: %w", err)
+	}
+	ranges := make([]*CellRange, 0, len(merged))
+	for _, mc := range merged {
+		r, err := ParseRange(mc.GetStartAxis() + ":" + mc.GetEndAxis())
+		if err != nil {
+			continue // Skip malformed merge references
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// lookup returns the merged region covering a cell, or nil
+func (m mergeIndex) lookup(col, row int) *mergeRegion {
+	for i := range m {
+		if m[i].bounds.Contains(col, row) {
+			return &m[i]
+		}
+	}
+	return nil
+}
+
+// fillRow gives every non-anchor cell of a merged region in a streamed row
+// the anchor's value and address. Rows shorter than a merged region they
+// overlap are extended to cover it.
+func (m mergeIndex) fillRow(rowNum int, cells []Cell, opts StreamOptions) []Cell {
+	var regions mergeIndex
+	for _, region := range m {
+		if rowNum >= region.bounds.StartRow && rowNum <= region.bounds.EndRow {
+			regions = append(regions, region)
+		}
+	}
+	if len(regions) == 0 {
+		return cells
+	}
+
+	for _, region := range regions {
+		for col := len(cells) + 1; col <= region.bounds.EndCol; col++ {
+			cells = append(cells, newCell(col, rowNum, "", opts))
+		}
+	}
+	for i := range cells {
+		regions.fillCell(&cells[i], opts)
+	}
+	return cells
+}
+
+// fillCell replaces a non-anchor merged cell's value with the anchor's
+func (m mergeIndex) fillCell(cell *Cell, opts StreamOptions) {
+	region := m.lookup(cell.Col, cell.Row)
+	if region == nil || region.anchor == cell.Address {
+		return
+	}
+	filled := newCell(cell.Col, cell.Row, region.value, opts)
+	filled.MergedInto = region.anchor
+	*cell = filled
+}
@@ -0,0 +1,132 @@
+package xlsx
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// createMergeTestFile creates a sheet with "Region" merged over B2:C3
+func createMergeTestFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "merged.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetCellValue("Sheet1", "A1", "Header"); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := f.SetCellValue("Sheet1", "B2", "Region"); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := f.MergeCell("Sheet1", "B2", "C3"); err != nil {
+		t.Fatalf("failed to merge cells: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
+func TestStreamRowsMergedFill(t *testing.T) {
+	path := createMergeTestFile(t)
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	read := func(opts StreamOptions) map[string]Cell {
+		ch, err := StreamRowsWithOptions(context.Background(), f, "Sheet1", 0, 0, opts)
+		if err != nil {
+			t.Fatalf("StreamRowsWithOptions failed: %v", err)
+		}
+		rows, err := CollectRows(ch)
+		if err != nil {
+			t.Fatalf("CollectRows failed: %v", err)
+		}
+		cells := map[string]Cell{}
+		for _, row := range rows {
+			for _, cell := range row.Cells {
+				cells[cell.Address] = cell
+			}
+		}
+		return cells
+	}
+
+	// Without the option only the anchor holds the value
+	cells := read(StreamOptions{})
+	if cells["C3"].Value != "" {
+		t.Errorf("expected C3 empty without mergedFill, got %q", cells["C3"].Value)
+	}
+
+	cells = read(StreamOptions{MergedFill: true})
+	for _, addr := range []string{"B2", "C2", "B3", "C3"} {
+		if cells[addr].Value != "Region" {
+			t.Errorf("%s: expected 'Region', got %q", addr, cells[addr].Value)
+		}
+	}
+	if cells["B2"].MergedInto != "" {
+		t.Errorf("expected anchor B2 to have no MergedInto, got %q", cells["B2"].MergedInto)
+	}
+	for _, addr := range []string{"C2", "B3", "C3"} {
+		if cells[addr].MergedInto != "B2" {
+			t.Errorf("%s: expected MergedInto B2, got %q", addr, cells[addr].MergedInto)
+		}
+	}
+	if cells["A2"].Value != "" || cells["A2"].MergedInto != "" {
+		t.Errorf("expected A2 outside the merge untouched, got %+v", cells["A2"])
+	}
+}
+
+func TestStreamRangeMergedFill(t *testing.T) {
+	path := createMergeTestFile(t)
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	ch, err := StreamRangeWithOptions(context.Background(), f, "Sheet1", "C3:C3", StreamOptions{MergedFill: true})
+	if err != nil {
+		t.Fatalf("StreamRangeWithOptions failed: %v", err)
+	}
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+	if len(rows) != 1 || len(rows[0].Cells) != 1 {
+		t.Fatalf("expected a single cell, got %+v", rows)
+	}
+	if cell := rows[0].Cells[0]; cell.Value != "Region" || cell.MergedInto != "B2" {
+		t.Errorf("expected C3 filled from B2, got %+v", cell)
+	}
+}
+
+func TestGetCellMergedFill(t *testing.T) {
+	path := createMergeTestFile(t)
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	cell, err := GetCell(f, "Sheet1", "C3")
+	if err != nil {
+		t.Fatalf("GetCell failed: %v", err)
+	}
+	if cell.MergedInto != "" {
+		t.Errorf("expected no MergedInto without mergedFill, got %+v", cell)
+	}
+
+	cell, err = GetCellWithOptions(f, "Sheet1", "c3", StreamOptions{MergedFill: true})
+	if err != nil {
+		t.Fatalf("GetCellWithOptions failed: %v", err)
+	}
+	if cell.Value != "Region" || cell.Type != "string" || cell.MergedInto != "B2" {
+		t.Errorf("expected C3 filled from B2, got %+v", cell)
+	}
+}
@@ -0,0 +1,229 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var (
+	ErrInvalidName  = errors.New("invalid name")
+	ErrNameExists   = errors.New("name already exists")
+	ErrNameNotFound = errors.New("name not found")
+)
+
+// workbookScope is the scope excelize reports for workbook-level names
+const workbookScope = "Workbook"
+
+// builtInNamePrefix marks names Excel manages itself, such as
+// _xlnm._FilterDatabase for autofilters and _xlnm.Print_Area
+const builtInNamePrefix = "_xlnm."
+
+// maxNameLength is the longest defined name Excel accepts
+const maxNameLength = 255
+
+// r1c1NameRegex matches names Excel rejects because they read as R1C1
+// references, such as R, C, R2, C3 and R2C3
+var r1c1NameRegex = regexp.MustCompile(`(?i)^(r\d*c?\d*|c\d*)$`)
+
+// GetNamedRanges returns the workbook's defined names mapped to the
+// references they stand for, without the leading "=". Names scoped to a
+// single sheet are keyed as Excel writes them, e.g. "Sheet1!Total".
+// Built-in names Excel manages itself are left out.
+func GetNamedRanges(f *excelize.File) map[string]string {
+	names := make(map[string]string)
+	for _, dn := range f.GetDefinedName() {
+		if strings.HasPrefix(dn.Name, builtInNamePrefix) {
+			continue
+		}
+		key := dn.Name
+		if dn.Scope != workbookScope {
+			key = quoteSheetName(dn.Scope) + "!" + dn.Name
+		}
+		names[key] = strings.TrimPrefix(dn.RefersTo, "=")
+	}
+	return names
+}
+
+// LookupNamedRange resolves a defined name to the sheet and bare range it
+// refers to, ready for StreamRange. Use "Sheet1!Name" for a name scoped to
+// Sheet1. Names are matched case-insensitively, as in Excel. It fails with
+// ErrNameNotFound when there is no such name and ErrInvalidRange when the
+// name refers to something other than a single range, such as a constant.
+func LookupNamedRange(f *excelize.File, name string) (sheet, rangeStr string, err error) {
+	dn, ok := findDefinedName(f, name)
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", ErrNameNotFound, name)
+	}
+	sheet, rangeStr, err = SplitQualifiedRange(dn.RefersTo)
+	if err != nil || sheet == "" {
+		return "", "", fmt.Errorf("%w: name %s refers to %s, which is not a single sheet range",
+			ErrInvalidRange, dn.Name, strings.TrimPrefix(dn.RefersTo, "="))
+	}
+	return sheet, rangeStr, nil
+}
+
+// ResolveRangeRef resolves a range argument that is either an A1 range,
+// optionally sheet-qualified, or a defined name. The sheet is empty for an
+// unqualified A1 range.
+func ResolveRangeRef(f *excelize.File, ref string) (sheet, rangeStr string, err error) {
+	sheet, rangeStr, err = SplitQualifiedRange(ref)
+	if err == nil {
+		return sheet, rangeStr, nil
+	}
+	nameSheet, nameRange, nameErr := LookupNamedRange(f, ref)
+	if errors.Is(nameErr, ErrNameNotFound) {
+		return "", "", fmt.Errorf("%w: %s is neither a range nor a defined name", ErrInvalidRange, ref)
+	}
+	return nameSheet, nameRange, nameErr
+}
+
+// AddNamedRange defines a workbook-level name for a range.
+// See Workbook.AddNamedRange.
+func AddNamedRange(path, name, rangeStr string) (*NamedRangeResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*NamedRangeResult, error) {
+		return wb.AddNamedRange(name, rangeStr)
+	})
+}
+
+// DeleteNamedRange removes a defined name. See Workbook.DeleteNamedRange.
+func DeleteNamedRange(path, name string) (*NamedRangeResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*NamedRangeResult, error) {
+		return wb.DeleteNamedRange(name)
+	})
+}
+
+// AddNamedRange defines a workbook-level name for rangeStr. The range may be
+// sheet-qualified (Sheet2!A1:C10, 'My Sheet'!$B$2); unqualified ranges refer
+// to the first sheet. The name is stored with absolute references, as Excel
+// does. Names must follow Excel's rules: see validateRangeName.
+func (wb *Workbook) AddNamedRange(name, rangeStr string) (*NamedRangeResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+	if err := validateRangeName(name); err != nil {
+		return nil, err
+	}
+	if _, ok := findDefinedName(wb.f, name); ok {
+		return nil, fmt.Errorf("%w: %s", ErrNameExists, name)
+	}
+
+	sheet, cellRange, err := parseQualifiedRange(rangeStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range for name %s: %w", name, err)
+	}
+	sheet, err = ResolveSheetName(wb.f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	refersTo := quoteSheetName(sheet) + "!" + absoluteRange(cellRange)
+	if err := wb.f.SetDefinedName(&excelize.DefinedName{Name: name, RefersTo: refersTo}); err != nil {
+		return nil, fmt.Errorf("failed to define name %s: %w", name, err)
+	}
+
+	return &NamedRangeResult{
+		Success:  true,
+		Name:     name,
+		RefersTo: refersTo,
+	}, nil
+}
+
+// DeleteNamedRange removes a defined name, matched case-insensitively. Use
+// "Sheet1!Name" for a name scoped to Sheet1. Formulas that use the name
+// are left as they are and show #NAME? in Excel.
+func (wb *Workbook) DeleteNamedRange(name string) (*NamedRangeResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	dn, ok := findDefinedName(wb.f, name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNameNotFound, name)
+	}
+	if err := wb.f.DeleteDefinedName(&excelize.DefinedName{Name: dn.Name, Scope: dn.Scope}); err != nil {
+		return nil, fmt.Errorf("failed to delete name %s: %w", name, err)
+	}
+
+	return &NamedRangeResult{
+		Success:  true,
+		Name:     dn.Name,
+		RefersTo: strings.TrimPrefix(dn.RefersTo, "="),
+	}, nil
+}
+
+// findDefinedName finds a defined name by the key GetNamedRanges uses: the
+// bare name for workbook scope or "Sheet!Name" for sheet scope
+func findDefinedName(f *excelize.File, key string) (excelize.DefinedName, bool) {
+	scope, name := workbookScope, key
+	if idx := strings.LastIndex(key, "!"); idx >= 0 {
+		scope, name = key[:idx], key[idx+1:]
+		if len(scope) >= 2 && strings.HasPrefix(scope, "'") && strings.HasSuffix(scope, "'") {
+			scope = strings.ReplaceAll(scope[1:len(scope)-1], "''", "'")
+		}
+	}
+	for _, dn := range f.GetDefinedName() {
+		if strings.EqualFold(dn.Name, name) && strings.EqualFold(dn.Scope, scope) {
+			return dn, true
+		}
+	}
+	return excelize.DefinedName{}, false
+}
+
+// validateRangeName checks a name against Excel's rules: 1 to 255
+// characters, starting with a letter, underscore or backslash, followed by
+// letters, digits, underscores, periods or backslashes, and not readable as
+// a cell reference (A1, XFD100) or an R1C1 reference (R, C, R2C3).
+func validateRangeName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidName)
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("%w: %s is longer than %d characters", ErrInvalidName, name, maxNameLength)
+	}
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r), r == '_', r == '\\':
+		case i > 0 && (unicode.IsDigit(r) || r == '.'):
+		case unicode.IsSpace(r):
+			return fmt.Errorf("%w: %q contains a space (use _ or . instead)", ErrInvalidName, name)
+		case i == 0:
+			return fmt.Errorf("%w: %q must start with a letter, underscore or backslash", ErrInvalidName, name)
+		default:
+			return fmt.Errorf("%w: %q contains %q; only letters, digits, _, . and \\ are allowed", ErrInvalidName, name, r)
+		}
+	}
+	if looksLikeCellRef(name) || r1c1NameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %q looks like a cell reference", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// looksLikeCellRef reports whether s reads as an A1 cell address within
+// Excel's grid (columns A to XFD, rows 1 to 1048576), so "ABCD1" does not
+func looksLikeCellRef(s string) bool {
+	_, _, err := ParseCellAddress(s)
+	return err == nil
+}
+
+// quoteSheetName quotes a sheet name for use in a reference when Excel
+// would: if it contains anything but letters, digits and underscores,
+// starts with a digit, or reads as a cell reference
+func quoteSheetName(sheet string) string {
+	plain := sheet != "" && !looksLikeCellRef(sheet)
+	for i, r := range sheet {
+		if !(unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r))) {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return sheet
+	}
+	return "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+}
@@ -0,0 +1,88 @@
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestNamedRanges(t *testing.T) {
+	path := createTestFile(t)
+
+	result, err := AddNamedRange(path, "Data", "A1:B2")
+	if err != nil {
+		t.Fatalf("AddNamedRange failed: %v", err)
+	}
+	if result.RefersTo != "Sheet1!$A$1:$B$2" {
+		t.Errorf("expected Sheet1!$A$1:$B$2, got %q", result.RefersTo)
+	}
+	if _, err := AddNamedRange(path, "data", "A1"); !errors.Is(err, ErrNameExists) {
+		t.Errorf("expected ErrNameExists for a name differing only in case, got: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	if err := f.SetDefinedName(&excelize.DefinedName{Name: "Local", RefersTo: "'Sheet2'!$A$1", Scope: "Sheet2"}); err != nil {
+		t.Fatalf("failed to add sheet-scoped name: %v", err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	f.Close()
+
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	names := GetNamedRanges(f)
+	if len(names) != 2 || names["Data"] != "Sheet1!$A$1:$B$2" || names["Sheet2!Local"] != "'Sheet2'!$A$1" {
+		t.Errorf("unexpected names: %v", names)
+	}
+
+	sheet, rangeStr, err := ResolveRangeRef(f, "DATA")
+	if err != nil {
+		t.Fatalf("ResolveRangeRef failed: %v", err)
+	}
+	ch, err := StreamRange(context.Background(), f, sheet, rangeStr)
+	if err != nil {
+		t.Fatalf("StreamRange failed: %v", err)
+	}
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+	if len(rows) != 2 || rows[1].Cells[1].Value != "42" {
+		t.Errorf("expected 2 rows ending in 42, got %+v", rows)
+	}
+	if _, _, err := ResolveRangeRef(f, "Missing"); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("expected ErrInvalidRange for an unknown name, got: %v", err)
+	}
+	f.Close()
+
+	if _, err := DeleteNamedRange(path, "Sheet2!local"); err != nil {
+		t.Fatalf("DeleteNamedRange failed: %v", err)
+	}
+	if _, err := DeleteNamedRange(path, "Local"); !errors.Is(err, ErrNameNotFound) {
+		t.Errorf("expected ErrNameNotFound, got: %v", err)
+	}
+}
+
+func TestValidateRangeName(t *testing.T) {
+	valid := []string{"Sales", "_total", `\path`, "Q1.Sales", "Tax_Rate2", "ABCD1", "Résumé"}
+	for _, name := range valid {
+		if err := validateRangeName(name); err != nil {
+			t.Errorf("validateRangeName(%q) failed: %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "My Name", "1st", "A1", "xfd100", "R", "c", "R2C3", "rc", "Sales-2024", "a!b"}
+	for _, name := range invalid {
+		if err := validateRangeName(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("validateRangeName(%q): expected ErrInvalidName, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package xlsx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// GetHeaderRow returns the values of row 1, streaming only that row
+func GetHeaderRow(ctx context.Context, f *excelize.File, sheet string) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch, err := StreamRows(ctx, f, sheet, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := CollectRows(ch)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || rows[0].Number != 1 {
+		return []string{}, nil
+	}
+
+	headers := make([]string, len(rows[0].Cells))
+	for i, cell := range rows[0].Cells {
+		headers[i] = cell.Value
+	}
+	return headers, nil
+}
+
+// GetLegend maps each column letter to its header, reading only row 1.
+// Empty header cells map to an empty string.
+func GetLegend(ctx context.Context, f *excelize.File, sheet string) (map[string]string, error) {
+	headers, err := GetHeaderRow(ctx, f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	legend := make(map[string]string, len(headers))
+	for i, h := range headers {
+		legend[ColumnNumberToName(i+1)] = h
+	}
+	return legend, nil
+}
+
+// GetAllHeaders maps every sheet name to its header row, reading only row 1
+// of each sheet. Empty sheets map to an empty list.
+func GetAllHeaders(ctx context.Context, f *excelize.File) (map[string][]string, error) {
+	sheets, err := GetSheets(f)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make(map[string][]string, len(sheets))
+	for _, sheet := range sheets {
+		headers, err := GetHeaderRow(ctx, f, sheet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read headers of sheet %s: %w", sheet, err)
+		}
+		all[sheet] = headers
+	}
+	return all, nil
+}
+
+// ObjectKeys builds unique object keys from a header row.
+// Duplicate headers get a numeric suffix (_2, _3, ...) and empty headers
+// fall back to the column letter.
+func ObjectKeys(headers []string) []string {
+	keys := make([]string, len(headers))
+	seen := make(map[string]int, len(headers))
+	for i, h := range headers {
+		key := h
+		if key == "" {
+			key = ColumnNumberToName(i + 1)
+		}
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key = fmt.Sprintf("%s_%d", key, n)
+			seen[key]++
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// ObjectField is one key/value pair of an Object
+type ObjectField struct {
+	Key   string
+	Value string
+}
+
+// Object is a row keyed by the header row. Unlike a map, it keeps its
+// fields in column order, and marshals to a JSON object in that order.
+type Object []ObjectField
+
+// Get returns the value of a key and whether the object has it
+func (o Object) Get(key string) (string, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+// MarshalJSON writes the object's fields in order
+func (o Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// RowsToObjects converts rows into objects keyed by the header row, with
+// fields in column order. Cells are matched to headers by column, so ranges
+// that do not start at column A still line up. Cells beyond the last header
+// are keyed by their column letter; should that letter also be a header,
+// the later cell wins, keeping the first one's position.
+func RowsToObjects(headers []string, rows []Row) []Object {
+	keys := ObjectKeys(headers)
+	result := make([]Object, len(rows))
+	for i, row := range rows {
+		obj := make(Object, 0, len(row.Cells))
+		index := make(map[string]int, len(row.Cells))
+		for _, cell := range row.Cells {
+			key := ColumnNumberToName(cell.Col)
+			if cell.Col >= 1 && cell.Col <= len(keys) {
+				key = keys[cell.Col-1]
+			}
+			if j, ok := index[key]; ok {
+				obj[j].Value = cell.Value
+				continue
+			}
+			index[key] = len(obj)
+			obj = append(obj, ObjectField{Key: key, Value: cell.Value})
+		}
+		result[i] = obj
+	}
+	return result
+}
+
+// DropHeaderRow removes row 1 from rows, if present
+func DropHeaderRow(rows []Row) []Row {
+	if len(rows) > 0 && rows[0].Number == 1 {
+		return rows[1:]
+	}
+	return rows
+}
@@ -0,0 +1,165 @@
+package xlsx
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestObjectKeys(t *testing.T) {
+	keys := ObjectKeys([]string{"Name", "Age", "Name", "", "Name"})
+	expected := []string{"Name", "Age", "Name_2", "D", "Name_3"}
+
+	if len(keys) != len(expected) {
+		t.Fatalf("expected %d keys, got %d", len(expected), len(keys))
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("key %d: expected %q, got %q", i, expected[i], keys[i])
+		}
+	}
+}
+
+func TestRowsToObjects(t *testing.T) {
+	headers := []string{"Name", "Age"}
+	rows := []Row{
+		{Number: 2, Cells: []Cell{
+			{Value: "Alice", Col: 1},
+			{Value: "30", Col: 2},
+			{Value: "extra", Col: 3},
+		}},
+	}
+
+	objects := RowsToObjects(headers, rows)
+	if len(objects) != 1 {
+		t.Fatalf("expected 1 object, got %d", len(objects))
+	}
+
+	obj := objects[0]
+	if v, _ := obj.Get("Name"); v != "Alice" {
+		t.Errorf("expected Name=Alice, got %q", v)
+	}
+	if v, _ := obj.Get("Age"); v != "30" {
+		t.Errorf("expected Age=30, got %q", v)
+	}
+	if v, ok := obj.Get("C"); !ok || v != "extra" {
+		t.Errorf("expected overflow cell keyed by column letter C, got %v", obj)
+	}
+}
+
+func TestRowsToObjects_KeyOrder(t *testing.T) {
+	headers := []string{"Name", "Age", "City"}
+	rows := []Row{
+		{Number: 2, Cells: []Cell{
+			{Value: "Alice", Col: 1},
+			{Value: "30", Col: 2},
+			{Value: "Paris", Col: 3},
+		}},
+	}
+
+	data, err := json.Marshal(RowsToObjects(headers, rows))
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	expected := `[{"Name":"Alice","Age":"30","City":"Paris"}]`
+	if string(data) != expected {
+		t.Errorf("expected keys in column order %s, got %s", expected, data)
+	}
+}
+
+func TestGetHeaderRow(t *testing.T) {
+	path := createTestFile(t)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	headers, err := GetHeaderRow(context.Background(), f, "Sheet1")
+	if err != nil {
+		t.Fatalf("GetHeaderRow failed: %v", err)
+	}
+	if len(headers) != 2 || headers[0] != "Header1" {
+		t.Errorf("expected [Header1 Header2], got %v", headers)
+	}
+}
+
+func TestGetLegend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legend.xlsx")
+	f := excelize.NewFile()
+	for addr, v := range map[string]string{"A1": "Name", "C1": "City", "B2": "ignored"} {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set %s: %v", addr, err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	legend, err := GetLegend(context.Background(), f, "")
+	if err != nil {
+		t.Fatalf("GetLegend failed: %v", err)
+	}
+
+	want := map[string]string{"A": "Name", "B": "", "C": "City"}
+	if !reflect.DeepEqual(legend, want) {
+		t.Errorf("GetLegend() = %v, want %v", legend, want)
+	}
+}
+
+func TestGetAllHeaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "headers.xlsx")
+	f := excelize.NewFile()
+	for addr, v := range map[string]string{"A1": "Name", "B1": "Age", "A2": "Alice"} {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set %s: %v", addr, err)
+		}
+	}
+	if _, err := f.NewSheet("Orders"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	for addr, v := range map[string]string{"A1": "ID", "B1": "Total", "C1": "Date"} {
+		if err := f.SetCellValue("Orders", addr, v); err != nil {
+			t.Fatalf("failed to set %s: %v", addr, err)
+		}
+	}
+	if _, err := f.NewSheet("Empty"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	headers, err := GetAllHeaders(context.Background(), f)
+	if err != nil {
+		t.Fatalf("GetAllHeaders failed: %v", err)
+	}
+
+	want := map[string][]string{
+		"Sheet1": {"Name", "Age"},
+		"Orders": {"ID", "Total", "Date"},
+		"Empty":  {},
+	}
+	if !reflect.DeepEqual(headers, want) {
+		t.Errorf("GetAllHeaders() = %v, want %v", headers, want)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package xlsx
+
+import "io/fs"
+
+// preserveOwner is a no-op on platforms without Unix file ownership
+func preserveOwner(path string, info fs.FileInfo) error {
+	return nil
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package xlsx
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// preserveOwner gives path the uid and gid of an existing file. Only root
+// can change ownership, so for other users this is a no-op.
+func preserveOwner(path string, info fs.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || os.Geteuid() != 0 {
+		return nil
+	}
+	return os.Chown(path, int(stat.Uid), int(stat.Gid))
+}
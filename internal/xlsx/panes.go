@@ -0,0 +1,87 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// FreezePanes freezes the top rows and left cols of a sheet so they stay
+// visible while scrolling, and saves atomically. Passing 0 for both
+// removes any existing freeze.
+func FreezePanes(path, sheet string, rows, cols int) (*FreezeResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*FreezeResult, error) {
+		return wb.FreezePanes(sheet, rows, cols)
+	})
+}
+
+// FreezePanes freezes the top rows and left cols of a sheet.
+// Passing 0 for both unfreezes the sheet.
+func (wb *Workbook) FreezePanes(sheet string, rows, cols int) (*FreezeResult, error) {
+	if rows < 0 || rows >= excelize.TotalRows {
+		return nil, fmt.Errorf("invalid rows: %d (must be between 0 and %d)", rows, excelize.TotalRows-1)
+	}
+	if cols < 0 || cols >= excelize.MaxColumns {
+		return nil, fmt.Errorf("invalid cols: %d (must be between 0 and %d)", cols, excelize.MaxColumns-1)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := freezePanes(wb.f, resolvedSheet, rows, cols); err != nil {
+		return nil, err
+	}
+
+	result := &FreezeResult{
+		Success: true,
+		Sheet:   resolvedSheet,
+		Rows:    rows,
+		Cols:    cols,
+	}
+	if rows > 0 || cols > 0 {
+		result.TopLeftCell = FormatCellAddress(cols+1, rows+1)
+	}
+	return result, nil
+}
+
+// freezePanes sets a frozen pane below rows and right of cols, or removes
+// the pane when both are 0
+func freezePanes(f *excelize.File, sheet string, rows, cols int) error {
+	if rows == 0 && cols == 0 {
+		if err := f.SetPanes(sheet, &excelize.Panes{}); err != nil {
+			return fmt.Errorf("failed to unfreeze panes: %w", err)
+		}
+		return nil
+	}
+
+	err := f.SetPanes(sheet, frozenPanes(rows, cols))
+	if err != nil {
+		return fmt.Errorf("failed to freeze panes: %w", err)
+	}
+	return nil
+}
+
+// frozenPanes returns the panes freezing the top rows and left cols
+func frozenPanes(rows, cols int) *excelize.Panes {
+	activePane := "bottomRight"
+	switch {
+	case cols == 0:
+		activePane = "bottomLeft"
+	case rows == 0:
+		activePane = "topRight"
+	}
+	topLeft := FormatCellAddress(cols+1, rows+1)
+
+	return &excelize.Panes{
+		Freeze:      true,
+		XSplit:      cols,
+		YSplit:      rows,
+		TopLeftCell: topLeft,
+		ActivePane:  activePane,
+		Selection: []excelize.Selection{
+			{SQRef: topLeft, ActiveCell: topLeft, Pane: activePane},
+		},
+	}
+}
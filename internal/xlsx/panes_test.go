@@ -0,0 +1,95 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func readPanes(t *testing.T, path, sheet string) (freeze bool, rows, cols int, topLeft string) {
+	t.Helper()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	panes, err := f.GetPanes(sheet)
+	if err != nil {
+		t.Fatalf("GetPanes failed: %v", err)
+	}
+	return panes.Freeze, panes.YSplit, panes.XSplit, panes.TopLeftCell
+}
+
+func TestFreezePanes(t *testing.T) {
+	path := createTestFile(t)
+
+	result, err := FreezePanes(path, "", 1, 2)
+	if err != nil {
+		t.Fatalf("FreezePanes failed: %v", err)
+	}
+	if result.Sheet != "Sheet1" || result.Rows != 1 || result.Cols != 2 || result.TopLeftCell != "C2" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	freeze, rows, cols, topLeft := readPanes(t, path, "Sheet1")
+	if !freeze || rows != 1 || cols != 2 || topLeft != "C2" {
+		t.Errorf("expected frozen 1 row and 2 cols at C2, got freeze=%v rows=%d cols=%d topLeft=%s",
+			freeze, rows, cols, topLeft)
+	}
+
+	// 0 rows and 0 cols unfreezes
+	result, err = FreezePanes(path, "Sheet1", 0, 0)
+	if err != nil {
+		t.Fatalf("FreezePanes failed: %v", err)
+	}
+	if result.TopLeftCell != "" {
+		t.Errorf("expected no top-left cell when unfrozen, got %q", result.TopLeftCell)
+	}
+	if freeze, _, _, _ := readPanes(t, path, "Sheet1"); freeze {
+		t.Error("expected panes to be unfrozen")
+	}
+}
+
+func TestFreezePanesErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := FreezePanes(path, "Sheet1", -1, 0); err == nil {
+		t.Error("expected error for negative rows")
+	}
+	if _, err := FreezePanes(path, "Sheet1", 0, -1); err == nil {
+		t.Error("expected error for negative cols")
+	}
+	if _, err := FreezePanes(path, "NoSuchSheet", 1, 0); err == nil {
+		t.Error("expected error for nonexistent sheet")
+	}
+}
+
+func TestCreateFileFreezeHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frozen.xlsx")
+
+	result, err := CreateFileWithOptions(path, "Data", []string{"Name", "Age"}, [][]any{{"Alice", 30}},
+		CreateFileOptions{FreezeHeader: true})
+	if err != nil {
+		t.Fatalf("CreateFileWithOptions failed: %v", err)
+	}
+	if result.FrozenRows != 1 {
+		t.Errorf("expected 1 frozen row, got %d", result.FrozenRows)
+	}
+
+	freeze, rows, cols, topLeft := readPanes(t, path, "Data")
+	if !freeze || rows != 1 || cols != 0 || topLeft != "A2" {
+		t.Errorf("expected header row frozen, got freeze=%v rows=%d cols=%d topLeft=%s",
+			freeze, rows, cols, topLeft)
+	}
+
+	// Without headers there is nothing to freeze
+	path = filepath.Join(t.TempDir(), "plain.xlsx")
+	result, err = CreateFileWithOptions(path, "", nil, nil, CreateFileOptions{FreezeHeader: true})
+	if err != nil {
+		t.Fatalf("CreateFileWithOptions failed: %v", err)
+	}
+	if result.FrozenRows != 0 {
+		t.Errorf("expected no frozen rows without headers, got %d", result.FrozenRows)
+	}
+}
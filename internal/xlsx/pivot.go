@@ -0,0 +1,196 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxPivotColumns caps the distinct values of a pivot's column field, which
+// each become a column of the result
+const MaxPivotColumns = 1000
+
+// PivotOptions configures Pivot. Fields are header labels or column letters.
+type PivotOptions struct {
+	Sheet   string     // Default: first sheet
+	Rows    string     // Field whose values become the result's rows
+	Columns string     // Field whose values become the result's columns
+	Values  string     // Field aggregated into each cell
+	Op      string     // sum (default), avg, min, max or count
+	Fill    string     // Value for row and column combinations with no data (default: blank)
+	Where   *Predicate // Optional filter; only matching rows are summarized
+}
+
+// PivotResult is a two-dimensional summary. Headers holds the row field's
+// label followed by the column keys; each row starts with its row key.
+// Ignored counts the non-empty value cells skipped as non-numeric.
+type PivotResult struct {
+	Sheet   string     `json:"sheet"`
+	Op      string     `json:"op"`
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+	Ignored int        `json:"ignored"`
+}
+
+// Pivot streams a sheet whose first row is a header and summarizes the
+// Values field by Rows × Columns, e.g. the sum of Amount by Region and
+// Month. Only one accumulator per combination present in the data is held
+// in memory. Row and column keys are sorted like groupby groups: numbers,
+// then text, then blank. Combinations with no rows get opts.Fill, and so
+// do those whose sum, avg, min or max has no numeric cell.
+func Pivot(ctx context.Context, f *excelize.File, opts PivotOptions) (*PivotResult, error) {
+	op := AggregateSum
+	if opts.Op != "" {
+		var err error
+		if op, err = normalizeAggregateOp(opts.Op); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Rows == "" || opts.Columns == "" || opts.Values == "" {
+		return nil, fmt.Errorf("a pivot needs a rows, a columns and a values field")
+	}
+
+	sheet, err := ResolveSheetName(f, opts.Sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	headers, err := GetHeaderRow(ctx, f, sheet)
+	if err != nil {
+		return nil, err
+	}
+	var cols [3]int
+	for i, field := range []string{opts.Rows, opts.Columns, opts.Values} {
+		if cols[i], _, err = resolveAggregateColumn(headers, field); err != nil {
+			return nil, err
+		}
+	}
+	rowCol, colCol, valueCol := cols[0], cols[1], cols[2]
+	if opts.Where != nil {
+		if err := opts.Where.Resolve(headers); err != nil {
+			return nil, err
+		}
+	}
+
+	ch, err := StreamRowsWithOptions(ctx, f, sheet, 2, 0,
+		StreamOptions{SkipTypeDetection: true, RawValues: true})
+	if err != nil {
+		return nil, err
+	}
+	if opts.Where != nil {
+		ch = FilterRows(ctx, ch, opts.Where, false)
+	}
+
+	cells := make(map[string]map[string]*accumulator)
+	colKeys := make(map[string]bool)
+	result := &PivotResult{Sheet: sheet, Op: op}
+	for rowResult := range ch {
+		if rowResult.Err != nil {
+			return nil, rowResult.Err
+		}
+		row := rowResult.Row.Cells
+		if len(row) == 0 {
+			continue
+		}
+
+		rowKey := trimmedCellValueAt(row, rowCol)
+		colKey := trimmedCellValueAt(row, colCol)
+		if !colKeys[colKey] {
+			if len(colKeys) >= MaxPivotColumns {
+				return nil, fmt.Errorf("column field %s has more than %d distinct values",
+					columnLabel(headers, colCol), MaxPivotColumns)
+			}
+			colKeys[colKey] = true
+		}
+
+		byCol, ok := cells[rowKey]
+		if !ok {
+			byCol = make(map[string]*accumulator)
+			cells[rowKey] = byCol
+		}
+		acc, ok := byCol[colKey]
+		if !ok {
+			acc = &accumulator{}
+			byCol[colKey] = acc
+		}
+		acc.add(cellValueAt(row, valueCol))
+	}
+
+	sortedCols := sortedGroupKeys(colKeys)
+	result.Headers = append([]string{columnLabel(headers, rowCol)}, sortedCols...)
+	result.Rows = make([][]string, 0, len(cells))
+	for _, rowKey := range sortedGroupKeys(cells) {
+		row := make([]string, 1, len(sortedCols)+1)
+		row[0] = rowKey
+		for _, colKey := range sortedCols {
+			value := opts.Fill
+			if acc, ok := cells[rowKey][colKey]; ok {
+				if v := acc.summary(op); v != nil {
+					value = formatAggregate(v)
+				}
+				result.Ignored += acc.ignored
+			}
+			row = append(row, value)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+// WritePivot computes a pivot and writes it to a new sheet.
+// See Workbook.WritePivot.
+func WritePivot(path, target string, opts PivotOptions) (*PivotWriteResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*PivotWriteResult, error) {
+		return wb.WritePivot(target, opts)
+	})
+}
+
+// WritePivot computes a pivot of one sheet and writes it, header first, to
+// a new sheet named target, which must not exist. Numeric results and keys
+// are written as numbers.
+func (wb *Workbook) WritePivot(target string, opts PivotOptions) (*PivotWriteResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	pivot, err := Pivot(context.Background(), wb.f, opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := wb.CreateSheet(target, pivot.Headers); err != nil {
+		return nil, err
+	}
+	wb.touched[target] = true
+
+	rows := make([][]any, len(pivot.Rows))
+	for i, row := range pivot.Rows {
+		rows[i] = make([]any, len(row))
+		for j, value := range row {
+			rows[i][j] = csvValue(value)
+		}
+	}
+	if err := wb.setRows(target, 2, rows); err != nil {
+		return nil, err
+	}
+
+	return &PivotWriteResult{
+		Success: true,
+		Sheet:   target,
+		Source:  pivot.Sheet,
+		Op:      pivot.Op,
+		Rows:    len(pivot.Rows),
+		Columns: len(pivot.Headers),
+		Ignored: pivot.Ignored,
+	}, nil
+}
+
+// trimmedCellValueAt is cellValueAt without surrounding whitespace, as
+// used for group and pivot keys
+func trimmedCellValueAt(cells []Cell, col int) string {
+	return strings.TrimSpace(cellValueAt(cells, col))
+}
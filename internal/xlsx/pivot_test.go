@@ -0,0 +1,104 @@
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func createPivotTestFile(t *testing.T) string {
+	t.Helper()
+	return writeRowsFile(t, [][]any{
+		{"Region", "Month", "Amount"},
+		{"West", 2, 10},
+		{"East", 1, 5},
+		{"West", 1, 3},
+		{"West", 2, "n/a"},
+		{"West", 2, 7},
+		{"North", 10, 1},
+	})
+}
+
+func TestPivot(t *testing.T) {
+	path := createPivotTestFile(t)
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	result, err := Pivot(context.Background(), f, PivotOptions{Rows: "Region", Columns: "month", Values: "C"})
+	if err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+	if want := []string{"Region", "1", "2", "10"}; !reflect.DeepEqual(result.Headers, want) {
+		t.Errorf("expected headers %v, got %v", want, result.Headers)
+	}
+	want := [][]string{
+		{"East", "5", "", ""},
+		{"North", "", "", "1"},
+		{"West", "3", "17", ""},
+	}
+	if !reflect.DeepEqual(result.Rows, want) {
+		t.Errorf("expected rows %v, got %v", want, result.Rows)
+	}
+	if result.Ignored != 1 || result.Op != AggregateSum {
+		t.Errorf("expected op sum with 1 ignored cell, got %+v", result)
+	}
+
+	result, err = Pivot(context.Background(), f, PivotOptions{Rows: "Month", Columns: "Region", Values: "Amount", Op: "count", Fill: "0"})
+	if err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+	want = [][]string{
+		{"1", "1", "0", "1"},
+		{"2", "0", "0", "2"},
+		{"10", "0", "1", "0"},
+	}
+	if !reflect.DeepEqual(result.Rows, want) {
+		t.Errorf("expected rows %v, got %v", want, result.Rows)
+	}
+
+	// A combination whose values are all text has no sum, not a sum of 0
+	pred, err := ParsePredicate("Amount==n/a")
+	if err != nil {
+		t.Fatalf("ParsePredicate failed: %v", err)
+	}
+	result, err = Pivot(context.Background(), f, PivotOptions{Rows: "Region", Columns: "Month", Values: "Amount", Where: pred})
+	if err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+	if want := [][]string{{"West", ""}}; !reflect.DeepEqual(result.Rows, want) {
+		t.Errorf("expected a blank sum, got %v", result.Rows)
+	}
+
+	if _, err := Pivot(context.Background(), f, PivotOptions{Rows: "Region", Values: "Amount"}); err == nil {
+		t.Error("expected error without a columns field")
+	}
+}
+
+func TestWritePivot(t *testing.T) {
+	path := createPivotTestFile(t)
+
+	result, err := WritePivot(path, "Summary", PivotOptions{Rows: "Region", Columns: "Month", Values: "Amount", Op: "max"})
+	if err != nil {
+		t.Fatalf("WritePivot failed: %v", err)
+	}
+	if result.Rows != 3 || result.Columns != 4 || result.Source != "Sheet1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+	if got, _ := f.GetCellValue("Summary", "C4"); got != "10" {
+		t.Errorf("expected max 10 for West in month 2, got %q", got)
+	}
+
+	if _, err := WritePivot(path, "Summary", PivotOptions{Rows: "Region", Columns: "Month", Values: "Amount"}); !errors.Is(err, ErrSheetExists) {
+		t.Errorf("expected ErrSheetExists, got: %v", err)
+	}
+}
@@ -1,8 +1,12 @@
 package xlsx
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -24,6 +28,27 @@ func OpenFile(path string) (*excelize.File, error) {
 	return f, nil
 }
 
+// OpenReader opens an xlsx workbook read from r, such as stdin. The whole
+// stream is read into memory, since the archive must be seekable, so it is
+// capped at MaxWriteFileSize like the files write operations load whole.
+// A longer stream fails with ErrInputTooLarge.
+func OpenReader(r io.Reader) (*excelize.File, error) {
+	// One byte past the cap tells a stream of exactly MaxWriteFileSize
+	// bytes from a longer one
+	limited := &io.LimitedReader{R: r, N: MaxWriteFileSize + 1}
+	f, err := excelize.OpenReader(limited)
+	if limited.N == 0 {
+		if f != nil {
+			f.Close()
+		}
+		return nil, fmt.Errorf("%w: stream is larger than %d bytes; save it to a file instead", ErrInputTooLarge, MaxWriteFileSize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open xlsx from stream: %w", err)
+	}
+	return f, nil
+}
+
 // GetSheets returns a list of all sheet names in the workbook
 func GetSheets(f *excelize.File) ([]string, error) {
 	if f == nil {
@@ -38,7 +63,55 @@ func GetSheets(f *excelize.File) ([]string, error) {
 	return sheets, nil
 }
 
-// GetSheetInfo returns metadata about a sheet using streaming to count rows
+// GetSheetsBySize returns every sheet with its row count, largest first.
+// Sheets with equal counts keep workbook order. Counting stops after
+// MaxSizeScanRows rows per sheet, flagging the sheet as truncated.
+func GetSheetsBySize(f *excelize.File) ([]SheetSize, error) {
+	sheets, err := GetSheets(f)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make([]SheetSize, 0, len(sheets))
+	for _, sheet := range sheets {
+		size, err := countSheetRows(f, sheet)
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, *size)
+	}
+
+	sort.SliceStable(sizes, func(i, j int) bool {
+		return sizes[i].Rows > sizes[j].Rows
+	})
+	return sizes, nil
+}
+
+// countSheetRows streams a sheet's rows up to MaxSizeScanRows
+func countSheetRows(f *excelize.File, sheet string) (*SheetSize, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rows from sheet %s: %w", sheet, err)
+	}
+	defer rows.Close()
+
+	size := &SheetSize{Name: sheet}
+	for rows.Next() {
+		if size.Rows >= MaxSizeScanRows {
+			size.Truncated = true
+			break
+		}
+		size.Rows++
+	}
+	if err := rows.Error(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return size, nil
+}
+
+// GetSheetInfo returns metadata about a sheet. When the file stores a usable
+// dimension, the size comes from it and only the header row is read;
+// otherwise rows are streamed and counted.
 func GetSheetInfo(f *excelize.File, sheet string) (*SheetInfo, error) {
 	if f == nil {
 		return nil, fmt.Errorf("file handle is nil")
@@ -58,6 +131,29 @@ func GetSheetInfo(f *excelize.File, sheet string) (*SheetInfo, error) {
 		return nil, fmt.Errorf("%w: %s", ErrSheetNotFound, sheet)
 	}
 
+	visibility := sheetVisibility(f, sheet)
+	hasMerges, err := sheetHasMergedCells(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	if bounds, ok := storedDimension(f, sheet); ok {
+		headers, err := GetHeaderRow(context.Background(), f, sheet)
+		if err != nil {
+			return nil, err
+		}
+		return &SheetInfo{
+			Name:           sheet,
+			Rows:           bounds.EndRow,
+			Cols:           bounds.EndCol,
+			Dimension:      bounds.String(),
+			HasMergedCells: hasMerges,
+			Hidden:         visibility != SheetVisible,
+			Visibility:     visibility,
+			Headers:        headers,
+		}, nil
+	}
+
 	// Use streaming API to count rows without loading all data
 	rows, err := f.Rows(sheet)
 	if err != nil {
@@ -66,9 +162,12 @@ func GetSheetInfo(f *excelize.File, sheet string) (*SheetInfo, error) {
 	defer rows.Close()
 
 	info := &SheetInfo{
-		Name: sheet,
-		Rows: 0,
-		Cols: 0,
+		Name:           sheet,
+		Rows:           0,
+		Cols:           0,
+		HasMergedCells: hasMerges,
+		Hidden:         visibility != SheetVisible,
+		Visibility:     visibility,
 	}
 
 	rowNum := 0
@@ -96,11 +195,42 @@ func GetSheetInfo(f *excelize.File, sheet string) (*SheetInfo, error) {
 	}
 
 	info.Rows = rowNum
+	if info.Rows > 0 && info.Cols > 0 {
+		info.Dimension = "A1:" + FormatCellAddress(info.Cols, info.Rows)
+	}
 	return info, nil
 }
 
+// sheetHasMergedCells reports whether a sheet has merged cells. It streams
+// the sheet part from disk, skipping cell data, unless f holds parsed
+// worksheets that may carry unsaved merges.
+func sheetHasMergedCells(f *excelize.File, sheet string) (bool, error) {
+	if f.Path != "" && !worksheetsLoaded(f) {
+		node, err := inspectSheetOnDisk(f, sheet)
+		if err != nil {
+			return false, err
+		}
+		return node.HasMergedCells, nil
+	}
+	merged, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return false, fmt.Errorf("failed to get merged cells: %w", err)
+	}
+	return len(merged) > 0, nil
+}
+
 // GetCell retrieves a single cell value
 func GetCell(f *excelize.File, sheet, addr string) (*Cell, error) {
+	return GetCellWithOptions(f, sheet, addr, StreamOptions{})
+}
+
+// GetCellWithOptions is GetCell with read options. With MergedFill, a
+// non-anchor cell of a merged region reports the anchor's value and type.
+// With Calc, a formula cell also reports its formula and the result of
+// evaluating it now, which helps with files whose writers left the cached
+// value stale or blank. Evaluation errors are reported on the cell rather
+// than failing the read.
+func GetCellWithOptions(f *excelize.File, sheet, addr string, opts StreamOptions) (*Cell, error) {
 	if f == nil {
 		return nil, fmt.Errorf("file handle is nil")
 	}
@@ -125,22 +255,66 @@ func GetCell(f *excelize.File, sheet, addr string) (*Cell, error) {
 		return nil, err
 	}
 
-	// Get cell value
-	value, err := f.GetCellValue(sheet, addr)
+	// Get cell value, from the merged region's anchor when filling
+	source := addr
+	merges, err := opts.loadMerges(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+	if region := merges.lookup(col, row); region != nil {
+		source = region.anchor
+	}
+
+	value, err := f.GetCellValue(sheet, source, opts.columnOptions())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cell %s: %w", addr, err)
+		return nil, fmt.Errorf("failed to get cell %s: %w", source, err)
 	}
 
 	// Get cell type
-	cellType := detectCellType(f, sheet, addr, value)
+	cellType := DetectCellType(f, sheet, source, value)
 
-	return &Cell{
+	cell := &Cell{
 		Address: strings.ToUpper(addr),
 		Value:   value,
 		Type:    cellType,
 		Row:     row,
 		Col:     col,
-	}, nil
+	}
+	if source != addr && source != cell.Address {
+		cell.MergedInto = source
+	}
+	if opts.Calc {
+		if err := calcCell(f, sheet, source, cell); err != nil {
+			return nil, err
+		}
+	}
+	return cell, nil
+}
+
+// calcCell evaluates a formula cell and records the formula and result.
+// Cells without a formula are left unchanged.
+func calcCell(f *excelize.File, sheet, addr string, cell *Cell) error {
+	formula, err := f.GetCellFormula(sheet, addr)
+	if err != nil {
+		return fmt.Errorf("failed to get formula for %s: %w", addr, err)
+	}
+	if formula == "" {
+		return nil
+	}
+	if !strings.HasPrefix(formula, "=") {
+		formula = "=" + formula
+	}
+	cell.Formula = formula
+
+	computed, err := f.CalcCellValue(sheet, addr)
+	if err != nil {
+		cell.CalcError = err.Error()
+		if computed == "" {
+			computed = err.Error()
+		}
+	}
+	cell.Computed = computed
+	return nil
 }
 
 // GetDefaultSheet returns the first sheet name or error if none exist
@@ -152,8 +326,27 @@ func GetDefaultSheet(f *excelize.File) (string, error) {
 	return sheets[0], nil
 }
 
-// detectCellType determines the type of a cell
-func detectCellType(f *excelize.File, sheet, addr, value string) string {
+// RowsWithTypes annotates every cell of rows with its type from
+// DetectCellType. This looks up each cell's stored type, so it is slower
+// than the value-based types set while streaming, but it tells apart e.g.
+// a number from a numeric-looking string.
+func RowsWithTypes(f *excelize.File, sheet string, rows []Row) [][]TypedCell {
+	result := make([][]TypedCell, len(rows))
+	for i, row := range rows {
+		result[i] = make([]TypedCell, len(row.Cells))
+		for j, cell := range row.Cells {
+			result[i][j] = TypedCell{
+				Value: cell.Value,
+				Type:  DetectCellType(f, sheet, cell.Address, cell.Value),
+			}
+		}
+	}
+	return result
+}
+
+// DetectCellType determines the type of a cell from its stored type,
+// returning empty, formula, number, bool, error or string
+func DetectCellType(f *excelize.File, sheet, addr, value string) string {
 	if value == "" {
 		return "empty"
 	}
@@ -168,13 +361,7 @@ func detectCellType(f *excelize.File, sheet, addr, value string) string {
 	cellType, err := f.GetCellType(sheet, addr)
 	if err != nil {
 		// Fallback to value-based detection
-		if _, err := strconv.ParseFloat(value, 64); err == nil {
-			return "number"
-		}
-		if value == "true" || value == "false" {
-			return "bool"
-		}
-		return "string"
+		return InferCellType(value)
 	}
 
 	switch cellType {
@@ -190,14 +377,24 @@ func detectCellType(f *excelize.File, sheet, addr, value string) string {
 		return "string"
 	default:
 		// Fallback: try to detect by value content
-		if _, err := strconv.ParseFloat(value, 64); err == nil {
-			return "number"
-		}
-		if value == "true" || value == "false" {
-			return "bool"
-		}
-		return "string"
+		return InferCellType(value)
+	}
+}
+
+// InferCellType determines a cell type from its displayed value alone.
+// Used where excelize's per-cell type lookup is unavailable or too costly,
+// such as the streaming readers. Returns empty, number, bool or string.
+func InferCellType(value string) string {
+	if value == "" {
+		return "empty"
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+		return "number"
+	}
+	if strings.EqualFold(value, "true") || strings.EqualFold(value, "false") {
+		return "bool"
 	}
+	return "string"
 }
 
 // SheetExists checks if a sheet exists in the workbook
@@ -211,6 +408,46 @@ func SheetExists(f *excelize.File, sheet string) bool {
 	return false
 }
 
+// sheetBounds returns the used range of a sheet: A1 to the last row and
+// column holding a value or formula. A sheet excelize has already parsed is
+// measured in memory; any other is streamed. Returns nil for an empty sheet.
+func sheetBounds(f *excelize.File, sheet string) (*CellRange, error) {
+	if bounds, ok := loadedSheetBounds(f, sheet); ok {
+		return bounds, nil
+	}
+	return streamSheetBounds(f, sheet)
+}
+
+// streamSheetBounds returns the used range of a sheet by streaming its rows.
+// Returns nil for an empty sheet.
+func streamSheetBounds(f *excelize.File, sheet string) (*CellRange, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open row iterator: %w", err)
+	}
+	defer rows.Close()
+
+	maxRow, maxCol := 0, 0
+	for rowNum := 1; rows.Next(); rowNum++ {
+		cols, err := rows.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("error reading row %d: %w", rowNum, err)
+		}
+		if len(cols) > 0 {
+			maxRow = rowNum
+			maxCol = max(maxCol, len(cols))
+		}
+	}
+	if err := rows.Error(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	if maxRow == 0 {
+		return nil, nil
+	}
+	return &CellRange{StartCol: 1, StartRow: 1, EndCol: maxCol, EndRow: maxRow}, nil
+}
+
 // ResolveSheetName returns the actual sheet name (with correct casing) or default
 func ResolveSheetName(f *excelize.File, sheet string) (string, error) {
 	if sheet == "" {
@@ -1,6 +1,10 @@
 package xlsx
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -50,6 +54,25 @@ func createTestFile(t *testing.T) string {
 	return path
 }
 
+// writeRowsFile saves rows to Sheet1 of a new workbook, starting at A1,
+// and returns its path
+func writeRowsFile(t *testing.T, rows [][]any) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "rows.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+	for i, row := range rows {
+		if err := f.SetSheetRow("Sheet1", FormatCellAddress(1, i+1), &row); err != nil {
+			t.Fatalf("failed to set row %d: %v", i+1, err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
 func TestOpenFile(t *testing.T) {
 	path := createTestFile(t)
 
@@ -66,6 +89,27 @@ func TestOpenFile(t *testing.T) {
 	}
 }
 
+func TestOpenReader(t *testing.T) {
+	data, err := os.ReadFile(createTestFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := MaxWriteFileSize
+	t.Cleanup(func() { MaxWriteFileSize = orig })
+
+	MaxWriteFileSize = int64(len(data))
+	f, err := OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("OpenReader failed at the size limit: %v", err)
+	}
+	f.Close()
+
+	MaxWriteFileSize = int64(len(data)) - 1
+	if _, err := OpenReader(bytes.NewReader(data)); !errors.Is(err, ErrInputTooLarge) {
+		t.Errorf("expected ErrInputTooLarge for a stream over the limit, got: %v", err)
+	}
+}
+
 func TestGetSheets(t *testing.T) {
 	path := createTestFile(t)
 
@@ -91,6 +135,117 @@ func TestGetSheets(t *testing.T) {
 	}
 }
 
+func TestGetSheetsBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sizes.xlsx")
+
+	f := excelize.NewFile()
+	if err := f.SetCellValue("Sheet1", "A1", "small"); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if _, err := f.NewSheet("Big"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	for row := 1; row <= 50; row++ {
+		if err := f.SetCellValue("Big", FormatCellAddress(1, row), row); err != nil {
+			t.Fatalf("failed to set cell: %v", err)
+		}
+	}
+	if _, err := f.NewSheet("Empty"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	sizes, err := GetSheetsBySize(f)
+	if err != nil {
+		t.Fatalf("GetSheetsBySize failed: %v", err)
+	}
+
+	expected := []SheetSize{
+		{Name: "Big", Rows: 50},
+		{Name: "Sheet1", Rows: 1},
+		{Name: "Empty", Rows: 0},
+	}
+	if len(sizes) != len(expected) {
+		t.Fatalf("expected %d sheets, got %d: %+v", len(expected), len(sizes), sizes)
+	}
+	for i, want := range expected {
+		if sizes[i] != want {
+			t.Errorf("sheet %d: expected %+v, got %+v", i, want, sizes[i])
+		}
+	}
+
+	// Test with nil file
+	if _, err := GetSheetsBySize(nil); err == nil {
+		t.Error("expected error for nil file")
+	}
+}
+
+func TestRowsWithTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "types.xlsx")
+
+	f := excelize.NewFile()
+	values := map[string]any{"A1": 30, "B1": "N/A", "C1": true, "D1": "007"}
+	for addr, v := range values {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set cell: %v", err)
+		}
+	}
+	// Set the cached result first, since SetCellValue drops formulas
+	if err := f.SetCellValue("Sheet1", "E1", 60); err != nil {
+		t.Fatalf("failed to set cached value: %v", err)
+	}
+	if err := f.SetCellFormula("Sheet1", "E1", "A1*2"); err != nil {
+		t.Fatalf("failed to set formula: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	ch, err := StreamRows(context.Background(), f, "Sheet1", 1, 1)
+	if err != nil {
+		t.Fatalf("StreamRows failed: %v", err)
+	}
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+
+	typed := RowsWithTypes(f, "Sheet1", rows)
+	if len(typed) != 1 || len(typed[0]) != 5 {
+		t.Fatalf("expected 1 row of 5 cells, got %+v", typed)
+	}
+
+	want := []TypedCell{
+		{Value: "30", Type: "number"},
+		{Value: "N/A", Type: "string"},
+		{Value: "TRUE", Type: "bool"},
+		{Value: "007", Type: "string"}, // Stored as text, not a number
+		{Value: "60", Type: "formula"},
+	}
+	for i, w := range want {
+		if typed[0][i] != w {
+			t.Errorf("cell %d: expected %+v, got %+v", i, w, typed[0][i])
+		}
+	}
+}
+
 func TestGetSheetInfo(t *testing.T) {
 	path := createTestFile(t)
 
@@ -143,6 +298,75 @@ func TestGetSheetInfo(t *testing.T) {
 	}
 }
 
+func TestGetSheetInfoDimension(t *testing.T) {
+	f, err := OpenFile(createDimensionTestFile(t, 50, 0))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	info, err := GetSheetInfo(f, "Sheet1")
+	if err != nil {
+		t.Fatalf("GetSheetInfo failed: %v", err)
+	}
+	if info.Dimension != "A1:B50" || info.Rows != 50 || info.Cols != 2 {
+		t.Errorf("expected A1:B50 with 50 rows and 2 cols, got %+v", info)
+	}
+	if len(info.Headers) != 2 || info.Headers[1] != "value" {
+		t.Errorf("headers mismatch: %v", info.Headers)
+	}
+	if info.HasMergedCells || info.Hidden {
+		t.Errorf("expected no merges and a visible sheet, got %+v", info)
+	}
+}
+
+func TestGetSheetInfoMergesAndHidden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.xlsx")
+	nf := excelize.NewFile()
+	if err := nf.SetSheetRow("Sheet1", "A1", &[]any{"Title", "", "Notes"}); err != nil {
+		t.Fatalf("SetSheetRow failed: %v", err)
+	}
+	if err := nf.SetCellValue("Sheet1", "B2", "x"); err != nil {
+		t.Fatalf("SetCellValue failed: %v", err)
+	}
+	if err := nf.MergeCell("Sheet1", "A1", "B1"); err != nil {
+		t.Fatalf("MergeCell failed: %v", err)
+	}
+	if _, err := nf.NewSheet("Hidden"); err != nil {
+		t.Fatalf("NewSheet failed: %v", err)
+	}
+	if err := nf.SetSheetVisible("Hidden", false); err != nil {
+		t.Fatalf("SetSheetVisible failed: %v", err)
+	}
+	if err := nf.SaveAs(path); err != nil {
+		t.Fatalf("SaveAs failed: %v", err)
+	}
+	nf.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	// excelize stores an A1 placeholder dimension, so rows are counted
+	info, err := GetSheetInfo(f, "Sheet1")
+	if err != nil {
+		t.Fatalf("GetSheetInfo failed: %v", err)
+	}
+	if !info.HasMergedCells || info.Hidden || info.Dimension != "A1:C2" {
+		t.Errorf("expected merged, visible sheet with dimension A1:C2, got %+v", info)
+	}
+
+	info, err = GetSheetInfo(f, "Hidden")
+	if err != nil {
+		t.Fatalf("GetSheetInfo failed: %v", err)
+	}
+	if !info.Hidden || info.HasMergedCells || info.Dimension != "" {
+		t.Errorf("expected empty hidden sheet without merges, got %+v", info)
+	}
+}
+
 func TestGetCell(t *testing.T) {
 	path := createTestFile(t)
 
@@ -206,6 +430,116 @@ func TestGetCell(t *testing.T) {
 	}
 }
 
+func TestGetCellCalc(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calc.xlsx")
+	nf := excelize.NewFile()
+	if err := nf.SetCellValue("Sheet1", "A1", 4); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := nf.SetCellFormula("Sheet1", "B1", "A1*2"); err != nil {
+		t.Fatalf("failed to set formula: %v", err)
+	}
+	if err := nf.SetCellFormula("Sheet1", "C1", "A1/0"); err != nil {
+		t.Fatalf("failed to set formula: %v", err)
+	}
+	if err := nf.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	nf.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	// No cached value was written, so only calc gives the result
+	cell, err := GetCellWithOptions(f, "Sheet1", "B1", StreamOptions{Calc: true})
+	if err != nil {
+		t.Fatalf("GetCellWithOptions failed: %v", err)
+	}
+	if cell.Formula != "=A1*2" || cell.Computed != "8" || cell.CalcError != "" {
+		t.Errorf("expected =A1*2 computed as 8, got %+v", cell)
+	}
+
+	cell, err = GetCellWithOptions(f, "Sheet1", "C1", StreamOptions{Calc: true})
+	if err != nil {
+		t.Fatalf("GetCellWithOptions failed: %v", err)
+	}
+	if cell.Computed != "#DIV/0!" || cell.CalcError == "" {
+		t.Errorf("expected #DIV/0! with calc error, got %+v", cell)
+	}
+
+	cell, err = GetCellWithOptions(f, "Sheet1", "A1", StreamOptions{Calc: true})
+	if err != nil {
+		t.Fatalf("GetCellWithOptions failed: %v", err)
+	}
+	if cell.Formula != "" || cell.Computed != "" {
+		t.Errorf("expected plain cell unchanged, got %+v", cell)
+	}
+
+	cell, err = GetCell(f, "Sheet1", "B1")
+	if err != nil {
+		t.Fatalf("GetCell failed: %v", err)
+	}
+	if cell.Formula != "" || cell.Computed != "" {
+		t.Errorf("expected no calc fields without option, got %+v", cell)
+	}
+}
+
+func TestGetCellRaw(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "percent.xlsx")
+	nf := excelize.NewFile()
+	if err := nf.SetCellValue("Sheet1", "A1", 0.5); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	percent, err := nf.NewStyle(&excelize.Style{NumFmt: 9}) // 0%
+	if err != nil {
+		t.Fatalf("failed to create style: %v", err)
+	}
+	if err := nf.SetCellStyle("Sheet1", "A1", "A1", percent); err != nil {
+		t.Fatalf("failed to set style: %v", err)
+	}
+	if err := nf.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	nf.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	cell, err := GetCell(f, "Sheet1", "A1")
+	if err != nil {
+		t.Fatalf("GetCell failed: %v", err)
+	}
+	if cell.Value != "50%" {
+		t.Errorf("formatted value = %q, want 50%%", cell.Value)
+	}
+
+	cell, err = GetCellWithOptions(f, "Sheet1", "A1", StreamOptions{RawValues: true})
+	if err != nil {
+		t.Fatalf("GetCellWithOptions failed: %v", err)
+	}
+	if cell.Value != "0.5" || cell.Type != "number" {
+		t.Errorf("raw cell = %+v, want number 0.5", cell)
+	}
+
+	ch, err := StreamRowsWithOptions(context.Background(), f, "Sheet1", 1, 1, StreamOptions{RawValues: true})
+	if err != nil {
+		t.Fatalf("StreamRowsWithOptions failed: %v", err)
+	}
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Cells[0].Value != "0.5" {
+		t.Errorf("raw rows = %+v, want 0.5", rows)
+	}
+}
+
 func TestGetDefaultSheet(t *testing.T) {
 	path := createTestFile(t)
 
@@ -335,3 +669,47 @@ func TestResolveSheetName(t *testing.T) {
 		t.Errorf("expected 'Sheet1', got %q", name)
 	}
 }
+
+func TestSheetBoundsLoaded(t *testing.T) {
+	path := createTestFile(t)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	if _, ok := loadedSheetBounds(f, "Sheet1"); ok {
+		t.Fatal("expected an unparsed sheet not to be measured in memory")
+	}
+	bounds, err := sheetBounds(f, "Sheet1")
+	if err != nil {
+		t.Fatalf("sheetBounds failed: %v", err)
+	}
+	if bounds == nil || bounds.String() != "A1:B3" {
+		t.Errorf("expected streamed bounds A1:B3, got %v", bounds)
+	}
+
+	// A styled blank cell is not data; a formula is, even before it has a
+	// cached value
+	if err := f.SetCellStyle("Sheet1", "F9", "F9", 0); err != nil {
+		t.Fatalf("SetCellStyle failed: %v", err)
+	}
+	if err := f.SetCellFormula("Sheet1", "D5", "SUM(B1:B2)"); err != nil {
+		t.Fatalf("SetCellFormula failed: %v", err)
+	}
+	bounds, ok := loadedSheetBounds(f, "Sheet1")
+	if !ok {
+		t.Fatal("expected a parsed sheet to be measured in memory")
+	}
+	if bounds == nil || bounds.String() != "A1:D5" {
+		t.Errorf("expected in-memory bounds A1:D5, got %v", bounds)
+	}
+
+	if err := f.SetCellValue("Sheet2", "A1", nil); err != nil {
+		t.Fatalf("SetCellValue failed: %v", err)
+	}
+	if bounds, ok := loadedSheetBounds(f, "Sheet2"); !ok || bounds != nil {
+		t.Errorf("expected a cleared sheet to have no bounds, got %v (%v)", bounds, ok)
+	}
+}
@@ -0,0 +1,98 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// refError is the reference Excel leaves behind when its target is deleted
+const refError = "#REF!"
+
+// adjustRefsForDeletion prepares the references to rows startRow..endRow of
+// sheet, in formulas on every sheet and in defined names, for removing
+// those rows, and returns how many became #REF!. Call it before removing
+// the rows. excelize shifts references below the deleted rows, but would
+// move references inside them onto the next row's data, and moves the
+// start of a range that begins on a deleted row up past it. So references
+// entirely within the deleted rows become #REF!, and ranges starting within
+// them are trimmed to start after them.
+func adjustRefsForDeletion(f *excelize.File, sheet string, startRow, endRow int) (int, error) {
+	invalidated := 0
+	for _, formulaSheet := range f.GetSheetList() {
+		bounds, err := sheetBounds(f, formulaSheet)
+		if err != nil {
+			return 0, err
+		}
+		if bounds == nil {
+			continue
+		}
+		for row := bounds.StartRow; row <= bounds.EndRow; row++ {
+			if formulaSheet == sheet && row >= startRow && row <= endRow {
+				continue // Removed along with the rows
+			}
+			for col := bounds.StartCol; col <= bounds.EndCol; col++ {
+				addr := FormatCellAddress(col, row)
+				formula, err := f.GetCellFormula(formulaSheet, addr)
+				if err != nil {
+					return 0, fmt.Errorf("failed to get formula for %s: %w", addr, err)
+				}
+				if formula == "" {
+					continue
+				}
+				updated, n := adjustFormulaForDeletion(formula, formulaSheet, sheet, startRow, endRow)
+				if updated == formula {
+					continue
+				}
+				if err := f.SetCellFormula(formulaSheet, addr, updated); err != nil {
+					return 0, fmt.Errorf("failed to update formula in %s: %w", addr, err)
+				}
+				invalidated += n
+			}
+		}
+	}
+
+	for _, name := range f.GetDefinedName() {
+		updated, n := adjustFormulaForDeletion(name.RefersTo, "", sheet, startRow, endRow)
+		if updated == name.RefersTo {
+			continue
+		}
+		if err := f.DeleteDefinedName(&excelize.DefinedName{Name: name.Name, Scope: name.Scope}); err != nil {
+			return 0, fmt.Errorf("failed to update defined name %s: %w", name.Name, err)
+		}
+		name.RefersTo = updated
+		if err := f.SetDefinedName(&name); err != nil {
+			return 0, fmt.Errorf("failed to update defined name %s: %w", name.Name, err)
+		}
+		invalidated += n
+	}
+	return invalidated, nil
+}
+
+// adjustFormulaForDeletion rewrites one formula's references to targetSheet
+// as described on adjustRefsForDeletion, returning the new formula and the
+// number of references that became #REF!
+func adjustFormulaForDeletion(formula, formulaSheet, targetSheet string, startRow, endRow int) (string, int) {
+	refs := formulaRefMatches(formula, formulaSheet, targetSheet)
+
+	// Rewrite from the end so earlier offsets stay valid
+	invalidated := 0
+	for i := len(refs) - 1; i >= 0; i-- {
+		m := refs[i]
+		switch {
+		case m.ref.StartRow >= startRow && m.ref.EndRow <= endRow:
+			formula = formula[:m.start] + refError + formula[m.end:]
+			invalidated++
+		case m.ref.StartRow >= startRow && m.ref.StartRow <= endRow:
+			for j := len(m.rows) - 1; j >= 0; j-- {
+				pos := m.rows[j]
+				if formula[pos[0]:pos[1]] == strconv.Itoa(m.ref.StartRow) {
+					formula = formula[:pos[0]] + strconv.Itoa(endRow+1) + formula[pos[1]:]
+					break
+				}
+			}
+		}
+	}
+	return formula, invalidated
+}
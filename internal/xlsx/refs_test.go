@@ -0,0 +1,163 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// createRefsTestFile builds a sheet with values in B1:B10, formulas above
+// and below row 5, a formula on another sheet and a defined name
+func createRefsTestFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "refs.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for row := 1; row <= 10; row++ {
+		if err := f.SetCellValue("Sheet1", FormatCellAddress(2, row), row); err != nil {
+			t.Fatalf("failed to set cell: %v", err)
+		}
+	}
+	formulas := map[string]string{
+		"C1":  "B5",
+		"C9":  "B5*2",
+		"C10": "SUM(B2:B6)",
+	}
+	for addr, formula := range formulas {
+		if err := f.SetCellFormula("Sheet1", addr, formula); err != nil {
+			t.Fatalf("failed to set formula: %v", err)
+		}
+	}
+	if _, err := f.NewSheet("Other"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	if err := f.SetCellFormula("Other", "A1", "Sheet1!B5"); err != nil {
+		t.Fatalf("failed to set formula: %v", err)
+	}
+	if err := f.SetDefinedName(&excelize.DefinedName{Name: "Block", RefersTo: "Sheet1!$B$4:$B$6"}); err != nil {
+		t.Fatalf("failed to set defined name: %v", err)
+	}
+	if err := f.SetDefinedName(&excelize.DefinedName{Name: "Five", RefersTo: "Sheet1!$B$5"}); err != nil {
+		t.Fatalf("failed to set defined name: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
+func checkFormulas(t *testing.T, path string, want map[string]string, wantNames map[string]string) {
+	t.Helper()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	for key, formula := range want {
+		sheet, addr := "Sheet1", key
+		if key == "Other!A1" {
+			sheet, addr = "Other", "A1"
+		}
+		got, err := f.GetCellFormula(sheet, addr)
+		if err != nil {
+			t.Fatalf("GetCellFormula %s failed: %v", key, err)
+		}
+		if got != formula {
+			t.Errorf("%s: expected formula %q, got %q", key, formula, got)
+		}
+	}
+
+	names := map[string]string{}
+	for _, name := range f.GetDefinedName() {
+		names[name.Name] = name.RefersTo
+	}
+	for name, refersTo := range wantNames {
+		if names[name] != refersTo {
+			t.Errorf("defined name %s: expected %q, got %q", name, refersTo, names[name])
+		}
+	}
+}
+
+func TestInsertRowsAdjustsReferences(t *testing.T) {
+	path := createRefsTestFile(t)
+
+	if _, err := InsertBlankRows(path, "Sheet1", 3, 2); err != nil {
+		t.Fatalf("InsertBlankRows failed: %v", err)
+	}
+
+	checkFormulas(t, path, map[string]string{
+		"C1":       "B7",
+		"C11":      "B7*2",
+		"C12":      "SUM(B2:B8)",
+		"Other!A1": "Sheet1!B7",
+	}, map[string]string{
+		"Block": "Sheet1!$B$6:$B$8",
+		"Five":  "Sheet1!$B$7",
+	})
+}
+
+func TestDeleteRowsInvalidatesReferences(t *testing.T) {
+	path := createRefsTestFile(t)
+
+	result, err := DeleteRows(path, "Sheet1", 5, 1)
+	if err != nil {
+		t.Fatalf("DeleteRows failed: %v", err)
+	}
+	// C1, C9, Other!A1 and the name Five pointed only at row 5
+	if result.RefsInvalidated != 4 {
+		t.Errorf("expected 4 references invalidated, got %d", result.RefsInvalidated)
+	}
+
+	checkFormulas(t, path, map[string]string{
+		"C1":       "#REF!",
+		"C8":       "#REF!*2",
+		"C9":       "SUM(B2:B5)",
+		"Other!A1": "#REF!",
+	}, map[string]string{
+		"Block": "Sheet1!$B$4:$B$5",
+		"Five":  "#REF!",
+	})
+}
+
+func TestDeleteRowsShiftsReferencesBelow(t *testing.T) {
+	path := createRefsTestFile(t)
+
+	// Rows above every reference: everything shifts up by two
+	if _, err := DeleteRows(path, "Sheet1", 2, 2); err != nil {
+		t.Fatalf("DeleteRows failed: %v", err)
+	}
+
+	checkFormulas(t, path, map[string]string{
+		"C1":       "B3",
+		"C7":       "B3*2",
+		"C8":       "SUM(B2:B4)",
+		"Other!A1": "Sheet1!B3",
+	}, map[string]string{
+		"Block": "Sheet1!$B$2:$B$4",
+		"Five":  "Sheet1!$B$3",
+	})
+}
+
+func TestAdjustFormulaForDeletionWholeRefs(t *testing.T) {
+	tests := []struct {
+		formula     string
+		want        string
+		invalidated int
+	}{
+		{"SUM(A:A)", "SUM(A:A)", 0},
+		{"SUM(5:5)", "SUM(#REF!)", 1},
+		{"SUM($5:$7)", "SUM($6:$7)", 0},
+		{"SUM(3:4)", "SUM(3:4)", 0},
+	}
+	for _, tt := range tests {
+		got, n := adjustFormulaForDeletion(tt.formula, "Sheet1", "Sheet1", 5, 5)
+		if got != tt.want || n != tt.invalidated {
+			t.Errorf("%s: expected %q (%d invalidated), got %q (%d)", tt.formula, tt.want, tt.invalidated, got, n)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxReplaceCells is the maximum number of cells a single replace may change
+const MaxReplaceCells = DefaultMaxWriteRangeCells
+
+// ReplaceOptions configures find-and-replace. SearchOptions.Sheet is
+// ignored in favor of the sheet argument, and MaxResults caps the number
+// of cells changed (0 = up to MaxReplaceCells).
+type ReplaceOptions struct {
+	SearchOptions
+	DryRun bool // Report matches without writing the file
+}
+
+// Replace rewrites cell values matching pattern in one sheet, or in every
+// sheet when sheet is empty, and saves atomically. In regex mode the
+// replacement may use $1-style capture group references. Formula cells are
+// matched and rewritten in their formula text. With DryRun the matches are
+// reported and the file is left untouched.
+func Replace(path, sheet, pattern, replacement string, opts ReplaceOptions) (*ReplaceResult, error) {
+	if !opts.DryRun {
+		return withWorkbook(path, func(wb *Workbook) (*ReplaceResult, error) {
+			return wb.Replace(sheet, pattern, replacement, opts)
+		})
+	}
+
+	wb, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer wb.Close()
+
+	return wb.Replace(sheet, pattern, replacement, opts)
+}
+
+// Replace rewrites matching cell values in one sheet, or in every sheet
+// when sheet is empty. opts.DryRun only affects the one-shot Replace;
+// on a Workbook nothing is saved until Commit.
+func (wb *Workbook) Replace(sheet, pattern, replacement string, opts ReplaceOptions) (*ReplaceResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	replacer, err := newReplacer(pattern, replacement, opts.SearchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets := wb.f.GetSheetList()
+	if sheet != "" {
+		resolvedSheet, err := wb.resolveSheet(sheet)
+		if err != nil {
+			return nil, err
+		}
+		sheets = []string{resolvedSheet}
+	}
+
+	limit := MaxReplaceCells
+	if opts.MaxResults > 0 && opts.MaxResults < limit {
+		limit = opts.MaxResults
+	}
+
+	result := &ReplaceResult{
+		Success: true,
+		DryRun:  opts.DryRun,
+		Changes: []ReplaceChange{},
+	}
+
+	for _, sheetName := range sheets {
+		changes, err := replaceInSheet(wb.f, sheetName, replacer, limit-len(result.Changes), opts.MaxResults > 0)
+		if err != nil {
+			return nil, err
+		}
+		result.Changes = append(result.Changes, changes...)
+	}
+
+	result.CellsChanged = len(result.Changes)
+	return result, nil
+}
+
+// SetWhereMatch sets every cell whose value matches pattern to newValue,
+// in one sheet or in every sheet when sheet is empty, and saves atomically.
+// Matching follows Search: substring by default, or a regex with
+// opts.Regex. The new value's type is detected as for write_cell "auto".
+// opts.MaxResults caps the number of cells changed (0 = up to
+// MaxReplaceCells; more matches than that is an error).
+func SetWhereMatch(path, sheet, pattern string, newValue any, opts SearchOptions) (*SetWhereResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SetWhereResult, error) {
+		return wb.SetWhereMatch(sheet, pattern, newValue, opts)
+	})
+}
+
+// SetWhereMatch sets every cell whose value matches pattern to newValue.
+// Enforces MaxReplaceCells limit.
+func (wb *Workbook) SetWhereMatch(sheet, pattern string, newValue any, opts SearchOptions) (*SetWhereResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	opts.Sheet = ""
+	if sheet != "" {
+		resolvedSheet, err := wb.resolveSheet(sheet)
+		if err != nil {
+			return nil, err
+		}
+		opts.Sheet = resolvedSheet
+	}
+
+	capped := opts.MaxResults > 0 && opts.MaxResults <= MaxReplaceCells
+	if !capped {
+		opts.MaxResults = MaxReplaceCells + 1 // One extra to detect overflow
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := Search(ctx, wb.f, pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := CollectSearchResults(ch)
+	if err != nil {
+		return nil, err
+	}
+	if !capped && len(matches) > MaxReplaceCells {
+		return nil, fmt.Errorf("%w: more than %d cells match",
+			ErrCellLimitExceeded, MaxReplaceCells)
+	}
+
+	result := &SetWhereResult{
+		Success: true,
+		Changes: []ReplaceChange{},
+	}
+	for _, match := range matches {
+		if err := setCellWithType(wb.f, match.Sheet, match.Address, newValue, "auto"); err != nil {
+			return nil, err
+		}
+		result.Changes = append(result.Changes, ReplaceChange{
+			Sheet:    match.Sheet,
+			Address:  match.Address,
+			OldValue: match.Value,
+			NewValue: fmt.Sprint(newValue),
+		})
+	}
+
+	result.CellsChanged = len(result.Changes)
+	return result, nil
+}
+
+// replaceInSheet applies replacer to every cell in a sheet's used range.
+// Once remaining reaches zero, further matches stop the scan when capped
+// is set, and are otherwise an ErrCellLimitExceeded error.
+func replaceInSheet(f *excelize.File, sheet string, replacer func(string) (string, bool), remaining int, capped bool) ([]ReplaceChange, error) {
+	bounds, err := sheetBounds(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []ReplaceChange{}
+	if bounds == nil {
+		return changes, nil
+	}
+
+	for row := bounds.StartRow; row <= bounds.EndRow; row++ {
+		for col := bounds.StartCol; col <= bounds.EndCol; col++ {
+			addr := FormatCellAddress(col, row)
+
+			formula, err := f.GetCellFormula(sheet, addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get formula for %s: %w", addr, err)
+			}
+
+			oldValue := formula
+			if formula == "" {
+				if oldValue, err = f.GetCellValue(sheet, addr); err != nil {
+					return nil, fmt.Errorf("failed to get cell %s: %w", addr, err)
+				}
+			}
+			if oldValue == "" {
+				continue
+			}
+
+			newValue, ok := replacer(oldValue)
+			if !ok || newValue == oldValue {
+				continue
+			}
+
+			if len(changes) >= remaining {
+				if capped {
+					return changes, nil
+				}
+				return nil, fmt.Errorf("%w: more than %d cells match",
+					ErrCellLimitExceeded, MaxReplaceCells)
+			}
+
+			if formula != "" {
+				err = f.SetCellFormula(sheet, addr, newValue)
+			} else {
+				err = setReplacedValue(f, sheet, addr, newValue)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to write cell %s: %w", addr, err)
+			}
+
+			changes = append(changes, ReplaceChange{
+				Sheet:    sheet,
+				Address:  addr,
+				OldValue: oldValue,
+				NewValue: newValue,
+				Formula:  formula != "",
+			})
+		}
+	}
+	return changes, nil
+}
+
+// setReplacedValue writes a replaced value, keeping numeric cells numeric
+// when the new text is still a number
+func setReplacedValue(f *excelize.File, sheet, addr, value string) error {
+	cellType, err := f.GetCellType(sheet, addr)
+	if err != nil {
+		return err
+	}
+	if cellType == excelize.CellTypeNumber || cellType == excelize.CellTypeUnset {
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return f.SetCellValue(sheet, addr, n)
+		}
+	}
+	return f.SetCellStr(sheet, addr, value)
+}
+
+// newReplacer builds a function returning the replaced text and whether
+// the pattern matched, following the same matching rules as Search
+func newReplacer(pattern, replacement string, opts SearchOptions) (func(string) (string, bool), error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("search pattern cannot be empty")
+	}
+
+	flags := ""
+	if opts.CaseInsensitive {
+		flags = "(?i)"
+	}
+
+	if opts.Regex {
+		re, err := regexp.Compile(flags + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex pattern: %w", err)
+		}
+		return func(s string) (string, bool) {
+			if !re.MatchString(s) {
+				return s, false
+			}
+			return re.ReplaceAllString(s, replacement), true
+		}, nil
+	}
+
+	if opts.CaseInsensitive {
+		re := regexp.MustCompile(flags + regexp.QuoteMeta(pattern))
+		return func(s string) (string, bool) {
+			if !re.MatchString(s) {
+				return s, false
+			}
+			return re.ReplaceAllLiteralString(s, replacement), true
+		}, nil
+	}
+
+	return func(s string) (string, bool) {
+		if !strings.Contains(s, pattern) {
+			return s, false
+		}
+		return strings.ReplaceAll(s, pattern, replacement), true
+	}, nil
+}
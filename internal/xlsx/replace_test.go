@@ -0,0 +1,286 @@
+package xlsx
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createReplaceTestFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "replace.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+
+	values := map[string]any{
+		"A1": "Status",
+		"A2": "pending review",
+		"A3": "Pending",
+		"A4": "done",
+		"B2": 1200,
+		"C2": "ID-42",
+	}
+	for addr, v := range values {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set cell: %v", err)
+		}
+	}
+	if err := f.SetCellFormula("Sheet1", "B3", "SUM(B2,Pending)"); err != nil {
+		t.Fatalf("failed to set formula: %v", err)
+	}
+	if _, err := f.NewSheet("Sheet2"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	if err := f.SetCellValue("Sheet2", "A1", "pending"); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
+func readCell(t *testing.T, path, sheet, addr string) string {
+	t.Helper()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	val, err := f.GetCellValue(sheet, addr)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", addr, err)
+	}
+	return val
+}
+
+func TestReplaceLiteral(t *testing.T) {
+	path := createReplaceTestFile(t)
+
+	result, err := Replace(path, "Sheet1", "pending", "approved", ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if result.CellsChanged != 1 {
+		t.Fatalf("expected 1 cell changed, got %d: %+v", result.CellsChanged, result.Changes)
+	}
+	if got := readCell(t, path, "Sheet1", "A2"); got != "approved review" {
+		t.Errorf("expected 'approved review' at A2, got %q", got)
+	}
+	if got := readCell(t, path, "Sheet1", "A3"); got != "Pending" {
+		t.Errorf("expected A3 unchanged by case-sensitive replace, got %q", got)
+	}
+	if got := readCell(t, path, "Sheet2", "A1"); got != "pending" {
+		t.Errorf("expected Sheet2 untouched, got %q", got)
+	}
+}
+
+func TestReplaceCaseInsensitiveAllSheets(t *testing.T) {
+	path := createReplaceTestFile(t)
+
+	opts := ReplaceOptions{SearchOptions: SearchOptions{CaseInsensitive: true}}
+	result, err := Replace(path, "", "PENDING", "open", opts)
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	// A2, A3, the B3 formula and Sheet2!A1
+	if result.CellsChanged != 4 {
+		t.Fatalf("expected 4 cells changed, got %d: %+v", result.CellsChanged, result.Changes)
+	}
+	if got := readCell(t, path, "Sheet1", "A3"); got != "open" {
+		t.Errorf("expected 'open' at A3, got %q", got)
+	}
+	if got := readCell(t, path, "Sheet2", "A1"); got != "open" {
+		t.Errorf("expected 'open' at Sheet2!A1, got %q", got)
+	}
+}
+
+func TestReplaceRegexCaptureGroups(t *testing.T) {
+	path := createReplaceTestFile(t)
+
+	opts := ReplaceOptions{SearchOptions: SearchOptions{Regex: true}}
+	result, err := Replace(path, "Sheet1", `^ID-([0-9]+)$`, "REF-$1", opts)
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if result.CellsChanged != 1 {
+		t.Fatalf("expected 1 cell changed, got %d", result.CellsChanged)
+	}
+	if got := readCell(t, path, "Sheet1", "C2"); got != "REF-42" {
+		t.Errorf("expected 'REF-42' at C2, got %q", got)
+	}
+}
+
+func TestReplaceFormula(t *testing.T) {
+	path := createReplaceTestFile(t)
+
+	result, err := Replace(path, "Sheet1", "SUM", "MAX", ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if result.CellsChanged != 1 || !result.Changes[0].Formula {
+		t.Fatalf("expected 1 formula change, got %+v", result.Changes)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	formula, err := f.GetCellFormula("Sheet1", "B3")
+	if err != nil {
+		t.Fatalf("failed to read formula: %v", err)
+	}
+	if formula != "MAX(B2,Pending)" {
+		t.Errorf("expected formula 'MAX(B2,Pending)', got %q", formula)
+	}
+}
+
+func TestReplaceKeepsNumbers(t *testing.T) {
+	path := createReplaceTestFile(t)
+
+	if _, err := Replace(path, "Sheet1", "12", "34", ReplaceOptions{}); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	cellType, err := f.GetCellType("Sheet1", "B2")
+	if err != nil {
+		t.Fatalf("failed to get cell type: %v", err)
+	}
+	if cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+		t.Errorf("expected B2 to stay numeric, got type %v", cellType)
+	}
+	if got, _ := f.GetCellValue("Sheet1", "B2"); got != "3400" {
+		t.Errorf("expected 3400 at B2, got %q", got)
+	}
+}
+
+func TestReplaceDryRun(t *testing.T) {
+	path := createReplaceTestFile(t)
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	opts := ReplaceOptions{DryRun: true}
+	result, err := Replace(path, "", "pending", "approved", opts)
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if !result.DryRun {
+		t.Error("expected dry_run=true in result")
+	}
+	if result.CellsChanged != 2 {
+		t.Errorf("expected 2 matches, got %d", result.CellsChanged)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("expected dry run to leave the file untouched")
+	}
+}
+
+func TestReplaceErrors(t *testing.T) {
+	path := createReplaceTestFile(t)
+
+	if _, err := Replace(path, "Sheet1", "", "x", ReplaceOptions{}); err == nil {
+		t.Error("expected error for empty pattern")
+	}
+
+	opts := ReplaceOptions{SearchOptions: SearchOptions{Regex: true}}
+	if _, err := Replace(path, "Sheet1", "([", "x", opts); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+
+	_, err := Replace(path, "NoSuchSheet", "a", "b", ReplaceOptions{})
+	if !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("expected ErrSheetNotFound, got: %v", err)
+	}
+}
+
+func TestSetWhereMatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "setwhere.xlsx")
+	f := excelize.NewFile()
+	values := map[string]string{
+		"A1": "Task",
+		"B1": "Status",
+		"B2": "TODO",
+		"B3": "DONE",
+		"B4": "TODO",
+		"C4": "todo later",
+	}
+	for addr, v := range values {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set cell: %v", err)
+		}
+	}
+	if _, err := f.NewSheet("Sheet2"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	if err := f.SetCellValue("Sheet2", "A1", "TODO"); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	result, err := SetWhereMatch(path, "Sheet1", "^TODO$", "DONE", SearchOptions{Regex: true})
+	if err != nil {
+		t.Fatalf("SetWhereMatch failed: %v", err)
+	}
+	if result.CellsChanged != 2 {
+		t.Errorf("expected 2 cells changed, got %d: %+v", result.CellsChanged, result.Changes)
+	}
+
+	for addr, want := range map[string]string{"B2": "DONE", "B3": "DONE", "B4": "DONE", "C4": "todo later"} {
+		if got := readCell(t, path, "Sheet1", addr); got != want {
+			t.Errorf("%s: expected %q, got %q", addr, want, got)
+		}
+	}
+	// The sheet argument limits the update
+	if got := readCell(t, path, "Sheet2", "A1"); got != "TODO" {
+		t.Errorf("expected Sheet2!A1 untouched, got %q", got)
+	}
+
+	// Case-insensitive substring matching across all sheets, capped
+	result, err = SetWhereMatch(path, "", "todo", 0, SearchOptions{CaseInsensitive: true, MaxResults: 1})
+	if err != nil {
+		t.Fatalf("SetWhereMatch failed: %v", err)
+	}
+	if result.CellsChanged != 1 {
+		t.Errorf("expected 1 cell changed with cap, got %d", result.CellsChanged)
+	}
+}
+
+func TestSetWhereMatchErrors(t *testing.T) {
+	path := createReplaceTestFile(t)
+
+	if _, err := SetWhereMatch(path, "", "", "x", SearchOptions{}); err == nil {
+		t.Error("expected error for empty pattern")
+	}
+	if _, err := SetWhereMatch(path, "", "(", "x", SearchOptions{Regex: true}); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+	if _, err := SetWhereMatch(path, "NoSuchSheet", "x", "x", SearchOptions{}); err == nil {
+		t.Error("expected error for nonexistent sheet")
+	}
+}
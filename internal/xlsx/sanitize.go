@@ -0,0 +1,136 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// formulaTriggers are the leading characters that make a string value a
+// formula injection risk when opened or exported as CSV
+const formulaTriggers = "=+-@"
+
+// sanitizeStrings enables neutralizing formula-like string values on write.
+// Protected by sanitizeMu for thread-safe access.
+var sanitizeStrings bool
+
+// sanitizeMu protects concurrent access to sanitizeStrings.
+var sanitizeMu sync.RWMutex
+
+// SetSanitizeStrings enables or disables neutralizing string cell values
+// that start with =, +, - or @. When enabled, such values written with the
+// "string" type (including "auto" values detected as strings) get Excel's
+// quote prefix style, as if typed with a leading ': the value is stored
+// unchanged but Excel keeps treating it as text when the cell is edited.
+// Explicit formula writes are never affected.
+func SetSanitizeStrings(on bool) {
+	sanitizeMu.Lock()
+	sanitizeStrings = on
+	sanitizeMu.Unlock()
+}
+
+// SanitizeStrings reports whether string values are neutralized on write
+func SanitizeStrings() bool {
+	sanitizeMu.RLock()
+	defer sanitizeMu.RUnlock()
+	return sanitizeStrings
+}
+
+// IsFormulaLike reports whether a string value starts with a formula
+// trigger (=, +, - or @)
+func IsFormulaLike(value string) bool {
+	return value != "" && strings.ContainsRune(formulaTriggers, rune(value[0]))
+}
+
+// quotePrefixStyle returns the ID of a cell style like styleID with the
+// quote prefix set, reusing an existing one when the workbook has it.
+// excelize does not expose the quote prefix, so the style is cloned from
+// the parsed style sheet.
+func quotePrefixStyle(f *excelize.File, styleID int) (int, error) {
+	// GetStyle loads the style sheet and checks the ID
+	if _, err := f.GetStyle(styleID); err != nil {
+		return 0, fmt.Errorf("failed to read style %d: %w", styleID, err)
+	}
+	xfs := f.Styles.CellXfs
+	quoted := xfs.Xf[styleID]
+	if quoted.QuotePrefix != nil && *quoted.QuotePrefix {
+		return styleID, nil
+	}
+	on := true
+	quoted.QuotePrefix = &on
+	for i, xf := range xfs.Xf {
+		if reflect.DeepEqual(xf, quoted) {
+			return i, nil
+		}
+	}
+	xfs.Xf = append(xfs.Xf, quoted)
+	xfs.Count = len(xfs.Xf)
+	return len(xfs.Xf) - 1, nil
+}
+
+// quoteCell gives a cell the quote prefix on top of its current style
+func quoteCell(f *excelize.File, sheet, cell string) error {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return fmt.Errorf("failed to get style of %s: %w", cell, err)
+	}
+	quoted, err := quotePrefixStyle(f, styleID)
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(sheet, cell, cell, quoted); err != nil {
+		return fmt.Errorf("failed to set quote prefix on %s: %w", cell, err)
+	}
+	return nil
+}
+
+// quoteFormulaLike gives the formula-like string values just written from
+// values the quote prefix, when sanitizing is enabled. addr returns the
+// cell the i-th value was written to.
+func quoteFormulaLike(f *excelize.File, sheet string, values []any, addr func(i int) string) error {
+	if !SanitizeStrings() {
+		return nil
+	}
+	for i, v := range values {
+		if s, ok := v.(string); ok && IsFormulaLike(s) {
+			if err := quoteCell(f, sheet, addr(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// quoteStreamRow prepares a row for a StreamWriter, giving its formula-like
+// string values the quote prefix when sanitizing is enabled. quoted caches
+// the quoted default style across rows; it starts at -1.
+func quoteStreamRow(f *excelize.File, row []any, quoted *int) ([]any, error) {
+	if !SanitizeStrings() {
+		return row, nil
+	}
+	var cells []any
+	for i, v := range row {
+		s, ok := v.(string)
+		if !ok || !IsFormulaLike(s) {
+			continue
+		}
+		if *quoted < 0 {
+			id, err := quotePrefixStyle(f, 0)
+			if err != nil {
+				return nil, err
+			}
+			*quoted = id
+		}
+		if cells == nil {
+			cells = append([]any(nil), row...)
+		}
+		cells[i] = excelize.Cell{StyleID: *quoted, Value: s}
+	}
+	if cells == nil {
+		return row, nil
+	}
+	return cells, nil
+}
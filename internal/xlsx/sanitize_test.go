@@ -0,0 +1,183 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func enableSanitize(t *testing.T) {
+	t.Helper()
+	SetSanitizeStrings(true)
+	t.Cleanup(func() { SetSanitizeStrings(false) })
+}
+
+func TestIsFormulaLike(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"=1+1", true},
+		{"+cmd", true},
+		{"-abc", true},
+		{"@SUM(A1)", true},
+		{"plain", false},
+		{"", false},
+		{"'quoted", false},
+	}
+	for _, tt := range tests {
+		if got := IsFormulaLike(tt.input); got != tt.want {
+			t.Errorf("IsFormulaLike(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// hasQuotePrefix reports whether a cell's style carries the quote prefix
+func hasQuotePrefix(t *testing.T, f *excelize.File, sheet, cell string) bool {
+	t.Helper()
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		t.Fatalf("GetCellStyle %s failed: %v", cell, err)
+	}
+	if _, err := f.GetStyle(styleID); err != nil {
+		t.Fatalf("GetStyle %d failed: %v", styleID, err)
+	}
+	quote := f.Styles.CellXfs.Xf[styleID].QuotePrefix
+	return quote != nil && *quote
+}
+
+func TestWriteCellSanitize(t *testing.T) {
+	path := createTestFile(t)
+	enableSanitize(t)
+
+	// A styled cell keeps its style under the quote prefix
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		t.Fatalf("NewStyle failed: %v", err)
+	}
+	if err := f.SetCellStyle("Sheet1", "C1", "C1", bold); err != nil {
+		t.Fatalf("SetCellStyle failed: %v", err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	f.Close()
+
+	writes := []struct {
+		addr, value, valueType string
+	}{
+		{"C1", "=1+1", "string"},
+		{"C2", "@cmd", "auto"},
+		{"C3", "-5", "auto"},
+		{"C4", "=1+1", "formula"},
+		{"C5", "plain", "string"},
+	}
+	for _, w := range writes {
+		if _, err := WriteCell(path, "Sheet1", w.addr, w.value, w.valueType); err != nil {
+			t.Fatalf("WriteCell %s failed: %v", w.addr, err)
+		}
+	}
+	if _, err := AppendRows(path, "Sheet1", [][]any{{"+x", 1}}); err != nil {
+		t.Fatalf("AppendRows failed: %v", err)
+	}
+
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	// Values are stored unchanged; the quote prefix lives in the style
+	stored := map[string]bool{"C1": true, "C2": true, "C3": false, "C5": false, "A6": true}
+	values := map[string]string{"C1": "=1+1", "C2": "@cmd", "C3": "-5", "C5": "plain", "A6": "+x"}
+	for addr, quoted := range stored {
+		got, err := f.GetCellValue("Sheet1", addr)
+		if err != nil {
+			t.Fatalf("GetCellValue %s failed: %v", addr, err)
+		}
+		if got != values[addr] {
+			t.Errorf("%s: expected stored %q, got %q", addr, values[addr], got)
+		}
+		if hasQuotePrefix(t, f, "Sheet1", addr) != quoted {
+			t.Errorf("%s: expected quote prefix %v", addr, quoted)
+		}
+	}
+
+	styleID, err := f.GetCellStyle("Sheet1", "C1")
+	if err != nil {
+		t.Fatalf("GetCellStyle failed: %v", err)
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		t.Fatalf("GetStyle failed: %v", err)
+	}
+	if style.Font == nil || !style.Font.Bold {
+		t.Errorf("expected C1 to stay bold under the quote prefix, got %+v", style.Font)
+	}
+
+	formula, err := f.GetCellFormula("Sheet1", "C4")
+	if err != nil {
+		t.Fatalf("GetCellFormula failed: %v", err)
+	}
+	if formula != "1+1" && formula != "=1+1" {
+		t.Errorf("expected formula write to be unaffected, got %q", formula)
+	}
+	if hasQuotePrefix(t, f, "Sheet1", "C4") {
+		t.Error("expected no quote prefix on a formula write")
+	}
+}
+
+func TestCreateFileSanitize(t *testing.T) {
+	enableSanitize(t)
+	orig := streamCreateThreshold
+	t.Cleanup(func() { streamCreateThreshold = orig })
+
+	for _, stream := range []bool{false, true} {
+		streamCreateThreshold = orig
+		if stream {
+			streamCreateThreshold = 1
+		}
+		path := filepath.Join(t.TempDir(), "created.xlsx")
+		if _, err := CreateFile(path, "Sheet1", []string{"Name"}, [][]any{{"=cmd"}, {"'quoted"}}, false); err != nil {
+			t.Fatalf("CreateFile (stream %v) failed: %v", stream, err)
+		}
+		f, err := OpenFile(path)
+		if err != nil {
+			t.Fatalf("OpenFile failed: %v", err)
+		}
+		for addr, want := range map[string]string{"A2": "=cmd", "A3": "'quoted"} {
+			if got, _ := f.GetCellValue("Sheet1", addr); got != want {
+				t.Errorf("stream %v, %s: expected %q, got %q", stream, addr, want, got)
+			}
+		}
+		if !hasQuotePrefix(t, f, "Sheet1", "A2") || hasQuotePrefix(t, f, "Sheet1", "A3") {
+			t.Errorf("stream %v: expected the quote prefix on A2 only", stream)
+		}
+		f.Close()
+	}
+}
+
+func TestWriteCellNoSanitizeByDefault(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := WriteCell(path, "Sheet1", "C1", "=1+1", "string"); err != nil {
+		t.Fatalf("WriteCell failed: %v", err)
+	}
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+	got, err := f.GetCellValue("Sheet1", "C1")
+	if err != nil {
+		t.Fatalf("GetCellValue failed: %v", err)
+	}
+	if got != "=1+1" {
+		t.Errorf("expected value unchanged, got %q", got)
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -15,6 +19,25 @@ type SearchOptions struct {
 	Sheet           string // Limit to specific sheet (empty = all sheets)
 	Regex           bool   // Treat pattern as regex
 	MaxResults      int    // Maximum results (0 = unlimited)
+	// Fuzzy matches whole cell values within MaxDistance edits of the
+	// pattern. It is slower than a literal search: every cell needs an
+	// edit distance, where a substring check can stop at the first hit.
+	Fuzzy       bool
+	MaxDistance int // Maximum edits for fuzzy matches (0 = DefaultMaxDistance)
+	// WholeWord requires a literal pattern to be delimited by non-word
+	// characters or the ends of the value, so "cat" matches "a cat" but not
+	// "category", and "C++" matches "C++ code" but not "ABC++"
+	WholeWord bool
+	// Range limits matches to cells inside it, e.g. A2:A10000 or one column
+	// as B:B. Open ends extend to Excel's last row or column.
+	Range *CellRange
+	// WithRow adds each match's row to the result, or only RowWindow
+	// columns on either side of the match when RowWindow is set
+	WithRow   bool
+	RowWindow int
+	// Numeric parses pattern as a NumericQuery (>1000, <=0, 100..200) and
+	// compares it against each cell's stored value, skipping non-numbers
+	Numeric bool
 }
 
 // SearchResultStream wraps a search result with potential error
@@ -24,6 +47,7 @@ type SearchResultStream struct {
 }
 
 // Search searches for cells matching a pattern across one or all sheets
+// Callers that stop reading before the channel is closed must cancel ctx
 func Search(ctx context.Context, f *excelize.File, pattern string, opts SearchOptions) (<-chan SearchResultStream, error) {
 	if f == nil {
 		return nil, fmt.Errorf("file handle is nil")
@@ -33,9 +57,36 @@ func Search(ctx context.Context, f *excelize.File, pattern string, opts SearchOp
 		return nil, fmt.Errorf("search pattern cannot be empty")
 	}
 
-	// Compile regex or create literal matcher
+	if opts.Fuzzy && opts.Regex {
+		return nil, fmt.Errorf("fuzzy and regex search cannot be combined")
+	}
+	if opts.Numeric && (opts.Regex || opts.Fuzzy) {
+		return nil, fmt.Errorf("numeric search cannot be combined with regex or fuzzy search")
+	}
+	if opts.WholeWord && (opts.Regex || opts.Fuzzy || opts.Numeric) {
+		return nil, fmt.Errorf("whole-word search only applies to literal patterns")
+	}
+	if opts.RowWindow < 0 {
+		return nil, fmt.Errorf("row window cannot be negative")
+	}
+	if opts.MaxDistance < 0 {
+		return nil, fmt.Errorf("max distance cannot be negative")
+	}
+
+	// Compile regex or create numeric, fuzzy or literal matcher
 	var matcher func(string) bool
-	if opts.Regex {
+	var query *NumericQuery
+	switch {
+	case opts.Numeric:
+		var err error
+		if query, err = ParseNumericQuery(pattern); err != nil {
+			return nil, err
+		}
+		matcher = func(s string) bool {
+			_, ok := query.Match(s)
+			return ok
+		}
+	case opts.Regex:
 		flags := ""
 		if opts.CaseInsensitive {
 			flags = "(?i)"
@@ -45,17 +96,42 @@ func Search(ctx context.Context, f *excelize.File, pattern string, opts SearchOp
 			return nil, fmt.Errorf("invalid regex pattern: %w", err)
 		}
 		matcher = re.MatchString
-	} else {
+	case opts.Fuzzy:
+		maxDist := opts.MaxDistance
+		if maxDist == 0 {
+			maxDist = DefaultMaxDistance
+		}
 		if opts.CaseInsensitive {
 			patternLower := strings.ToLower(pattern)
 			matcher = func(s string) bool {
-				return strings.Contains(strings.ToLower(s), patternLower)
+				return withinDistance(strings.ToLower(s), patternLower, maxDist)
 			}
 		} else {
 			matcher = func(s string) bool {
-				return strings.Contains(s, pattern)
+				return withinDistance(s, pattern, maxDist)
 			}
 		}
+	case opts.WholeWord:
+		flags := ""
+		if opts.CaseInsensitive {
+			flags = "(?i)"
+		}
+		// \b would need a word character inside the pattern's edges, so
+		// patterns such as C++ or #N/A could never match
+		re, err := regexp.Compile(flags + `(?:^|\W)` + regexp.QuoteMeta(pattern) + `(?:\W|$)`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		matcher = re.MatchString
+	case opts.CaseInsensitive:
+		patternLower := strings.ToLower(pattern)
+		matcher = func(s string) bool {
+			return strings.Contains(strings.ToLower(s), patternLower)
+		}
+	default:
+		matcher = func(s string) bool {
+			return strings.Contains(s, pattern)
+		}
 	}
 
 	// Determine which sheets to search
@@ -74,99 +150,312 @@ func Search(ctx context.Context, f *excelize.File, pattern string, opts SearchOp
 		sheetsToSearch = sheets
 	}
 
-	ch := make(chan SearchResultStream)
+	// Numeric search compares stored values, so number formats such as
+	// "1,200.00" do not hide a number
+	var colOpts []excelize.Options
+	if opts.Numeric {
+		colOpts = []excelize.Options{{RawCellValue: true}}
+	}
+
+	// Close any open end of the range at the sheet limits
+	var bounds *CellRange
+	if opts.Range != nil {
+		b := *opts.Range
+		if b.EndRow == 0 {
+			b.EndRow = excelize.TotalRows
+		}
+		if b.EndCol == 0 {
+			b.EndCol = excelize.MaxColumns
+		}
+		bounds = &b
+	}
 
-	go func() {
-		defer close(ch)
-
-		resultCount := 0
-		for _, sheet := range sheetsToSearch {
-			rows, err := f.Rows(sheet)
-			if err != nil {
-				select {
-				case <-ctx.Done():
+	scan := &sheetScan{
+		f:          f,
+		matcher:    matcher,
+		query:      query,
+		colOpts:    colOpts,
+		bounds:     bounds,
+		opts:       opts,
+		ch:         make(chan SearchResultStream),
+		maxResults: int64(opts.MaxResults),
+	}
+
+	// Sheets are searched in parallel, one worker per CPU at most, each
+	// taking the next unsearched sheet. excelize indexes a shared string
+	// table too large to keep in memory lazily and without locking, so
+	// such workbooks are searched one sheet at a time.
+	workers := 1
+	if len(sheetsToSearch) > 1 && sharedStringsInMemory(f) {
+		workers = min(runtime.GOMAXPROCS(0), len(sheetsToSearch))
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(sheetsToSearch) {
 					return
-				case ch <- SearchResultStream{Err: fmt.Errorf("failed to read sheet %s: %w", sheet, err)}:
+				}
+				if !scan.searchSheet(ctx, sheetsToSearch[i], i) {
 					return
 				}
 			}
+		}()
+	}
 
-			rowNum := 0
-			for rows.Next() {
-				// Check context before processing row
-				select {
-				case <-ctx.Done():
-					rows.Close()
-					return
-				default:
-				}
+	go func() {
+		wg.Wait()
+		close(scan.ch)
+	}()
 
-				rowNum++
-
-				cols, err := rows.Columns()
-				if err != nil {
-					rows.Close()
-					select {
-					case <-ctx.Done():
-						return
-					case ch <- SearchResultStream{Err: fmt.Errorf("error at row %d: %w", rowNum, err)}:
-						return
-					}
-				}
+	return scan.ch, nil
+}
 
-				for colIdx, val := range cols {
-					if val != "" && matcher(val) {
-						result := &SearchResult{
-							Sheet:   sheet,
-							Address: FormatCellAddress(colIdx+1, rowNum),
-							Value:   val,
-							Row:     rowNum,
-							Col:     colIdx + 1,
-						}
-						select {
-						case <-ctx.Done():
-							rows.Close()
-							return
-						case ch <- SearchResultStream{Result: result}:
-						}
-
-						resultCount++
-						if opts.MaxResults > 0 && resultCount >= opts.MaxResults {
-							rows.Close()
-							return
-						}
-					}
-				}
+// sharedStringsPath is where excelize keeps a workbook's shared strings
+const sharedStringsPath = "xl/sharedStrings.xml"
+
+// sharedStringsInMemory reports whether the workbook's shared string table
+// is held in memory, where excelize reads it under a lock. A missing table
+// is indistinguishable from one spilled to a temporary file, so it also
+// reports false.
+func sharedStringsInMemory(f *excelize.File) bool {
+	_, ok := f.Pkg.Load(sharedStringsPath)
+	return ok
+}
+
+// sheetScan is the state shared by the workers of one search
+type sheetScan struct {
+	f       *excelize.File
+	matcher func(string) bool
+	query   *NumericQuery
+	colOpts []excelize.Options
+	bounds  *CellRange
+	opts    SearchOptions
+	ch      chan SearchResultStream
+
+	// sent counts the results claimed by all workers, against maxResults
+	sent       atomic.Int64
+	maxResults int64
+	// failed is set once a worker has sent an error; the others then stop
+	failed atomic.Bool
+}
+
+// full reports whether no more results may be sent
+func (s *sheetScan) full() bool {
+	return s.failed.Load() || (s.maxResults > 0 && s.sent.Load() >= s.maxResults)
+}
+
+// send delivers stream unless ctx is cancelled first
+func (s *sheetScan) send(ctx context.Context, stream SearchResultStream) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case s.ch <- stream:
+		return true
+	}
+}
+
+// fail sends err, stopping every worker
+func (s *sheetScan) fail(ctx context.Context, err error) bool {
+	if s.failed.Swap(true) {
+		return false
+	}
+	s.send(ctx, SearchResultStream{Err: err})
+	return false
+}
+
+// searchSheet streams the matches in one sheet. It returns false when the
+// search should stop: the context is done, an error was sent or
+// MaxResults has been reached.
+func (s *sheetScan) searchSheet(ctx context.Context, sheet string, sheetIndex int) bool {
+	if s.full() {
+		return false
+	}
+
+	rows, err := s.f.Rows(sheet)
+	if err != nil {
+		return s.fail(ctx, fmt.Errorf("failed to read sheet %s: %w", sheet, err))
+	}
+	defer rows.Close()
+
+	rowNum := 0
+	for rows.Next() {
+		// Check context and the shared result count before processing row
+		select {
+		case <-ctx.Done():
+			return false
+		default:
+		}
+		if s.full() {
+			return false
+		}
+
+		rowNum++
+
+		if s.bounds != nil {
+			// Skip rows before the range and stop after it
+			if rowNum < s.bounds.StartRow {
+				continue
+			}
+			if rowNum > s.bounds.EndRow {
+				break
 			}
+		}
 
-			if err := rows.Error(); err != nil {
-				rows.Close()
-				select {
-				case <-ctx.Done():
-					return
-				case ch <- SearchResultStream{Err: fmt.Errorf("row iteration error in sheet %s: %w", sheet, err)}:
-					return
-				}
+		cols, err := rows.Columns(s.colOpts...)
+		if err != nil {
+			return s.fail(ctx, fmt.Errorf("error at row %d: %w", rowNum, err))
+		}
+
+		for colIdx, val := range cols {
+			if s.bounds != nil && !s.bounds.Contains(colIdx+1, rowNum) {
+				continue
+			}
+			if val == "" || !s.matcher(val) {
+				continue
+			}
+
+			// Claim a slot before sending so workers never exceed MaxResults
+			if s.maxResults > 0 && s.sent.Add(1) > s.maxResults {
+				return false
+			}
+			result := &SearchResult{
+				Sheet:      sheet,
+				Address:    FormatCellAddress(colIdx+1, rowNum),
+				Value:      val,
+				Row:        rowNum,
+				Col:        colIdx + 1,
+				sheetIndex: sheetIndex,
+			}
+			if s.query != nil {
+				n, _ := s.query.Match(val)
+				result.Number = &n
+			}
+			if s.opts.WithRow {
+				result.Context, result.ContextRange = rowContext(cols, colIdx, rowNum, s.opts.RowWindow)
+			}
+			if !s.send(ctx, SearchResultStream{Result: result}) {
+				return false
 			}
-			rows.Close()
 		}
-	}()
+	}
 
-	return ch, nil
+	if err := rows.Error(); err != nil {
+		return s.fail(ctx, fmt.Errorf("row iteration error in sheet %s: %w", sheet, err))
+	}
+	return true
+}
+
+// rowContext returns the values of a streamed row around the match at
+// colIdx, the whole row when window is 0, with the range they cover. cols
+// is the row already read by the scan, so nothing is read again.
+func rowContext(cols []string, colIdx, rowNum, window int) ([]string, string) {
+	start, end := 0, len(cols)
+	if window > 0 {
+		start = max(0, colIdx-window)
+		end = min(len(cols), colIdx+window+1)
+	}
+	r := CellRange{StartCol: start + 1, StartRow: rowNum, EndCol: end, EndRow: rowNum}
+	return cols[start:end], r.String()
+}
+
+// Count counts the cells matching pattern with the same options as Search,
+// keeping only a counter per sheet so memory stays constant however many
+// cells match. MaxResults is ignored: every match is counted. Sheets without
+// matches are left out of the breakdown.
+func Count(ctx context.Context, f *excelize.File, pattern string, opts SearchOptions) (*CountResult, error) {
+	opts.MaxResults = 0
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch, err := Search(ctx, f, pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CountResult{Pattern: pattern, Sheets: []SheetCount{}}
+	// Sheets are searched in parallel, so keep each sheet's workbook
+	// position to order the breakdown
+	positions := map[string]int{}
+	counts := map[string]int{}
+	for stream := range ch {
+		if stream.Err != nil {
+			return nil, stream.Err
+		}
+		if stream.Result == nil {
+			continue
+		}
+		positions[stream.Result.Sheet] = stream.Result.sheetIndex
+		counts[stream.Result.Sheet]++
+		result.Total++
+	}
+
+	for sheet, n := range counts {
+		result.Sheets = append(result.Sheets, SheetCount{Sheet: sheet, Matches: n})
+	}
+	sort.Slice(result.Sheets, func(i, j int) bool {
+		return positions[result.Sheets[i].Sheet] < positions[result.Sheets[j].Sheet]
+	})
+	return result, nil
 }
 
 // CollectSearchResults collects all search results into a slice
 func CollectSearchResults(ch <-chan SearchResultStream) ([]SearchResult, error) {
+	results, _, err := CollectSearchResultsWithBounds(ch)
+	return results, err
+}
+
+// CollectSearchResultsWithBounds collects all search results along with
+// the bounding range of the matches in each sheet (e.g. "B2:D5"), so a
+// single read per sheet covers every match. Results are sorted by sheet,
+// in workbook order, then by row and column.
+func CollectSearchResultsWithBounds(ch <-chan SearchResultStream) ([]SearchResult, map[string]string, error) {
 	var results []SearchResult
+	bounds := map[string]*CellRange{}
 	for stream := range ch {
 		if stream.Err != nil {
-			return nil, stream.Err
+			return nil, nil, stream.Err
+		}
+		if stream.Result == nil {
+			continue
 		}
-		if stream.Result != nil {
-			results = append(results, *stream.Result)
+		results = append(results, *stream.Result)
+
+		r := stream.Result
+		b, ok := bounds[r.Sheet]
+		if !ok {
+			bounds[r.Sheet] = &CellRange{StartCol: r.Col, StartRow: r.Row, EndCol: r.Col, EndRow: r.Row}
+			continue
 		}
+		b.StartCol = min(b.StartCol, r.Col)
+		b.StartRow = min(b.StartRow, r.Row)
+		b.EndCol = max(b.EndCol, r.Col)
+		b.EndRow = max(b.EndRow, r.Row)
+	}
+
+	// Sheets are searched in parallel, so results arrive interleaved
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.sheetIndex != b.sheetIndex {
+			return a.sheetIndex < b.sheetIndex
+		}
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		return a.Col < b.Col
+	})
+
+	ranges := make(map[string]string, len(bounds))
+	for sheet, b := range bounds {
+		ranges[sheet] = b.String()
 	}
-	return results, nil
+	return results, ranges, nil
 }
 
 // SearchSimple is a convenience function for simple searches
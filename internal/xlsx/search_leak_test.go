@@ -2,6 +2,7 @@ package xlsx
 
 import (
 	"context"
+	"fmt"
 	"runtime"
 	"testing"
 	"time"
@@ -105,3 +106,113 @@ func TestGoroutineLeakSearch(t *testing.T) {
 			leakedGoroutines, leakAttempts)
 	}
 }
+
+// createMultiSheetSearchFile writes sheets sheets of rows rows, every cell
+// of column A holding "needle"
+func createMultiSheetSearchFile(tb testing.TB, sheets, rows int) string {
+	tb.Helper()
+
+	path := tb.TempDir() + "/multi.xlsx"
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for s := 1; s <= sheets; s++ {
+		name := fmt.Sprintf("S%d", s)
+		if s == 1 {
+			if err := f.SetSheetName("Sheet1", name); err != nil {
+				tb.Fatalf("failed to rename sheet: %v", err)
+			}
+		} else if _, err := f.NewSheet(name); err != nil {
+			tb.Fatalf("failed to create sheet: %v", err)
+		}
+		sw, err := f.NewStreamWriter(name)
+		if err != nil {
+			tb.Fatalf("failed to create stream writer: %v", err)
+		}
+		for r := 1; r <= rows; r++ {
+			cell, _ := excelize.CoordinatesToCellName(1, r)
+			if err := sw.SetRow(cell, []any{"needle", r, "hay"}); err != nil {
+				tb.Fatalf("failed to write row: %v", err)
+			}
+		}
+		if err := sw.Flush(); err != nil {
+			tb.Fatalf("failed to flush: %v", err)
+		}
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		tb.Fatalf("failed to save test file: %v", err)
+	}
+	return path
+}
+
+// TestSearchConcurrentSheets checks that sheets searched in parallel give
+// ordered results, stay within MaxResults and leave no workers behind
+func TestSearchConcurrentSheets(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	path := createMultiSheetSearchFile(t, 6, 50)
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	results, err := SearchSimple(f, "needle", false)
+	if err != nil {
+		t.Fatalf("SearchSimple failed: %v", err)
+	}
+	if len(results) != 6*50 {
+		t.Fatalf("expected %d results, got %d", 6*50, len(results))
+	}
+	for i, r := range results {
+		want := fmt.Sprintf("S%d!A%d", i/50+1, i%50+1)
+		if got := r.Sheet + "!" + r.Address; got != want {
+			t.Fatalf("result %d: expected %s, got %s", i, want, got)
+		}
+	}
+
+	runtime.GC()
+	time.Sleep(100 * time.Millisecond)
+	baseline := runtime.NumGoroutine()
+
+	for range 10 {
+		ch, err := Search(context.Background(), f, "needle", SearchOptions{MaxResults: 3})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		results, err := CollectSearchResults(ch)
+		if err != nil {
+			t.Fatalf("CollectSearchResults failed: %v", err)
+		}
+		if len(results) != 3 {
+			t.Errorf("expected 3 results (max), got %d", len(results))
+		}
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if leaked := runtime.NumGoroutine() - baseline; leaked > 0 {
+		t.Errorf("%d goroutines left running after MaxResults was reached", leaked)
+	}
+}
+
+// BenchmarkSearchMultiSheet searches every sheet of a multi-sheet workbook
+func BenchmarkSearchMultiSheet(b *testing.B) {
+	path := createMultiSheetSearchFile(b, 8, 5000)
+	f, err := OpenFile(path)
+	if err != nil {
+		b.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	b.ResetTimer()
+	for range b.N {
+		ch, err := Search(context.Background(), f, "hay", SearchOptions{})
+		if err != nil {
+			b.Fatalf("Search failed: %v", err)
+		}
+		if _, err := CollectSearchResults(ch); err != nil {
+			b.Fatalf("CollectSearchResults failed: %v", err)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package xlsx
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/xuri/excelize/v2"
@@ -229,6 +232,322 @@ func TestSearchInvalidRegex(t *testing.T) {
 	}
 }
 
+func TestSearchFuzzy(t *testing.T) {
+	path := createSearchTestFile(t)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	// "Helo" is one edit from "hello" ignoring case, and far from the rest
+	ch, err := Search(context.Background(), f, "Helo", SearchOptions{
+		Fuzzy:           true,
+		CaseInsensitive: true,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	results, err := CollectSearchResults(ch)
+	if err != nil {
+		t.Fatalf("CollectSearchResults failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Address != "B1" {
+		t.Errorf("expected only B1 to match, got %+v", results)
+	}
+
+	// "Tst12" is two edits from "Test123": within the default, not within 1
+	for maxDist, want := range map[int]int{0: 1, 1: 0} {
+		ch, err := Search(context.Background(), f, "Tst12", SearchOptions{Fuzzy: true, MaxDistance: maxDist})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		results, err := CollectSearchResults(ch)
+		if err != nil {
+			t.Fatalf("CollectSearchResults failed: %v", err)
+		}
+		if len(results) != want {
+			t.Errorf("max distance %d: expected %d results, got %d", maxDist, want, len(results))
+		}
+	}
+
+	if _, err := Search(context.Background(), f, "x", SearchOptions{Fuzzy: true, Regex: true}); err == nil {
+		t.Error("expected error combining fuzzy and regex")
+	}
+}
+
+func TestSearchNumeric(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "numeric.xlsx")
+	f := excelize.NewFile()
+	if err := f.SetSheetRow("Sheet1", "A1", &[]any{"Amount", 999, 1000, 1500.5, "2000 units", 3000}); err != nil {
+		t.Fatalf("failed to write row: %v", err)
+	}
+	// A thousands separator format must not hide F1's number
+	style, err := f.NewStyle(&excelize.Style{NumFmt: 4})
+	if err != nil {
+		t.Fatalf("failed to create style: %v", err)
+	}
+	if err := f.SetCellStyle("Sheet1", "F1", "F1", style); err != nil {
+		t.Fatalf("failed to set style: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	f.Close()
+
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	ch, err := Search(context.Background(), f, ">1000", SearchOptions{Numeric: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	results, err := CollectSearchResults(ch)
+	if err != nil {
+		t.Fatalf("CollectSearchResults failed: %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		if r.Number == nil {
+			t.Fatalf("expected a number on %s", r.Address)
+		}
+		got = append(got, fmt.Sprintf("%s=%g", r.Address, *r.Number))
+	}
+	if want := []string{"D1=1500.5", "F1=3000"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if _, err := Search(context.Background(), f, "big", SearchOptions{Numeric: true}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter, got %v", err)
+	}
+	if _, err := Search(context.Background(), f, ">1", SearchOptions{Numeric: true, Regex: true}); err == nil {
+		t.Error("expected error combining numeric and regex")
+	}
+}
+
+func TestSearchRange(t *testing.T) {
+	path := createSearchTestFile(t)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	tests := map[string][]string{
+		"A2:A3": {"Sheet1!A3"}, // A1 and B1 are outside
+		"B:B":   {"Sheet1!B1"},
+		"A1:B1": {"Sheet1!A1", "Sheet1!B1", "Sheet2!A1"},
+	}
+	for rangeStr, want := range tests {
+		t.Run(rangeStr, func(t *testing.T) {
+			r, err := ParseRange(rangeStr)
+			if err != nil {
+				t.Fatalf("ParseRange failed: %v", err)
+			}
+			ch, err := Search(context.Background(), f, "hello", SearchOptions{CaseInsensitive: true, Range: r})
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			results, err := CollectSearchResults(ch)
+			if err != nil {
+				t.Fatalf("CollectSearchResults failed: %v", err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Sheet+"!"+r.Address)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestSearchWholeWord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.xlsx")
+	f := excelize.NewFile()
+	if err := f.SetSheetRow("Sheet1", "A1", &[]any{"cat", "category", "Cat food", "the cat.", "bobcat"}); err != nil {
+		t.Fatalf("failed to write row: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want []string
+	}{
+		{"case-sensitive", SearchOptions{WholeWord: true}, []string{"A1", "D1"}},
+		{"ignore case", SearchOptions{WholeWord: true, CaseInsensitive: true}, []string{"A1", "C1", "D1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, err := Search(context.Background(), f, "cat", tt.opts)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			results, err := CollectSearchResults(ch)
+			if err != nil {
+				t.Fatalf("CollectSearchResults failed: %v", err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Address)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := Search(context.Background(), f, "cat", SearchOptions{WholeWord: true, Regex: true}); err == nil {
+		t.Error("expected error combining whole-word and regex")
+	}
+}
+
+func TestSearchWholeWordPunctuation(t *testing.T) {
+	f, err := OpenFile(writeRowsFile(t, [][]any{
+		{"C++", "C++ code", "ABC++", "#N/A", "x#N/A", "f (x) = 1", "f(x)"},
+	}))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"C++", []string{"A1", "B1"}},
+		{"#N/A", []string{"D1"}},
+		{"(x)", []string{"F1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			ch, err := Search(context.Background(), f, tt.pattern, SearchOptions{WholeWord: true})
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			results, err := CollectSearchResults(ch)
+			if err != nil {
+				t.Fatalf("CollectSearchResults failed: %v", err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Address)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	path := createSearchTestFile(t)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	// MaxResults does not cap a count
+	result, err := Count(context.Background(), f, "hello", SearchOptions{CaseInsensitive: true, MaxResults: 1})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	want := []SheetCount{{Sheet: "Sheet1", Matches: 3}, {Sheet: "Sheet2", Matches: 1}}
+	if result.Total != 4 || !reflect.DeepEqual(result.Sheets, want) {
+		t.Errorf("expected total 4 and %v, got %+v", want, result)
+	}
+
+	result, err = Count(context.Background(), f, "nonexistent", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if result.Total != 0 || len(result.Sheets) != 0 {
+		t.Errorf("expected no matches, got %+v", result)
+	}
+
+	if _, err := Count(context.Background(), f, "[", SearchOptions{Regex: true}); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
+
+func TestSearchWithRow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "context.xlsx")
+	f := excelize.NewFile()
+	row := []any{"id-7", "Ada", "needle", "London", 42}
+	if err := f.SetSheetRow("Sheet1", "A3", &row); err != nil {
+		t.Fatalf("failed to write row: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	want, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatalf("failed to read rows: %v", err)
+	}
+
+	tests := []struct {
+		window    int
+		context   []string
+		rangeWant string
+	}{
+		{0, want[2], "A3:E3"},
+		{1, want[2][1:4], "B3:D3"},
+		{5, want[2], "A3:E3"},
+	}
+	for _, tt := range tests {
+		ch, err := Search(context.Background(), f, "needle", SearchOptions{WithRow: true, RowWindow: tt.window})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		results, err := CollectSearchResults(ch)
+		if err != nil {
+			t.Fatalf("CollectSearchResults failed: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		if !reflect.DeepEqual(results[0].Context, tt.context) || results[0].ContextRange != tt.rangeWant {
+			t.Errorf("window %d: expected %v (%s), got %v (%s)",
+				tt.window, tt.context, tt.rangeWant, results[0].Context, results[0].ContextRange)
+		}
+	}
+
+	// Context is off by default
+	results, err := SearchSimple(f, "needle", false)
+	if err != nil {
+		t.Fatalf("SearchSimple failed: %v", err)
+	}
+	if results[0].Context != nil {
+		t.Errorf("expected no context by default, got %v", results[0].Context)
+	}
+}
+
 func TestSearchResultFields(t *testing.T) {
 	path := createSearchTestFile(t)
 
@@ -345,3 +664,57 @@ func TestSearchWithEmptyCells(t *testing.T) {
 		t.Errorf("expected 1 result, got %d", len(results))
 	}
 }
+
+func TestCollectSearchResultsWithBounds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bounds.xlsx")
+	f := excelize.NewFile()
+	for sheet, cells := range map[string][]string{
+		"Sheet1": {"B2", "D5", "C3"},
+		"Other":  {"E7"},
+	} {
+		if sheet != "Sheet1" {
+			if _, err := f.NewSheet(sheet); err != nil {
+				t.Fatalf("failed to create sheet: %v", err)
+			}
+		}
+		for _, addr := range cells {
+			if err := f.SetCellValue(sheet, addr, "match"); err != nil {
+				t.Fatalf("failed to set cell value: %v", err)
+			}
+		}
+	}
+	if err := f.SetCellValue("Sheet1", "A1", "other"); err != nil {
+		t.Fatalf("failed to set cell value: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	ch, err := Search(context.Background(), f, "match", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	results, bounds, err := CollectSearchResultsWithBounds(ch)
+	if err != nil {
+		t.Fatalf("CollectSearchResultsWithBounds failed: %v", err)
+	}
+	if len(results) != 4 {
+		t.Errorf("expected 4 results, got %d", len(results))
+	}
+	if bounds["Sheet1"] != "B2:D5" {
+		t.Errorf("expected Sheet1 bounds B2:D5, got %q", bounds["Sheet1"])
+	}
+	if bounds["Other"] != "E7" {
+		t.Errorf("expected Other bounds E7, got %q", bounds["Other"])
+	}
+	if len(bounds) != 2 {
+		t.Errorf("expected bounds for 2 sheets, got %v", bounds)
+	}
+}
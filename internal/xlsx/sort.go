@@ -0,0 +1,238 @@
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxSortRows is the maximum number of rows SortRows will reorder
+const MaxSortRows = 100000
+
+// SortKey is one column to sort by. Column is a letter (B) or, when the
+// sheet has a header row, a header name (Age).
+type SortKey struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// ParseSortKeys parses a spec like "Age:desc,Name:asc" into sort keys.
+// The direction is optional and defaults to ascending.
+func ParseSortKeys(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key := SortKey{Column: part}
+		if idx := strings.LastIndex(part, ":"); idx >= 0 {
+			switch strings.ToLower(strings.TrimSpace(part[idx+1:])) {
+			case "asc":
+			case "desc":
+				key.Desc = true
+			default:
+				return nil, fmt.Errorf("invalid sort direction in %q (valid: asc, desc)", part)
+			}
+			key.Column = strings.TrimSpace(part[:idx])
+		}
+		if key.Column == "" {
+			return nil, fmt.Errorf("missing column in sort key %q", part)
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no sort keys given")
+	}
+	return keys, nil
+}
+
+// SortRows reorders the rows of a sheet by one or more columns and saves
+// atomically. With header set, the first row stays pinned at the top.
+// Numbers sort numerically and before text, text sorts case-insensitively,
+// and empty cells always sort last. Rows keep their styles; formulas move
+// with their row but their references are not adjusted.
+// Sorting an empty or single-row sheet is a no-op.
+func SortRows(path, sheet string, keys []SortKey, header bool) (*SortResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SortResult, error) {
+		return wb.SortRows(sheet, keys, header)
+	})
+}
+
+// SortRows reorders the rows of a sheet by one or more columns.
+// Enforces MaxSortRows limit.
+func (wb *Workbook) SortRows(sheet string, keys []SortKey, header bool) (*SortResult, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no sort keys given")
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SortResult{Success: true, Sheet: resolvedSheet}
+
+	bounds, err := sheetBounds(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	if bounds == nil {
+		return result, nil
+	}
+
+	cols, err := resolveSortColumns(wb.f, resolvedSheet, bounds, keys, header)
+	if err != nil {
+		return nil, err
+	}
+
+	region := *bounds
+	if header {
+		region.StartRow++
+	}
+	rowCount := region.EndRow - region.StartRow + 1
+	if rowCount <= 1 {
+		return result, nil
+	}
+	if rowCount > MaxSortRows {
+		return nil, fmt.Errorf("%w: attempting to sort %d rows, limit is %d",
+			ErrRowLimitExceeded, rowCount, MaxSortRows)
+	}
+
+	// 1. Read rows with their sort values
+	cells, err := copyRange(wb.f, resolvedSheet, &region)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sortRow, rowCount)
+	for i := range rows {
+		rows[i].keys = make([]string, len(cols))
+		for k, col := range cols {
+			addr := FormatCellAddress(col, region.StartRow+i)
+			value, err := wb.f.GetCellValue(resolvedSheet, addr, excelize.Options{RawCellValue: true})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cell %s: %w", addr, err)
+			}
+			rows[i].keys[k] = value
+		}
+	}
+	for _, cell := range cells {
+		rows[cell.row].cells = append(rows[cell.row].cells, cell)
+	}
+
+	// 2. Sort
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, key := range keys {
+			if c := compareSortValues(rows[i].keys[k], rows[j].keys[k], key.Desc); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	// 3. Clear and write rows back in their new order
+	if err := clearCells(wb.f, resolvedSheet, &region); err != nil {
+		return nil, err
+	}
+	var sorted []copiedCell
+	for i, row := range rows {
+		for _, cell := range row.cells {
+			cell.row = i
+			sorted = append(sorted, cell)
+		}
+	}
+	if err := pasteRange(wb.f, resolvedSheet, region.StartCol, region.StartRow, sorted); err != nil {
+		return nil, err
+	}
+
+	result.Range = region.String()
+	result.RowsSorted = rowCount
+	return result, nil
+}
+
+// sortRow is a row's copied cells and its values for each sort key
+type sortRow struct {
+	cells []copiedCell
+	keys  []string
+}
+
+// resolveSortColumns maps sort keys to column numbers, matching header
+// names first (case-insensitive) and then column letters
+func resolveSortColumns(f *excelize.File, sheet string, bounds *CellRange, keys []SortKey, header bool) ([]int, error) {
+	headers := map[string]int{}
+	if header {
+		for col := bounds.StartCol; col <= bounds.EndCol; col++ {
+			name, err := f.GetCellValue(sheet, FormatCellAddress(col, bounds.StartRow))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read header: %w", err)
+			}
+			name = strings.ToLower(strings.TrimSpace(name))
+			if _, exists := headers[name]; name != "" && !exists {
+				headers[name] = col
+			}
+		}
+	}
+
+	cols := make([]int, len(keys))
+	for i, key := range keys {
+		if col, ok := headers[strings.ToLower(strings.TrimSpace(key.Column))]; ok {
+			cols[i] = col
+			continue
+		}
+		col, err := ParseColumnName(key.Column)
+		if err != nil {
+			return nil, fmt.Errorf("unknown sort column %q: not a header or column letter", key.Column)
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// compareSortValues orders two cell values for a sort key. Empty values
+// go last in both directions; numbers come before text.
+func compareSortValues(a, b string, desc bool) int {
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	c := compareCellValues(a, b)
+	if desc {
+		return -c
+	}
+	return c
+}
+
+// compareCellValues compares numerically when both values are numbers
+// (as detected for writes) and case-insensitively otherwise
+func compareCellValues(a, b string) int {
+	aNum := detectValueType(a) == "number"
+	bNum := detectValueType(b) == "number"
+
+	switch {
+	case aNum && bNum:
+		x, _ := strconv.ParseFloat(a, 64)
+		y, _ := strconv.ParseFloat(b, 64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -0,0 +1,135 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func createSortTestFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "sort.xlsx")
+	rows := [][]any{
+		{"Bob", 9, "b"},
+		{"alice", 30, "a"},
+		{"Carol", 100, "c"},
+		{"Dave", nil, "d"},
+		{"Erin", 30, "e"},
+	}
+	if _, err := CreateFile(path, "Sheet1", []string{"Name", "Age", "Code"}, rows, false); err != nil {
+		t.Fatalf("CreateFile failed: %v", err)
+	}
+	return path
+}
+
+func readColumn(t *testing.T, path string, col, rows int) []string {
+	t.Helper()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	values := make([]string, rows)
+	for i := range values {
+		values[i], err = f.GetCellValue("Sheet1", FormatCellAddress(col, i+1))
+		if err != nil {
+			t.Fatalf("GetCellValue failed: %v", err)
+		}
+	}
+	return values
+}
+
+func TestSortRows(t *testing.T) {
+	path := createSortTestFile(t)
+
+	keys := []SortKey{{Column: "Age", Desc: true}, {Column: "name"}}
+	result, err := SortRows(path, "Sheet1", keys, true)
+	if err != nil {
+		t.Fatalf("SortRows failed: %v", err)
+	}
+	if result.RowsSorted != 5 || result.Range != "A2:C6" {
+		t.Errorf("expected 5 rows sorted in A2:C6, got %d in %s", result.RowsSorted, result.Range)
+	}
+
+	// Numeric descending (100 before 30 before 9), ties by name,
+	// empty age last, header pinned
+	want := []string{"Name", "Carol", "alice", "Erin", "Bob", "Dave"}
+	if got := readColumn(t, path, 1, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected names %v, got %v", want, got)
+	}
+	// Other columns move with their row
+	want = []string{"Code", "c", "a", "e", "b", "d"}
+	if got := readColumn(t, path, 3, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected codes %v, got %v", want, got)
+	}
+}
+
+func TestSortRowsByLetterNoHeader(t *testing.T) {
+	path := createSortTestFile(t)
+
+	if _, err := SortRows(path, "", []SortKey{{Column: "C", Desc: true}}, false); err != nil {
+		t.Fatalf("SortRows failed: %v", err)
+	}
+
+	want := []string{"e", "d", "Code", "c", "b", "a"}
+	if got := readColumn(t, path, 3, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected codes %v, got %v", want, got)
+	}
+}
+
+func TestSortRowsNoOp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "single.xlsx")
+	if _, err := CreateFile(path, "Sheet1", []string{"Name"}, [][]any{{"only"}}, false); err != nil {
+		t.Fatalf("CreateFile failed: %v", err)
+	}
+
+	result, err := SortRows(path, "Sheet1", []SortKey{{Column: "Name"}}, true)
+	if err != nil {
+		t.Fatalf("SortRows failed: %v", err)
+	}
+	if result.RowsSorted != 0 {
+		t.Errorf("expected no rows sorted, got %d", result.RowsSorted)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.xlsx")
+	if _, err := CreateFile(empty, "Sheet1", nil, nil, false); err != nil {
+		t.Fatalf("CreateFile failed: %v", err)
+	}
+	if _, err := SortRows(empty, "Sheet1", []SortKey{{Column: "A"}}, false); err != nil {
+		t.Errorf("expected sorting an empty sheet to succeed, got: %v", err)
+	}
+}
+
+func TestSortRowsErrors(t *testing.T) {
+	path := createSortTestFile(t)
+
+	if _, err := SortRows(path, "Sheet1", nil, true); err == nil {
+		t.Error("expected error for no sort keys")
+	}
+	if _, err := SortRows(path, "Sheet1", []SortKey{{Column: "Missing Header"}}, true); err == nil {
+		t.Error("expected error for unknown column")
+	}
+	if _, err := SortRows(path, "NoSuchSheet", []SortKey{{Column: "A"}}, true); err == nil {
+		t.Error("expected error for nonexistent sheet")
+	}
+}
+
+func TestParseSortKeys(t *testing.T) {
+	keys, err := ParseSortKeys("Age:desc, Name:asc,C")
+	if err != nil {
+		t.Fatalf("ParseSortKeys failed: %v", err)
+	}
+	want := []SortKey{{Column: "Age", Desc: true}, {Column: "Name"}, {Column: "C"}}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("expected %+v, got %+v", want, keys)
+	}
+
+	for _, spec := range []string{"", "Age:sideways", ":desc"} {
+		if _, err := ParseSortKeys(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
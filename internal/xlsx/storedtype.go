@@ -0,0 +1,195 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// storedTypes looks up the stored types of a sheet's cells in row order,
+// alongside a row stream that does not report them. It streams the sheet
+// part from disk, reading only cell references and types, unless f holds
+// parsed worksheets that may carry unsaved edits or was not opened from a
+// file; then it asks excelize, which parses the sheet.
+type storedTypes struct {
+	f     *excelize.File
+	sheet string
+
+	pkg  *zip.ReadCloser
+	part io.ReadCloser
+	d    *xml.Decoder
+	row  int            // last row read from the part
+	attr map[int]string // t attributes of row's cells by column
+	done bool           // the part has no more rows
+}
+
+// openStoredTypes prepares type lookups for a sheet. Close releases it.
+func openStoredTypes(f *excelize.File, sheet string) (*storedTypes, error) {
+	st := &storedTypes{f: f, sheet: sheet}
+	if f.Path == "" || worksheetsLoaded(f) {
+		return st, nil
+	}
+
+	pkg, err := zip.OpenReader(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	parts := make(map[string]*zip.File, len(pkg.File))
+	for _, zf := range pkg.File {
+		parts[zf.Name] = zf
+	}
+	sheetParts, err := workbookSheetParts(parts)
+	if err != nil {
+		pkg.Close()
+		return nil, err
+	}
+	zf, ok := parts[sheetParts[sheet]]
+	if !ok {
+		pkg.Close()
+		return nil, fmt.Errorf("%w: no part for sheet %s", ErrInvalidFormat, sheet)
+	}
+	part, err := zf.Open()
+	if err != nil {
+		pkg.Close()
+		return nil, fmt.Errorf("failed to open sheet %s: %w", sheet, err)
+	}
+	st.pkg, st.part, st.d = pkg, part, xml.NewDecoder(part)
+	return st, nil
+}
+
+// Close releases the sheet part, if one is open
+func (st *storedTypes) Close() error {
+	if st.pkg == nil {
+		return nil
+	}
+	st.part.Close()
+	return st.pkg.Close()
+}
+
+// value converts a cell's streamed raw value to the Go value that writes it
+// back with its stored type: a number, a bool or text. Text that looks like
+// a number stays text. An empty value is nil. Cells must be asked for in
+// row order.
+func (st *storedTypes) value(col, row int, raw string) (any, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	valueType, err := st.valueType(col, row, raw)
+	if err != nil {
+		return nil, err
+	}
+	switch valueType {
+	case "bool":
+		return raw == "1" || strings.EqualFold(raw, "true"), nil
+	case "number":
+		return csvValue(raw), nil
+	}
+	return raw, nil
+}
+
+// valueType maps a cell's stored type to a setCellWithType type
+func (st *storedTypes) valueType(col, row int, raw string) (string, error) {
+	if st.d == nil {
+		return copiedValueType(st.f, st.sheet, FormatCellAddress(col, row), raw)
+	}
+	if err := st.seek(row); err != nil {
+		return "", fmt.Errorf("failed to read cell types of %s: %w", st.sheet, err)
+	}
+	t := ""
+	if st.row == row {
+		t = st.attr[col]
+	}
+	switch t {
+	case "b":
+		return "bool", nil
+	case "", "n":
+		if InferCellType(raw) == "number" {
+			return "number", nil
+		}
+	}
+	return "string", nil
+}
+
+// seek reads the part up to the given row, keeping the types of the last
+// row read
+func (st *storedTypes) seek(row int) error {
+	for !st.done && st.row < row {
+		if err := st.nextRow(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextRow reads the next row element's cell types
+func (st *storedTypes) nextRow() error {
+	for {
+		tok, err := st.d.Token()
+		if errors.Is(err, io.EOF) {
+			st.done = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if el.Name.Local != "row" {
+				continue
+			}
+			rowNum := st.row + 1
+			if r := xmlAttr(el, "", "r"); r != "" {
+				if rowNum, err = strconv.Atoi(r); err != nil {
+					return fmt.Errorf("invalid row number %q", r)
+				}
+			}
+			st.row = rowNum
+			return st.readCells()
+		case xml.EndElement:
+			if el.Name.Local == "sheetData" {
+				st.done = true
+				return nil
+			}
+		}
+	}
+}
+
+// readCells reads the t attribute of each cell of the current row element
+func (st *storedTypes) readCells() error {
+	st.attr = make(map[int]string)
+	col := 0
+	for {
+		tok, err := st.d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if el.Name.Local != "c" {
+				continue
+			}
+			col++
+			if ref := xmlAttr(el, "", "r"); ref != "" {
+				if col, _, err = excelize.CellNameToCoordinates(ref); err != nil {
+					return err
+				}
+			}
+			if t := xmlAttr(el, "", "t"); t != "" {
+				st.attr[col] = t
+			}
+			if err := st.d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if el.Name.Local == "row" {
+				return nil
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package xlsx
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -13,16 +14,96 @@ type RowResult struct {
 	Err error
 }
 
+// StreamOptions configures how rows are streamed
+type StreamOptions struct {
+	SkipTypeDetection bool // Report every cell as "string" instead of inferring its type
+	RawValues         bool // Read stored values without number formats
+	MergedFill        bool // Give every cell of a merged region its anchor's value
+	VisibleOnly       bool // Skip hidden rows and drop the cells of hidden columns
+	Calc              bool // Evaluate formula cells live instead of using cached values (GetCellWithOptions only)
+	SkipEmpty         bool // Drop rows in which every cell is empty; the rest keep their row numbers
+	Trim              bool // Strip leading and trailing whitespace from every value
+}
+
+// skipRow reports whether a streamed row is dropped by SkipEmpty
+func (o StreamOptions) skipRow(cells []Cell) bool {
+	if !o.SkipEmpty {
+		return false
+	}
+	for _, cell := range cells {
+		if cell.Value != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// loadHidden loads the sheet's hidden rows and columns when VisibleOnly is set
+func (o StreamOptions) loadHidden(f *excelize.File, sheet string) (*hiddenIndex, error) {
+	if !o.VisibleOnly {
+		return nil, nil
+	}
+	hidden, _, err := loadHiddenIndex(f, sheet)
+	return hidden, err
+}
+
+// loadMerges loads the sheet's merged regions when MergedFill is set
+func (o StreamOptions) loadMerges(f *excelize.File, sheet string) (mergeIndex, error) {
+	if !o.MergedFill {
+		return nil, nil
+	}
+	return loadMergeIndex(f, sheet, o)
+}
+
+// columnOptions returns the excelize options for reading a row's columns
+func (o StreamOptions) columnOptions() excelize.Options {
+	return excelize.Options{RawCellValue: o.RawValues}
+}
+
+// newCell builds a Cell for a streamed value, inferring its type unless disabled.
+func newCell(col, row int, value string, opts StreamOptions) Cell {
+	if opts.Trim {
+		value = strings.TrimSpace(value)
+	}
+	cellType := "string"
+	if !opts.SkipTypeDetection {
+		cellType = InferCellType(value)
+	}
+	return Cell{
+		Address: FormatCellAddress(col, row),
+		Value:   value,
+		Type:    cellType,
+		Row:     row,
+		Col:     col,
+	}
+}
+
 // StreamRows streams rows from startRow to endRow (1-based, inclusive)
 // If endRow is 0, streams to end of sheet
 // Returns a channel that yields rows and closes when done
-// The context can be used to cancel the streaming operation
+// The context can be used to cancel the streaming operation. Callers that
+// stop reading before the channel is closed MUST cancel ctx, otherwise the
+// producer goroutine blocks forever on its next send.
 func StreamRows(ctx context.Context, f *excelize.File, sheet string, startRow, endRow int) (<-chan RowResult, error) {
+	return StreamRowsWithOptions(ctx, f, sheet, startRow, endRow, StreamOptions{})
+}
+
+// StreamRowsWithOptions is StreamRows with explicit streaming options
+func StreamRowsWithOptions(ctx context.Context, f *excelize.File, sheet string, startRow, endRow int, opts StreamOptions) (<-chan RowResult, error) {
 	resolvedSheet, err := ResolveSheetName(f, sheet)
 	if err != nil {
 		return nil, err
 	}
 
+	merges, err := opts.loadMerges(f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	hidden, err := opts.loadHidden(f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := f.Rows(resolvedSheet)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open row iterator: %w", err)
@@ -48,7 +129,11 @@ func StreamRows(ctx context.Context, f *excelize.File, sheet string, startRow, e
 				break
 			}
 
-			cols, err := rows.Columns()
+			if hidden.rowHidden(rowNum) {
+				continue
+			}
+
+			cols, err := rows.Columns(opts.columnOptions())
 			if err != nil {
 				select {
 				case <-ctx.Done():
@@ -60,13 +145,14 @@ func StreamRows(ctx context.Context, f *excelize.File, sheet string, startRow, e
 
 			cells := make([]Cell, len(cols))
 			for i, val := range cols {
-				cells[i] = Cell{
-					Address: FormatCellAddress(i+1, rowNum),
-					Value:   val,
-					Type:    "string", // Basic type, could enhance later
-					Row:     rowNum,
-					Col:     i + 1,
-				}
+				cells[i] = newCell(i+1, rowNum, val, opts)
+			}
+			if merges != nil {
+				cells = merges.fillRow(rowNum, cells, opts)
+			}
+			cells = hidden.visibleCells(cells)
+			if opts.skipRow(cells) {
+				continue
 			}
 
 			select {
@@ -88,9 +174,17 @@ func StreamRows(ctx context.Context, f *excelize.File, sheet string, startRow, e
 	return ch, nil
 }
 
-// StreamRange streams cells within a specified range (e.g., "A1:C10")
-// The context can be used to cancel the streaming operation
+// StreamRange streams cells within a specified range (e.g., "A1:C10").
+// Open ranges such as "A:C" or "A5:C" end at the last row holding data, and
+// whole-row ranges such as "2:5" give each row's cells up to its last value.
+// The context can be used to cancel the streaming operation. As with
+// StreamRows, callers that abandon the channel early must cancel ctx.
 func StreamRange(ctx context.Context, f *excelize.File, sheet, rangeStr string) (<-chan RowResult, error) {
+	return StreamRangeWithOptions(ctx, f, sheet, rangeStr, StreamOptions{})
+}
+
+// StreamRangeWithOptions is StreamRange with explicit streaming options
+func StreamRangeWithOptions(ctx context.Context, f *excelize.File, sheet, rangeStr string, opts StreamOptions) (<-chan RowResult, error) {
 	resolvedSheet, err := ResolveSheetName(f, sheet)
 	if err != nil {
 		return nil, err
@@ -101,6 +195,15 @@ func StreamRange(ctx context.Context, f *excelize.File, sheet, rangeStr string)
 		return nil, err
 	}
 
+	merges, err := opts.loadMerges(f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	hidden, err := opts.loadHidden(f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := f.Rows(resolvedSheet)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open row iterator: %w", err)
@@ -112,6 +215,30 @@ func StreamRange(ctx context.Context, f *excelize.File, sheet, rangeStr string)
 		defer close(ch)
 		defer rows.Close()
 
+		// send reports false once the consumer has gone
+		send := func(rowNum int, cols []string) bool {
+			if hidden.rowHidden(rowNum) {
+				return true
+			}
+			cells := rangeCells(cellRange, rowNum, cols, opts)
+			for i := range cells {
+				merges.fillCell(&cells[i], opts)
+			}
+			cells = hidden.visibleCells(cells)
+			if opts.skipRow(cells) {
+				return true
+			}
+			select {
+			case <-ctx.Done():
+				return false
+			case ch <- RowResult{Row: &Row{Number: rowNum, Cells: cells}}:
+				return true
+			}
+		}
+
+		// Without an end row the last row with data is not known until the
+		// end of the sheet, so blank rows wait for a later row with data
+		var pending []int
 		rowNum := 0
 		for rows.Next() {
 			rowNum++
@@ -122,11 +249,11 @@ func StreamRange(ctx context.Context, f *excelize.File, sheet, rangeStr string)
 			}
 
 			// Stop after range
-			if rowNum > cellRange.EndRow {
+			if cellRange.EndRow != 0 && rowNum > cellRange.EndRow {
 				break
 			}
 
-			cols, err := rows.Columns()
+			cols, err := rows.Columns(opts.columnOptions())
 			if err != nil {
 				select {
 				case <-ctx.Done():
@@ -136,26 +263,18 @@ func StreamRange(ctx context.Context, f *excelize.File, sheet, rangeStr string)
 				}
 			}
 
-			// Extract only columns in range
-			var cells []Cell
-			for colIdx := cellRange.StartCol; colIdx <= cellRange.EndCol; colIdx++ {
-				val := ""
-				if colIdx-1 < len(cols) {
-					val = cols[colIdx-1]
+			if cellRange.EndRow == 0 && len(cols) == 0 {
+				pending = append(pending, rowNum)
+				continue
+			}
+			for _, blank := range pending {
+				if !send(blank, nil) {
+					return
 				}
-				cells = append(cells, Cell{
-					Address: FormatCellAddress(colIdx, rowNum),
-					Value:   val,
-					Type:    "string",
-					Row:     rowNum,
-					Col:     colIdx,
-				})
 			}
-
-			select {
-			case <-ctx.Done():
+			pending = pending[:0]
+			if !send(rowNum, cols) {
 				return
-			case ch <- RowResult{Row: &Row{Number: rowNum, Cells: cells}}:
 			}
 		}
 
@@ -171,12 +290,67 @@ func StreamRange(ctx context.Context, f *excelize.File, sheet, rangeStr string)
 	return ch, nil
 }
 
+// rangeCells returns the cells of a row within r. A range without an end
+// column runs to the row's last value.
+func rangeCells(r *CellRange, rowNum int, cols []string, opts StreamOptions) []Cell {
+	endCol := r.EndCol
+	if endCol == 0 {
+		endCol = len(cols)
+	}
+	var cells []Cell
+	for colIdx := r.StartCol; colIdx <= endCol; colIdx++ {
+		val := ""
+		if colIdx-1 < len(cols) {
+			val = cols[colIdx-1]
+		}
+		cells = append(cells, newCell(colIdx, rowNum, val, opts))
+	}
+	return cells
+}
+
 // StreamHead streams the first n rows of a sheet
 func StreamHead(ctx context.Context, f *excelize.File, sheet string, n int) (<-chan RowResult, error) {
+	return StreamHeadWithOptions(ctx, f, sheet, n, StreamOptions{})
+}
+
+// StreamHeadWithOptions is StreamHead with explicit streaming options. With
+// SkipEmpty, blank rows do not count toward n: it streams the first n rows
+// that are kept.
+func StreamHeadWithOptions(ctx context.Context, f *excelize.File, sheet string, n int, opts StreamOptions) (<-chan RowResult, error) {
 	if n <= 0 {
 		n = 10 // Default to 10 rows
 	}
-	return StreamRows(ctx, f, sheet, 1, n)
+	if !opts.SkipEmpty {
+		return StreamRowsWithOptions(ctx, f, sheet, 1, n, opts)
+	}
+
+	// The last row is unknown, so stream to the end and stop after n rows
+	ctx, cancel := context.WithCancel(ctx)
+	in, err := StreamRowsWithOptions(ctx, f, sheet, 1, 0, opts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	out := make(chan RowResult)
+	go func() {
+		defer close(out)
+		defer cancel()
+		for sent := 0; sent < n; sent++ {
+			result, ok := <-in
+			if !ok {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- result:
+			}
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+	return out, nil
 }
 
 // rawRow stores raw column values before Cell construction
@@ -187,19 +361,39 @@ type rawRow struct {
 }
 
 // StreamTail returns the last n rows of a sheet
-// Unlike other streaming functions, this must read the entire sheet
-// and uses a ring buffer to keep memory bounded
+// With a trustworthy stored dimension it streams only from where the last
+// n rows start. Otherwise it must read the entire sheet and uses a ring
+// buffer to keep memory bounded
 // Memory optimization: only constructs Cell structs for the final N rows returned
 func StreamTail(f *excelize.File, sheet string, n int) ([]Row, error) {
+	return StreamTailWithOptions(f, sheet, n, StreamOptions{})
+}
+
+// StreamTailWithOptions is StreamTail with streaming options. Only
+// SkipTypeDetection, RawValues, Trim and SkipEmpty apply. With SkipEmpty,
+// blank rows do not count toward n: it returns the last n rows that are kept.
+func StreamTailWithOptions(f *excelize.File, sheet string, n int, opts StreamOptions) ([]Row, error) {
 	if n <= 0 {
 		n = 10 // Default to 10 rows
 	}
+	opts = StreamOptions{
+		SkipTypeDetection: opts.SkipTypeDetection,
+		RawValues:         opts.RawValues,
+		Trim:              opts.Trim,
+		SkipEmpty:         opts.SkipEmpty,
+	}
 
 	resolvedSheet, err := ResolveSheetName(f, sheet)
 	if err != nil {
 		return nil, err
 	}
 
+	if tail, ok, err := tailFromDimension(f, resolvedSheet, n, opts); err != nil {
+		return nil, err
+	} else if ok {
+		return tail, nil
+	}
+
 	rows, err := f.Rows(resolvedSheet)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open row iterator: %w", err)
@@ -214,15 +408,24 @@ func StreamTail(f *excelize.File, sheet string, n int) ([]Row, error) {
 	}
 	bufIdx := 0
 	totalRows := 0
+	// excelize copies passed options to the heap on every call, so only
+	// pass them when they differ from the defaults
+	var colOpts []excelize.Options
+	if opts.RawValues {
+		colOpts = []excelize.Options{opts.columnOptions()}
+	}
 
 	rowNum := 0
 	for rows.Next() {
 		rowNum++
 
-		cols, err := rows.Columns()
+		cols, err := rows.Columns(colOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("error reading row %d: %w", rowNum, err)
 		}
+		if opts.SkipEmpty && blankValues(cols, opts.Trim) {
+			continue
+		}
 
 		// Reuse the slice in the ring buffer position, but ensure capacity
 		// This way we only allocate N slices total, not one per row
@@ -262,35 +465,81 @@ func StreamTail(f *excelize.File, sheet string, n int) ([]Row, error) {
 	if totalRows < n {
 		// Didn't fill the buffer, construct Cells from start
 		for i := 0; i < totalRows; i++ {
-			result[i] = constructRow(buffer[i])
+			result[i] = constructRow(buffer[i], opts)
 		}
 	} else {
 		// Buffer is full, read from bufIdx (oldest) to end, then start to bufIdx
 		// Now construct Cell structs ONLY for the N rows we're returning
 		for i := 0; i < n; i++ {
-			result[i] = constructRow(buffer[(bufIdx+i)%n])
+			result[i] = constructRow(buffer[(bufIdx+i)%n], opts)
 		}
 	}
 
 	return result, nil
 }
 
+// tailFromDimension reads the last n rows starting at the row the stored
+// dimension puts n rows from the end. Rows past the dimension are still read,
+// so a dimension that understates the sheet only costs extra rows. It reports
+// false when the dimension overstates the sheet and fewer than n rows follow
+// the start, in which case the caller must scan the whole sheet.
+func tailFromDimension(f *excelize.File, sheet string, n int, opts StreamOptions) ([]Row, bool, error) {
+	bounds, ok := storedDimension(f, sheet)
+	if !ok {
+		return nil, false, nil
+	}
+	startRow := max(bounds.EndRow-n+1, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := StreamRowsWithOptions(ctx, f, sheet, startRow, 0, opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tail := make([]Row, 0, n)
+	for result := range ch {
+		if result.Err != nil {
+			return nil, false, result.Err
+		}
+		if len(tail) == n {
+			copy(tail, tail[1:])
+			tail = tail[:n-1]
+		}
+		tail = append(tail, *result.Row)
+	}
+
+	if len(tail) < n && startRow > 1 {
+		return nil, false, nil
+	}
+	return tail, true, nil
+}
+
 // constructRow builds a Row with Cell structs from raw values
 // Only called for rows that will be returned to the caller
-func constructRow(raw rawRow) Row {
+func constructRow(raw rawRow, opts StreamOptions) Row {
 	cells := make([]Cell, len(raw.values))
 	for i, val := range raw.values {
-		cells[i] = Cell{
-			Address: FormatCellAddress(i+1, raw.number),
-			Value:   val,
-			Type:    "string",
-			Row:     raw.number,
-			Col:     i + 1,
-		}
+		cells[i] = newCell(i+1, raw.number, val, opts)
 	}
 	return Row{Number: raw.number, Cells: cells}
 }
 
+// blankValues reports whether every raw value of a row is empty, ignoring
+// surrounding whitespace when trim is set
+func blankValues(values []string, trim bool) bool {
+	for _, v := range values {
+		if trim {
+			v = strings.TrimSpace(v)
+		}
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
+
 // CollectRows collects all rows from a channel into a slice
 // Useful for small datasets or when you need all rows in memory
 func CollectRows(ch <-chan RowResult) ([]Row, error) {
@@ -332,6 +581,44 @@ func CollectRowsWithLimit(ch <-chan RowResult, limit int) ([]Row, int, bool, err
 	return rows, total, truncated, nil
 }
 
+// CollectRowsAndCancel collects up to limit rows from a channel and then
+// calls cancel so the producer goroutine exits without scanning the rest
+// of the sheet. It reads at most one row past the limit to report truncation.
+// Returns: (rows, truncated, error)
+func CollectRowsAndCancel(ch <-chan RowResult, limit int, cancel context.CancelFunc) ([]Row, bool, error) {
+	rows, _, truncated, err := CollectPageAndCancel(ch, 0, limit, cancel)
+	return rows, truncated, err
+}
+
+// CollectPageAndCancel is CollectRowsAndCancel for one page: it skips the
+// first offset rows, then collects up to limit rows (no limit when <= 0).
+// Returns: (rows, scanned, truncated, error)
+// - scanned: rows read from the channel, including skipped ones
+// - truncated: true if at least one more row follows the page
+func CollectPageAndCancel(ch <-chan RowResult, offset, limit int, cancel context.CancelFunc) ([]Row, int, bool, error) {
+	defer cancel()
+
+	var rows []Row
+	scanned := 0
+	for result := range ch {
+		if result.Err != nil {
+			return nil, scanned, false, result.Err
+		}
+		if result.Row == nil {
+			continue
+		}
+		scanned++
+		if scanned <= offset {
+			continue
+		}
+		if limit > 0 && len(rows) >= limit {
+			return rows, scanned, true, nil
+		}
+		rows = append(rows, *result.Row)
+	}
+	return rows, scanned, false, nil
+}
+
 // RowsToStringSlice converts rows to [][]string for output formatting
 func RowsToStringSlice(rows []Row) [][]string {
 	result := make([][]string, len(rows))
@@ -344,6 +631,63 @@ func RowsToStringSlice(rows []Row) [][]string {
 	return result
 }
 
+// RowsToCells extracts the cells of each row for typed output formatting
+func RowsToCells(rows []Row) [][]Cell {
+	result := make([][]Cell, len(rows))
+	for i, row := range rows {
+		result[i] = row.Cells
+	}
+	return result
+}
+
+// PadRows pads every row with empty cells to the widest row's cell count,
+// producing a rectangular grid. Rows are modified in place and returned.
+func PadRows(rows []Row) []Row {
+	width := 0
+	startCol := 0
+	for _, row := range rows {
+		width = max(width, len(row.Cells))
+		if len(row.Cells) > 0 && (startCol == 0 || row.Cells[0].Col < startCol) {
+			startCol = row.Cells[0].Col
+		}
+	}
+	if startCol == 0 {
+		startCol = 1
+	}
+
+	for i := range rows {
+		for n := len(rows[i].Cells); n < width; n++ {
+			col := startCol + n
+			if n > 0 {
+				col = rows[i].Cells[n-1].Col + 1
+			}
+			rows[i].Cells = append(rows[i].Cells, Cell{
+				Address: FormatCellAddress(col, rows[i].Number),
+				Type:    "empty",
+				Row:     rows[i].Number,
+				Col:     col,
+			})
+		}
+	}
+	return rows
+}
+
+// LimitColumns truncates every row to its first n cells, reporting whether
+// any row was cut. n <= 0 means no limit. Rows are modified in place.
+func LimitColumns(rows []Row, n int) ([]Row, bool) {
+	truncated := false
+	if n <= 0 {
+		return rows, truncated
+	}
+	for i := range rows {
+		if len(rows[i].Cells) > n {
+			rows[i].Cells = rows[i].Cells[:n]
+			truncated = true
+		}
+	}
+	return rows, truncated
+}
+
 // StreamRowsToStrings is a convenience function that collects and converts
 func StreamRowsToStrings(ctx context.Context, f *excelize.File, sheet string, startRow, endRow int) ([][]string, error) {
 	ch, err := StreamRows(ctx, f, sheet, startRow, endRow)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/xuri/excelize/v2"
@@ -142,6 +143,98 @@ func TestStreamRange(t *testing.T) {
 	}
 }
 
+func TestStreamRangeOpen(t *testing.T) {
+	path := createLargeTestFile(t, 50)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		rangeStr       string
+		rows, cells    int
+		first, lastRow string
+	}{
+		{"B:C", 50, 2, "B1", "B50"},
+		{"2:5", 4, 3, "A2", "A5"},
+		{"A45:B", 6, 2, "A45", "A50"},
+		{"C48:A", 3, 3, "A48", "A50"},
+		{"A60:C", 0, 0, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rangeStr, func(t *testing.T) {
+			ch, err := StreamRange(context.Background(), f, "Sheet1", tt.rangeStr)
+			if err != nil {
+				t.Fatalf("StreamRange failed: %v", err)
+			}
+			rows, err := CollectRows(ch)
+			if err != nil {
+				t.Fatalf("CollectRows failed: %v", err)
+			}
+			if len(rows) != tt.rows {
+				t.Fatalf("expected %d rows, got %d", tt.rows, len(rows))
+			}
+			if tt.rows == 0 {
+				return
+			}
+			if len(rows[0].Cells) != tt.cells {
+				t.Errorf("expected %d cells per row, got %d", tt.cells, len(rows[0].Cells))
+			}
+			if got := rows[0].Cells[0].Address; got != tt.first {
+				t.Errorf("expected first cell %s, got %s", tt.first, got)
+			}
+			if got := rows[len(rows)-1].Cells[0].Address; got != tt.lastRow {
+				t.Errorf("expected last row to start at %s, got %s", tt.lastRow, got)
+			}
+		})
+	}
+}
+
+func TestStreamRangeOpenTrailingBlankRows(t *testing.T) {
+	// Rows 1-3 hold data, then formatted blank rows follow
+	path := createDimensionTestFile(t, 3, 4)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+	// A blank row between data rows is kept
+	if err := f.SetCellValue("Sheet1", "A5", "after gap"); err != nil {
+		t.Fatalf("SetCellValue failed: %v", err)
+	}
+	staged := filepath.Join(t.TempDir(), "gap.xlsx")
+	if err := f.SaveAs(staged); err != nil {
+		t.Fatalf("SaveAs failed: %v", err)
+	}
+	g, err := OpenFile(staged)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer g.Close()
+
+	for rangeStr, want := range map[string][]int{"A:B": {1, 2, 3, 4, 5}, "A3:B": {3, 4, 5}} {
+		ch, err := StreamRange(context.Background(), g, "Sheet1", rangeStr)
+		if err != nil {
+			t.Fatalf("StreamRange(%s) failed: %v", rangeStr, err)
+		}
+		rows, err := CollectRows(ch)
+		if err != nil {
+			t.Fatalf("CollectRows failed: %v", err)
+		}
+		var got []int
+		for _, row := range rows {
+			got = append(got, row.Number)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("StreamRange(%s) rows = %v, want %v", rangeStr, got, want)
+		}
+	}
+}
+
 func TestStreamRangeSingleCell(t *testing.T) {
 	path := createLargeTestFile(t, 10)
 
@@ -340,6 +433,64 @@ func TestStreamTailEmptySheet(t *testing.T) {
 	}
 }
 
+func TestStreamTailDimension(t *testing.T) {
+	tests := []struct {
+		name string
+		dim  string
+	}{
+		{"accurate", "A1:B20"},
+		{"understated", "A1:B10"},
+		{"overstated", "A1:B50"},
+		{"placeholder", "A1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tail.xlsx")
+			f := excelize.NewFile()
+			for i := 1; i <= 20; i++ {
+				if err := f.SetSheetRow("Sheet1", FormatCellAddress(1, i), &[]any{i, fmt.Sprintf("row%d", i)}); err != nil {
+					t.Fatalf("failed to set row %d: %v", i, err)
+				}
+			}
+			if err := f.SetSheetDimension("Sheet1", tt.dim); err != nil {
+				t.Fatalf("failed to set dimension: %v", err)
+			}
+			if err := f.SaveAs(path); err != nil {
+				t.Fatalf("failed to save file: %v", err)
+			}
+			f.Close()
+
+			f2, err := OpenFile(path)
+			if err != nil {
+				t.Fatalf("OpenFile failed: %v", err)
+			}
+			defer f2.Close()
+
+			rows, err := StreamTail(f2, "Sheet1", 5)
+			if err != nil {
+				t.Fatalf("StreamTail failed: %v", err)
+			}
+			if len(rows) != 5 {
+				t.Fatalf("expected 5 rows, got %d", len(rows))
+			}
+			for i, row := range rows {
+				if want := 16 + i; row.Number != want || row.Cells[1].Value != fmt.Sprintf("row%d", want) {
+					t.Errorf("row %d: expected row%d, got %d %q", i, want, row.Number, row.Cells[1].Value)
+				}
+			}
+
+			all, err := StreamTail(f2, "Sheet1", 50)
+			if err != nil {
+				t.Fatalf("StreamTail failed: %v", err)
+			}
+			if len(all) != 20 {
+				t.Errorf("expected all 20 rows when n exceeds total, got %d", len(all))
+			}
+		})
+	}
+}
+
 func TestRowsToStringSlice(t *testing.T) {
 	rows := []Row{
 		{Number: 1, Cells: []Cell{{Value: "a"}, {Value: "b"}}},
@@ -361,6 +512,74 @@ func TestRowsToStringSlice(t *testing.T) {
 	}
 }
 
+func TestPadRows(t *testing.T) {
+	rows := []Row{
+		{Number: 1, Cells: []Cell{
+			{Value: "a", Col: 1, Row: 1}, {Value: "b", Col: 2, Row: 1},
+		}},
+		{Number: 2, Cells: []Cell{
+			{Value: "c", Col: 1, Row: 2}, {Value: "d", Col: 2, Row: 2},
+			{Value: "e", Col: 3, Row: 2}, {Value: "f", Col: 4, Row: 2},
+		}},
+		{Number: 3},
+	}
+
+	result := PadRows(rows)
+
+	for _, row := range result {
+		if len(row.Cells) != 4 {
+			t.Errorf("row %d: expected width 4, got %d", row.Number, len(row.Cells))
+		}
+	}
+
+	padded := result[0].Cells[3]
+	if padded.Value != "" || padded.Address != "D1" || padded.Col != 4 {
+		t.Errorf("unexpected padded cell: %+v", padded)
+	}
+	if result[2].Cells[0].Address != "A3" {
+		t.Errorf("expected empty row padding to start at A3, got %s", result[2].Cells[0].Address)
+	}
+}
+
+func TestLimitColumns(t *testing.T) {
+	path := createTestFileWithWideRows(t, 5, 10)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	ch, err := StreamRows(context.Background(), f, "Sheet1", 0, 0)
+	if err != nil {
+		t.Fatalf("StreamRows failed: %v", err)
+	}
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+
+	rows, truncated := LimitColumns(rows, 3)
+	if !truncated {
+		t.Error("expected truncated=true")
+	}
+	for _, row := range rows {
+		if len(row.Cells) != 3 {
+			t.Fatalf("row %d: expected 3 columns, got %d", row.Number, len(row.Cells))
+		}
+		if last := row.Cells[2]; last.Col != 3 || last.Value != fmt.Sprintf("R%dC3", row.Number) {
+			t.Errorf("row %d: unexpected third cell %+v", row.Number, last)
+		}
+	}
+
+	if _, truncated := LimitColumns(rows, 0); truncated {
+		t.Error("expected no truncation without a limit")
+	}
+	if _, truncated := LimitColumns(rows, 3); truncated {
+		t.Error("expected no truncation when rows already fit")
+	}
+}
+
 func TestStreamRowsToStrings(t *testing.T) {
 	path := createLargeTestFile(t, 10)
 
@@ -502,6 +721,57 @@ func TestCollectRowsWithLimitNoTruncation(t *testing.T) {
 	}
 }
 
+func TestCollectPageAndCancel(t *testing.T) {
+	tests := []struct {
+		name          string
+		offset, limit int
+		wantFirst     int
+		wantRows      int
+		wantScanned   int
+		wantTruncated bool
+	}{
+		{"first page", 0, 4, 1, 4, 5, true},
+		{"middle page", 4, 4, 5, 4, 9, true},
+		{"last page", 8, 4, 9, 2, 10, false},
+		{"past the end", 20, 4, 0, 0, 10, false},
+		{"no limit", 3, 0, 4, 7, 10, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan RowResult)
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				defer close(ch)
+				for i := 1; i <= 10; i++ {
+					select {
+					case <-ctx.Done():
+						return
+					case ch <- RowResult{Row: &Row{Number: i}}:
+					}
+				}
+			}()
+
+			rows, scanned, truncated, err := CollectPageAndCancel(ch, tt.offset, tt.limit, cancel)
+			if err != nil {
+				t.Fatalf("CollectPageAndCancel failed: %v", err)
+			}
+			if len(rows) != tt.wantRows {
+				t.Fatalf("expected %d rows, got %d", tt.wantRows, len(rows))
+			}
+			if len(rows) > 0 && rows[0].Number != tt.wantFirst {
+				t.Errorf("expected first row %d, got %d", tt.wantFirst, rows[0].Number)
+			}
+			if scanned != tt.wantScanned {
+				t.Errorf("expected %d scanned, got %d", tt.wantScanned, scanned)
+			}
+			if truncated != tt.wantTruncated {
+				t.Errorf("expected truncated %v, got %v", tt.wantTruncated, truncated)
+			}
+		})
+	}
+}
+
 func TestCollectRowsWithLimitError(t *testing.T) {
 	// Test error handling with limits
 	ch := make(chan RowResult)
@@ -522,6 +792,46 @@ func TestCollectRowsWithLimitError(t *testing.T) {
 	}
 }
 
+func TestCollectRowsAndCancel(t *testing.T) {
+	ch := make(chan RowResult, 5)
+	for i := 1; i <= 5; i++ {
+		ch <- RowResult{Row: &Row{Number: i}}
+	}
+	close(ch)
+
+	canceled := false
+	cancel := func() { canceled = true }
+
+	rows, truncated, err := CollectRowsAndCancel(ch, 3, cancel)
+	if err != nil {
+		t.Fatalf("CollectRowsAndCancel failed: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Errorf("expected 3 rows, got %d", len(rows))
+	}
+	if !truncated {
+		t.Error("expected truncated to be true")
+	}
+	if !canceled {
+		t.Error("expected cancel to be called")
+	}
+
+	// Exactly at the limit is not truncated
+	ch2 := make(chan RowResult, 3)
+	for i := 1; i <= 3; i++ {
+		ch2 <- RowResult{Row: &Row{Number: i}}
+	}
+	close(ch2)
+
+	rows, truncated, err = CollectRowsAndCancel(ch2, 3, func() {})
+	if err != nil {
+		t.Fatalf("CollectRowsAndCancel failed: %v", err)
+	}
+	if len(rows) != 3 || truncated {
+		t.Errorf("expected 3 rows without truncation, got %d (truncated=%v)", len(rows), truncated)
+	}
+}
+
 func TestStreamRowsDefaultSheet(t *testing.T) {
 	path := createLargeTestFile(t, 10)
 
@@ -547,6 +857,191 @@ func TestStreamRowsDefaultSheet(t *testing.T) {
 	}
 }
 
+func TestStreamRowsCellTypes(t *testing.T) {
+	path := createTestFile(t)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	ch, err := StreamRows(context.Background(), f, "Sheet1", 1, 2)
+	if err != nil {
+		t.Fatalf("StreamRows failed: %v", err)
+	}
+
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if got := rows[0].Cells[0].Type; got != "string" {
+		t.Errorf("A1: expected type string, got %q", got)
+	}
+	if got := rows[1].Cells[1].Type; got != "number" {
+		t.Errorf("B2: expected type number, got %q", got)
+	}
+}
+
+func TestStreamRowsSkipTypeDetection(t *testing.T) {
+	path := createLargeTestFile(t, 5)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	opts := StreamOptions{SkipTypeDetection: true}
+	ch, err := StreamRowsWithOptions(context.Background(), f, "Sheet1", 1, 5, opts)
+	if err != nil {
+		t.Fatalf("StreamRowsWithOptions failed: %v", err)
+	}
+
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+
+	for _, row := range rows {
+		for _, cell := range row.Cells {
+			if cell.Type != "string" {
+				t.Errorf("%s: expected type string with detection skipped, got %q", cell.Address, cell.Type)
+			}
+		}
+	}
+}
+
+func TestStreamSkipEmptyAndTrim(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blanks.xlsx")
+	f := excelize.NewFile()
+	// Row 3 holds only whitespace, row 5 nothing at all
+	for addr, v := range map[string]any{"A1": "Name", "B1": "Qty", "A2": "  a  ", "B2": " 7 ", "A3": "   ", "A4": "b", "A6": "c"} {
+		if err := f.SetCellValue("Sheet1", addr, v); err != nil {
+			t.Fatalf("failed to set %s: %v", addr, err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	numbers := func(rows []Row) []int {
+		var n []int
+		for _, row := range rows {
+			n = append(n, row.Number)
+		}
+		return n
+	}
+	collect := func(ch <-chan RowResult, err error) []Row {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("stream failed: %v", err)
+		}
+		rows, err := CollectRows(ch)
+		if err != nil {
+			t.Fatalf("CollectRows failed: %v", err)
+		}
+		return rows
+	}
+	ctx := context.Background()
+
+	rows := collect(StreamRowsWithOptions(ctx, f, "Sheet1", 0, 0, StreamOptions{SkipEmpty: true}))
+	if got := numbers(rows); fmt.Sprint(got) != "[1 2 3 4 6]" {
+		t.Errorf("SkipEmpty: expected rows [1 2 3 4 6], got %v", got)
+	}
+
+	opts := StreamOptions{SkipEmpty: true, Trim: true}
+	rows = collect(StreamRowsWithOptions(ctx, f, "Sheet1", 0, 0, opts))
+	if got := numbers(rows); fmt.Sprint(got) != "[1 2 4 6]" {
+		t.Errorf("SkipEmpty with Trim: expected rows [1 2 4 6], got %v", got)
+	}
+	if rows[1].Cells[0].Value != "a" || rows[1].Cells[1].Value != "7" || rows[1].Cells[1].Type != "number" {
+		t.Errorf("expected trimmed values, got %+v", rows[1].Cells)
+	}
+
+	rows = collect(StreamRangeWithOptions(ctx, f, "Sheet1", "A2:B5", opts))
+	if got := numbers(rows); fmt.Sprint(got) != "[2 4]" {
+		t.Errorf("range: expected rows [2 4], got %v", got)
+	}
+
+	// Blank rows do not count toward n
+	rows = collect(StreamHeadWithOptions(ctx, f, "Sheet1", 3, opts))
+	if got := numbers(rows); fmt.Sprint(got) != "[1 2 4]" {
+		t.Errorf("head: expected rows [1 2 4], got %v", got)
+	}
+	rows, err = StreamTailWithOptions(f, "Sheet1", 3, opts)
+	if err != nil {
+		t.Fatalf("StreamTailWithOptions failed: %v", err)
+	}
+	if got := numbers(rows); fmt.Sprint(got) != "[2 4 6]" {
+		t.Errorf("tail: expected rows [2 4 6], got %v", got)
+	}
+	if rows[0].Cells[0].Value != "a" {
+		t.Errorf("tail: expected trimmed value, got %q", rows[0].Cells[0].Value)
+	}
+}
+
+func TestStreamRangeCellTypes(t *testing.T) {
+	path := createLargeTestFile(t, 5)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	ch, err := StreamRange(context.Background(), f, "Sheet1", "A1:B2")
+	if err != nil {
+		t.Fatalf("StreamRange failed: %v", err)
+	}
+
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+
+	for _, row := range rows {
+		for _, cell := range row.Cells {
+			if cell.Type != "number" {
+				t.Errorf("%s: expected type number, got %q", cell.Address, cell.Type)
+			}
+		}
+	}
+}
+
+func TestInferCellType(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", "empty"},
+		{"42", "number"},
+		{"-3.5", "number"},
+		{"TRUE", "bool"},
+		{"false", "bool"},
+		{"hello", "string"},
+		{"NaN", "string"},
+		{"Inf", "string"},
+	}
+
+	for _, tt := range tests {
+		if got := InferCellType(tt.value); got != tt.want {
+			t.Errorf("InferCellType(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
 // Benchmark tests
 func BenchmarkStreamRows(b *testing.B) {
 	path := createLargeTestFile(&testing.T{}, 1000)
@@ -0,0 +1,167 @@
+package xlsx
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// hexColorPattern matches colors like #1F4E79 or 1F4E79
+var hexColorPattern = regexp.MustCompile(`^#?[0-9A-Fa-f]{6}$`)
+
+// Alignment keywords accepted by StyleSpec
+var (
+	horizontalAlignments = []string{"general", "left", "center", "right", "fill", "justify", "centerContinuous", "distributed"}
+	verticalAlignments   = []string{"top", "center", "bottom", "justify", "distributed"}
+)
+
+// StyleSpec describes style changes to apply to cells. Fields left empty
+// (or nil) keep the cell's existing setting.
+type StyleSpec struct {
+	Bold         *bool  `json:"bold,omitempty"`
+	Italic       *bool  `json:"italic,omitempty"`
+	FontColor    string `json:"font_color,omitempty"`    // Hex color, e.g. #1F4E79
+	Fill         string `json:"fill,omitempty"`          // Background hex color, e.g. #D9E1F2
+	Horizontal   string `json:"horizontal,omitempty"`    // left, center, right, ...
+	Vertical     string `json:"vertical,omitempty"`      // top, center, bottom, ...
+	NumberFormat string `json:"number_format,omitempty"` // Excel number format, e.g. #,##0.00
+}
+
+// Validate checks colors and alignment keywords
+func (s StyleSpec) Validate() error {
+	if s.Bold == nil && s.Italic == nil && s.FontColor == "" && s.Fill == "" &&
+		s.Horizontal == "" && s.Vertical == "" && s.NumberFormat == "" {
+		return fmt.Errorf("style has no properties to set")
+	}
+	for name, color := range map[string]string{"font_color": s.FontColor, "fill": s.Fill} {
+		if color != "" && !hexColorPattern.MatchString(color) {
+			return fmt.Errorf("invalid %s %q: expected a hex color like #1F4E79", name, color)
+		}
+	}
+	if s.Horizontal != "" && !slices.Contains(horizontalAlignments, s.Horizontal) {
+		return fmt.Errorf("unknown horizontal alignment %q (valid: %s)",
+			s.Horizontal, strings.Join(horizontalAlignments, ", "))
+	}
+	if s.Vertical != "" && !slices.Contains(verticalAlignments, s.Vertical) {
+		return fmt.Errorf("unknown vertical alignment %q (valid: %s)",
+			s.Vertical, strings.Join(verticalAlignments, ", "))
+	}
+	return nil
+}
+
+// apply overlays the spec onto an existing style
+func (s StyleSpec) apply(style *excelize.Style) {
+	if s.Bold != nil || s.Italic != nil || s.FontColor != "" {
+		if style.Font == nil {
+			style.Font = &excelize.Font{}
+		}
+		if s.Bold != nil {
+			style.Font.Bold = *s.Bold
+		}
+		if s.Italic != nil {
+			style.Font.Italic = *s.Italic
+		}
+		if s.FontColor != "" {
+			// Drop theme and indexed colors so the explicit color wins
+			style.Font.Color = normalizeHexColor(s.FontColor)
+			style.Font.ColorTheme = nil
+			style.Font.ColorIndexed = 0
+		}
+	}
+	if s.Fill != "" {
+		style.Fill = excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{normalizeHexColor(s.Fill)}}
+	}
+	if s.Horizontal != "" || s.Vertical != "" {
+		if style.Alignment == nil {
+			style.Alignment = &excelize.Alignment{}
+		}
+		if s.Horizontal != "" {
+			style.Alignment.Horizontal = s.Horizontal
+		}
+		if s.Vertical != "" {
+			style.Alignment.Vertical = s.Vertical
+		}
+	}
+	if s.NumberFormat != "" {
+		format := s.NumberFormat
+		style.NumFmt = 0
+		style.CustomNumFmt = &format
+	}
+}
+
+// SetCellStyle applies a style to every cell in a range and saves
+// atomically. Only the properties set in the spec change; the rest of each
+// cell's existing style is kept.
+// Enforces MaxWriteRangeCells limit.
+func SetCellStyle(path, sheet, rangeStr string, spec StyleSpec) (*StyleResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*StyleResult, error) {
+		return wb.SetCellStyle(sheet, rangeStr, spec)
+	})
+}
+
+// SetCellStyle applies a style to every cell in a range.
+// Enforces MaxWriteRangeCells limit.
+func (wb *Workbook) SetCellStyle(sheet, rangeStr string, spec StyleSpec) (*StyleResult, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	cellRange, err := parseClosedRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	totalCells := (cellRange.EndCol - cellRange.StartCol + 1) * (cellRange.EndRow - cellRange.StartRow + 1)
+	if totalCells > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to style %d cells, limit is %d",
+			ErrCellLimitExceeded, totalCells, MaxWriteRangeCells)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derived style IDs are cached per original style
+	cache := map[int]int{}
+	for row := cellRange.StartRow; row <= cellRange.EndRow; row++ {
+		for col := cellRange.StartCol; col <= cellRange.EndCol; col++ {
+			addr := FormatCellAddress(col, row)
+			styleID, err := wb.f.GetCellStyle(resolvedSheet, addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get style for %s: %w", addr, err)
+			}
+
+			newID, ok := cache[styleID]
+			if !ok {
+				style, err := wb.f.GetStyle(styleID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read style %d: %w", styleID, err)
+				}
+				spec.apply(style)
+				newID, err = wb.f.NewStyle(style)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create style: %w", err)
+				}
+				cache[styleID] = newID
+			}
+
+			if err := wb.f.SetCellStyle(resolvedSheet, addr, addr, newID); err != nil {
+				return nil, fmt.Errorf("failed to set style for %s: %w", addr, err)
+			}
+		}
+	}
+
+	return &StyleResult{
+		Success:     true,
+		Sheet:       resolvedSheet,
+		Range:       cellRange.String(),
+		CellsStyled: totalCells,
+	}, nil
+}
+
+// normalizeHexColor returns a hex color with a leading # in upper case
+func normalizeHexColor(color string) string {
+	return "#" + strings.ToUpper(strings.TrimPrefix(color, "#"))
+}
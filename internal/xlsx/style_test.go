@@ -0,0 +1,91 @@
+package xlsx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetCellStyle(t *testing.T) {
+	path := createTestFile(t)
+
+	bold := true
+	result, err := SetCellStyle(path, "Sheet1", "A1:B1", StyleSpec{
+		Bold:       &bold,
+		FontColor:  "1f4e79",
+		Fill:       "#D9E1F2",
+		Horizontal: "center",
+	})
+	if err != nil {
+		t.Fatalf("SetCellStyle failed: %v", err)
+	}
+	if result.Range != "A1:B1" || result.CellsStyled != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// A second call changes only the number format and keeps the rest
+	if _, err := SetCellStyle(path, "Sheet1", "B1", StyleSpec{NumberFormat: "#,##0.00"}); err != nil {
+		t.Fatalf("SetCellStyle failed: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	for _, addr := range []string{"A1", "B1"} {
+		id, err := f.GetCellStyle("Sheet1", addr)
+		if err != nil {
+			t.Fatalf("GetCellStyle failed: %v", err)
+		}
+		style, err := f.GetStyle(id)
+		if err != nil {
+			t.Fatalf("GetStyle failed: %v", err)
+		}
+		if style.Font == nil || !style.Font.Bold || style.Font.ColorTheme != nil || !strings.EqualFold(strings.TrimPrefix(style.Font.Color, "#"), "1F4E79") {
+			t.Errorf("%s: expected bold #1F4E79 font, got %+v", addr, style.Font)
+		}
+		if len(style.Fill.Color) != 1 || !strings.EqualFold(strings.TrimPrefix(style.Fill.Color[0], "#"), "D9E1F2") {
+			t.Errorf("%s: expected #D9E1F2 fill, got %+v", addr, style.Fill)
+		}
+		if style.Alignment == nil || style.Alignment.Horizontal != "center" {
+			t.Errorf("%s: expected centered alignment, got %+v", addr, style.Alignment)
+		}
+		if addr == "B1" && (style.CustomNumFmt == nil || *style.CustomNumFmt != "#,##0.00") {
+			t.Errorf("B1: expected number format #,##0.00, got %v", style.CustomNumFmt)
+		}
+	}
+
+	// Cells outside the range keep the default style
+	if id, err := f.GetCellStyle("Sheet1", "A2"); err != nil || id != 0 {
+		t.Errorf("expected A2 unstyled, got style %d (err %v)", id, err)
+	}
+}
+
+func TestSetCellStyleErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	tests := []struct {
+		name string
+		spec StyleSpec
+		want string
+	}{
+		{"empty", StyleSpec{}, "no properties"},
+		{"bad color", StyleSpec{FontColor: "red"}, "invalid font_color"},
+		{"bad fill", StyleSpec{Fill: "#12345"}, "invalid fill"},
+		{"bad horizontal", StyleSpec{Horizontal: "middle"}, "unknown horizontal alignment"},
+		{"bad vertical", StyleSpec{Vertical: "left"}, "unknown vertical alignment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SetCellStyle(path, "Sheet1", "A1", tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := SetCellStyle(path, "Sheet1", "A1:A20000", StyleSpec{Horizontal: "left"}); err == nil {
+		t.Error("expected error for too many cells")
+	}
+}
@@ -0,0 +1,165 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// SwapRows exchanges the contents of two rows and saves atomically. Every
+// column in the sheet's used range is swapped, so rows of differing widths
+// are handled. With styles, cell styles move with their values; otherwise
+// each cell keeps its style. Formula references are not adjusted.
+func SwapRows(path, sheet string, rowA, rowB int, styles bool) (*SwapResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SwapResult, error) {
+		return wb.SwapRows(sheet, rowA, rowB, styles)
+	})
+}
+
+// SwapRows exchanges the contents of two rows.
+// Enforces MaxWriteRangeCells limit per row.
+func (wb *Workbook) SwapRows(sheet string, rowA, rowB int, styles bool) (*SwapResult, error) {
+	for _, row := range []int{rowA, rowB} {
+		if row < 1 || row > excelize.TotalRows {
+			return nil, fmt.Errorf("invalid row: %d (must be between 1 and %d)", row, excelize.TotalRows)
+		}
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SwapResult{
+		Success: true,
+		Sheet:   resolvedSheet,
+		First:   strconv.Itoa(rowA),
+		Second:  strconv.Itoa(rowB),
+	}
+
+	bounds, err := sheetBounds(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	if bounds == nil || rowA == rowB {
+		return result, nil
+	}
+	if bounds.EndCol > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to swap %d cells per row, limit is %d",
+			ErrCellLimitExceeded, bounds.EndCol, MaxWriteRangeCells)
+	}
+
+	a := &CellRange{StartCol: 1, StartRow: rowA, EndCol: bounds.EndCol, EndRow: rowA}
+	b := &CellRange{StartCol: 1, StartRow: rowB, EndCol: bounds.EndCol, EndRow: rowB}
+	result.CellsMoved, err = swapRanges(wb.f, resolvedSheet, a, b, styles)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SwapColumns exchanges the contents of two columns, given as letters, and
+// saves atomically. Every row in the sheet's used range is swapped, so
+// columns of differing lengths are handled. With styles, cell styles move
+// with their values; otherwise each cell keeps its style. Column widths
+// and formula references are not changed.
+func SwapColumns(path, sheet, colA, colB string, styles bool) (*SwapResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SwapResult, error) {
+		return wb.SwapColumns(sheet, colA, colB, styles)
+	})
+}
+
+// SwapColumns exchanges the contents of two columns.
+// Enforces MaxWriteRangeCells limit per column.
+func (wb *Workbook) SwapColumns(sheet, colA, colB string, styles bool) (*SwapResult, error) {
+	numA, err := ParseColumnName(colA)
+	if err != nil {
+		return nil, err
+	}
+	numB, err := ParseColumnName(colB)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SwapResult{
+		Success: true,
+		Sheet:   resolvedSheet,
+		First:   ColumnNumberToName(numA),
+		Second:  ColumnNumberToName(numB),
+	}
+
+	bounds, err := sheetBounds(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	if bounds == nil || numA == numB {
+		return result, nil
+	}
+	if bounds.EndRow > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to swap %d cells per column, limit is %d",
+			ErrCellLimitExceeded, bounds.EndRow, MaxWriteRangeCells)
+	}
+
+	a := &CellRange{StartCol: numA, StartRow: 1, EndCol: numA, EndRow: bounds.EndRow}
+	b := &CellRange{StartCol: numB, StartRow: 1, EndCol: numB, EndRow: bounds.EndRow}
+	result.CellsMoved, err = swapRanges(wb.f, resolvedSheet, a, b, styles)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// swapRanges exchanges the cells of two same-sized ranges, returning how
+// many cells were moved. Without styles, only values and formulas move.
+func swapRanges(f *excelize.File, sheet string, a, b *CellRange, styles bool) (int, error) {
+	cellsA, err := copyRange(f, sheet, a)
+	if err != nil {
+		return 0, err
+	}
+	cellsB, err := copyRange(f, sheet, b)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range []*CellRange{a, b} {
+		if styles {
+			err = clearCells(f, sheet, r)
+		} else {
+			_, err = clearValues(f, sheet, r)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if !styles {
+		cellsA = withoutStyles(cellsA)
+		cellsB = withoutStyles(cellsB)
+	}
+
+	if err := pasteRange(f, sheet, b.StartCol, b.StartRow, cellsA); err != nil {
+		return 0, err
+	}
+	if err := pasteRange(f, sheet, a.StartCol, a.StartRow, cellsB); err != nil {
+		return 0, err
+	}
+	return len(cellsA) + len(cellsB), nil
+}
+
+// withoutStyles drops styles from copied cells, leaving only those with a value
+func withoutStyles(cells []copiedCell) []copiedCell {
+	kept := cells[:0]
+	for _, cell := range cells {
+		if cell.valueType == "" {
+			continue
+		}
+		cell.style = 0
+		kept = append(kept, cell)
+	}
+	return kept
+}
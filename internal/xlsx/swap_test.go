@@ -0,0 +1,144 @@
+package xlsx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createSwapTestFile(t *testing.T) string {
+	t.Helper()
+	return writeRowsFile(t, [][]any{
+		{"Name", "Age", "City"},
+		{"Alice", 30},
+		{"Bob", 25, "Boston"},
+		{"Charlie", 35, "Chicago", "extra"},
+		{"Dana", 28, "Denver"},
+	})
+}
+
+func readSwapRows(t *testing.T, path string) [][]string {
+	t.Helper()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatalf("GetRows failed: %v", err)
+	}
+	return rows
+}
+
+func TestSwapRows(t *testing.T) {
+	path := createSwapTestFile(t)
+
+	result, err := SwapRows(path, "", 2, 4, false)
+	if err != nil {
+		t.Fatalf("SwapRows failed: %v", err)
+	}
+	if result.Sheet != "Sheet1" || result.First != "2" || result.Second != "4" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	expected := [][]string{
+		{"Name", "Age", "City"},
+		{"Charlie", "35", "Chicago", "extra"},
+		{"Bob", "25", "Boston"},
+		{"Alice", "30"},
+		{"Dana", "28", "Denver"},
+	}
+	if rows := readSwapRows(t, path); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+}
+
+func TestSwapRowsStyles(t *testing.T) {
+	path := createSwapTestFile(t)
+
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open: %v", err)
+	}
+	style, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		t.Fatalf("failed to create style: %v", err)
+	}
+	if err := f.SetCellStyle("Sheet1", "A2", "A2", style); err != nil {
+		t.Fatalf("failed to set style: %v", err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	f.Close()
+
+	styleAt := func(addr string) int {
+		f, err := OpenFile(path)
+		if err != nil {
+			t.Fatalf("OpenFile failed: %v", err)
+		}
+		defer f.Close()
+		s, err := f.GetCellStyle("Sheet1", addr)
+		if err != nil {
+			t.Fatalf("GetCellStyle failed: %v", err)
+		}
+		return s
+	}
+
+	// Without styles the bold stays on A2
+	if _, err := SwapRows(path, "Sheet1", 2, 3, false); err != nil {
+		t.Fatalf("SwapRows failed: %v", err)
+	}
+	if styleAt("A2") != style || styleAt("A3") == style {
+		t.Errorf("expected style to stay on A2")
+	}
+
+	// With styles the bold moves with the value
+	if _, err := SwapRows(path, "Sheet1", 2, 3, true); err != nil {
+		t.Fatalf("SwapRows failed: %v", err)
+	}
+	if styleAt("A3") != style || styleAt("A2") == style {
+		t.Errorf("expected style to move to A3")
+	}
+}
+
+func TestSwapColumns(t *testing.T) {
+	path := createSwapTestFile(t)
+
+	result, err := SwapColumns(path, "Sheet1", "a", "C", false)
+	if err != nil {
+		t.Fatalf("SwapColumns failed: %v", err)
+	}
+	if result.First != "A" || result.Second != "C" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	expected := [][]string{
+		{"City", "Age", "Name"},
+		{"", "30", "Alice"},
+		{"Boston", "25", "Bob"},
+		{"Chicago", "35", "Charlie", "extra"},
+		{"Denver", "28", "Dana"},
+	}
+	if rows := readSwapRows(t, path); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+}
+
+func TestSwapInvalid(t *testing.T) {
+	path := createSwapTestFile(t)
+
+	if _, err := SwapRows(path, "Sheet1", 0, 2, false); err == nil {
+		t.Error("expected error for row 0")
+	}
+	if _, err := SwapColumns(path, "Sheet1", "A", "1", false); err == nil {
+		t.Error("expected error for invalid column")
+	}
+	if _, err := SwapRows(path, "Missing", 1, 2, false); err == nil {
+		t.Error("expected error for missing sheet")
+	}
+}
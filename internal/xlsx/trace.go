@@ -0,0 +1,311 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxTracePrecedents caps how many cells a formula's ranges are expanded into
+const MaxTracePrecedents = 10000
+
+// MaxTraceDependents caps how many dependent cells TraceCell reports
+const MaxTraceDependents = 10000
+
+// formulaRefRegex matches cell references and ranges in a formula, with an
+// optional sheet qualifier (Sheet1!A1, 'My Sheet'!$A$1:$B$2), as well as
+// whole-column and whole-row ranges (A:A, Sheet1!$2:$5)
+var formulaRefRegex = regexp.MustCompile(
+	`(?:('(?:[^']|'')+'|[A-Za-z0-9_.]+)!)?` +
+		`(?:\$?([A-Za-z]{1,3})\$?([0-9]+)(?::\$?([A-Za-z]{1,3})\$?([0-9]+))?` +
+		`|\$?([A-Za-z]{1,3}):\$?([A-Za-z]{1,3})` +
+		`|\$?([0-9]+):\$?([0-9]+))`)
+
+// TraceCell returns the cells referenced by a cell's formula (precedents)
+// and the cells whose formulas reference it (dependents), within one sheet.
+// Ranges in formulas are expanded into individual cells.
+func TraceCell(f *excelize.File, sheet, addr string) (*TraceResult, error) {
+	resolvedSheet, err := ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	col, row, err := ParseCellAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	target := FormatCellAddress(col, row)
+
+	formula, err := f.GetCellFormula(resolvedSheet, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get formula for %s: %w", target, err)
+	}
+
+	result := &TraceResult{
+		Sheet:      resolvedSheet,
+		Cell:       target,
+		Formula:    formula,
+		Precedents: []string{},
+		Dependents: []string{},
+	}
+
+	refs := FormulaRefs(formula, resolvedSheet)
+	if err := clipWholeRefs(f, resolvedSheet, refs); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+expand:
+	for _, ref := range refs {
+		for r := ref.StartRow; r <= ref.EndRow; r++ {
+			for c := ref.StartCol; c <= ref.EndCol; c++ {
+				cell := FormatCellAddress(c, r)
+				if seen[cell] {
+					continue
+				}
+				if len(result.Precedents) >= MaxTracePrecedents {
+					result.Truncated = true
+					break expand
+				}
+				seen[cell] = true
+				result.Precedents = append(result.Precedents, cell)
+			}
+		}
+	}
+
+	dependents, truncated, err := findDependents(f, resolvedSheet, col, row)
+	if err != nil {
+		return nil, err
+	}
+	result.Dependents = dependents
+	result.Truncated = result.Truncated || truncated
+
+	return result, nil
+}
+
+// clipWholeRefs narrows whole-column and whole-row references to the
+// sheet's used range, so A:A expands to the cells that hold data rather
+// than a million rows
+func clipWholeRefs(f *excelize.File, sheet string, refs []*CellRange) error {
+	var used *CellRange
+	for _, ref := range refs {
+		if ref.EndRow < excelize.TotalRows && ref.EndCol < excelize.MaxColumns {
+			continue
+		}
+		if used == nil {
+			bounds, err := sheetBounds(f, sheet)
+			if err != nil {
+				return err
+			}
+			if bounds == nil {
+				bounds = &CellRange{StartCol: 1, StartRow: 1, EndCol: 1, EndRow: 1}
+			}
+			used = bounds
+		}
+		ref.EndRow = max(min(ref.EndRow, used.EndRow), ref.StartRow)
+		ref.EndCol = max(min(ref.EndCol, used.EndCol), ref.StartCol)
+	}
+	return nil
+}
+
+// findDependents scans every formula in the sheet for references to a
+// cell, stopping at MaxTraceDependents. It reports whether it stopped early.
+func findDependents(f *excelize.File, sheet string, col, row int) ([]string, bool, error) {
+	dependents := []string{}
+	truncated := false
+	err := formulaCells(f, sheet, func(addr string) (bool, error) {
+		formula, err := f.GetCellFormula(sheet, addr)
+		if err != nil {
+			return false, fmt.Errorf("failed to get formula for %s: %w", addr, err)
+		}
+		for _, ref := range FormulaRefs(formula, sheet) {
+			if !ref.Contains(col, row) {
+				continue
+			}
+			if len(dependents) >= MaxTraceDependents {
+				truncated = true
+				return false, nil
+			}
+			dependents = append(dependents, addr)
+			break
+		}
+		return true, nil
+	})
+	return dependents, truncated, err
+}
+
+// formulaCells calls fn with the address of each cell of the sheet that
+// may hold a formula, in row order, until fn returns false. A sheet
+// excelize has parsed, as reading any formula does, is walked in memory and
+// yields only the cells with a formula. Otherwise the rows are streamed and
+// every cell they hold is yielded, since the stream does not tell formulas
+// from values.
+func formulaCells(f *excelize.File, sheet string, fn func(addr string) (bool, error)) error {
+	if ws, known := loadedWorksheet(f, sheet); known && ws.IsValid() {
+		if cells, ok := worksheetFormulaCells(ws); ok {
+			for _, addr := range cells {
+				if more, err := fn(addr); err != nil || !more {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return fmt.Errorf("failed to open row iterator: %w", err)
+	}
+	defer rows.Close()
+	for rowNum := 1; rows.Next(); rowNum++ {
+		cols, err := rows.Columns()
+		if err != nil {
+			return fmt.Errorf("error reading row %d: %w", rowNum, err)
+		}
+		for c := range cols {
+			if more, err := fn(FormatCellAddress(c+1, rowNum)); err != nil || !more {
+				return err
+			}
+		}
+	}
+	if err := rows.Error(); err != nil {
+		return fmt.Errorf("row iteration error: %w", err)
+	}
+	return nil
+}
+
+// worksheetFormulaCells returns the addresses of the cells of a parsed
+// worksheet that hold a formula. It reports false when the worksheet does
+// not have the expected layout.
+func worksheetFormulaCells(ws reflect.Value) ([]string, bool) {
+	rows := fieldOf(ws, "SheetData", "Row")
+	if rows.Kind() != reflect.Slice {
+		return nil, false
+	}
+	var cells []string
+	for i := 0; i < rows.Len(); i++ {
+		row := fieldOf(rows.Index(i), "C")
+		if row.Kind() != reflect.Slice {
+			return nil, false
+		}
+		for j := 0; j < row.Len(); j++ {
+			ref, formula := fieldOf(row.Index(j), "R"), fieldOf(row.Index(j), "F")
+			if ref.Kind() != reflect.String || formula.Kind() != reflect.Pointer {
+				return nil, false
+			}
+			if !formula.IsNil() {
+				cells = append(cells, ref.String())
+			}
+		}
+	}
+	return cells, true
+}
+
+// FormulaRefs extracts the cell ranges a formula references on the given
+// sheet. Whole-column and whole-row references run to the edge of the
+// sheet, so A:A is A1:A1048576. References qualified with another sheet
+// name, and text inside string literals, are ignored.
+func FormulaRefs(formula, sheet string) []*CellRange {
+	var refs []*CellRange
+	for _, m := range formulaRefMatches(formula, sheet, sheet) {
+		refs = append(refs, m.ref)
+	}
+	return refs
+}
+
+// formulaRef is a reference found in a formula and its position in the text
+type formulaRef struct {
+	start, end int      // Byte offsets of the reference, including any sheet qualifier
+	rows       [][2]int // Byte offsets of the row numbers, one per corner
+	ref        *CellRange
+}
+
+// formulaRefMatches finds the references in a formula on formulaSheet that
+// point at targetSheet. Unqualified references belong to formulaSheet; an
+// empty formulaSheet (as for defined names) only matches qualified ones.
+func formulaRefMatches(formula, formulaSheet, targetSheet string) []formulaRef {
+	formula = blankStringLiterals(formula)
+
+	var refs []formulaRef
+	for _, loc := range formulaRefRegex.FindAllStringSubmatchIndex(formula, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && isRefNameChar(formula[start-1]) {
+			continue
+		}
+		if end < len(formula) && (isRefNameChar(formula[end]) || formula[end] == '(') {
+			continue // function name like LOG10( or part of a longer name
+		}
+
+		if loc[2] >= 0 {
+			if !sameSheetName(formula[loc[2]:loc[3]], targetSheet) {
+				continue
+			}
+		} else if formulaSheet == "" || !strings.EqualFold(formulaSheet, targetSheet) {
+			continue
+		}
+
+		m := formulaRef{start: start, end: end}
+		var refStr string
+		switch {
+		case loc[4] >= 0:
+			refStr = formula[loc[4]:loc[5]] + formula[loc[6]:loc[7]]
+			m.rows = [][2]int{{loc[6], loc[7]}}
+			if loc[8] >= 0 {
+				refStr += ":" + formula[loc[8]:loc[9]] + formula[loc[10]:loc[11]]
+				m.rows = append(m.rows, [2]int{loc[10], loc[11]})
+			}
+		case loc[12] >= 0:
+			refStr = formula[loc[12]:loc[13]] + ":" + formula[loc[14]:loc[15]]
+		default:
+			refStr = formula[loc[16]:loc[17]] + ":" + formula[loc[18]:loc[19]]
+			m.rows = [][2]int{{loc[16], loc[17]}, {loc[18], loc[19]}}
+		}
+		ref, err := ParseRange(refStr)
+		if err != nil || ref.EndCol > excelize.MaxColumns || ref.EndRow > excelize.TotalRows {
+			continue
+		}
+		// Whole columns and rows run to the edge of the sheet
+		if ref.EndRow == 0 {
+			ref.EndRow = excelize.TotalRows
+		}
+		if ref.EndCol == 0 {
+			ref.EndCol = excelize.MaxColumns
+		}
+		m.ref = ref
+		refs = append(refs, m)
+	}
+	return refs
+}
+
+// blankStringLiterals replaces the contents of "..." literals with spaces so
+// text like "A1" is not mistaken for a reference
+func blankStringLiterals(formula string) string {
+	b := []byte(formula)
+	inString := false
+	for i := 0; i < len(b); i++ {
+		if b[i] == '"' {
+			inString = !inString
+			continue
+		}
+		if inString {
+			b[i] = ' '
+		}
+	}
+	return string(b)
+}
+
+// sameSheetName compares a formula sheet qualifier against a sheet name
+func sameSheetName(qualifier, sheet string) bool {
+	if strings.HasPrefix(qualifier, "'") {
+		qualifier = strings.ReplaceAll(strings.Trim(qualifier, "'"), "''", "'")
+	}
+	return strings.EqualFold(qualifier, sheet)
+}
+
+func isRefNameChar(c byte) bool {
+	return c == '_' || c == '.' || c == '!' ||
+		(c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
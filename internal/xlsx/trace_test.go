@@ -0,0 +1,241 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createFormulaFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "formulas.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for i, v := range []int{10, 20, 30} {
+		if err := f.SetCellValue("Sheet1", FormatCellAddress(1, i+1), v); err != nil {
+			t.Fatalf("failed to set value: %v", err)
+		}
+	}
+	formulas := map[string]string{
+		"A4": "SUM(A1:A3)",
+		"B1": "A4*2",
+		"B2": `IF(A2>0,"A4",0)`,
+		"B3": "Sheet2!A4+1",
+		"C1": "LOG10(A1)",
+	}
+	for addr, formula := range formulas {
+		if err := f.SetCellFormula("Sheet1", addr, formula); err != nil {
+			t.Fatalf("failed to set formula %s: %v", addr, err)
+		}
+	}
+	if _, err := f.NewSheet("Sheet2"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	return path
+}
+
+func TestTraceCell(t *testing.T) {
+	f, err := OpenFile(createFormulaFile(t))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	result, err := TraceCell(f, "Sheet1", "A4")
+	if err != nil {
+		t.Fatalf("TraceCell failed: %v", err)
+	}
+
+	if want := []string{"A1", "A2", "A3"}; !reflect.DeepEqual(result.Precedents, want) {
+		t.Errorf("precedents = %v, want %v", result.Precedents, want)
+	}
+	if want := []string{"B1"}; !reflect.DeepEqual(result.Dependents, want) {
+		t.Errorf("dependents = %v, want %v", result.Dependents, want)
+	}
+}
+
+func TestTraceCellDependentsOfRangeMember(t *testing.T) {
+	f, err := OpenFile(createFormulaFile(t))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	result, err := TraceCell(f, "Sheet1", "a2")
+	if err != nil {
+		t.Fatalf("TraceCell failed: %v", err)
+	}
+
+	if result.Cell != "A2" || result.Formula != "" || len(result.Precedents) != 0 {
+		t.Errorf("unexpected result for value cell: %+v", result)
+	}
+	if want := []string{"B2", "A4"}; !reflect.DeepEqual(result.Dependents, want) {
+		t.Errorf("dependents = %v, want %v", result.Dependents, want)
+	}
+}
+
+func TestSheetBoundsStoredDimension(t *testing.T) {
+	path := createFormulaFile(t)
+
+	tests := []struct {
+		name string
+		dim  string
+	}{
+		{"accurate", "A1:C4"},
+		{"stale", "A1:A2"},
+		{"trailing blank rows", "A1:C10"},
+		{"unbounded", "A1:XFD1048576"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := OpenFile(path)
+			if err != nil {
+				t.Fatalf("OpenFile failed: %v", err)
+			}
+			defer f.Close()
+			if err := f.SetSheetDimension("Sheet1", tt.dim); err != nil {
+				t.Fatalf("failed to set dimension: %v", err)
+			}
+			staged := filepath.Join(t.TempDir(), "dim.xlsx")
+			if err := f.SaveAs(staged); err != nil {
+				t.Fatalf("failed to save file: %v", err)
+			}
+
+			g, err := OpenFile(staged)
+			if err != nil {
+				t.Fatalf("OpenFile failed: %v", err)
+			}
+			defer g.Close()
+			bounds, err := sheetBounds(g, "Sheet1")
+			if err != nil {
+				t.Fatalf("sheetBounds failed: %v", err)
+			}
+			if bounds == nil || bounds.String() != "A1:C4" {
+				t.Errorf("expected bounds A1:C4 for dimension %s, got %v", tt.dim, bounds)
+			}
+
+			result, err := TraceCell(g, "Sheet1", "A4")
+			if err != nil {
+				t.Fatalf("TraceCell failed: %v", err)
+			}
+			if want := []string{"B1"}; !reflect.DeepEqual(result.Dependents, want) {
+				t.Errorf("dependents = %v, want %v", result.Dependents, want)
+			}
+		})
+	}
+}
+
+func TestFormulaRefs(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    []string
+	}{
+		{"SUM(A1:A3)", []string{"A1:A3"}},
+		{"$B$2*C3", []string{"B2", "C3"}},
+		{"Sheet1!A1+Other!B1", []string{"A1"}},
+		{"'Sheet1'!D4", []string{"D4"}},
+		{`CONCAT("A1",B1)`, []string{"B1"}},
+		{"LOG10(E5)", []string{"E5"}},
+		{"SUM(A:A)", []string{"A1:A1048576"}},
+		{"SUM(Sheet1!$C:$B)", []string{"B1:C1048576"}},
+		{"SUM(1:1)+SUM($3:$2)", []string{"A1:XFD1", "A2:XFD3"}},
+		{"SUM(Other!A:A)+COUNT(Other!2:2)", []string{}},
+	}
+
+	for _, tt := range tests {
+		refs := FormulaRefs(tt.formula, "Sheet1")
+		got := make([]string, len(refs))
+		for i, ref := range refs {
+			got[i] = ref.String()
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FormulaRefs(%q) = %v, want %v", tt.formula, got, tt.want)
+		}
+	}
+}
+
+func TestTraceCellWholeColumnAndRowRefs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "whole.xlsx")
+	f := excelize.NewFile()
+	for i, v := range []int{10, 20, 30} {
+		if err := f.SetCellValue("Sheet1", FormatCellAddress(1, i+1), v); err != nil {
+			t.Fatalf("failed to set value: %v", err)
+		}
+	}
+	for addr, formula := range map[string]string{"B1": "SUM(A:A)", "C4": "SUM($2:$2)"} {
+		if err := f.SetCellFormula("Sheet1", addr, formula); err != nil {
+			t.Fatalf("failed to set formula %s: %v", addr, err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	f.Close()
+
+	g, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer g.Close()
+
+	result, err := TraceCell(g, "Sheet1", "A2")
+	if err != nil {
+		t.Fatalf("TraceCell failed: %v", err)
+	}
+	if want := []string{"B1", "C4"}; !reflect.DeepEqual(result.Dependents, want) {
+		t.Errorf("dependents = %v, want %v", result.Dependents, want)
+	}
+
+	// A whole column expands only as far as the used range
+	result, err = TraceCell(g, "Sheet1", "B1")
+	if err != nil {
+		t.Fatalf("TraceCell failed: %v", err)
+	}
+	if want := []string{"A1", "A2", "A3", "A4"}; !reflect.DeepEqual(result.Precedents, want) || result.Truncated {
+		t.Errorf("precedents = %v (truncated %v), want %v", result.Precedents, result.Truncated, want)
+	}
+}
+
+func TestTraceCellDependentsCapped(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	// A shared formula gives every cell of B1:B<n> a formula on A1
+	formulaType, ref := excelize.STCellFormulaTypeShared, "B1:B"+strconv.Itoa(MaxTraceDependents+5)
+	if err := f.SetCellFormula("Sheet1", "B1", "$A$1*2", excelize.FormulaOpts{Type: &formulaType, Ref: &ref}); err != nil {
+		t.Fatalf("SetCellFormula failed: %v", err)
+	}
+	if err := f.SetCellValue("Sheet1", "C1", 1); err != nil {
+		t.Fatalf("SetCellValue failed: %v", err)
+	}
+
+	result, err := TraceCell(f, "Sheet1", "A1")
+	if err != nil {
+		t.Fatalf("TraceCell failed: %v", err)
+	}
+	if len(result.Dependents) != MaxTraceDependents || !result.Truncated {
+		t.Errorf("expected %d dependents and truncated, got %d (truncated %v)", MaxTraceDependents, len(result.Dependents), result.Truncated)
+	}
+	if result.Dependents[0] != "B1" {
+		t.Errorf("expected B1 first, got %s", result.Dependents[0])
+	}
+}
+
+func TestTraceCellInvalidAddress(t *testing.T) {
+	f, err := OpenFile(createFormulaFile(t))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := TraceCell(f, "Sheet1", "not-a-cell"); err == nil {
+		t.Error("expected error for invalid address")
+	}
+}
@@ -0,0 +1,283 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// relationshipsNS is the namespace of r:id attributes in workbook parts
+const relationshipsNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+
+// WorkbookTree returns an overview of the workbook and each of its sheets:
+// dimension, header count, charts, images and protection. Sheet parts are
+// streamed from the package and their cell data is skipped, and only row 1
+// is read for headers, so memory per sheet stays small. Sheets without a
+// stored dimension have their rows streamed to find it.
+func WorkbookTree(f *excelize.File) (*WorkbookTreeNode, error) {
+	if f == nil {
+		return nil, fmt.Errorf("file handle is nil")
+	}
+	if f.Path == "" {
+		return nil, fmt.Errorf("workbook has no file path")
+	}
+
+	pkg, err := zip.OpenReader(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	defer pkg.Close()
+
+	parts := make(map[string]*zip.File, len(pkg.File))
+	for _, zf := range pkg.File {
+		parts[zf.Name] = zf
+	}
+
+	sheetParts, err := workbookSheetParts(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &WorkbookTreeNode{
+		File:   path.Base(f.Path),
+		Sheets: []SheetNode{},
+	}
+	for i, name := range f.GetSheetList() {
+		node := SheetNode{Name: name, Index: i}
+
+		part := sheetParts[name]
+		if strings.HasPrefix(part, "xl/chartsheets/") {
+			node.Type = "chartsheet"
+			node.HasCharts = true
+			tree.Sheets = append(tree.Sheets, node)
+			continue
+		}
+		node.Type = "worksheet"
+
+		if err := inspectSheetPart(parts, part, &node); err != nil {
+			return nil, fmt.Errorf("failed to inspect sheet %s: %w", name, err)
+		}
+		if node.Dimension == "" || node.Dimension == "A1" {
+			// Some writers store A1 for any sheet; stream the rows instead
+			bounds, err := sheetBounds(f, name)
+			if err != nil {
+				return nil, err
+			}
+			node.Dimension = ""
+			if bounds != nil {
+				node.Dimension = bounds.String()
+			}
+		}
+
+		headers, err := GetHeaderRow(context.Background(), f, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range headers {
+			if strings.TrimSpace(h) != "" {
+				node.Headers++
+			}
+		}
+		tree.Sheets = append(tree.Sheets, node)
+	}
+	return tree, nil
+}
+
+// workbookSheetParts maps sheet names to their part paths in the package
+func workbookSheetParts(parts map[string]*zip.File) (map[string]string, error) {
+	var wb struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodePart(parts, "xl/workbook.xml", &wb); err != nil {
+		return nil, err
+	}
+	rels, err := readRelationships(parts, "xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	sheets := make(map[string]string, len(wb.Sheets))
+	for _, s := range wb.Sheets {
+		sheets[s.Name] = rels[s.RID]
+	}
+	return sheets, nil
+}
+
+// inspectSheetOnDisk runs inspectSheetPart for one sheet of the file f was
+// opened from. Unsaved edits in f are not seen.
+func inspectSheetOnDisk(f *excelize.File, sheet string) (*SheetNode, error) {
+	pkg, err := zip.OpenReader(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	defer pkg.Close()
+
+	parts := make(map[string]*zip.File, len(pkg.File))
+	for _, zf := range pkg.File {
+		parts[zf.Name] = zf
+	}
+	sheetParts, err := workbookSheetParts(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &SheetNode{Name: sheet}
+	if err := inspectSheetPart(parts, sheetParts[sheet], node); err != nil {
+		return nil, fmt.Errorf("failed to inspect sheet %s: %w", sheet, err)
+	}
+	return node, nil
+}
+
+// inspectSheetPart reads a worksheet's dimension, merges, protection and
+// drawing, skipping its cell data
+func inspectSheetPart(parts map[string]*zip.File, part string, node *SheetNode) error {
+	zf, ok := parts[part]
+	if !ok {
+		return nil
+	}
+	r, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	drawingID := ""
+	d := xml.NewDecoder(r)
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "sheetData":
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		case "dimension":
+			node.Dimension = xmlAttr(start, "", "ref")
+		case "mergeCells":
+			node.HasMergedCells = true
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		case "sheetProtection":
+			sheet := xmlAttr(start, "", "sheet")
+			node.Protected = sheet == "1" || sheet == "true"
+		case "drawing":
+			drawingID = xmlAttr(start, relationshipsNS, "id")
+		}
+	}
+
+	if drawingID == "" {
+		return nil
+	}
+	rels, err := readRelationships(parts, part)
+	if err != nil {
+		return err
+	}
+	return inspectDrawingPart(parts, rels[drawingID], node)
+}
+
+// inspectDrawingPart reports whether a drawing holds charts or pictures
+func inspectDrawingPart(parts map[string]*zip.File, part string, node *SheetNode) error {
+	zf, ok := parts[part]
+	if !ok {
+		return nil
+	}
+	r, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	d := xml.NewDecoder(r)
+	for !(node.HasCharts && node.HasImages) {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "chart":
+				node.HasCharts = true
+			case "pic":
+				node.HasImages = true
+			}
+		}
+	}
+	return nil
+}
+
+// readRelationships maps relationship IDs of a part to resolved part paths
+func readRelationships(parts map[string]*zip.File, part string) (map[string]string, error) {
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	dir, base := path.Split(part)
+	if err := decodePart(parts, path.Join(dir, "_rels", base+".rels"), &rels); err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join(dir, target)
+		}
+		targets[rel.ID] = target
+	}
+	return targets, nil
+}
+
+// decodePart unmarshals a package part. A missing part leaves v untouched.
+func decodePart(parts map[string]*zip.File, part string, v any) error {
+	zf, ok := parts[part]
+	if !ok {
+		return nil
+	}
+	r, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := xml.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", part, err)
+	}
+	return nil
+}
+
+// xmlAttr returns the value of an attribute, or ""
+func xmlAttr(start xml.StartElement, space, local string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == space && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package xlsx
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWorkbookTree(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.xlsx")
+	f := excelize.NewFile()
+
+	header := []any{"Name", "Q1", "Q2"}
+	if err := f.SetSheetRow("Sheet1", "A1", &header); err != nil {
+		t.Fatalf("failed to write headers: %v", err)
+	}
+	for row, values := range [][]any{{"North", 10, 20}, {"South", 15, 25}} {
+		if err := f.SetSheetRow("Sheet1", FormatCellAddress(1, row+2), &values); err != nil {
+			t.Fatalf("failed to write row: %v", err)
+		}
+	}
+	err := f.AddChart("Sheet1", "E2", &excelize.Chart{
+		Type:   excelize.Col,
+		Series: []excelize.ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$3", Values: "Sheet1!$B$2:$B$3"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to add chart: %v", err)
+	}
+
+	if _, err := f.NewSheet("Locked"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	if err := f.SetCellValue("Locked", "A1", "Secret"); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := f.ProtectSheet("Locked", &excelize.SheetProtectionOptions{Password: "pw"}); err != nil {
+		t.Fatalf("failed to protect sheet: %v", err)
+	}
+
+	if _, err := f.NewSheet("Pictures"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	err = f.AddPictureFromBytes("Pictures", "B2", &excelize.Picture{Extension: ".png", File: img.Bytes()})
+	if err != nil {
+		t.Fatalf("failed to add picture: %v", err)
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	f.Close()
+
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	tree, err := WorkbookTree(f)
+	if err != nil {
+		t.Fatalf("WorkbookTree failed: %v", err)
+	}
+	if tree.File != "tree.xlsx" {
+		t.Errorf("expected file tree.xlsx, got %q", tree.File)
+	}
+
+	expected := []SheetNode{
+		{Name: "Sheet1", Index: 0, Type: "worksheet", Dimension: "A1:C3", Headers: 3, HasCharts: true},
+		{Name: "Locked", Index: 1, Type: "worksheet", Dimension: "A1", Headers: 1, Protected: true},
+		{Name: "Pictures", Index: 2, Type: "worksheet", HasImages: true},
+	}
+	if len(tree.Sheets) != len(expected) {
+		t.Fatalf("expected %d sheets, got %+v", len(expected), tree.Sheets)
+	}
+	for i, want := range expected {
+		if got := tree.Sheets[i]; got != want {
+			t.Errorf("sheet %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
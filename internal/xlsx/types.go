@@ -6,6 +6,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/xuri/excelize/v2"
 )
 
 // Error types
@@ -15,6 +17,8 @@ var (
 	ErrSheetNotFound  = errors.New("sheet not found")
 	ErrFileNotFound   = errors.New("file not found")
 	ErrInvalidFormat  = errors.New("invalid xlsx format")
+	ErrOutOfBounds    = errors.New("cell outside the sheet") // Past row 1048576 or column XFD
+	ErrInputTooLarge  = errors.New("input exceeds size limit")
 )
 
 // CellRange represents a rectangular range of cells (e.g., A1:C10)
@@ -27,10 +31,84 @@ type CellRange struct {
 
 // SheetInfo contains metadata about a worksheet
 type SheetInfo struct {
-	Name    string   `json:"name"`
-	Rows    int      `json:"rows"`
-	Cols    int      `json:"cols"`
-	Headers []string `json:"headers,omitempty"`
+	Name           string   `json:"name"`
+	Rows           int      `json:"rows"`
+	Cols           int      `json:"cols"`
+	Dimension      string   `json:"dimension,omitempty"` // Used range, e.g. A1:F10432
+	HasMergedCells bool     `json:"has_merged_cells"`
+	Hidden         bool     `json:"hidden"`
+	Visibility     string   `json:"visibility"` // visible, hidden or veryHidden
+	Headers        []string `json:"headers,omitempty"`
+}
+
+// SheetVisibility reports whether a sheet is visible, hidden or very hidden
+type SheetVisibility struct {
+	Sheet      string `json:"sheet"`
+	Visibility string `json:"visibility"`
+}
+
+// MaxSizeScanRows caps how many rows are counted per sheet when listing
+// sheets by size, keeping the listing responsive on huge workbooks
+const MaxSizeScanRows = 1000000
+
+// SheetSize is a sheet name annotated with its row count
+type SheetSize struct {
+	Name      string `json:"name"`
+	Rows      int    `json:"rows"`
+	Truncated bool   `json:"truncated,omitempty"` // Row count stopped at MaxSizeScanRows
+}
+
+// WorkbookTreeNode is an overview of a workbook's structure
+type WorkbookTreeNode struct {
+	File   string      `json:"file"`
+	Sheets []SheetNode `json:"sheets"`
+}
+
+// SheetNode describes one sheet in a WorkbookTreeNode
+type SheetNode struct {
+	Name           string `json:"name"`
+	Index          int    `json:"index"`
+	Type           string `json:"type"`                // worksheet or chartsheet
+	Dimension      string `json:"dimension,omitempty"` // Used range as stored in the file
+	Headers        int    `json:"headers"`             // Non-empty cells in row 1
+	HasCharts      bool   `json:"has_charts"`
+	HasImages      bool   `json:"has_images"`
+	HasMergedCells bool   `json:"has_merged_cells"`
+	Protected      bool   `json:"protected"`
+}
+
+// CalcProps describes a workbook's calculation mode and formula cache state
+type CalcProps struct {
+	Mode            string   `json:"mode"` // auto, manual or autoNoTable
+	FullCalcOnLoad  bool     `json:"full_calc_on_load"`
+	ForceFullCalc   bool     `json:"force_full_calc"`
+	CacheMayBeStale bool     `json:"cache_may_be_stale"` // Cached formula values may not reflect current inputs
+	StaleReasons    []string `json:"stale_reasons"`
+}
+
+// VisibleRange describes the part of a sheet's used range that is not hidden
+type VisibleRange struct {
+	Sheet          string   `json:"sheet"`
+	UsedRange      string   `json:"used_range"`    // Empty for an empty sheet
+	VisibleRange   string   `json:"visible_range"` // Bounding range of visible rows and columns; empty if all are hidden
+	HiddenRows     []int    `json:"hidden_rows"`
+	HiddenColumns  []string `json:"hidden_columns"`
+	VisibleRows    int      `json:"visible_rows"`
+	VisibleColumns int      `json:"visible_columns"`
+}
+
+// ControlCharMatch is a cell containing control or invisible characters
+type ControlCharMatch struct {
+	Address string   `json:"address"`
+	Value   string   `json:"value"`
+	Chars   []string `json:"chars"` // Distinct offending characters, e.g. TAB, CR, LF, NUL, U+200B
+}
+
+// ControlCharsResult lists the cells of a sheet with control characters
+type ControlCharsResult struct {
+	Sheet     string             `json:"sheet"`
+	Matches   []ControlCharMatch `json:"matches"`
+	Truncated bool               `json:"truncated,omitempty"` // More than MaxControlCharResults cells matched
 }
 
 // Cell represents a single cell with its value and metadata
@@ -40,6 +118,23 @@ type Cell struct {
 	Type    string `json:"type"` // string, number, bool, formula, error, empty
 	Row     int    `json:"row"`
 	Col     int    `json:"col"`
+
+	// MergedInto is the anchor address when the value was filled in from
+	// a merged region's top-left cell (MergedFill read option)
+	MergedInto string `json:"merged_into,omitempty"`
+
+	// Formula, Computed and CalcError are set for formula cells read with
+	// the Calc option. Computed holds the live result, or the Excel error
+	// value (e.g. #DIV/0!) when evaluation fails; CalcError explains why.
+	Formula   string `json:"formula,omitempty"`
+	Computed  string `json:"computed,omitempty"`
+	CalcError string `json:"calc_error,omitempty"`
+}
+
+// TypedCell is a cell value annotated with its detected type
+type TypedCell struct {
+	Value string `json:"value"`
+	Type  string `json:"type"` // string, number, bool, formula, error, empty
 }
 
 // Row represents a row of cells
@@ -55,6 +150,69 @@ type SearchResult struct {
 	Value   string `json:"value"`
 	Row     int    `json:"row"`
 	Col     int    `json:"col"`
+	// Number is the parsed value of a numeric search match
+	Number *float64 `json:"number,omitempty"`
+	// Context holds the values of the match's row, or of a window of it,
+	// when requested; ContextRange is the range they cover
+	Context      []string `json:"context,omitempty"`
+	ContextRange string   `json:"context_range,omitempty"`
+
+	sheetIndex int // Position of Sheet among the searched sheets, for ordering
+}
+
+// CountResult is the number of cells matching a search pattern
+type CountResult struct {
+	Pattern string       `json:"pattern"`
+	Total   int          `json:"total"`
+	Sheets  []SheetCount `json:"sheets"`
+}
+
+// SheetCount is the number of matching cells in one sheet
+type SheetCount struct {
+	Sheet   string `json:"sheet"`
+	Matches int    `json:"matches"`
+}
+
+// TraceResult lists the cells a formula depends on and the cells depending on it
+type TraceResult struct {
+	Sheet      string   `json:"sheet"`
+	Cell       string   `json:"cell"`
+	Formula    string   `json:"formula,omitempty"`
+	Precedents []string `json:"precedents"`
+	Dependents []string `json:"dependents"`
+	Truncated  bool     `json:"truncated,omitempty"` // Precedents or dependents stopped at their cap
+}
+
+// AggregateResult is the result of aggregating a numeric column
+type AggregateResult struct {
+	Sheet   string   `json:"sheet"`
+	Column  string   `json:"column"`
+	Op      string   `json:"op"`
+	Value   *float64 `json:"value"`   // nil for avg, min and max when no cell is numeric
+	Count   int      `json:"count"`   // Numeric cells included
+	Ignored int      `json:"ignored"` // Non-empty cells skipped as non-numeric
+}
+
+// ColumnProfile describes one column in a data dictionary
+type ColumnProfile struct {
+	Column         string         `json:"column"`
+	Header         string         `json:"header"`
+	Type           string         `json:"type"`            // number, bool, string, mixed or empty
+	Types          map[string]int `json:"types"`           // Non-empty cells per inferred type
+	FillRatio      float64        `json:"fill_ratio"`      // Share of scanned rows with a value, 0 to 1
+	NonEmpty       int            `json:"non_empty"`       // Scanned rows with a value
+	Distinct       int            `json:"distinct"`        // Distinct values, up to MaxDistinctValues
+	DistinctCapped bool           `json:"distinct_capped"` // More than MaxDistinctValues distinct values
+	Samples        []string       `json:"samples"`         // First few distinct values
+}
+
+// DataDictionaryResult describes every column of a sheet
+type DataDictionaryResult struct {
+	Sheet            string          `json:"sheet"`
+	RowsScanned      int             `json:"rows_scanned"`                // Data rows after the header
+	Truncated        bool            `json:"truncated,omitempty"`         // More rows exist than were scanned
+	ColumnsTruncated bool            `json:"columns_truncated,omitempty"` // More than MaxDictionaryColumns columns
+	Columns          []ColumnProfile `json:"columns"`
 }
 
 // cellAddrRegex matches cell addresses like A1, B23, AA100
@@ -69,15 +227,104 @@ func ParseCellAddress(addr string) (col, row int, err error) {
 		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidAddress, addr)
 	}
 
-	col = ColumnNameToNumber(matches[1])
 	row, err = strconv.Atoi(matches[2])
 	if err != nil || row < 1 {
 		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidAddress, addr)
 	}
+	// Longer names would overflow ColumnNameToNumber; all are past XFD anyway
+	if len(matches[1]) > len(lastColumnName) {
+		return 0, 0, fmt.Errorf("%w: %s: column %s is past the last column %s",
+			ErrInvalidAddress, addr, matches[1], lastColumnName)
+	}
+	col = ColumnNameToNumber(matches[1])
+	if err := CheckCellBounds(col, row); err != nil {
+		return 0, 0, fmt.Errorf("%w: %s: %w", ErrInvalidAddress, addr, err)
+	}
 
 	return col, row, nil
 }
 
+// lastColumnName is the name of Excel's last column, number 16384
+const lastColumnName = "XFD"
+
+// CheckCellBounds reports whether a 1-based column and row lie within
+// Excel's grid, A1 to XFD1048576. Writers call it on computed end cells
+// before mutating, since FormatCellAddress formats any column and row.
+func CheckCellBounds(col, row int) error {
+	if col < 1 || col > excelize.MaxColumns {
+		return fmt.Errorf("%w: column %d is past the last column %s (%d)",
+			ErrOutOfBounds, col, lastColumnName, excelize.MaxColumns)
+	}
+	if row < 1 || row > excelize.TotalRows {
+		return fmt.Errorf("%w: row %d is past the last row %d",
+			ErrOutOfBounds, row, excelize.TotalRows)
+	}
+	return nil
+}
+
+// splitSheetRef splits a reference copied from Excel, like "Sheet1!$B$3" or
+// "='My Sheet'!A1:C10", into its sheet name and the bare reference. A leading
+// "=" and "$" absolute markers are dropped and quoted sheet names unquoted.
+// qualified reports whether a "!" was present, even if the sheet is empty.
+func splitSheetRef(ref string) (sheet, bare string, qualified bool) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "=")
+
+	if idx := strings.LastIndex(ref, "!"); idx >= 0 {
+		sheet = ref[:idx]
+		ref = ref[idx+1:]
+		if len(sheet) >= 2 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+			sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+		}
+		qualified = true
+	}
+	return sheet, strings.ReplaceAll(ref, "$", ""), qualified
+}
+
+// ParseQualifiedAddress parses a cell address as Excel writes it, such as
+// "Sheet1!$B$3", "'My Sheet'!A1" or "$A$1". The sheet is empty when the
+// address is unqualified.
+func ParseQualifiedAddress(ref string) (sheet string, col, row int, err error) {
+	sheet, bare, qualified := splitSheetRef(ref)
+	if qualified && sheet == "" {
+		return "", 0, 0, fmt.Errorf("%w: empty sheet name in %s", ErrInvalidAddress, ref)
+	}
+	col, row, err = ParseCellAddress(bare)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return sheet, col, row, nil
+}
+
+// SplitQualifiedRange splits a range as Excel writes it, such as
+// "Sheet1!$A$1:$C$10" or "'My Sheet'!A:C", into its sheet name (empty if
+// unqualified) and a bare range string for ParseRange or StreamRange.
+func SplitQualifiedRange(ref string) (sheet, rangeStr string, err error) {
+	sheet, bare, qualified := splitSheetRef(ref)
+	if qualified && sheet == "" {
+		return "", "", fmt.Errorf("%w: empty sheet name in %s", ErrInvalidRange, ref)
+	}
+	if _, err := ParseRange(bare); err != nil {
+		return "", "", err
+	}
+	return sheet, bare, nil
+}
+
+// colNameRegex matches column names like A, Z, AA, XFD
+var colNameRegex = regexp.MustCompile(`^[A-Za-z]{1,3}$`)
+
+// ParseColumnName validates a column name like "C" and returns its 1-based number
+func ParseColumnName(name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if !colNameRegex.MatchString(name) {
+		return 0, fmt.Errorf("%w: invalid column %q", ErrInvalidAddress, name)
+	}
+	col := ColumnNameToNumber(name)
+	if err := CheckCellBounds(col, 1); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+	return col, nil
+}
+
 // ColumnNameToNumber converts a column name (A, B, ..., Z, AA, AB, ...) to a 1-based number
 func ColumnNameToNumber(name string) int {
 	name = strings.ToUpper(name)
@@ -99,12 +346,19 @@ func ColumnNumberToName(col int) string {
 	return name
 }
 
-// FormatCellAddress formats a column and row number into an address like "A1"
+// FormatCellAddress formats a column and row number into an address like "A1".
+// It does not check Excel's limits; see CheckCellBounds.
 func FormatCellAddress(col, row int) string {
 	return fmt.Sprintf("%s%d", ColumnNumberToName(col), row)
 }
 
-// ParseRange parses a range string like "A1:C10" or "A1" into a CellRange
+// rowNumberRegex matches row numbers like 1, 25, 1048576
+var rowNumberRegex = regexp.MustCompile(`^[0-9]+$`)
+
+// ParseRange parses a range string like "A1:C10" or "A1" into a CellRange.
+// It also accepts whole-column ranges ("A:C"), whole-row ranges ("2:5") and
+// ranges open at the bottom ("A5:C"). Their missing end row or column is left
+// as 0; see IsOpen.
 func ParseRange(rangeStr string) (*CellRange, error) {
 	rangeStr = strings.TrimSpace(strings.ToUpper(rangeStr))
 
@@ -124,6 +378,10 @@ func ParseRange(rangeStr string) (*CellRange, error) {
 		}, nil
 
 	case 2:
+		if r, ok := parseOpenRange(parts[0], parts[1]); ok {
+			return r, nil
+		}
+
 		// Range: "A1:C10"
 		startCol, startRow, err := ParseCellAddress(parts[0])
 		if err != nil {
@@ -154,14 +412,82 @@ func ParseRange(rangeStr string) (*CellRange, error) {
 	}
 }
 
+// parseOpenRange parses the open forms ParseRange accepts: "A:C", "2:5" and
+// "A5:C". Reports false for anything else.
+func parseOpenRange(start, end string) (*CellRange, bool) {
+	switch {
+	case colNameRegex.MatchString(start) && colNameRegex.MatchString(end):
+		startCol, endCol := ColumnNameToNumber(start), ColumnNameToNumber(end)
+		if CheckCellBounds(max(startCol, endCol), 1) != nil {
+			return nil, false
+		}
+		return &CellRange{StartCol: min(startCol, endCol), StartRow: 1, EndCol: max(startCol, endCol)}, true
+
+	case rowNumberRegex.MatchString(start) && rowNumberRegex.MatchString(end):
+		startRow, err1 := strconv.Atoi(start)
+		endRow, err2 := strconv.Atoi(end)
+		if err1 != nil || err2 != nil || startRow < 1 || endRow < 1 ||
+			max(startRow, endRow) > excelize.TotalRows {
+			return nil, false
+		}
+		return &CellRange{StartCol: 1, StartRow: min(startRow, endRow), EndRow: max(startRow, endRow)}, true
+
+	case colNameRegex.MatchString(end):
+		startCol, startRow, err := ParseCellAddress(start)
+		if err != nil {
+			return nil, false
+		}
+		endCol := ColumnNameToNumber(end)
+		if CheckCellBounds(endCol, 1) != nil {
+			return nil, false
+		}
+		return &CellRange{StartCol: min(startCol, endCol), StartRow: startRow, EndCol: max(startCol, endCol)}, true
+	}
+	return nil, false
+}
+
+// parseClosedRange is ParseRange for operations that need both ends of the
+// range, such as writes. Open forms like "A:C" are rejected.
+func parseClosedRange(rangeStr string) (*CellRange, error) {
+	r, err := ParseRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsOpen() {
+		return nil, fmt.Errorf("%w: %s must name both corners", ErrInvalidRange, rangeStr)
+	}
+	return r, nil
+}
+
+// IsOpen reports whether the range has no end row or end column, as with
+// "A:C", "2:5" or "A5:C"
+func (r *CellRange) IsOpen() bool {
+	return r.EndRow == 0 || r.EndCol == 0
+}
+
 // Contains checks if a cell address is within this range
 func (r *CellRange) Contains(col, row int) bool {
 	return col >= r.StartCol && col <= r.EndCol &&
 		row >= r.StartRow && row <= r.EndRow
 }
 
-// String returns the range as a string like "A1:C10"
+// Overlaps checks if this range shares at least one cell with other
+func (r *CellRange) Overlaps(other *CellRange) bool {
+	return r.StartCol <= other.EndCol && other.StartCol <= r.EndCol &&
+		r.StartRow <= other.EndRow && other.StartRow <= r.EndRow
+}
+
+// String returns the range as a string like "A1:C10". Open ranges print
+// the way ParseRange reads them: "A:C", "A5:C", "2:5".
 func (r *CellRange) String() string {
+	switch {
+	case r.EndRow == 0 && r.StartRow == 1:
+		return fmt.Sprintf("%s:%s", ColumnNumberToName(r.StartCol), ColumnNumberToName(r.EndCol))
+	case r.EndRow == 0:
+		return fmt.Sprintf("%s:%s", FormatCellAddress(r.StartCol, r.StartRow), ColumnNumberToName(r.EndCol))
+	case r.EndCol == 0:
+		return fmt.Sprintf("%d:%d", r.StartRow, r.EndRow)
+	}
 	if r.StartCol == r.EndCol && r.StartRow == r.EndRow {
 		return FormatCellAddress(r.StartCol, r.StartRow)
 	}
@@ -74,9 +74,67 @@ func TestParseRange(t *testing.T) {
 			input:   "invalid",
 			wantErr: ErrInvalidAddress,
 		},
+		{
+			name:     "open-ended range A5:C",
+			input:    "A5:C",
+			startCol: 1,
+			startRow: 5,
+			endCol:   3,
+			endRow:   0,
+		},
+		{
+			name:     "reversed open-ended range C5:A",
+			input:    "C5:A",
+			startCol: 1,
+			startRow: 5,
+			endCol:   3,
+			endRow:   0,
+		},
+		{
+			name:     "whole columns A:C",
+			input:    "A:C",
+			startCol: 1,
+			startRow: 1,
+			endCol:   3,
+			endRow:   0,
+		},
+		{
+			name:     "reversed whole columns c:a",
+			input:    "c:a",
+			startCol: 1,
+			startRow: 1,
+			endCol:   3,
+			endRow:   0,
+		},
+		{
+			name:     "whole rows 2:5",
+			input:    "2:5",
+			startCol: 1,
+			startRow: 2,
+			endCol:   0,
+			endRow:   5,
+		},
+		{
+			name:     "reversed whole rows 5:2",
+			input:    "5:2",
+			startCol: 1,
+			startRow: 2,
+			endCol:   0,
+			endRow:   5,
+		},
 		{
 			name:    "incomplete range",
-			input:   "A1:B",
+			input:   "A1:",
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "row zero",
+			input:   "0:5",
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "column then row",
+			input:   "A:5",
 			wantErr: ErrInvalidRange,
 		},
 		{
@@ -116,6 +174,81 @@ func TestParseRange(t *testing.T) {
 	}
 }
 
+func TestParseQualifiedAddress(t *testing.T) {
+	tests := []struct {
+		input    string
+		sheet    string
+		col, row int
+		wantErr  error
+	}{
+		{"B3", "", 2, 3, nil},
+		{"$B$3", "", 2, 3, nil},
+		{"Sheet1!$B$3", "Sheet1", 2, 3, nil},
+		{"'My Sheet'!A1", "My Sheet", 1, 1, nil},
+		{"'It''s'!$C2", "It's", 3, 2, nil},
+		{"=Data!AA10", "Data", 27, 10, nil},
+		{"!A1", "", 0, 0, ErrInvalidAddress},
+		{"Sheet1!A1:B2", "", 0, 0, ErrInvalidAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			sheet, col, row, err := ParseQualifiedAddress(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParseQualifiedAddress(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQualifiedAddress(%q) unexpected error: %v", tt.input, err)
+			}
+			if sheet != tt.sheet || col != tt.col || row != tt.row {
+				t.Errorf("ParseQualifiedAddress(%q) = %q,%d,%d, want %q,%d,%d",
+					tt.input, sheet, col, row, tt.sheet, tt.col, tt.row)
+			}
+		})
+	}
+}
+
+func TestSplitQualifiedRange(t *testing.T) {
+	sheet, rangeStr, err := SplitQualifiedRange("'Q1 Data'!$A$1:$C")
+	if err != nil {
+		t.Fatalf("SplitQualifiedRange failed: %v", err)
+	}
+	if sheet != "Q1 Data" || rangeStr != "A1:C" {
+		t.Errorf("SplitQualifiedRange = %q, %q", sheet, rangeStr)
+	}
+	if _, _, err := SplitQualifiedRange("Data"); err == nil {
+		t.Error("expected a bare sheet name to be rejected")
+	}
+}
+
+func TestParseClosedRangeRejectsOpen(t *testing.T) {
+	for _, input := range []string{"A:C", "2:5", "A5:C"} {
+		if _, err := parseClosedRange(input); !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("parseClosedRange(%q) error = %v, want %v", input, err, ErrInvalidRange)
+		}
+	}
+}
+
+func TestCellRangeStringOpen(t *testing.T) {
+	tests := map[string]string{"A5:C": "A5:C", "A:C": "A:C", "2:5": "2:5", "C:A": "A:C"}
+	for input, want := range tests {
+		r, err := ParseRange(input)
+		if err != nil {
+			t.Fatalf("ParseRange(%q) failed: %v", input, err)
+		}
+		if got := r.String(); got != want {
+			t.Errorf("ParseRange(%q).String() = %q, want %q", input, got, want)
+		}
+		back, err := ParseRange(r.String())
+		if err != nil || *back != *r {
+			t.Errorf("ParseRange(%q) = %v, %v, want %v", r.String(), back, err, r)
+		}
+	}
+}
+
 func TestColumnConversion(t *testing.T) {
 	tests := []struct {
 		name string
@@ -233,6 +366,27 @@ func TestParseCellAddress(t *testing.T) {
 			addr:    "A0",
 			wantErr: ErrInvalidAddress,
 		},
+		{
+			name:    "last cell XFD1048576",
+			addr:    "XFD1048576",
+			wantCol: 16384,
+			wantRow: 1048576,
+		},
+		{
+			name:    "column past XFD",
+			addr:    "XFE1",
+			wantErr: ErrOutOfBounds,
+		},
+		{
+			name:    "row past 1048576",
+			addr:    "A1048577",
+			wantErr: ErrOutOfBounds,
+		},
+		{
+			name:    "four-letter column",
+			addr:    "ABCD1",
+			wantErr: ErrInvalidAddress,
+		},
 	}
 
 	for _, tt := range tests {
@@ -284,6 +438,26 @@ func TestFormatCellAddress(t *testing.T) {
 	}
 }
 
+func TestCheckCellBounds(t *testing.T) {
+	if err := CheckCellBounds(16384, 1048576); err != nil {
+		t.Errorf("expected XFD1048576 to be within bounds, got %v", err)
+	}
+	for _, c := range [][2]int{{16385, 1}, {1, 1048577}, {0, 1}, {1, 0}} {
+		if err := CheckCellBounds(c[0], c[1]); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("CheckCellBounds(%d, %d): expected ErrOutOfBounds, got %v", c[0], c[1], err)
+		}
+	}
+
+	for _, input := range []string{"A:XFE", "1:1048577", "A5:XFE"} {
+		if _, err := ParseRange(input); err == nil {
+			t.Errorf("ParseRange(%q): expected error past the sheet", input)
+		}
+	}
+	if _, err := ParseColumnName("XFE"); err == nil {
+		t.Error("ParseColumnName(XFE): expected error past the sheet")
+	}
+}
+
 func TestCellRangeContains(t *testing.T) {
 	r := &CellRange{
 		StartCol: 2, // B
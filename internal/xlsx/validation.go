@@ -0,0 +1,114 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// formulaXMLEscaper escapes characters that are not allowed in the raw
+// formula XML excelize writes for data validations
+var formulaXMLEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// AddDropdownFromRange adds a list validation to targetRange whose allowed
+// values come from sourceRange. sourceRange may be sheet-qualified
+// (Sheet2!$A$1:$A$10, 'My Sheet'!A1:A10); unqualified ranges refer to the
+// target sheet. The validation is read back after saving to confirm it.
+func AddDropdownFromRange(path, sheet, targetRange, sourceRange string) (*DropdownResult, error) {
+	result, err := withWorkbook(path, func(wb *Workbook) (*DropdownResult, error) {
+		return wb.AddDropdownFromRange(sheet, targetRange, sourceRange)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyDropdown(path, result.Sheet, result.Range); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AddDropdownFromRange adds a list validation to targetRange whose allowed
+// values come from sourceRange
+func (wb *Workbook) AddDropdownFromRange(sheet, targetRange, sourceRange string) (*DropdownResult, error) {
+	target, err := parseClosedRange(targetRange)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target range: %w", err)
+	}
+	sourceSheet, source, err := parseQualifiedRange(sourceRange)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source range: %w", err)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if sourceSheet == "" {
+		sourceSheet = resolvedSheet
+	} else if sourceSheet, err = ResolveSheetName(wb.f, sourceSheet); err != nil {
+		return nil, fmt.Errorf("failed to resolve source sheet name: %w", err)
+	}
+
+	formula := fmt.Sprintf("'%s'!%s", strings.ReplaceAll(sourceSheet, "'", "''"), absoluteRange(source))
+
+	dv := excelize.NewDataValidation(true)
+	dv.Sqref = target.String()
+	dv.SetSqrefDropList(formulaXMLEscaper.Replace(formula))
+	if err := wb.f.AddDataValidation(resolvedSheet, dv); err != nil {
+		return nil, fmt.Errorf("failed to add data validation: %w", err)
+	}
+
+	return &DropdownResult{
+		Success: true,
+		Sheet:   resolvedSheet,
+		Range:   dv.Sqref,
+		Source:  formula,
+	}, nil
+}
+
+// verifyDropdown reopens the saved file and checks the validation exists
+func verifyDropdown(path, sheet, sqref string) error {
+	f, err := OpenFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to reopen file: %w", err)
+	}
+	defer f.Close()
+
+	dvs, err := f.GetDataValidations(sheet)
+	if err != nil {
+		return fmt.Errorf("failed to read data validations: %w", err)
+	}
+	for _, dv := range dvs {
+		if dv.Sqref == sqref {
+			return nil
+		}
+	}
+	return fmt.Errorf("data validation on %s not found after save", sqref)
+}
+
+// parseQualifiedRange splits a range like "Sheet2!$A$1:$A$10" or
+// "='My Sheet'!A1" into its sheet name (empty if unqualified) and range.
+// A leading "=" and "$" absolute markers are ignored.
+func parseQualifiedRange(ref string) (string, *CellRange, error) {
+	sheet, bare, qualified := splitSheetRef(ref)
+	if qualified && sheet == "" {
+		return "", nil, fmt.Errorf("%w: empty sheet name", ErrInvalidRange)
+	}
+
+	cellRange, err := parseClosedRange(bare)
+	if err != nil {
+		return "", nil, err
+	}
+	return sheet, cellRange, nil
+}
+
+// absoluteRange formats a range with absolute references, e.g. $A$1:$A$10
+func absoluteRange(r *CellRange) string {
+	start := fmt.Sprintf("$%s$%d", ColumnNumberToName(r.StartCol), r.StartRow)
+	if r.StartCol == r.EndCol && r.StartRow == r.EndRow {
+		return start
+	}
+	return fmt.Sprintf("%s:$%s$%d", start, ColumnNumberToName(r.EndCol), r.EndRow)
+}
@@ -0,0 +1,95 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createDropdownFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dropdown.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if _, err := f.NewSheet("My Lists"); err != nil {
+		t.Fatalf("failed to create sheet: %v", err)
+	}
+	for i, v := range []string{"Red", "Green", "Blue"} {
+		if err := f.SetCellValue("My Lists", FormatCellAddress(1, i+1), v); err != nil {
+			t.Fatalf("failed to set value: %v", err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	return path
+}
+
+func TestAddDropdownFromRange(t *testing.T) {
+	path := createDropdownFile(t)
+
+	result, err := AddDropdownFromRange(path, "Sheet1", "B2:B10", "='my lists'!$A$1:$A$3")
+	if err != nil {
+		t.Fatalf("AddDropdownFromRange failed: %v", err)
+	}
+	if result.Range != "B2:B10" || result.Source != "'My Lists'!$A$1:$A$3" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	dvs, err := f.GetDataValidations("Sheet1")
+	if err != nil {
+		t.Fatalf("GetDataValidations failed: %v", err)
+	}
+	if len(dvs) != 1 {
+		t.Fatalf("expected 1 data validation, got %d", len(dvs))
+	}
+	if dvs[0].Type != "list" || dvs[0].Sqref != "B2:B10" {
+		t.Errorf("unexpected validation: type=%q sqref=%q", dvs[0].Type, dvs[0].Sqref)
+	}
+	if dvs[0].Formula1 != "'My Lists'!$A$1:$A$3" {
+		t.Errorf("expected source formula 'My Lists'!$A$1:$A$3, got %q", dvs[0].Formula1)
+	}
+}
+
+func TestAddDropdownFromRangeSameSheet(t *testing.T) {
+	path := createDropdownFile(t)
+
+	result, err := AddDropdownFromRange(path, "", "C1", "E1:E5")
+	if err != nil {
+		t.Fatalf("AddDropdownFromRange failed: %v", err)
+	}
+	if result.Source != "'Sheet1'!$E$1:$E$5" {
+		t.Errorf("expected unqualified source on target sheet, got %q", result.Source)
+	}
+}
+
+func TestAddDropdownFromRangeInvalid(t *testing.T) {
+	path := createDropdownFile(t)
+
+	tests := []struct {
+		name   string
+		target string
+		source string
+	}{
+		{"invalid target", "B2:", "A1:A3"},
+		{"invalid source", "B2", "Lists!nope"},
+		{"missing source sheet", "B2", "Missing!A1:A3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := AddDropdownFromRange(path, "Sheet1", tt.target, tt.source); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
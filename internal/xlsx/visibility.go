@@ -0,0 +1,131 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Sheet visibility states, as stored in the workbook. A very hidden sheet
+// cannot be unhidden from Excel's interface, only programmatically.
+const (
+	SheetVisible    = "visible"
+	SheetHidden     = "hidden"
+	SheetVeryHidden = "veryHidden"
+)
+
+// ParseSheetVisibility validates a visibility state, ignoring case, and
+// returns its stored spelling
+func ParseSheetVisibility(state string) (string, error) {
+	for _, s := range []string{SheetVisible, SheetHidden, SheetVeryHidden} {
+		if strings.EqualFold(strings.TrimSpace(state), s) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid sheet visibility: %q (valid: visible, hidden, veryHidden)", state)
+}
+
+// GetSheetVisibility returns whether sheet is visible, hidden or very
+// hidden. An empty sheet name means the first sheet.
+func GetSheetVisibility(f *excelize.File, sheet string) (*SheetVisibility, error) {
+	resolved, err := ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+	return &SheetVisibility{Sheet: resolved, Visibility: sheetVisibility(f, resolved)}, nil
+}
+
+// sheetVisibility reads a sheet's state from the workbook. excelize's
+// GetSheetVisible only reports visible or not, so very hidden sheets are
+// told apart from the parsed workbook itself.
+func sheetVisibility(f *excelize.File, sheet string) string {
+	// GetSheetList makes sure the workbook has been parsed
+	f.GetSheetList()
+	if f.WorkBook != nil {
+		for _, s := range f.WorkBook.Sheets.Sheet {
+			if !strings.EqualFold(s.Name, sheet) {
+				continue
+			}
+			switch s.State {
+			case SheetHidden:
+				return SheetHidden
+			case SheetVeryHidden:
+				return SheetVeryHidden
+			}
+		}
+	}
+	return SheetVisible
+}
+
+// SetSheetVisibility shows, hides or very-hides a sheet and saves
+// atomically. See Workbook.SetSheetVisibility.
+func SetSheetVisibility(path, sheet, state string) (*SheetVisibilityResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SheetVisibilityResult, error) {
+		return wb.SetSheetVisibility(sheet, state)
+	})
+}
+
+// SetSheetVisibility sets a sheet to visible, hidden or veryHidden. Excel
+// requires at least one visible sheet, so hiding the last one fails with
+// ErrNoVisibleSheet. Hiding the active sheet makes the first remaining
+// visible sheet active.
+func (wb *Workbook) SetSheetVisibility(sheet, state string) (*SheetVisibilityResult, error) {
+	state, err := ParseSheetVisibility(state)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SheetVisibilityResult{
+		Success:    true,
+		Sheet:      resolved,
+		Visibility: state,
+		Previous:   sheetVisibility(wb.f, resolved),
+	}
+	if state == result.Previous {
+		return result, nil
+	}
+
+	if state != SheetVisible {
+		if err := wb.activateOtherVisibleSheet(resolved); err != nil {
+			return nil, err
+		}
+	}
+	if err := wb.f.SetSheetVisible(resolved, state == SheetVisible, state == SheetVeryHidden); err != nil {
+		return nil, fmt.Errorf("failed to set visibility of sheet %s: %w", resolved, err)
+	}
+	// excelize leaves the state unchanged rather than fail when it refuses
+	if got := sheetVisibility(wb.f, resolved); got != state {
+		return nil, fmt.Errorf("failed to set visibility of sheet %s: still %s", resolved, got)
+	}
+	return result, nil
+}
+
+// activateOtherVisibleSheet makes sure a sheet other than sheet is visible
+// and, when sheet is the active one, makes the first such sheet active, so
+// sheet can be hidden
+func (wb *Workbook) activateOtherVisibleSheet(sheet string) error {
+	other := -1
+	for i, name := range wb.f.GetSheetList() {
+		if !strings.EqualFold(name, sheet) && sheetVisibility(wb.f, name) == SheetVisible {
+			other = i
+			break
+		}
+	}
+	if other == -1 {
+		return fmt.Errorf("%w: cannot hide %s", ErrNoVisibleSheet, sheet)
+	}
+
+	index, err := wb.f.GetSheetIndex(sheet)
+	if err != nil {
+		return fmt.Errorf("failed to check sheet index: %w", err)
+	}
+	if wb.f.GetActiveSheetIndex() == index {
+		wb.f.SetActiveSheet(other)
+	}
+	return nil
+}
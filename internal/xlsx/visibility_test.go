@@ -0,0 +1,102 @@
+package xlsx
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// createVisibilityFile writes a workbook with sheets First, Helper and Last,
+// with First active
+func createVisibilityFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName("Sheet1", "First"); err != nil {
+		t.Fatal(err)
+	}
+	for _, sheet := range []string{"Helper", "Last"} {
+		if _, err := f.NewSheet(sheet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func sheetStates(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+	states := map[string]string{}
+	for _, sheet := range f.GetSheetList() {
+		v, err := GetSheetVisibility(f, sheet)
+		if err != nil {
+			t.Fatalf("GetSheetVisibility(%s) failed: %v", sheet, err)
+		}
+		states[sheet] = v.Visibility
+	}
+	return states
+}
+
+func TestSetSheetVisibility(t *testing.T) {
+	path := createVisibilityFile(t)
+
+	result, err := SetSheetVisibility(path, "helper", "veryhidden")
+	if err != nil {
+		t.Fatalf("SetSheetVisibility failed: %v", err)
+	}
+	if result.Sheet != "Helper" || result.Visibility != SheetVeryHidden || result.Previous != SheetVisible {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// Hiding the active sheet moves the active tab to a visible one
+	if _, err := SetSheetVisibility(path, "First", SheetHidden); err != nil {
+		t.Fatalf("SetSheetVisibility failed: %v", err)
+	}
+	want := map[string]string{"First": SheetHidden, "Helper": SheetVeryHidden, "Last": SheetVisible}
+	if got := sheetStates(t, path); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	if active := f.GetSheetName(f.GetActiveSheetIndex()); active != "Last" {
+		t.Errorf("expected Last to become active, got %s", active)
+	}
+	info, err := GetSheetInfo(f, "Helper")
+	if err != nil {
+		t.Fatalf("GetSheetInfo failed: %v", err)
+	}
+	if !info.Hidden || info.Visibility != SheetVeryHidden {
+		t.Errorf("expected info to report a very hidden sheet, got %+v", info)
+	}
+	f.Close()
+
+	if _, err := SetSheetVisibility(path, "Last", SheetHidden); !errors.Is(err, ErrNoVisibleSheet) {
+		t.Errorf("expected ErrNoVisibleSheet, got %v", err)
+	}
+	if _, err := SetSheetVisibility(path, "Last", "invisible"); err == nil {
+		t.Error("expected an error for an unknown state")
+	}
+	if _, err := SetSheetVisibility(path, "Missing", SheetHidden); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("expected ErrSheetNotFound, got %v", err)
+	}
+
+	if _, err := SetSheetVisibility(path, "First", SheetVisible); err != nil {
+		t.Fatalf("SetSheetVisibility failed: %v", err)
+	}
+	if got := sheetStates(t, path)["First"]; got != SheetVisible {
+		t.Errorf("expected First visible again, got %s", got)
+	}
+}
@@ -0,0 +1,119 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// hiddenIndex holds the hidden rows and columns within a sheet's used range
+type hiddenIndex struct {
+	rows map[int]bool
+	cols map[int]bool
+}
+
+// loadHiddenIndex checks the visibility of every row and column in the
+// sheet's used range. Returns nil for an empty sheet.
+func loadHiddenIndex(f *excelize.File, sheet string) (*hiddenIndex, *CellRange, error) {
+	bounds, err := sheetBounds(f, sheet)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bounds == nil {
+		return nil, nil, nil
+	}
+
+	h := &hiddenIndex{rows: map[int]bool{}, cols: map[int]bool{}}
+	for row := bounds.StartRow; row <= bounds.EndRow; row++ {
+		visible, err := f.GetRowVisible(sheet, row)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get visibility of row %d: %w", row, err)
+		}
+		if !visible {
+			h.rows[row] = true
+		}
+	}
+	for col := bounds.StartCol; col <= bounds.EndCol; col++ {
+		name := ColumnNumberToName(col)
+		visible, err := f.GetColVisible(sheet, name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get visibility of column %s: %w", name, err)
+		}
+		if !visible {
+			h.cols[col] = true
+		}
+	}
+	return h, bounds, nil
+}
+
+// rowHidden reports whether a row is hidden
+func (h *hiddenIndex) rowHidden(row int) bool {
+	return h != nil && h.rows[row]
+}
+
+// visibleCells drops the cells of hidden columns
+func (h *hiddenIndex) visibleCells(cells []Cell) []Cell {
+	if h == nil || len(h.cols) == 0 {
+		return cells
+	}
+	visible := cells[:0]
+	for _, cell := range cells {
+		if !h.cols[cell.Col] {
+			visible = append(visible, cell)
+		}
+	}
+	return visible
+}
+
+// GetVisibleRange returns a sheet's used range and the part of it a user
+// sees: the bounding range of rows and columns that are not hidden, with
+// the hidden ones listed. Rows hidden by an autofilter count as hidden, so
+// this reflects what a filtered report shows.
+func GetVisibleRange(f *excelize.File, sheet string) (*VisibleRange, error) {
+	resolvedSheet, err := ResolveSheetName(f, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VisibleRange{
+		Sheet:         resolvedSheet,
+		HiddenRows:    []int{},
+		HiddenColumns: []string{},
+	}
+	hidden, bounds, err := loadHiddenIndex(f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	if bounds == nil {
+		return result, nil
+	}
+	result.UsedRange = bounds.String()
+
+	visible := CellRange{}
+	for row := bounds.StartRow; row <= bounds.EndRow; row++ {
+		if hidden.rowHidden(row) {
+			result.HiddenRows = append(result.HiddenRows, row)
+			continue
+		}
+		if visible.StartRow == 0 {
+			visible.StartRow = row
+		}
+		visible.EndRow = row
+		result.VisibleRows++
+	}
+	for col := bounds.StartCol; col <= bounds.EndCol; col++ {
+		if hidden.cols[col] {
+			result.HiddenColumns = append(result.HiddenColumns, ColumnNumberToName(col))
+			continue
+		}
+		if visible.StartCol == 0 {
+			visible.StartCol = col
+		}
+		visible.EndCol = col
+		result.VisibleColumns++
+	}
+	if result.VisibleRows > 0 && result.VisibleColumns > 0 {
+		result.VisibleRange = visible.String()
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package xlsx
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createHiddenTestFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "hidden.xlsx")
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for row := 1; row <= 5; row++ {
+		for col := 1; col <= 4; col++ {
+			addr := FormatCellAddress(col, row)
+			if err := f.SetCellValue("Sheet1", addr, addr); err != nil {
+				t.Fatalf("failed to set cell: %v", err)
+			}
+		}
+	}
+	for _, row := range []int{3, 5} {
+		if err := f.SetRowVisible("Sheet1", row, false); err != nil {
+			t.Fatalf("failed to hide row: %v", err)
+		}
+	}
+	if err := f.SetColVisible("Sheet1", "D", false); err != nil {
+		t.Fatalf("failed to hide column: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
+func TestGetVisibleRange(t *testing.T) {
+	f, err := OpenFile(createHiddenTestFile(t))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	got, err := GetVisibleRange(f, "")
+	if err != nil {
+		t.Fatalf("GetVisibleRange failed: %v", err)
+	}
+	want := &VisibleRange{
+		Sheet:          "Sheet1",
+		UsedRange:      "A1:D5",
+		VisibleRange:   "A1:C4",
+		HiddenRows:     []int{3, 5},
+		HiddenColumns:  []string{"D"},
+		VisibleRows:    3,
+		VisibleColumns: 3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestGetVisibleRangeEmptySheet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.xlsx")
+	nf := excelize.NewFile()
+	if err := nf.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	nf.Close()
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	got, err := GetVisibleRange(f, "Sheet1")
+	if err != nil {
+		t.Fatalf("GetVisibleRange failed: %v", err)
+	}
+	if got.UsedRange != "" || got.VisibleRange != "" {
+		t.Errorf("expected empty ranges, got %+v", got)
+	}
+}
+
+func TestStreamRowsVisibleOnly(t *testing.T) {
+	f, err := OpenFile(createHiddenTestFile(t))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := StreamRowsWithOptions(ctx, f, "Sheet1", 0, 0, StreamOptions{VisibleOnly: true})
+	if err != nil {
+		t.Fatalf("StreamRowsWithOptions failed: %v", err)
+	}
+	rows, err := CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+
+	var got [][]string
+	for _, row := range rows {
+		var values []string
+		for _, cell := range row.Cells {
+			values = append(values, cell.Value)
+		}
+		got = append(got, values)
+	}
+	want := [][]string{
+		{"A1", "B1", "C1"},
+		{"A2", "B2", "C2"},
+		{"A4", "B4", "C4"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	ch, err = StreamRangeWithOptions(ctx, f, "Sheet1", "B2:D4", StreamOptions{VisibleOnly: true})
+	if err != nil {
+		t.Fatalf("StreamRangeWithOptions failed: %v", err)
+	}
+	rows, err = CollectRows(ch)
+	if err != nil {
+		t.Fatalf("CollectRows failed: %v", err)
+	}
+	if len(rows) != 2 || len(rows[1].Cells) != 2 || rows[1].Cells[1].Address != "C4" {
+		t.Errorf("expected rows 2 and 4 with columns B-C, got %+v", rows)
+	}
+}
@@ -0,0 +1,730 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrWorkbookClosed is returned when using a Workbook after Close
+var ErrWorkbookClosed = errors.New("workbook is closed")
+
+// Workbook is an open xlsx file that collects several edits in memory and
+// writes them with a single atomic save on Commit. If any edit fails, call
+// Close without Commit and the file on disk is left untouched.
+//
+//	wb, err := xlsx.Open(path)
+//	if err != nil { ... }
+//	defer wb.Close()
+//	if _, err := wb.InsertRows("Sheet1", 2, rows); err != nil { ... }
+//	if _, err := wb.RenameSheet("Sheet1", "Data"); err != nil { ... }
+//	err = wb.Commit()
+type Workbook struct {
+	path string
+	f    *excelize.File
+
+	// touched holds the sheets edited through Workbook methods; rawAccess is
+	// set once File hands out the excelize handle. Commit drops the stored
+	// dimension of these sheets because excelize does not keep it current.
+	touched   map[string]bool
+	rawAccess bool
+}
+
+// Open opens an existing xlsx file for a transaction of edits.
+// It applies the same existence and size checks as OpenFileForWrite.
+func Open(path string) (*Workbook, error) {
+	f, err := OpenFileForWrite(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file for write: %w", err)
+	}
+	return &Workbook{path: path, f: f, touched: make(map[string]bool)}, nil
+}
+
+// File returns the underlying excelize handle for edits not covered by
+// Workbook methods. Returns nil after Close.
+func (wb *Workbook) File() *excelize.File {
+	if wb.f != nil {
+		wb.rawAccess = true
+	}
+	return wb.f
+}
+
+// Commit saves all edits made so far atomically. The workbook stays open,
+// so further edits can be made and committed again.
+func (wb *Workbook) Commit() error {
+	if wb.f == nil {
+		return ErrWorkbookClosed
+	}
+	if err := wb.updateDimensions(); err != nil {
+		return err
+	}
+	if err := SaveFileAtomic(wb.f, wb.path); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	return nil
+}
+
+// Close releases the workbook. Uncommitted edits are discarded.
+func (wb *Workbook) Close() error {
+	if wb.f == nil {
+		return nil
+	}
+	err := wb.f.Close()
+	wb.f = nil
+	return err
+}
+
+// updateDimensions stores the used range of every edited sheet as its
+// dimension. excelize writes the dimension back unchanged, so after appends
+// it would understate the used range and mislead readers on the next open.
+// Edited sheets are parsed, so they are measured in memory; a sheet that was
+// never parsed was not changed and keeps its dimension.
+func (wb *Workbook) updateDimensions() error {
+	for _, name := range wb.f.GetSheetList() {
+		if !wb.rawAccess && !wb.touched[name] {
+			continue
+		}
+		ws, known := loadedWorksheet(wb.f, name)
+		if known && !ws.IsValid() {
+			continue
+		}
+		// An empty sheet gets the A1 placeholder excelize writes itself, and
+		// one that cannot be measured loses its dimension
+		dim := "A1"
+		bounds, ok := worksheetBounds(ws)
+		switch {
+		case !known || !ok:
+			dim = ""
+		case bounds != nil:
+			dim = bounds.String()
+		}
+		if err := wb.f.SetSheetDimension(name, dim); err != nil {
+			return fmt.Errorf("failed to set dimension of sheet %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// resolveSheet resolves a sheet name (empty for default) on an open workbook
+// and marks it as edited.
+func (wb *Workbook) resolveSheet(sheet string) (string, error) {
+	if wb.f == nil {
+		return "", ErrWorkbookClosed
+	}
+	resolved, err := ResolveSheetName(wb.f, sheet)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve sheet name: %w", err)
+	}
+	wb.touched[resolved] = true
+	return resolved, nil
+}
+
+// withWorkbook runs a single edit as its own transaction: open, edit, commit.
+// It backs the one-shot functions such as WriteCell and AppendRows.
+func withWorkbook[T any](path string, edit func(wb *Workbook) (T, error)) (T, error) {
+	return Edit(path, false, edit)
+}
+
+// Edit runs a single edit as its own transaction like the one-shot
+// functions do. With dryRun set the workbook is closed without Commit, so
+// every check runs and the result is computed but the file is not written.
+func Edit[T any](path string, dryRun bool, edit func(wb *Workbook) (T, error)) (T, error) {
+	var zero T
+
+	wb, err := Open(path)
+	if err != nil {
+		return zero, err
+	}
+	defer wb.Close()
+
+	result, err := edit(wb)
+	if err != nil {
+		return zero, err
+	}
+	if dryRun {
+		return result, nil
+	}
+	if err := wb.Commit(); err != nil {
+		return zero, err
+	}
+	return result, nil
+}
+
+// WriteCell writes a value to a cell and returns the previous value.
+// valueType can be: "auto", "string", "number", "bool", "formula", "date", "datetime".
+func (wb *Workbook) WriteCell(sheet, cell string, value any, valueType string) (*WriteResult, error) {
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := ParseCellAddress(cell); err != nil {
+		return nil, err
+	}
+
+	previousValue, err := wb.f.GetCellValue(resolvedSheet, cell)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous cell value: %w", err)
+	}
+
+	if err := setCellWithType(wb.f, resolvedSheet, cell, value, valueType); err != nil {
+		return nil, fmt.Errorf("failed to write cell: %w", err)
+	}
+
+	return &WriteResult{
+		Success:       true,
+		Cell:          cell,
+		PreviousValue: previousValue,
+		NewValue:      value,
+	}, nil
+}
+
+// AppendRows writes rows after the last row with data.
+// Enforces MaxAppendRows limit.
+func (wb *Workbook) AppendRows(sheet string, rows [][]any) (*AppendResult, error) {
+	if len(rows) > MaxAppendRows {
+		return nil, fmt.Errorf("%w: attempting to append %d rows, limit is %d",
+			ErrRowLimitExceeded, len(rows), MaxAppendRows)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRow, err := getLastRow(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last row: %w", err)
+	}
+
+	startingRow := lastRow + 1
+	if err := wb.setRows(resolvedSheet, startingRow, rows); err != nil {
+		return nil, err
+	}
+
+	return &AppendResult{
+		Success:     true,
+		RowsAdded:   len(rows),
+		StartingRow: startingRow,
+		EndingRow:   startingRow + len(rows) - 1,
+	}, nil
+}
+
+// AppendCols writes columns after the last column with data, starting at
+// row 1. Each inner slice holds one column's values from top to bottom;
+// columns may differ in length. Enforces MaxWriteRangeCells limit.
+func (wb *Workbook) AppendCols(sheet string, cols [][]any) (*AppendColsResult, error) {
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns to append")
+	}
+	totalCells := 0
+	for _, col := range cols {
+		totalCells += len(col)
+	}
+	if totalCells > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to write %d cells, limit is %d",
+			ErrCellLimitExceeded, totalCells, MaxWriteRangeCells)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds, err := sheetBounds(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last column: %w", err)
+	}
+	startingCol := 1
+	if bounds != nil {
+		startingCol = bounds.EndCol + 1
+	}
+	endingCol := startingCol + len(cols) - 1
+	if endingCol > excelize.MaxColumns {
+		return nil, fmt.Errorf("%w: column %d exceeds the sheet's last column %s",
+			ErrCellLimitExceeded, endingCol, ColumnNumberToName(excelize.MaxColumns))
+	}
+
+	for i, col := range cols {
+		colNum := startingCol + i
+		if err := wb.f.SetSheetCol(resolvedSheet, FormatCellAddress(colNum, 1), &col); err != nil {
+			return nil, fmt.Errorf("failed to write column %s: %w", ColumnNumberToName(colNum), err)
+		}
+		if err := quoteFormulaLike(wb.f, resolvedSheet, col, func(i int) string { return FormatCellAddress(colNum, i+1) }); err != nil {
+			return nil, err
+		}
+	}
+
+	return &AppendColsResult{
+		Success:        true,
+		ColsAdded:      len(cols),
+		CellsWritten:   totalCells,
+		StartingColumn: ColumnNumberToName(startingCol),
+		EndingColumn:   ColumnNumberToName(endingCol),
+	}, nil
+}
+
+// WriteRange writes a 2D array of values starting at startCell.
+// The data array is rows x columns. Enforces MaxWriteRangeCells limit.
+func (wb *Workbook) WriteRange(sheet, startCell string, data [][]any) (*WriteResult, error) {
+	totalCells := 0
+	for _, row := range data {
+		totalCells += len(row)
+	}
+	if totalCells > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to write %d cells, limit is %d",
+			ErrCellLimitExceeded, totalCells, MaxWriteRangeCells)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	startCol, startRow, err := ParseCellAddress(startCell)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse start cell %s: %w", startCell, err)
+	}
+	if err := checkBlockBounds(startCol, startRow, data); err != nil {
+		return nil, err
+	}
+
+	for rowOffset, row := range data {
+		for colOffset, value := range row {
+			cellAddr := FormatCellAddress(startCol+colOffset, startRow+rowOffset)
+
+			// Use auto type detection for each value
+			if err := setCellWithType(wb.f, resolvedSheet, cellAddr, value, "auto"); err != nil {
+				return nil, fmt.Errorf("failed to write cell %s: %w", cellAddr, err)
+			}
+		}
+	}
+
+	endCol, endRow := startCol, startRow
+	if len(data) > 0 && len(data[0]) > 0 {
+		endCol = startCol + len(data[0]) - 1
+		endRow = startRow + len(data) - 1
+	}
+
+	rangeStr := fmt.Sprintf("%s:%s",
+		FormatCellAddress(startCol, startRow),
+		FormatCellAddress(endCol, endRow))
+
+	return &WriteResult{
+		Success:  true,
+		Cell:     rangeStr,
+		NewValue: fmt.Sprintf("Wrote %d cells", totalCells),
+	}, nil
+}
+
+// CreateSheet adds a new sheet, optionally writing a header row.
+func (wb *Workbook) CreateSheet(name string, headers []string) (*SheetResult, error) {
+	return wb.CreateSheetWithOptions(name, headers, CreateSheetOptions{})
+}
+
+// CreateSheetWithOptions adds a new sheet like CreateSheet, optionally
+// styling the header row.
+func (wb *Workbook) CreateSheetWithOptions(name string, headers []string, opts CreateSheetOptions) (*SheetResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	sheetIndex, err := wb.f.GetSheetIndex(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if sheet exists: %w", err)
+	}
+	if sheetIndex != -1 {
+		return nil, fmt.Errorf("%w: sheet %s already exists", ErrSheetExists, name)
+	}
+
+	if _, err := wb.f.NewSheet(name); err != nil {
+		return nil, fmt.Errorf("failed to create sheet %s: %w", name, err)
+	}
+
+	if len(headers) > 0 {
+		headerCells := make([]any, len(headers))
+		for i, header := range headers {
+			headerCells[i] = header
+		}
+		if err := wb.f.SetSheetRow(name, FormatCellAddress(1, 1), &headerCells); err != nil {
+			return nil, fmt.Errorf("failed to write headers: %w", err)
+		}
+		if opts.StyleHeaders {
+			if err := styleHeaderRow(wb.f, name, len(headers)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &SheetResult{
+		Success: true,
+		Sheet:   name,
+	}, nil
+}
+
+// DeleteSheet removes a sheet. The last remaining sheet cannot be deleted.
+func (wb *Workbook) DeleteSheet(sheet string) (*SheetResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	sheetIndex, err := wb.f.GetSheetIndex(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check sheet index: %w", err)
+	}
+	if sheetIndex == -1 {
+		return nil, fmt.Errorf("%w: sheet %s does not exist", ErrSheetNotFound, sheet)
+	}
+
+	if len(wb.f.GetSheetList()) <= 1 {
+		return nil, fmt.Errorf("%w: workbook must have at least one sheet", ErrCannotDeleteLastSheet)
+	}
+
+	if err := wb.f.DeleteSheet(sheet); err != nil {
+		return nil, fmt.Errorf("failed to delete sheet %s: %w", sheet, err)
+	}
+
+	return &SheetResult{
+		Success: true,
+		Sheet:   sheet,
+	}, nil
+}
+
+// RenameSheet renames a sheet. The new name must not already exist.
+func (wb *Workbook) RenameSheet(oldName, newName string) (*SheetResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	oldSheetIndex, err := wb.f.GetSheetIndex(oldName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check old sheet index: %w", err)
+	}
+	if oldSheetIndex == -1 {
+		return nil, fmt.Errorf("%w: sheet %s does not exist", ErrSheetNotFound, oldName)
+	}
+
+	newSheetIndex, err := wb.f.GetSheetIndex(newName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check new sheet name: %w", err)
+	}
+	if newSheetIndex != -1 {
+		return nil, fmt.Errorf("%w: sheet %s already exists", ErrSheetExists, newName)
+	}
+
+	if err := wb.f.SetSheetName(oldName, newName); err != nil {
+		return nil, fmt.Errorf("failed to rename sheet from %s to %s: %w", oldName, newName, err)
+	}
+	for name := range wb.touched {
+		if strings.EqualFold(name, oldName) {
+			delete(wb.touched, name)
+			wb.touched[newName] = true
+		}
+	}
+
+	return &SheetResult{
+		Success: true,
+		Sheet:   newName,
+	}, nil
+}
+
+// CopySheet duplicates srcSheet, including its cell values, styles, merged
+// cells and column widths, into a new sheet destSheet added at the end of
+// the workbook. The copy is independent: later edits to either sheet do not
+// affect the other.
+func (wb *Workbook) CopySheet(srcSheet, destSheet string) (*SheetResult, error) {
+	if wb.f == nil {
+		return nil, ErrWorkbookClosed
+	}
+
+	resolvedSrc, err := ResolveSheetName(wb.f, srcSheet)
+	if err != nil {
+		return nil, err
+	}
+	srcIndex, err := wb.f.GetSheetIndex(resolvedSrc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check source sheet index: %w", err)
+	}
+
+	destIndex, err := wb.f.GetSheetIndex(destSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check destination sheet name: %w", err)
+	}
+	if destIndex != -1 {
+		return nil, fmt.Errorf("%w: sheet %s already exists", ErrSheetExists, destSheet)
+	}
+
+	destIndex, err = wb.f.NewSheet(destSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sheet %s: %w", destSheet, err)
+	}
+	if err := wb.f.CopySheet(srcIndex, destIndex); err != nil {
+		return nil, fmt.Errorf("failed to copy sheet %s to %s: %w", resolvedSrc, destSheet, err)
+	}
+	// The copy carries the source's stored dimension, stale if it was edited
+	if wb.touched[resolvedSrc] {
+		wb.touched[destSheet] = true
+	}
+
+	return &SheetResult{
+		Success: true,
+		Sheet:   destSheet,
+	}, nil
+}
+
+// InsertRows inserts rows at a 1-based position, shifting existing rows down.
+// Enforces MaxAppendRows limit.
+func (wb *Workbook) InsertRows(sheet string, row int, data [][]any) (*AppendResult, error) {
+	if len(data) > MaxAppendRows {
+		return nil, fmt.Errorf("%w: attempting to insert %d rows, limit is %d",
+			ErrRowLimitExceeded, len(data), MaxAppendRows)
+	}
+	if row < 1 {
+		return nil, fmt.Errorf("invalid row number: %d (must be >= 1)", row)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wb.f.InsertRows(resolvedSheet, row, len(data)); err != nil {
+		return nil, fmt.Errorf("failed to insert rows at row %d: %w", row, err)
+	}
+
+	if err := wb.setRows(resolvedSheet, row, data); err != nil {
+		return nil, err
+	}
+
+	return &AppendResult{
+		Success:     true,
+		RowsAdded:   len(data),
+		StartingRow: row,
+		EndingRow:   row + len(data) - 1,
+	}, nil
+}
+
+// InsertBlankRows inserts count empty rows at row, shifting existing rows down.
+// Enforces MaxAppendRows limit.
+func (wb *Workbook) InsertBlankRows(sheet string, row, count int) (*AppendResult, error) {
+	if row < 1 {
+		return nil, fmt.Errorf("invalid row number: %d (must be >= 1)", row)
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("invalid count: %d (must be >= 1)", count)
+	}
+	if count > MaxAppendRows {
+		return nil, fmt.Errorf("%w: attempting to insert %d rows, limit is %d",
+			ErrRowLimitExceeded, count, MaxAppendRows)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wb.f.InsertRows(resolvedSheet, row, count); err != nil {
+		return nil, fmt.Errorf("failed to insert rows at row %d: %w", row, err)
+	}
+
+	return &AppendResult{
+		Success:     true,
+		RowsAdded:   count,
+		StartingRow: row,
+		EndingRow:   row + count - 1,
+	}, nil
+}
+
+// DeleteRows deletes count rows starting at startRow.
+// Max 1000 rows can be deleted at once.
+func (wb *Workbook) DeleteRows(sheet string, startRow, count int) (*DeleteRowsResult, error) {
+	if startRow < 1 {
+		return nil, fmt.Errorf("invalid start row: %d (must be >= 1)", startRow)
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("invalid count: %d (must be >= 1)", count)
+	}
+	if count > MaxAppendRows {
+		return nil, fmt.Errorf("%w: attempting to delete %d rows, limit is %d",
+			ErrRowLimitExceeded, count, MaxAppendRows)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	withData, err := countRowsWithData(wb.f, resolvedSheet, startRow, startRow+count-1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Point references to the deleted rows at #REF! instead of letting
+	// them shift onto the rows below, and trim ranges that start in them
+	refsInvalidated, err := adjustRefsForDeletion(wb.f, resolvedSheet, startRow, startRow+count-1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete in reverse order to keep indices stable
+	for i := startRow + count - 1; i >= startRow; i-- {
+		if err := wb.f.RemoveRow(resolvedSheet, i); err != nil {
+			return nil, fmt.Errorf("failed to remove row %d: %w", i, err)
+		}
+	}
+
+	return &DeleteRowsResult{
+		Success:         true,
+		RowsDeleted:     count,
+		RowsWithData:    withData,
+		RefsInvalidated: refsInvalidated,
+	}, nil
+}
+
+// ClearRange removes values and formulas from the cells in a range.
+// Enforces MaxWriteRangeCells limit.
+func (wb *Workbook) ClearRange(sheet, rangeStr string) (*ClearRangeResult, error) {
+	cellRange, err := parseClosedRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	totalCells := (cellRange.EndCol - cellRange.StartCol + 1) * (cellRange.EndRow - cellRange.StartRow + 1)
+	if totalCells > MaxWriteRangeCells {
+		return nil, fmt.Errorf("%w: attempting to clear %d cells, limit is %d",
+			ErrCellLimitExceeded, totalCells, MaxWriteRangeCells)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	cleared, err := clearValues(wb.f, resolvedSheet, cellRange)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClearRangeResult{
+		Success:      true,
+		Range:        cellRange.String(),
+		CellsCleared: cleared,
+	}, nil
+}
+
+// MergeCells merges a range of at least two cells. Only the top-left
+// cell's value is kept by Excel. Fails if the range overlaps an existing
+// merged region.
+func (wb *Workbook) MergeCells(sheet, rangeStr string) (*MergeResult, error) {
+	cellRange, err := parseClosedRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	if cellRange.StartCol == cellRange.EndCol && cellRange.StartRow == cellRange.EndRow {
+		return nil, fmt.Errorf("%w: %s is a single cell, merge needs at least two", ErrInvalidRange, rangeStr)
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := mergedRanges(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range merged {
+		if existing.Overlaps(cellRange) {
+			return nil, fmt.Errorf("range %s overlaps merged cells %s; unmerge them first",
+				cellRange.String(), existing.String())
+		}
+	}
+
+	start := FormatCellAddress(cellRange.StartCol, cellRange.StartRow)
+	end := FormatCellAddress(cellRange.EndCol, cellRange.EndRow)
+	if err := wb.f.MergeCell(resolvedSheet, start, end); err != nil {
+		return nil, fmt.Errorf("failed to merge %s: %w", cellRange.String(), err)
+	}
+
+	return &MergeResult{
+		Success: true,
+		Sheet:   resolvedSheet,
+		Range:   cellRange.String(),
+		Regions: 1,
+	}, nil
+}
+
+// UnmergeCells removes every merged region that overlaps a range.
+// Unmerging a range with no merged cells succeeds with a count of 0.
+func (wb *Workbook) UnmergeCells(sheet, rangeStr string) (*MergeResult, error) {
+	cellRange, err := parseClosedRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedSheet, err := wb.resolveSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := mergedRanges(wb.f, resolvedSheet)
+	if err != nil {
+		return nil, err
+	}
+	regions := 0
+	for _, existing := range merged {
+		if !existing.Overlaps(cellRange) {
+			continue
+		}
+		start := FormatCellAddress(existing.StartCol, existing.StartRow)
+		end := FormatCellAddress(existing.EndCol, existing.EndRow)
+		if err := wb.f.UnmergeCell(resolvedSheet, start, end); err != nil {
+			return nil, fmt.Errorf("failed to unmerge %s: %w", existing.String(), err)
+		}
+		regions++
+	}
+
+	return &MergeResult{
+		Success: true,
+		Sheet:   resolvedSheet,
+		Range:   cellRange.String(),
+		Regions: regions,
+	}, nil
+}
+
+// setRows writes each row starting at column A of consecutive rows
+func (wb *Workbook) setRows(sheet string, startRow int, rows [][]any) error {
+	if err := checkBlockBounds(1, startRow, rows); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		rowNum := startRow + i
+		if err := wb.f.SetSheetRow(sheet, FormatCellAddress(1, rowNum), &row); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", rowNum, err)
+		}
+		if err := quoteFormulaLike(wb.f, sheet, row, func(j int) string { return FormatCellAddress(j+1, rowNum) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBlockBounds checks, before anything is written, that rows written
+// from startCol and startRow end within Excel's grid. The widest row sets
+// the end column.
+func checkBlockBounds(startCol, startRow int, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	width := 1
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	endCol, endRow := startCol+width-1, startRow+len(rows)-1
+	if err := CheckCellBounds(endCol, endRow); err != nil {
+		return fmt.Errorf("%w: writing %d rows from %s would end at %s",
+			err, len(rows), FormatCellAddress(startCol, startRow), FormatCellAddress(endCol, endRow))
+	}
+	return nil
+}
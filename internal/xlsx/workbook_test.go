@@ -0,0 +1,198 @@
+package xlsx
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createWorkbookTestFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "workbook.xlsx")
+	if _, err := CreateFile(path, "Sheet1", []string{"Name", "Age"}, [][]any{{"Alice", 30}}, false); err != nil {
+		t.Fatalf("CreateFile failed: %v", err)
+	}
+	return path
+}
+
+func TestWorkbookCommitsEditsTogether(t *testing.T) {
+	path := createWorkbookTestFile(t)
+
+	wb, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer wb.Close()
+
+	if _, err := wb.InsertRows("Sheet1", 2, [][]any{{"Bob", 25}}); err != nil {
+		t.Fatalf("InsertRows failed: %v", err)
+	}
+	if _, err := wb.RenameSheet("Sheet1", "People"); err != nil {
+		t.Fatalf("RenameSheet failed: %v", err)
+	}
+	if _, err := wb.WriteCell("People", "C1", "City", "string"); err != nil {
+		t.Fatalf("WriteCell failed: %v", err)
+	}
+	if err := wb.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	checks := map[string]string{"A2": "Bob", "A3": "Alice", "C1": "City"}
+	for addr, want := range checks {
+		got, err := f.GetCellValue("People", addr)
+		if err != nil {
+			t.Fatalf("GetCellValue %s failed: %v", addr, err)
+		}
+		if got != want {
+			t.Errorf("%s: expected %q, got %q", addr, want, got)
+		}
+	}
+}
+
+func TestWorkbookFailedEditLeavesFileUntouched(t *testing.T) {
+	path := createWorkbookTestFile(t)
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	wb, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	if _, err := wb.WriteCell("Sheet1", "A1", "Changed", "string"); err != nil {
+		t.Fatalf("WriteCell failed: %v", err)
+	}
+	if _, err := wb.AppendRows("Sheet1", [][]any{{"Carol", 41}}); err != nil {
+		t.Fatalf("AppendRows failed: %v", err)
+	}
+	_, err = wb.RenameSheet("Missing", "Other")
+	if !errors.Is(err, ErrSheetNotFound) {
+		t.Fatalf("expected ErrSheetNotFound, got %v", err)
+	}
+	if err := wb.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("file changed even though the transaction was not committed")
+	}
+}
+
+func TestWorkbookClosed(t *testing.T) {
+	path := createWorkbookTestFile(t)
+
+	wb, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := wb.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if err := wb.Commit(); !errors.Is(err, ErrWorkbookClosed) {
+		t.Errorf("expected ErrWorkbookClosed from Commit, got %v", err)
+	}
+	if _, err := wb.WriteCell("Sheet1", "A1", "x", "auto"); !errors.Is(err, ErrWorkbookClosed) {
+		t.Errorf("expected ErrWorkbookClosed from WriteCell, got %v", err)
+	}
+	if err := wb.Close(); err != nil {
+		t.Errorf("second Close should be a no-op, got %v", err)
+	}
+}
+
+func TestOneShotFailureLeavesFileUntouched(t *testing.T) {
+	path := createWorkbookTestFile(t)
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	// The invalid start cell fails before anything is saved
+	if _, err := WriteRange(path, "Sheet1", "not-a-cell", [][]any{{1}}); err == nil {
+		t.Fatal("expected WriteRange error")
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("file changed after a failed one-shot edit")
+	}
+}
+
+func TestEditDryRun(t *testing.T) {
+	path := createWorkbookTestFile(t)
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	result, err := Edit(path, true, func(wb *Workbook) (*WriteResult, error) {
+		return wb.WriteRange("Sheet1", "B2", [][]any{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if result.Cell != "B2:D4" {
+		t.Errorf("expected range B2:D4, got %s", result.Cell)
+	}
+
+	deleted, err := Edit(path, true, func(wb *Workbook) (*DeleteRowsResult, error) {
+		return wb.DeleteRows("Sheet1", 2, 5)
+	})
+	if err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	if deleted.RowsWithData != 1 {
+		t.Errorf("expected 1 row with data in the range, got %d", deleted.RowsWithData)
+	}
+
+	// Validation still runs on dry runs
+	if _, err := Edit(path, true, func(wb *Workbook) (*WriteResult, error) {
+		return wb.WriteCell("Missing", "A1", "x", "auto")
+	}); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("expected ErrSheetNotFound, got %v", err)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("file changed during a dry run")
+	}
+
+	// Without dryRun the edit is saved
+	if _, err := Edit(path, false, func(wb *Workbook) (*WriteResult, error) {
+		return wb.WriteCell("Sheet1", "A2", "Bob", "string")
+	}); err != nil {
+		t.Fatalf("Edit failed: %v", err)
+	}
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+	if got, _ := f.GetCellValue("Sheet1", "A2"); got != "Bob" {
+		t.Errorf("expected A2 = Bob, got %q", got)
+	}
+}
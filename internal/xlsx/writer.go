@@ -2,16 +2,31 @@ package xlsx
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/xuri/excelize/v2"
 )
 
+// DefaultDateTimeFormat is the number format applied to "datetime" writes
+const DefaultDateTimeFormat = "yyyy-mm-dd hh:mm:ss"
+
+// Layouts accepted for "date" and "datetime" values. Date-only layouts
+// come first so detectValueType can tell dates from datetimes.
+var (
+	dateLayouts     = []string{"2006-01-02"}
+	datetimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+)
+
 // OpenFileForWrite opens an existing xlsx file for write operations.
-// It validates the file exists and is within size limits.
+// It validates the file exists and is within size limits. A workbook with
+// macros must have a macro-enabled extension such as .xlsm, so that saving
+// keeps them; see ErrMacroExtension.
 func OpenFileForWrite(path string) (*excelize.File, error) {
 	// Check file exists
 	fileInfo, err := os.Stat(path)
@@ -34,9 +49,38 @@ func OpenFileForWrite(path string) (*excelize.File, error) {
 		return nil, fmt.Errorf("failed to open file %s for write: %w", path, err)
 	}
 
+	// excelize keeps the VBA project and picks the package content type from
+	// the extension, so a macro workbook saved under .xlsx would be written
+	// as an invalid file
+	if HasMacros(f) && !IsMacroExtension(path) {
+		f.Close()
+		return nil, fmt.Errorf("%w: %s (rename it to .xlsm to edit it)", ErrMacroExtension, filepath.Base(path))
+	}
+
 	return f, nil
 }
 
+// MacroExtensions are the extensions of macro-enabled workbooks. Editing
+// one keeps its VBA project (xl/vbaProject.bin) and content type.
+var MacroExtensions = []string{".xlsm", ".xltm", ".xlam"}
+
+// IsMacroExtension reports whether path has a macro-enabled extension
+func IsMacroExtension(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, m := range MacroExtensions {
+		if ext == m {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMacros reports whether a workbook contains a VBA project
+func HasMacros(f *excelize.File) bool {
+	_, ok := f.Pkg.Load("xl/vbaProject.bin")
+	return ok
+}
+
 // SaveFile saves the xlsx file to disk.
 func SaveFile(f *excelize.File, path string) error {
 	if err := f.SaveAs(path); err != nil {
@@ -46,8 +90,30 @@ func SaveFile(f *excelize.File, path string) error {
 }
 
 // SaveFileAtomic saves the file atomically using temp file + rename.
-// This prevents corruption if the process is interrupted.
+// This prevents corruption if the process is interrupted. When overwriting,
+// the existing file's permission bits are kept, and its owner too when
+// running as root.
+//
+// The save is durable: the temp file is fsynced before the rename and the
+// parent directory after it, so once SaveFileAtomic returns the new content
+// survives a crash or power loss. The directory fsync is best-effort, since
+// some platforms (e.g. Windows) and filesystems do not support it.
 func SaveFileAtomic(f *excelize.File, path string) error {
+	return writeFileAtomic(path, path, func(w io.Writer) error {
+		return f.Write(w)
+	})
+}
+
+// WriteFileAtomic writes a non-workbook file, such as a CSV export, through
+// write with the same temp file, fsync and rename steps as SaveFileAtomic.
+func WriteFileAtomic(path string, write func(w io.Writer) error) error {
+	return writeFileAtomic(path, path, write)
+}
+
+// writeFileAtomic writes path through a synced temp file and a rename, as
+// described on SaveFileAtomic. The permissions and owner are taken from
+// modeFrom when it exists.
+func writeFileAtomic(path, modeFrom string, write func(w io.Writer) error) error {
 	// Ensure parent directory exists
 	dir := filepath.Dir(path)
 	if dir != "" && dir != "." {
@@ -60,25 +126,43 @@ func SaveFileAtomic(f *excelize.File, path string) error {
 	base := filepath.Base(path)
 	tmpPath := filepath.Join(dir, base+".tmp")
 
-	// Write to temp file using excelize's WriteTo for better control
 	tmpFile, err := os.Create(tmpPath)
 	if err != nil {
 		return fmt.Errorf("failed to create temp file %s: %w", tmpPath, err)
 	}
 
 	// Write the file content
-	if err := f.Write(tmpFile); err != nil {
+	if err := write(tmpFile); err != nil {
 		tmpFile.Close()
 		_ = os.Remove(tmpPath)
 		return fmt.Errorf("failed to write to temp file %s: %w", tmpPath, err)
 	}
 
+	// Flush the content to disk before it can replace the target
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to sync temp file %s: %w", tmpPath, err)
+	}
+
 	// Close temp file
 	if err := tmpFile.Close(); err != nil {
 		_ = os.Remove(tmpPath)
 		return fmt.Errorf("failed to close temp file %s: %w", tmpPath, err)
 	}
 
+	// Keep the existing file's permissions (and owner, when possible)
+	if info, err := os.Stat(modeFrom); err == nil {
+		if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+			_ = os.Remove(tmpPath)
+			return fmt.Errorf("failed to set permissions on temp file %s: %w", tmpPath, err)
+		}
+		if err := preserveOwner(tmpPath, info); err != nil {
+			_ = os.Remove(tmpPath)
+			return fmt.Errorf("failed to set owner on temp file %s: %w", tmpPath, err)
+		}
+	}
+
 	// Rename temp to target (atomic on most filesystems)
 	if err := os.Rename(tmpPath, path); err != nil {
 		// Clean up temp file on failure
@@ -86,12 +170,54 @@ func SaveFileAtomic(f *excelize.File, path string) error {
 		return fmt.Errorf("failed to rename temp file to %s: %w", path, err)
 	}
 
+	// Persist the rename itself
+	syncDir(dir)
+
 	return nil
 }
 
+// BackupSuffix is appended to a file name to form its backup copy
+const BackupSuffix = ".bak"
+
+// BackupFile copies path to path+BackupSuffix ahead of a destructive edit,
+// replacing any earlier backup. The copy is written the same way as
+// SaveFileAtomic and keeps the original's permissions. When path does not
+// exist yet there is nothing to protect: it returns "" without copying.
+func BackupFile(path string) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to open %s for backup: %w", path, err)
+	}
+	defer src.Close()
+
+	backupPath := path + BackupSuffix
+	err = writeFileAtomic(backupPath, path, func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return backupPath, nil
+}
+
+// syncDir fsyncs a directory so a rename within it is durable. Errors are
+// ignored because not every platform supports syncing directories.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
+
 // setCellWithType writes a value to a cell with appropriate type handling.
-// valueType can be: "auto", "string", "number", "bool", "formula"
-// "auto" detects type from Go value
+// valueType can be: "auto", "string", "number", "bool", "formula", "date",
+// "datetime". "auto" detects type from Go value
 func setCellWithType(f *excelize.File, sheet, cell string, value any, valueType string) error {
 	// Determine actual type to use
 	actualType := valueType
@@ -106,6 +232,11 @@ func setCellWithType(f *excelize.File, sheet, cell string, value any, valueType
 		if err := f.SetCellStr(sheet, cell, val); err != nil {
 			return fmt.Errorf("failed to set cell %s as string: %w", cell, err)
 		}
+		if SanitizeStrings() && IsFormulaLike(val) {
+			if err := quoteCell(f, sheet, cell); err != nil {
+				return err
+			}
+		}
 
 	case "number":
 		var num float64
@@ -164,6 +295,22 @@ func setCellWithType(f *excelize.File, sheet, cell string, value any, valueType
 			return fmt.Errorf("failed to set cell %s as formula: %w", cell, err)
 		}
 
+	case "date", "datetime":
+		t, err := parseDateValue(value)
+		if err != nil {
+			return err
+		}
+		if err := f.SetCellValue(sheet, cell, t); err != nil {
+			return fmt.Errorf("failed to set cell %s as %s: %w", cell, actualType, err)
+		}
+		format := DefaultDateFormat
+		if actualType == "datetime" {
+			format = DefaultDateTimeFormat
+		}
+		if err := applyDateFormat(f, sheet, cell, format, map[int]int{}); err != nil {
+			return err
+		}
+
 	default:
 		return fmt.Errorf("unknown value type: %s", actualType)
 	}
@@ -171,6 +318,37 @@ func setCellWithType(f *excelize.File, sheet, cell string, value any, valueType
 	return nil
 }
 
+// parseDateValue converts a time.Time or a string in one of the date or
+// datetime layouts to a time.Time
+func parseDateValue(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		for _, layouts := range [][]string{dateLayouts, datetimeLayouts} {
+			for _, layout := range layouts {
+				if t, err := time.Parse(layout, s); err == nil {
+					return t, nil
+				}
+			}
+		}
+		return time.Time{}, fmt.Errorf("failed to parse %q as date (expected YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC3339)", v)
+	default:
+		return time.Time{}, fmt.Errorf("cannot convert %T to date", value)
+	}
+}
+
+// matchesLayout reports whether s parses with any of the layouts
+func matchesLayout(s string, layouts []string) bool {
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // detectValueType infers the value type from a Go value
 func detectValueType(value any) string {
 	if value == nil {
@@ -184,6 +362,8 @@ func detectValueType(value any) string {
 		return "number"
 	case float32, float64:
 		return "number"
+	case time.Time:
+		return "datetime"
 	case string:
 		// Check if it looks like a formula
 		if strings.HasPrefix(v, "=") {
@@ -193,6 +373,13 @@ func detectValueType(value any) string {
 		if _, err := strconv.ParseFloat(v, 64); err == nil {
 			return "number"
 		}
+		// Check if it's an ISO date or datetime
+		if matchesLayout(v, dateLayouts) {
+			return "date"
+		}
+		if matchesLayout(v, datetimeLayouts) {
+			return "datetime"
+		}
 		// Check if it's a bool
 		if _, err := strconv.ParseBool(v); err == nil {
 			return "bool"
@@ -203,136 +390,259 @@ func detectValueType(value any) string {
 	}
 }
 
+// lastRowWindow is how many rows above a stored dimension's last row
+// getLastRow decodes while trimming trailing blank rows. Rows before the
+// window are only skipped over, not decoded.
+const lastRowWindow = 1000
+
 // getLastRow returns the last row number with data in the sheet.
-// Uses streaming to avoid loading entire sheet.
+// A sheet excelize has already parsed is measured in memory. Otherwise the
+// rows are streamed, and a usable stored dimension limits decoding to the
+// rows just above its last row; when those are all blank the whole sheet is
+// decoded instead.
 func getLastRow(f *excelize.File, sheet string) (int, error) {
-	rows, err := f.Rows(sheet)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get rows for sheet %s: %w", sheet, err)
-	}
-	defer func() {
-		if closeErr := rows.Close(); closeErr != nil {
-			err = fmt.Errorf("failed to close rows: %w", closeErr)
+	if bounds, ok := loadedSheetBounds(f, sheet); ok {
+		if bounds == nil {
+			return 0, nil
 		}
-	}()
-
-	lastRow := 0
-	for rows.Next() {
-		lastRow++
+		return bounds.EndRow, nil
 	}
 
-	if err := rows.Error(); err != nil {
-		return 0, fmt.Errorf("error while streaming rows: %w", err)
+	from := 1
+	if bounds, ok := storedDimension(f, sheet); ok {
+		from = max(bounds.EndRow-lastRowWindow+1, 1)
+	}
+	lastRow, err := streamLastRow(f, sheet, from)
+	if err == nil && lastRow == 0 && from > 1 {
+		return streamLastRow(f, sheet, 1)
 	}
-
 	return lastRow, err
 }
 
-// WriteCell writes a value to a specific cell in an xlsx file.
-// It opens the file, writes the cell, and saves atomically.
-// Returns the previous value for confirmation.
-func WriteCell(path, sheet, cell string, value any, valueType string) (*WriteResult, error) {
-	// 1. Open file for write
-	f, err := OpenFileForWrite(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file for write: %w", err)
+// storedDimension returns the sheet's stored dimension when it can be trusted
+// without reading any rows. It reports false when the dimension is missing,
+// is the "A1" placeholder excelize writes, or a worksheet has already been
+// parsed into memory: excelize does not update the dimension as cells are
+// edited.
+func storedDimension(f *excelize.File, sheet string) (*CellRange, bool) {
+	if worksheetsLoaded(f) {
+		return nil, false
 	}
-	defer f.Close()
-
-	// 2. Resolve sheet name (use empty string for default sheet)
-	resolvedSheet, err := ResolveSheetName(f, sheet)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve sheet name: %w", err)
+	dim, err := f.GetSheetDimension(sheet)
+	if err != nil || dim == "" {
+		return nil, false
 	}
+	bounds, err := ParseRange(dim)
+	if err != nil || bounds.String() == "A1" {
+		return nil, false
+	}
+	return bounds, true
+}
 
-	// 3. Get previous value
-	previousValue, err := f.GetCellValue(resolvedSheet, cell)
+// worksheetsLoaded reports whether excelize holds any parsed worksheet of f,
+// which is where unsaved edits live.
+func worksheetsLoaded(f *excelize.File) bool {
+	loaded := false
+	f.Sheet.Range(func(_, ws any) bool {
+		loaded = ws != nil
+		return !loaded
+	})
+	return loaded
+}
+
+// streamLastRow returns the last row with a value or formula by streaming
+// the sheet. Rows before from are skipped without decoding their cells, so
+// the caller must know they do not matter.
+func streamLastRow(f *excelize.File, sheet string, from int) (int, error) {
+	rows, err := f.Rows(sheet)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get previous cell value: %w", err)
+		return 0, fmt.Errorf("failed to get rows for sheet %s: %w", sheet, err)
 	}
+	defer rows.Close()
 
-	// 4. Use setCellWithType to write new value
-	if err := setCellWithType(f, resolvedSheet, cell, value, valueType); err != nil {
-		return nil, fmt.Errorf("failed to write cell: %w", err)
+	lastRow := 0
+	for rowNum := 1; rows.Next(); rowNum++ {
+		if rowNum < from {
+			continue
+		}
+		cols, err := rows.Columns()
+		if err != nil {
+			return 0, fmt.Errorf("error reading row %d: %w", rowNum, err)
+		}
+		if len(cols) > 0 {
+			lastRow = rowNum
+		}
 	}
+	if err := rows.Error(); err != nil {
+		return 0, fmt.Errorf("error while streaming rows: %w", err)
+	}
+	return lastRow, nil
+}
 
-	// 5. Save atomically
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
+// loadedSheetBounds measures a sheet excelize has already parsed by walking
+// its cells in memory; streaming a parsed sheet would first serialize it
+// back to XML. The bounds match streamSheetBounds and are nil for a sheet
+// without data. It reports false when the sheet is not parsed.
+func loadedSheetBounds(f *excelize.File, sheet string) (*CellRange, bool) {
+	ws, known := loadedWorksheet(f, sheet)
+	if !known || !ws.IsValid() {
+		return nil, false
 	}
+	return worksheetBounds(ws)
+}
 
-	// 6. Return WriteResult
-	return &WriteResult{
-		Success:       true,
-		Cell:          cell,
-		PreviousValue: previousValue,
-		NewValue:      value,
-	}, nil
+// loadedWorksheet returns the parsed worksheet excelize holds for a sheet,
+// or an invalid value when the sheet has not been parsed. excelize keeps
+// both the sheet's part name and the worksheet type unexported, so they are
+// reached through reflection; known is false when the File does not have the
+// expected layout.
+func loadedWorksheet(f *excelize.File, sheet string) (ws reflect.Value, known bool) {
+	parts := reflect.ValueOf(f).Elem().FieldByName("sheetMap")
+	if parts.Kind() != reflect.Map || parts.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+	part := parts.MapIndex(reflect.ValueOf(sheet))
+	if !part.IsValid() || part.Kind() != reflect.String {
+		return reflect.Value{}, true
+	}
+	loaded, ok := f.Sheet.Load(part.String())
+	if !ok || loaded == nil {
+		return reflect.Value{}, true
+	}
+	v := reflect.ValueOf(loaded)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	return v.Elem(), true
 }
 
-// AppendRows appends rows to the end of a sheet.
-// It finds the last row and writes new data starting at lastRow+1.
-// Enforces MaxAppendRows limit.
-func AppendRows(path, sheet string, rows [][]any) (*AppendResult, error) {
-	// 1. Validate row count
-	if len(rows) > MaxAppendRows {
-		return nil, fmt.Errorf("%w: attempting to append %d rows, limit is %d",
-			ErrRowLimitExceeded, len(rows), MaxAppendRows)
+// worksheetBounds returns the used range of a parsed worksheet: A1 to the
+// last row and column holding a value or formula, or nil when none does. It
+// reports false when the worksheet does not have the expected layout.
+func worksheetBounds(ws reflect.Value) (*CellRange, bool) {
+	rows := fieldOf(ws, "SheetData", "Row")
+	if rows.Kind() != reflect.Slice {
+		return nil, false
+	}
+	maxRow, maxCol := 0, 0
+	for i := 0; i < rows.Len(); i++ {
+		cells := fieldOf(rows.Index(i), "C")
+		if cells.Kind() != reflect.Slice {
+			return nil, false
+		}
+		for j := cells.Len() - 1; j >= 0; j-- {
+			col, row, hasData, ok := parsedCell(cells.Index(j))
+			if !ok {
+				return nil, false
+			}
+			if hasData {
+				maxRow = max(maxRow, row)
+				maxCol = max(maxCol, col)
+				break
+			}
+		}
 	}
+	if maxRow == 0 {
+		return nil, true
+	}
+	return &CellRange{StartCol: 1, StartRow: 1, EndCol: maxCol, EndRow: maxRow}, true
+}
 
-	// 2. Open file for write
-	f, err := OpenFileForWrite(path)
+// parsedCell reads the position of a parsed cell and whether it holds a
+// value, an inline string or a formula
+func parsedCell(c reflect.Value) (col, row int, hasData, ok bool) {
+	ref, value := fieldOf(c, "R"), fieldOf(c, "V")
+	inline, formula := fieldOf(c, "IS"), fieldOf(c, "F")
+	if ref.Kind() != reflect.String || value.Kind() != reflect.String ||
+		inline.Kind() != reflect.Pointer || formula.Kind() != reflect.Pointer {
+		return 0, 0, false, false
+	}
+	col, row, err := excelize.CellNameToCoordinates(ref.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file for write: %w", err)
+		return 0, 0, false, false
 	}
-	defer f.Close()
+	return col, row, value.String() != "" || !inline.IsNil() || !formula.IsNil(), true
+}
 
-	// 3. Resolve sheet name
-	resolvedSheet, err := ResolveSheetName(f, sheet)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve sheet name: %w", err)
+// fieldOf follows a path of struct fields, returning an invalid value when
+// one is missing
+func fieldOf(v reflect.Value, path ...string) reflect.Value {
+	for _, name := range path {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		v = v.FieldByName(name)
 	}
+	return v
+}
 
-	// 4. Use getLastRow to find last row
-	lastRow, err := getLastRow(f, resolvedSheet)
+// countRowsWithData counts the rows between startRow and endRow (inclusive)
+// that hold at least one value or formula
+func countRowsWithData(f *excelize.File, sheet string, startRow, endRow int) (int, error) {
+	rows, err := f.Rows(sheet)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last row: %w", err)
+		return 0, fmt.Errorf("failed to get rows for sheet %s: %w", sheet, err)
 	}
+	defer rows.Close()
 
-	// 5. Write each row using f.SetSheetRow()
-	startingRow := lastRow + 1
-	for i, row := range rows {
-		rowNum := startingRow + i
-
-		// Convert []any to []any for SetSheetRow
-		cells := make([]any, len(row))
-		copy(cells, row)
-
-		// Use column A (1-based) as the starting cell
-		cellAddr := FormatCellAddress(1, rowNum)
-		if err := f.SetSheetRow(resolvedSheet, cellAddr, &cells); err != nil {
-			return nil, fmt.Errorf("failed to write row %d: %w", rowNum, err)
+	count := 0
+	for row := 1; row <= endRow && rows.Next(); row++ {
+		if row < startRow {
+			continue
+		}
+		// Columns only keeps cells with a value or a formula, so any
+		// column at all means the row has data
+		cols, err := rows.Columns(excelize.Options{RawCellValue: true})
+		if err != nil {
+			return 0, fmt.Errorf("failed to read row %d: %w", row, err)
+		}
+		if len(cols) > 0 {
+			count++
 		}
 	}
 
-	// 6. Save atomically
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
+	if err := rows.Error(); err != nil {
+		return 0, fmt.Errorf("error while streaming rows: %w", err)
 	}
+	return count, nil
+}
 
-	// 7. Return AppendResult
-	endingRow := startingRow + len(rows) - 1
-	return &AppendResult{
-		Success:     true,
-		RowsAdded:   len(rows),
-		StartingRow: startingRow,
-		EndingRow:   endingRow,
-	}, nil
+// WriteCell writes a value to a specific cell in an xlsx file.
+// It opens the file, writes the cell, and saves atomically.
+// Returns the previous value for confirmation.
+func WriteCell(path, sheet, cell string, value any, valueType string) (*WriteResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*WriteResult, error) {
+		return wb.WriteCell(sheet, cell, value, valueType)
+	})
+}
+
+// AppendRows appends rows to the end of a sheet.
+// It finds the last row and writes new data starting at lastRow+1.
+// Enforces MaxAppendRows limit.
+func AppendRows(path, sheet string, rows [][]any) (*AppendResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*AppendResult, error) {
+		return wb.AppendRows(sheet, rows)
+	})
+}
+
+// AppendCols appends columns to the right of a sheet's data.
+// It finds the last used column and writes new columns starting at
+// lastCol+1, each from row 1 down. Enforces MaxWriteRangeCells limit.
+func AppendCols(path, sheet string, cols [][]any) (*AppendColsResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*AppendColsResult, error) {
+		return wb.AppendCols(sheet, cols)
+	})
 }
 
 // CreateFile creates a new xlsx file with optional initial data.
 // Uses StreamWriter for efficiency when writing many rows.
 func CreateFile(path, sheetName string, headers []string, rows [][]any, overwrite bool) (*CreateFileResult, error) {
+	return CreateFileWithOptions(path, sheetName, headers, rows, CreateFileOptions{Overwrite: overwrite})
+}
+
+// CreateFileWithOptions creates a new xlsx file like CreateFile, optionally
+// freezing the header row
+func CreateFileWithOptions(path, sheetName string, headers []string, rows [][]any, opts CreateFileOptions) (*CreateFileResult, error) {
 	// 1. Validate row count
 	if len(rows) > MaxCreateFileRows {
 		return nil, fmt.Errorf("%w: attempting to create file with %d rows, limit is %d",
@@ -342,7 +652,7 @@ func CreateFile(path, sheetName string, headers []string, rows [][]any, overwrit
 	// 2. Check if file exists
 	if _, err := os.Stat(path); err == nil {
 		// File exists
-		if !overwrite {
+		if !opts.Overwrite {
 			return nil, fmt.Errorf("%w: %s", ErrFileExists, path)
 		}
 	} else if !os.IsNotExist(err) {
@@ -371,364 +681,274 @@ func CreateFile(path, sheetName string, headers []string, rows [][]any, overwrit
 		f.SetActiveSheet(defaultSheetIndex)
 	}
 
-	rowsWritten := 0
-	currentRow := 1
-
-	// 5. If headers provided, write to row 1
-	if len(headers) > 0 {
-		headerCells := make([]any, len(headers))
-		for i, header := range headers {
-			headerCells[i] = header
-		}
-		cellAddr := FormatCellAddress(1, currentRow)
-		if err := f.SetSheetRow(finalSheetName, cellAddr, &headerCells); err != nil {
-			return nil, fmt.Errorf("failed to write headers: %w", err)
-		}
-		rowsWritten++
-		currentRow++
+	// 5. Write headers and rows, streamed for large datasets
+	write := writeCreateRows
+	if len(rows) >= streamCreateThreshold {
+		write = streamCreateRows
 	}
-
-	// 6. Write rows
-	for _, row := range rows {
-		cells := make([]any, len(row))
-		copy(cells, row)
-		cellAddr := FormatCellAddress(1, currentRow)
-		if err := f.SetSheetRow(finalSheetName, cellAddr, &cells); err != nil {
-			return nil, fmt.Errorf("failed to write row %d: %w", currentRow, err)
-		}
-		rowsWritten++
-		currentRow++
+	rowsWritten, frozenRows, err := write(f, finalSheetName, headers, rows, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// 7. Save atomically
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
+	// 6. Back up the file being replaced and save atomically, unless this
+	// is a dry run
+	backupPath := ""
+	if !opts.DryRun {
+		if opts.Backup {
+			if backupPath, err = BackupFile(path); err != nil {
+				return nil, err
+			}
+		}
+		if err := SaveFileAtomic(f, path); err != nil {
+			return nil, fmt.Errorf("failed to save file: %w", err)
+		}
 	}
 
-	// 8. Return CreateFileResult
+	// 7. Return CreateFileResult
 	return &CreateFileResult{
 		Success:     true,
+		DryRun:      opts.DryRun,
 		File:        path,
 		SheetName:   finalSheetName,
 		RowsWritten: rowsWritten,
+		FrozenRows:  frozenRows,
+		Backup:      backupPath,
 	}, nil
 }
 
 // WriteRange writes a 2D array of values starting at the specified cell.
 // The data array is rows x columns. Enforces MaxWriteRangeCells limit.
 func WriteRange(path, sheet, startCell string, data [][]any) (*WriteResult, error) {
-	// 1. Calculate total cells and validate against MaxWriteRangeCells
-	totalCells := 0
-	for _, row := range data {
-		totalCells += len(row)
-	}
-	if totalCells > MaxWriteRangeCells {
-		return nil, fmt.Errorf("%w: attempting to write %d cells, limit is %d",
-			ErrCellLimitExceeded, totalCells, MaxWriteRangeCells)
-	}
-
-	// 2. Open file for write
-	f, err := OpenFileForWrite(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file for write: %w", err)
-	}
-	defer f.Close()
-
-	// 3. Resolve sheet name
-	resolvedSheet, err := ResolveSheetName(f, sheet)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve sheet name: %w", err)
-	}
-
-	// 4. Parse startCell to get starting row/col
-	startCol, startRow, err := ParseCellAddress(startCell)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse start cell %s: %w", startCell, err)
-	}
-
-	// 5. Iterate data and write each cell using setCellWithType
-	for rowOffset, row := range data {
-		currentRow := startRow + rowOffset
-		for colOffset, value := range row {
-			currentCol := startCol + colOffset
-			cellAddr := FormatCellAddress(currentCol, currentRow)
-
-			// Use auto type detection for each value
-			if err := setCellWithType(f, resolvedSheet, cellAddr, value, "auto"); err != nil {
-				return nil, fmt.Errorf("failed to write cell %s: %w", cellAddr, err)
-			}
-		}
-	}
-
-	// 6. Save atomically
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
-	}
-
-	// 7. Return WriteResult with cell count
-	var endCol, endRow int
-	if len(data) == 0 || len(data[0]) == 0 {
-		endCol = startCol
-		endRow = startRow
-	} else {
-		endCol = startCol + len(data[0]) - 1
-		endRow = startRow + len(data) - 1
-	}
-
-	rangeStr := fmt.Sprintf("%s:%s",
-		FormatCellAddress(startCol, startRow),
-		FormatCellAddress(endCol, endRow))
-
-	return &WriteResult{
-		Success:  true,
-		Cell:     rangeStr,
-		NewValue: fmt.Sprintf("Wrote %d cells", totalCells),
-	}, nil
+	return withWorkbook(path, func(wb *Workbook) (*WriteResult, error) {
+		return wb.WriteRange(sheet, startCell, data)
+	})
 }
 
 // CreateSheet creates a new sheet in an existing workbook.
 // Optionally writes a header row.
 func CreateSheet(path, name string, headers []string) (*SheetResult, error) {
-	// 1. Open file for write
-	f, err := OpenFileForWrite(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file for write: %w", err)
-	}
-	defer f.Close()
+	return withWorkbook(path, func(wb *Workbook) (*SheetResult, error) {
+		return wb.CreateSheet(name, headers)
+	})
+}
 
-	// 2. Check if sheet already exists
-	sheetIndex, err := f.GetSheetIndex(name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check if sheet exists: %w", err)
-	}
-	if sheetIndex != -1 {
-		return nil, fmt.Errorf("%w: sheet %s already exists", ErrSheetExists, name)
-	}
+// CreateSheetWithOptions creates a new sheet like CreateSheet, optionally
+// styling the header row
+func CreateSheetWithOptions(path, name string, headers []string, opts CreateSheetOptions) (*SheetResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SheetResult, error) {
+		return wb.CreateSheetWithOptions(name, headers, opts)
+	})
+}
 
-	// 3. Create new sheet
-	_, err = f.NewSheet(name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create sheet %s: %w", name, err)
-	}
+// streamCreateThreshold is the row count from which CreateFile writes through
+// excelize's StreamWriter instead of building the sheet in memory
+var streamCreateThreshold = 1000
 
-	// 4. If headers provided, write to row 1
+// writeCreateRows writes CreateFile's headers and rows cell by cell. It
+// returns the rows written and the rows frozen.
+func writeCreateRows(f *excelize.File, sheet string, headers []string, rows [][]any, opts CreateFileOptions) (int, int, error) {
+	rowsWritten := 0
+	currentRow := 1
+	frozenRows := 0
+
+	// If headers provided, write to row 1
 	if len(headers) > 0 {
 		headerCells := make([]any, len(headers))
 		for i, header := range headers {
 			headerCells[i] = header
 		}
-		cellAddr := FormatCellAddress(1, 1)
-		if err := f.SetSheetRow(name, cellAddr, &headerCells); err != nil {
-			return nil, fmt.Errorf("failed to write headers: %w", err)
+		cellAddr := FormatCellAddress(1, currentRow)
+		if err := f.SetSheetRow(sheet, cellAddr, &headerCells); err != nil {
+			return 0, 0, fmt.Errorf("failed to write headers: %w", err)
 		}
-	}
+		rowsWritten++
+		currentRow++
 
-	// 5. Save atomically
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
+		if opts.StyleHeaders {
+			if err := styleHeaderRow(f, sheet, len(headers)); err != nil {
+				return 0, 0, err
+			}
+		}
+		if opts.FreezeHeader {
+			if err := freezePanes(f, sheet, 1, 0); err != nil {
+				return 0, 0, err
+			}
+			frozenRows = 1
+		}
 	}
 
-	// 6. Return SheetResult
-	return &SheetResult{
-		Success: true,
-		Sheet:   name,
-	}, nil
+	for _, row := range rows {
+		cellAddr := FormatCellAddress(1, currentRow)
+		if err := f.SetSheetRow(sheet, cellAddr, &row); err != nil {
+			return 0, 0, fmt.Errorf("failed to write row %d: %w", currentRow, err)
+		}
+		rowNum := currentRow
+		if err := quoteFormulaLike(f, sheet, row, func(i int) string { return FormatCellAddress(i+1, rowNum) }); err != nil {
+			return 0, 0, err
+		}
+		rowsWritten++
+		currentRow++
+	}
+	return rowsWritten, frozenRows, nil
 }
 
-// DeleteSheet deletes a sheet from the workbook.
-// Returns error if trying to delete the last sheet.
-func DeleteSheet(path, sheet string) (*SheetResult, error) {
-	// 1. Open file for write
-	f, err := OpenFileForWrite(path)
+// streamCreateRows is writeCreateRows through a StreamWriter, which
+// encodes each row as it goes instead of keeping a cell tree for the whole
+// sheet. The output holds the same values, styles and panes.
+func streamCreateRows(f *excelize.File, sheet string, headers []string, rows [][]any, opts CreateFileOptions) (int, int, error) {
+	sw, err := f.NewStreamWriter(sheet)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file for write: %w", err)
+		return 0, 0, fmt.Errorf("failed to create stream writer: %w", err)
 	}
-	defer f.Close()
 
-	// 2. Verify sheet exists
-	sheetIndex, err := f.GetSheetIndex(sheet)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check sheet index: %w", err)
-	}
-	if sheetIndex == -1 {
-		return nil, fmt.Errorf("%w: sheet %s does not exist", ErrSheetNotFound, sheet)
-	}
+	rowsWritten := 0
+	currentRow := 1
+	frozenRows := 0
 
-	// 3. Verify it's not the last sheet
-	sheets := f.GetSheetList()
-	if len(sheets) <= 1 {
-		return nil, fmt.Errorf("%w: workbook must have at least one sheet", ErrCannotDeleteLastSheet)
+	if len(headers) > 0 {
+		// Panes must be set before the first row
+		if opts.FreezeHeader {
+			if err := sw.SetPanes(frozenPanes(1, 0)); err != nil {
+				return 0, 0, fmt.Errorf("failed to freeze panes: %w", err)
+			}
+			frozenRows = 1
+		}
+		style := 0
+		if opts.StyleHeaders {
+			if style, err = newHeaderStyle(f); err != nil {
+				return 0, 0, err
+			}
+		}
+		headerCells := make([]any, len(headers))
+		for i, header := range headers {
+			headerCells[i] = excelize.Cell{StyleID: style, Value: header}
+		}
+		if err := sw.SetRow(FormatCellAddress(1, currentRow), headerCells); err != nil {
+			return 0, 0, fmt.Errorf("failed to write headers: %w", err)
+		}
+		rowsWritten++
+		currentRow++
 	}
 
-	// 4. Delete the sheet
-	if err := f.DeleteSheet(sheet); err != nil {
-		return nil, fmt.Errorf("failed to delete sheet %s: %w", sheet, err)
+	quoted := -1
+	for _, row := range rows {
+		cells, err := quoteStreamRow(f, row, &quoted)
+		if err != nil {
+			return 0, 0, err
+		}
+		if err := sw.SetRow(FormatCellAddress(1, currentRow), cells); err != nil {
+			return 0, 0, fmt.Errorf("failed to write row %d: %w", currentRow, err)
+		}
+		rowsWritten++
+		currentRow++
 	}
 
-	// 5. Save atomically
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
+	if err := sw.Flush(); err != nil {
+		return 0, 0, fmt.Errorf("failed to flush rows: %w", err)
 	}
-
-	// 6. Return SheetResult
-	return &SheetResult{
-		Success: true,
-		Sheet:   sheet,
-	}, nil
+	return rowsWritten, frozenRows, nil
 }
 
-// RenameSheet renames a sheet in the workbook.
-func RenameSheet(path, oldName, newName string) (*SheetResult, error) {
-	// 1. Open file for write
-	f, err := OpenFileForWrite(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file for write: %w", err)
-	}
-	defer f.Close()
-
-	// 2. Verify old sheet exists
-	oldSheetIndex, err := f.GetSheetIndex(oldName)
+// newHeaderStyle creates the bold, lightly filled style of header rows
+func newHeaderStyle(f *excelize.File) (int, error) {
+	style, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#D9E1F2"}},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to check old sheet index: %w", err)
-	}
-	if oldSheetIndex == -1 {
-		return nil, fmt.Errorf("%w: sheet %s does not exist", ErrSheetNotFound, oldName)
+		return 0, fmt.Errorf("failed to create header style: %w", err)
 	}
+	return style, nil
+}
 
-	// 3. Verify new name doesn't exist
-	newSheetIndex, err := f.GetSheetIndex(newName)
+// styleHeaderRow makes the first count cells of row 1 bold with a light fill
+func styleHeaderRow(f *excelize.File, sheet string, count int) error {
+	style, err := newHeaderStyle(f)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check new sheet name: %w", err)
+		return err
 	}
-	if newSheetIndex != -1 {
-		return nil, fmt.Errorf("%w: sheet %s already exists", ErrSheetExists, newName)
+	if err := f.SetCellStyle(sheet, "A1", FormatCellAddress(count, 1), style); err != nil {
+		return fmt.Errorf("failed to style headers: %w", err)
 	}
+	return nil
+}
 
-	// 4. Rename the sheet
-	if err := f.SetSheetName(oldName, newName); err != nil {
-		return nil, fmt.Errorf("failed to rename sheet from %s to %s: %w", oldName, newName, err)
-	}
+// DeleteSheet deletes a sheet from the workbook.
+// Returns error if trying to delete the last sheet.
+func DeleteSheet(path, sheet string) (*SheetResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SheetResult, error) {
+		return wb.DeleteSheet(sheet)
+	})
+}
 
-	// 5. Save atomically
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
-	}
+// RenameSheet renames a sheet in the workbook.
+func RenameSheet(path, oldName, newName string) (*SheetResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SheetResult, error) {
+		return wb.RenameSheet(oldName, newName)
+	})
+}
 
-	// 6. Return SheetResult
-	return &SheetResult{
-		Success: true,
-		Sheet:   newName,
-	}, nil
+// CopySheet duplicates a sheet with its contents and styles into a new sheet.
+// Returns ErrSheetNotFound if srcSheet does not exist and ErrSheetExists if
+// destSheet does.
+func CopySheet(path, srcSheet, destSheet string) (*SheetResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*SheetResult, error) {
+		return wb.CopySheet(srcSheet, destSheet)
+	})
 }
 
 // InsertRows inserts rows at a specific position, shifting existing rows down.
-// The row parameter is 1-based. Enforces MaxAppendRows limit.
+// The row parameter is 1-based. References in formulas and defined names
+// to rows at or below the insertion point shift down with them.
+// Enforces MaxAppendRows limit.
 func InsertRows(path, sheet string, row int, data [][]any) (*AppendResult, error) {
-	// 1. Validate len(data) <= MaxAppendRows
-	if len(data) > MaxAppendRows {
-		return nil, fmt.Errorf("%w: attempting to insert %d rows, limit is %d",
-			ErrRowLimitExceeded, len(data), MaxAppendRows)
-	}
-
-	// 2. Validate row >= 1
-	if row < 1 {
-		return nil, fmt.Errorf("invalid row number: %d (must be >= 1)", row)
-	}
-
-	// 3. OpenFileForWrite(path)
-	f, err := OpenFileForWrite(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file for write: %w", err)
-	}
-	defer f.Close()
-
-	// 4. Resolve sheet name
-	resolvedSheet, err := ResolveSheetName(f, sheet)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve sheet name: %w", err)
-	}
-
-	// 5. f.InsertRows(sheet, row, len(data)) - this shifts existing rows
-	if err := f.InsertRows(resolvedSheet, row, len(data)); err != nil {
-		return nil, fmt.Errorf("failed to insert rows at row %d: %w", row, err)
-	}
-
-	// 6. Write each row of data starting at `row`
-	for i, rowData := range data {
-		rowNum := row + i
-
-		// Convert []any to []any for SetSheetRow
-		cells := make([]any, len(rowData))
-		copy(cells, rowData)
-
-		// Use column A (1-based) as the starting cell
-		cellAddr := FormatCellAddress(1, rowNum)
-		if err := f.SetSheetRow(resolvedSheet, cellAddr, &cells); err != nil {
-			return nil, fmt.Errorf("failed to write row %d: %w", rowNum, err)
-		}
-	}
-
-	// 7. SaveFileAtomic()
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
-	}
+	return withWorkbook(path, func(wb *Workbook) (*AppendResult, error) {
+		return wb.InsertRows(sheet, row, data)
+	})
+}
 
-	// 8. Return AppendResult
-	endingRow := row + len(data) - 1
-	return &AppendResult{
-		Success:     true,
-		RowsAdded:   len(data),
-		StartingRow: row,
-		EndingRow:   endingRow,
-	}, nil
+// InsertBlankRows inserts count empty rows at the specified position,
+// shifting existing rows down, and saves atomically.
+// Enforces MaxAppendRows limit.
+func InsertBlankRows(path, sheet string, row, count int) (*AppendResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*AppendResult, error) {
+		return wb.InsertBlankRows(sheet, row, count)
+	})
 }
 
 // DeleteRows deletes rows starting at startRow.
 // Both startRow and count are validated. Max 1000 rows can be deleted at once.
+// References in formulas and defined names are adjusted as in Excel: those
+// below the deleted rows shift up, and those entirely within them become #REF!.
 func DeleteRows(path, sheet string, startRow, count int) (*DeleteRowsResult, error) {
-	// 1. Validate startRow >= 1 and count >= 1 and count <= MaxAppendRows
-	if startRow < 1 {
-		return nil, fmt.Errorf("invalid start row: %d (must be >= 1)", startRow)
-	}
-	if count < 1 {
-		return nil, fmt.Errorf("invalid count: %d (must be >= 1)", count)
-	}
-	if count > MaxAppendRows {
-		return nil, fmt.Errorf("%w: attempting to delete %d rows, limit is %d",
-			ErrRowLimitExceeded, count, MaxAppendRows)
-	}
-
-	// 2. OpenFileForWrite(path)
-	f, err := OpenFileForWrite(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file for write: %w", err)
-	}
-	defer f.Close()
-
-	// 3. Resolve sheet name
-	resolvedSheet, err := ResolveSheetName(f, sheet)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve sheet name: %w", err)
-	}
+	return withWorkbook(path, func(wb *Workbook) (*DeleteRowsResult, error) {
+		return wb.DeleteRows(sheet, startRow, count)
+	})
+}
 
-	// 4. Delete rows in reverse order to maintain indices:
-	//    for i := startRow + count - 1; i >= startRow; i-- {
-	//        f.RemoveRow(sheet, i)
-	//    }
-	for i := startRow + count - 1; i >= startRow; i-- {
-		if err := f.RemoveRow(resolvedSheet, i); err != nil {
-			return nil, fmt.Errorf("failed to remove row %d: %w", i, err)
-		}
-	}
+// ClearRange removes the values and formulas of every cell in a range,
+// keeping cell styles. Cells that are already empty are left alone, so
+// clearing an empty range succeeds with a count of 0.
+// Enforces MaxWriteRangeCells limit.
+func ClearRange(path, sheet, rangeStr string) (*ClearRangeResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*ClearRangeResult, error) {
+		return wb.ClearRange(sheet, rangeStr)
+	})
+}
 
-	// 5. SaveFileAtomic()
-	if err := SaveFileAtomic(f, path); err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
-	}
+// MergeCells merges a range of at least two cells and saves atomically.
+// Ranges overlapping an existing merged region are rejected.
+func MergeCells(path, sheet, rangeStr string) (*MergeResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*MergeResult, error) {
+		return wb.MergeCells(sheet, rangeStr)
+	})
+}
 
-	// 6. Return DeleteRowsResult
-	return &DeleteRowsResult{
-		Success:     true,
-		RowsDeleted: count,
-	}, nil
+// UnmergeCells removes the merged regions overlapping a range and saves
+// atomically. Cell values are not changed.
+func UnmergeCells(path, sheet, rangeStr string) (*MergeResult, error) {
+	return withWorkbook(path, func(wb *Workbook) (*MergeResult, error) {
+		return wb.UnmergeCells(sheet, rangeStr)
+	})
 }
@@ -1,12 +1,15 @@
 package xlsx
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -182,6 +185,99 @@ func TestSaveFileAtomic(t *testing.T) {
 	}
 }
 
+func TestSaveFileAtomicPreservesMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not preserved on windows")
+	}
+	path := createTestFile(t)
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatalf("failed to chmod: %v", err)
+	}
+
+	if _, err := WriteCell(path, "Sheet1", "C1", "x", "string"); err != nil {
+		t.Fatalf("WriteCell failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("expected mode 0600 after write, got %o", mode)
+	}
+}
+
+func TestBackupFile(t *testing.T) {
+	path := createTestFile(t)
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatalf("failed to chmod: %v", err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	backupPath, err := BackupFile(path)
+	if err != nil {
+		t.Fatalf("BackupFile failed: %v", err)
+	}
+	if backupPath != path+BackupSuffix {
+		t.Errorf("expected backup at %s, got %s", path+BackupSuffix, backupPath)
+	}
+	copied, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+	if !bytes.Equal(copied, original) {
+		t.Error("backup content differs from the original")
+	}
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(backupPath); err != nil || info.Mode().Perm() != 0600 {
+			t.Errorf("expected backup mode 0600, got %v (err %v)", info.Mode().Perm(), err)
+		}
+	}
+
+	missing := filepath.Join(t.TempDir(), "new.xlsx")
+	if backupPath, err := BackupFile(missing); err != nil || backupPath != "" {
+		t.Errorf("expected no backup for a new file, got %q, %v", backupPath, err)
+	}
+	if _, err := os.Stat(missing + BackupSuffix); !os.IsNotExist(err) {
+		t.Error("backup created for a file that does not exist")
+	}
+}
+
+func TestCreateFileBackup(t *testing.T) {
+	path := createTestFile(t)
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	opts := CreateFileOptions{Overwrite: true, Backup: true}
+
+	// A create that fails while building the workbook makes no backup
+	if _, err := CreateFileWithOptions(path, "Bad/Name", nil, nil, opts); err == nil {
+		t.Fatal("expected an invalid sheet name to fail")
+	}
+	if _, err := os.Stat(path + BackupSuffix); !os.IsNotExist(err) {
+		t.Errorf("expected no backup after a failed create, got %v", err)
+	}
+
+	result, err := CreateFileWithOptions(path, "Sheet1", []string{"New"}, nil, opts)
+	if err != nil {
+		t.Fatalf("CreateFileWithOptions failed: %v", err)
+	}
+	if result.Backup != path+BackupSuffix {
+		t.Errorf("expected backup at %s, got %q", path+BackupSuffix, result.Backup)
+	}
+	copied, err := os.ReadFile(result.Backup)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+	if !bytes.Equal(copied, original) {
+		t.Error("backup content differs from the original")
+	}
+}
+
 func TestSetCellWithType(t *testing.T) {
 	f := excelize.NewFile()
 	defer f.Close()
@@ -387,6 +483,75 @@ func TestSetCellWithType(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:      "date",
+			cell:      "N1",
+			value:     "2024-01-15",
+			valueType: "date",
+			wantErr:   false,
+			verify: func(t *testing.T, f *excelize.File, cell string) {
+				val, err := f.GetCellValue("Sheet1", cell)
+				if err != nil {
+					t.Fatalf("failed to get cell: %v", err)
+				}
+				if val != "2024-01-15" {
+					t.Errorf("expected '2024-01-15', got %q", val)
+				}
+				raw, err := f.GetCellValue("Sheet1", cell, excelize.Options{RawCellValue: true})
+				if err != nil {
+					t.Fatalf("failed to get raw cell: %v", err)
+				}
+				if raw != "45306" {
+					t.Errorf("expected serial 45306, got %q", raw)
+				}
+			},
+		},
+		{
+			name:      "datetime",
+			cell:      "O1",
+			value:     "2024-01-15T10:30:00Z",
+			valueType: "datetime",
+			wantErr:   false,
+			verify: func(t *testing.T, f *excelize.File, cell string) {
+				val, err := f.GetCellValue("Sheet1", cell)
+				if err != nil {
+					t.Fatalf("failed to get cell: %v", err)
+				}
+				if val != "2024-01-15 10:30:00" {
+					t.Errorf("expected '2024-01-15 10:30:00', got %q", val)
+				}
+			},
+		},
+		{
+			name:      "auto type - date",
+			cell:      "P1",
+			value:     "2024-01-15 08:00:00",
+			valueType: "auto",
+			wantErr:   false,
+			verify: func(t *testing.T, f *excelize.File, cell string) {
+				val, err := f.GetCellValue("Sheet1", cell)
+				if err != nil {
+					t.Fatalf("failed to get cell: %v", err)
+				}
+				if val != "2024-01-15 08:00:00" {
+					t.Errorf("expected '2024-01-15 08:00:00', got %q", val)
+				}
+				cellType, err := f.GetCellType("Sheet1", cell)
+				if err != nil {
+					t.Fatalf("failed to get cell type: %v", err)
+				}
+				if cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+					t.Errorf("expected a date serial, got a string cell")
+				}
+			},
+		},
+		{
+			name:      "invalid date",
+			cell:      "Q1",
+			value:     "15/01/2024",
+			valueType: "date",
+			wantErr:   true,
+		},
 		{
 			name:      "invalid type",
 			cell:      "M1",
@@ -432,6 +597,14 @@ func TestDetectValueType(t *testing.T) {
 		{"string number", "123", "number"},
 		{"string bool", "true", "bool"},
 		{"formula", "=SUM(A1:A10)", "formula"},
+		{"iso date", "2024-01-15", "date"},
+		{"iso datetime", "2024-01-15 10:30:00", "datetime"},
+		{"rfc3339", "2024-01-15T10:30:00+02:00", "datetime"},
+		{"time value", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), "datetime"},
+		{"plain number stays number", "20240115", "number"},
+		{"year stays number", "2024", "number"},
+		{"ambiguous date is string", "01/15/2024", "string"},
+		{"invalid date is string", "2024-13-45", "string"},
 		{"struct", struct{}{}, "string"},
 	}
 
@@ -527,6 +700,160 @@ func TestGetLastRowStreaming(t *testing.T) {
 	}
 }
 
+// createDimensionTestFile writes n rows of data followed by blank formatted
+// rows, and stores the dimension Excel would write for it.
+func createDimensionTestFile(tb testing.TB, n, blank int) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "dimension.xlsx")
+
+	f := excelize.NewFile()
+	defer f.Close()
+	for i := 1; i <= n; i++ {
+		if err := f.SetSheetRow("Sheet1", FormatCellAddress(1, i), &[]any{i, "value"}); err != nil {
+			tb.Fatalf("failed to set row %d: %v", i, err)
+		}
+	}
+	for i := n + 1; i <= n+blank; i++ {
+		if err := f.SetRowHeight("Sheet1", i, 30); err != nil {
+			tb.Fatalf("failed to format row %d: %v", i, err)
+		}
+	}
+	if err := f.SetSheetDimension("Sheet1", "A1:"+FormatCellAddress(2, n+blank)); err != nil {
+		tb.Fatalf("failed to set dimension: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		tb.Fatalf("failed to save file: %v", err)
+	}
+	return path
+}
+
+func TestGetLastRowDimension(t *testing.T) {
+	path := createDimensionTestFile(t, 100, 5)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	// A full scan would count the formatted rows too and return 105.
+	lastRow, err := getLastRow(f, "Sheet1")
+	if err != nil {
+		t.Fatalf("getLastRow failed: %v", err)
+	}
+	if lastRow != 100 {
+		t.Errorf("expected trailing blank rows to be trimmed to 100, got %d", lastRow)
+	}
+}
+
+func TestGetLastRowBlankWindow(t *testing.T) {
+	// More trailing blank rows than getLastRow decodes below the dimension
+	path := createDimensionTestFile(t, 3, lastRowWindow+10)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	lastRow, err := getLastRow(f, "Sheet1")
+	if err != nil {
+		t.Fatalf("getLastRow failed: %v", err)
+	}
+	if lastRow != 3 {
+		t.Errorf("expected 3, got %d", lastRow)
+	}
+}
+
+func TestGetLastRowStaleDimension(t *testing.T) {
+	path := createDimensionTestFile(t, 3, 0)
+
+	// Two appends in one transaction: the second must see the first even
+	// though the dimension still says A1:B3.
+	wb, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer wb.Close()
+	for _, want := range []int{4, 5} {
+		result, err := wb.AppendRows("Sheet1", [][]any{{want}})
+		if err != nil {
+			t.Fatalf("AppendRows failed: %v", err)
+		}
+		if result.StartingRow != want {
+			t.Errorf("expected append at row %d, got %d", want, result.StartingRow)
+		}
+	}
+	if err := wb.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	// The committed file must carry the real dimension, so the next open
+	// still finds the last row without a scan.
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	dim, err := f.GetSheetDimension("Sheet1")
+	if err != nil {
+		t.Fatalf("GetSheetDimension failed: %v", err)
+	}
+	if dim != "A1:B5" {
+		t.Errorf("expected committed dimension A1:B5, got %q", dim)
+	}
+	if lastRow, err := getLastRow(f, "Sheet1"); err != nil || lastRow != 5 {
+		t.Errorf("expected getLastRow to find row 5, got %d (%v)", lastRow, err)
+	}
+	f.Close()
+
+	result, err := AppendRows(path, "Sheet1", [][]any{{6}})
+	if err != nil {
+		t.Fatalf("AppendRows failed: %v", err)
+	}
+	if result.StartingRow != 6 {
+		t.Errorf("expected append at row 6 after commit, got %d", result.StartingRow)
+	}
+}
+
+// BenchmarkGetLastRow measures getLastRow as appends use it: find the last
+// row, then write below it.
+func BenchmarkGetLastRow(b *testing.B) {
+	path := createDimensionTestFile(b, 10000, 0)
+
+	for _, bm := range []struct {
+		name    string
+		lastRow func(f *excelize.File, sheet string) (int, error)
+	}{
+		{"dimension", getLastRow},
+		{"scan", func(f *excelize.File, sheet string) (int, error) {
+			return streamLastRow(f, sheet, 1)
+		}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				// Reopen each time: a parsed worksheet disables the dimension path.
+				f, err := OpenFile(path)
+				if err != nil {
+					b.Fatalf("OpenFile failed: %v", err)
+				}
+				lastRow, err := bm.lastRow(f, "Sheet1")
+				if err != nil {
+					b.Fatalf("getLastRow failed: %v", err)
+				}
+				if lastRow != 10000 {
+					b.Fatalf("expected 10000 rows, got %d", lastRow)
+				}
+				// Appends write right after, which parses the worksheet anyway.
+				if err := f.SetCellValue("Sheet1", FormatCellAddress(1, lastRow+1), lastRow+1); err != nil {
+					b.Fatalf("SetCellValue failed: %v", err)
+				}
+				f.Close()
+			}
+		})
+	}
+}
+
 func TestWriteCell(t *testing.T) {
 	// Create test file
 	path := createTestFile(t)
@@ -864,6 +1191,51 @@ func TestAppendRowsErrors(t *testing.T) {
 	}
 }
 
+func TestAppendCols(t *testing.T) {
+	path := createTestFile(t)
+
+	cols := [][]any{
+		{"Total", 10, 20},
+		{"Note"},
+	}
+	result, err := AppendCols(path, "Sheet1", cols)
+	if err != nil {
+		t.Fatalf("AppendCols failed: %v", err)
+	}
+	if result.ColsAdded != 2 || result.CellsWritten != 4 {
+		t.Errorf("expected 2 columns and 4 cells, got %+v", result)
+	}
+	if result.StartingColumn != "C" || result.EndingColumn != "D" {
+		t.Errorf("expected columns C:D, got %s:%s", result.StartingColumn, result.EndingColumn)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file for verification: %v", err)
+	}
+	defer f.Close()
+
+	expected := map[string]string{"C1": "Total", "C2": "10", "C3": "20", "D1": "Note", "D2": "", "B2": "42"}
+	for addr, want := range expected {
+		if got, _ := f.GetCellValue("Sheet1", addr); got != want {
+			t.Errorf("%s: expected %q, got %q", addr, want, got)
+		}
+	}
+}
+
+func TestAppendColsErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := AppendCols(path, "Sheet1", nil); err == nil {
+		t.Error("expected error for no columns")
+	}
+
+	big := make([]any, MaxWriteRangeCells+1)
+	if _, err := AppendCols(path, "Sheet1", [][]any{big}); !errors.Is(err, ErrCellLimitExceeded) {
+		t.Errorf("expected ErrCellLimitExceeded, got: %v", err)
+	}
+}
+
 func TestCreateFile(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "new_file.xlsx")
@@ -1091,6 +1463,44 @@ func TestCreateFileRowLimit(t *testing.T) {
 	}
 }
 
+func TestWriteSheetBounds(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := WriteCell(path, "Sheet1", "XFD1", "last", "auto"); err != nil {
+		t.Fatalf("WriteCell at XFD1 failed: %v", err)
+	}
+	if _, err := WriteCell(path, "Sheet1", "XFE1", "past", "auto"); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("WriteCell at XFE1: expected ErrOutOfBounds, got %v", err)
+	}
+	if _, err := WriteCell(path, "Sheet1", "A1048577", "past", "auto"); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("WriteCell at A1048577: expected ErrOutOfBounds, got %v", err)
+	}
+
+	// The end column is checked before any cell is written
+	if _, err := WriteRange(path, "Sheet1", "XFC2", [][]any{{"a", "b", "c"}}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("WriteRange past XFD: expected ErrOutOfBounds, got %v", err)
+	}
+	if got := readCell(t, path, "Sheet1", "XFC2"); got != "" {
+		t.Errorf("expected XFC2 untouched, got %q", got)
+	}
+	if _, err := WriteRange(path, "Sheet1", "XFC2", [][]any{{"a", "b"}}); err != nil {
+		t.Errorf("WriteRange ending at XFD failed: %v", err)
+	}
+
+	if _, err := WriteCell(path, "Sheet1", "A1048575", "near the end", "auto"); err != nil {
+		t.Fatalf("WriteCell at A1048575 failed: %v", err)
+	}
+	if _, err := AppendRows(path, "Sheet1", [][]any{{"x"}, {"y"}}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("AppendRows past row 1048576: expected ErrOutOfBounds, got %v", err)
+	}
+	if got := readCell(t, path, "Sheet1", "A1048576"); got != "" {
+		t.Errorf("expected A1048576 untouched, got %q", got)
+	}
+	if _, err := AppendRows(path, "Sheet1", [][]any{{"x"}}); err != nil {
+		t.Errorf("AppendRows ending at row 1048576 failed: %v", err)
+	}
+}
+
 func TestWriteRange(t *testing.T) {
 	// Create test file
 	path := createTestFile(t)
@@ -1534,6 +1944,69 @@ func TestRenameSheetErrors(t *testing.T) {
 	t.Logf("error: %v", err)
 }
 
+func TestCopySheet(t *testing.T) {
+	path := createTestFile(t)
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	style, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		t.Fatalf("failed to create style: %v", err)
+	}
+	if err := f.SetCellStyle("Sheet1", "A1", "B1", style); err != nil {
+		t.Fatalf("failed to set style: %v", err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("failed to save file: %v", err)
+	}
+	f.Close()
+
+	result, err := CopySheet(path, "Sheet1", "Template Copy")
+	if err != nil {
+		t.Fatalf("CopySheet failed: %v", err)
+	}
+	if !result.Success || result.Sheet != "Template Copy" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// Edit the copy; the original must not change
+	if _, err := WriteCell(path, "Template Copy", "A2", "changed", "auto"); err != nil {
+		t.Fatalf("WriteCell failed: %v", err)
+	}
+
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file for verification: %v", err)
+	}
+	defer f.Close()
+
+	if v, _ := f.GetCellValue("Template Copy", "B2"); v != "42" {
+		t.Errorf("expected copied B2 42, got %q", v)
+	}
+	if v, _ := f.GetCellValue("Template Copy", "A2"); v != "changed" {
+		t.Errorf("expected edited copy A2, got %q", v)
+	}
+	if v, _ := f.GetCellValue("Sheet1", "A2"); v != "Value1" {
+		t.Errorf("expected original A2 unchanged, got %q", v)
+	}
+	if id, _ := f.GetCellStyle("Template Copy", "A1"); id != style {
+		t.Errorf("expected copied header style %d, got %d", style, id)
+	}
+}
+
+func TestCopySheetErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := CopySheet(path, "NonExistent", "Copy"); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("expected ErrSheetNotFound, got: %v", err)
+	}
+	if _, err := CopySheet(path, "Sheet1", "Sheet2"); !errors.Is(err, ErrSheetExists) {
+		t.Errorf("expected ErrSheetExists, got: %v", err)
+	}
+}
+
 func TestInsertRows(t *testing.T) {
 	// Create test file with 3 rows
 	path := createTestFile(t)
@@ -1933,3 +2406,343 @@ func TestDeleteRowsErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestClearRange(t *testing.T) {
+	path := createTestFile(t)
+
+	result, err := ClearRange(path, "Sheet1", "A2:B3")
+	if err != nil {
+		t.Fatalf("ClearRange failed: %v", err)
+	}
+	if !result.Success {
+		t.Error("expected success=true")
+	}
+	if result.Range != "A2:B3" {
+		t.Errorf("expected range A2:B3, got %q", result.Range)
+	}
+	// A2, B2 and A3 hold values; B3 is already empty
+	if result.CellsCleared != 3 {
+		t.Errorf("expected 3 cells cleared, got %d", result.CellsCleared)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file for verification: %v", err)
+	}
+	defer f.Close()
+
+	for _, addr := range []string{"A2", "B2", "A3"} {
+		val, err := f.GetCellValue("Sheet1", addr)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", addr, err)
+		}
+		if val != "" {
+			t.Errorf("expected %s to be cleared, got %q", addr, val)
+		}
+	}
+
+	// Cells outside the range are untouched
+	val, err := f.GetCellValue("Sheet1", "A1")
+	if err != nil {
+		t.Fatalf("failed to read A1: %v", err)
+	}
+	if val != "Header1" {
+		t.Errorf("expected 'Header1' at A1, got %q", val)
+	}
+}
+
+func TestClearRangeFormula(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := WriteCell(path, "Sheet1", "C1", "=B2*2", "formula"); err != nil {
+		t.Fatalf("WriteCell failed: %v", err)
+	}
+
+	result, err := ClearRange(path, "Sheet1", "C1")
+	if err != nil {
+		t.Fatalf("ClearRange failed: %v", err)
+	}
+	if result.CellsCleared != 1 {
+		t.Errorf("expected 1 cell cleared, got %d", result.CellsCleared)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file for verification: %v", err)
+	}
+	defer f.Close()
+
+	formula, err := f.GetCellFormula("Sheet1", "C1")
+	if err != nil {
+		t.Fatalf("failed to read formula: %v", err)
+	}
+	if formula != "" {
+		t.Errorf("expected formula to be removed, got %q", formula)
+	}
+}
+
+func TestClearRangeEmpty(t *testing.T) {
+	path := createTestFile(t)
+
+	result, err := ClearRange(path, "Sheet1", "D5:E6")
+	if err != nil {
+		t.Fatalf("ClearRange failed: %v", err)
+	}
+	if !result.Success {
+		t.Error("expected success=true")
+	}
+	if result.CellsCleared != 0 {
+		t.Errorf("expected 0 cells cleared, got %d", result.CellsCleared)
+	}
+}
+
+func TestClearRangeErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	_, err := ClearRange(path, "Sheet1", "A1:A20000")
+	if !errors.Is(err, ErrCellLimitExceeded) {
+		t.Errorf("expected ErrCellLimitExceeded, got: %v", err)
+	}
+
+	if _, err := ClearRange(path, "Sheet1", "not-a-range"); err == nil {
+		t.Error("expected error for invalid range")
+	}
+
+	if _, err := ClearRange(path, "NoSuchSheet", "A1"); err == nil {
+		t.Error("expected error for nonexistent sheet")
+	}
+}
+
+func TestMergeCells(t *testing.T) {
+	path := createTestFile(t)
+
+	result, err := MergeCells(path, "Sheet1", "A1:B1")
+	if err != nil {
+		t.Fatalf("MergeCells failed: %v", err)
+	}
+	if !result.Success || result.Range != "A1:B1" || result.Regions != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file for verification: %v", err)
+	}
+	merged, err := f.GetMergeCells("Sheet1")
+	f.Close()
+	if err != nil {
+		t.Fatalf("failed to read merged cells: %v", err)
+	}
+	if len(merged) != 1 || merged[0].GetStartAxis() != "A1" || merged[0].GetEndAxis() != "B1" {
+		t.Fatalf("expected A1:B1 merged, got %v", merged)
+	}
+
+	// Overlapping an existing merge is rejected and leaves the file alone
+	_, err = MergeCells(path, "Sheet1", "B1:C2")
+	if err == nil || !strings.Contains(err.Error(), "overlaps merged cells A1:B1") {
+		t.Errorf("expected overlap error, got: %v", err)
+	}
+
+	result, err = UnmergeCells(path, "Sheet1", "B1")
+	if err != nil {
+		t.Fatalf("UnmergeCells failed: %v", err)
+	}
+	if result.Regions != 1 {
+		t.Errorf("expected 1 region unmerged, got %d", result.Regions)
+	}
+
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file for verification: %v", err)
+	}
+	defer f.Close()
+	merged, err = f.GetMergeCells("Sheet1")
+	if err != nil {
+		t.Fatalf("failed to read merged cells: %v", err)
+	}
+	if len(merged) != 0 {
+		t.Errorf("expected no merged cells, got %v", merged)
+	}
+}
+
+func TestMergeCellsErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := MergeCells(path, "Sheet1", "A1"); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("expected ErrInvalidRange for single cell, got: %v", err)
+	}
+	if _, err := MergeCells(path, "Sheet1", "not-a-range"); err == nil {
+		t.Error("expected error for invalid range")
+	}
+	if _, err := MergeCells(path, "NoSuchSheet", "A1:B2"); err == nil {
+		t.Error("expected error for nonexistent sheet")
+	}
+
+	result, err := UnmergeCells(path, "Sheet1", "A1:C3")
+	if err != nil {
+		t.Fatalf("UnmergeCells failed: %v", err)
+	}
+	if result.Regions != 0 {
+		t.Errorf("expected 0 regions unmerged, got %d", result.Regions)
+	}
+}
+
+func TestCreateFileStyleHeaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "styled.xlsx")
+
+	_, err := CreateFileWithOptions(path, "", []string{"Name", "Age"}, [][]any{{"Alice", 30}},
+		CreateFileOptions{StyleHeaders: true})
+	if err != nil {
+		t.Fatalf("CreateFileWithOptions failed: %v", err)
+	}
+	if _, err := CreateSheetWithOptions(path, "Other", []string{"ID"}, CreateSheetOptions{StyleHeaders: true}); err != nil {
+		t.Fatalf("CreateSheetWithOptions failed: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file for verification: %v", err)
+	}
+	defer f.Close()
+
+	for _, c := range []struct {
+		sheet, addr string
+		styled      bool
+	}{
+		{"Sheet1", "A1", true},
+		{"Sheet1", "B1", true},
+		{"Sheet1", "C1", false}, // Past the last header
+		{"Sheet1", "A2", false}, // Data rows stay plain
+		{"Other", "A1", true},
+	} {
+		style, err := f.GetCellStyle(c.sheet, c.addr)
+		if err != nil {
+			t.Fatalf("failed to read style of %s!%s: %v", c.sheet, c.addr, err)
+		}
+		if (style != 0) != c.styled {
+			t.Errorf("%s!%s: expected styled=%v, got style %d", c.sheet, c.addr, c.styled, style)
+		}
+	}
+}
+
+func TestCreateFileStreamed(t *testing.T) {
+	headers := []string{"Name", "Score", "Active", "Note"}
+	rows := [][]any{
+		{"Alice", 90.5, true, nil},
+		{"Bob", 72, false, "=SUM(1,2)"},
+		{},
+		{"Cy", int64(-3), nil, "late"},
+	}
+	opts := CreateFileOptions{StyleHeaders: true, FreezeHeader: true}
+
+	create := func(name string, threshold int) string {
+		t.Helper()
+		defer func(old int) { streamCreateThreshold = old }(streamCreateThreshold)
+		streamCreateThreshold = threshold
+
+		path := filepath.Join(t.TempDir(), name)
+		result, err := CreateFileWithOptions(path, "Data", headers, rows, opts)
+		if err != nil {
+			t.Fatalf("CreateFileWithOptions failed: %v", err)
+		}
+		if result.RowsWritten != len(rows)+1 || result.FrozenRows != 1 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		return path
+	}
+	plain := create("plain.xlsx", len(rows)+1)
+	streamed := create("streamed.xlsx", 1)
+
+	read := func(path string) ([][]string, []int, excelize.Panes) {
+		t.Helper()
+		f, err := OpenFile(path)
+		if err != nil {
+			t.Fatalf("failed to open file for verification: %v", err)
+		}
+		defer f.Close()
+		got, err := f.GetRows("Data")
+		if err != nil {
+			t.Fatalf("failed to read rows: %v", err)
+		}
+		var styles []int
+		for _, addr := range []string{"A1", "D1", "E1", "A2"} {
+			style, err := f.GetCellStyle("Data", addr)
+			if err != nil {
+				t.Fatalf("failed to read style of %s: %v", addr, err)
+			}
+			styles = append(styles, min(style, 1))
+		}
+		panes, err := f.GetPanes("Data")
+		if err != nil {
+			t.Fatalf("failed to read panes: %v", err)
+		}
+		return got, styles, panes
+	}
+
+	wantRows, wantStyles, wantPanes := read(plain)
+	gotRows, gotStyles, gotPanes := read(streamed)
+	if !slices.EqualFunc(gotRows, wantRows, slices.Equal) {
+		t.Errorf("streamed rows differ: expected %v, got %v", wantRows, gotRows)
+	}
+	if !slices.Equal(gotStyles, wantStyles) {
+		t.Errorf("streamed header styles differ: expected %v, got %v", wantStyles, gotStyles)
+	}
+	if gotPanes.Freeze != wantPanes.Freeze || gotPanes.YSplit != wantPanes.YSplit || gotPanes.TopLeftCell != wantPanes.TopLeftCell {
+		t.Errorf("streamed panes differ: expected %+v, got %+v", wantPanes, gotPanes)
+	}
+	if got := readCell(t, streamed, "Data", "B3"); got != "72" {
+		t.Errorf("expected B3 to be 72, got %q", got)
+	}
+}
+
+func TestInsertBlankRows(t *testing.T) {
+	path := createTestFile(t)
+
+	result, err := InsertBlankRows(path, "Sheet1", 2, 3)
+	if err != nil {
+		t.Fatalf("InsertBlankRows failed: %v", err)
+	}
+	if !result.Success || result.RowsAdded != 3 || result.StartingRow != 2 || result.EndingRow != 4 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open file for verification: %v", err)
+	}
+	defer f.Close()
+
+	// Row 1 stays, rows 2-4 are blank and the old rows 2-3 move to 5-6
+	expected := map[string]string{
+		"A1": "Header1",
+		"A2": "", "B2": "", "A3": "", "A4": "",
+		"A5": "Value1", "B5": "42",
+		"A6": "Value3",
+	}
+	for addr, want := range expected {
+		val, err := f.GetCellValue("Sheet1", addr)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", addr, err)
+		}
+		if val != want {
+			t.Errorf("%s: expected %q, got %q", addr, want, val)
+		}
+	}
+}
+
+func TestInsertBlankRowsErrors(t *testing.T) {
+	path := createTestFile(t)
+
+	if _, err := InsertBlankRows(path, "Sheet1", 0, 1); err == nil {
+		t.Error("expected error for row 0")
+	}
+	if _, err := InsertBlankRows(path, "Sheet1", 1, 0); err == nil {
+		t.Error("expected error for count 0")
+	}
+	if _, err := InsertBlankRows(path, "Sheet1", 1, MaxAppendRows+1); !errors.Is(err, ErrRowLimitExceeded) {
+		t.Errorf("expected ErrRowLimitExceeded, got: %v", err)
+	}
+	if _, err := InsertBlankRows(path, "NoSuchSheet", 1, 1); err == nil {
+		t.Error("expected error for nonexistent sheet")
+	}
+}
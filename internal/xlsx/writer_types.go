@@ -2,12 +2,21 @@ package xlsx
 
 import "errors"
 
-// Constants for write operation limits
+// Default write operation limits
 const (
-	MaxWriteFileSize   = 50 * 1024 * 1024 // 50MB - maximum file size for write operations
-	MaxAppendRows      = 1000             // Maximum rows that can be appended in a single operation
-	MaxWriteRangeCells = 10000            // Maximum cells that can be written in a single range operation
-	MaxCreateFileRows  = 10000            // Maximum rows when creating a new file
+	DefaultMaxWriteFileSize   = 50 * 1024 * 1024 // 50MB - maximum file size for write operations
+	DefaultMaxAppendRows      = 1000             // Maximum rows that can be appended in a single operation
+	DefaultMaxWriteRangeCells = 10000            // Maximum cells that can be written in a single range operation
+	DefaultMaxCreateFileRows  = 10000            // Maximum rows when creating a new file
+)
+
+// Write operation limits. They start at the defaults and may be overridden
+// once at startup (see mcp.LoadLimitsFromEnv), before any write runs.
+var (
+	MaxWriteFileSize   int64 = DefaultMaxWriteFileSize
+	MaxAppendRows            = DefaultMaxAppendRows
+	MaxWriteRangeCells       = DefaultMaxWriteRangeCells
+	MaxCreateFileRows        = DefaultMaxCreateFileRows
 )
 
 // Error types for write operations
@@ -19,40 +28,326 @@ var (
 	ErrCellLimitExceeded     = errors.New("cell limit exceeded")
 	ErrCannotDeleteLastSheet = errors.New("cannot delete the last sheet")
 	ErrSheetExists           = errors.New("sheet already exists")
+	ErrNoVisibleSheet        = errors.New("at least one sheet must stay visible")
+	ErrMacroExtension        = errors.New("workbook contains macros but its extension is not macro-enabled")
 )
 
 // WriteResult represents the result of a single cell write operation
 type WriteResult struct {
 	Success       bool   `json:"success"`
+	DryRun        bool   `json:"dry_run,omitempty"`
 	Cell          string `json:"cell,omitempty"`
 	PreviousValue any    `json:"previous_value,omitempty"`
 	NewValue      any    `json:"new_value,omitempty"`
 }
 
+// CellWrite is a value and type to write to a single cell.
+// Type is one of auto, string, number, bool, formula, date, datetime (empty means auto).
+type CellWrite struct {
+	Value any    `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// WriteCellsResult represents the result of a batch cell write
+type WriteCellsResult struct {
+	Success      bool          `json:"success"`
+	DryRun       bool          `json:"dry_run,omitempty"`
+	CellsWritten int           `json:"cells_written"`
+	Cells        []WriteResult `json:"cells"`
+}
+
 // AppendResult represents the result of appending rows to a sheet
 type AppendResult struct {
 	Success     bool `json:"success"`
+	DryRun      bool `json:"dry_run,omitempty"`
 	RowsAdded   int  `json:"rows_added"`
 	StartingRow int  `json:"starting_row"`
 	EndingRow   int  `json:"ending_row"`
 }
 
+// AppendColsResult represents the result of appending columns to a sheet
+type AppendColsResult struct {
+	Success        bool   `json:"success"`
+	DryRun         bool   `json:"dry_run,omitempty"`
+	ColsAdded      int    `json:"cols_added"`
+	CellsWritten   int    `json:"cells_written"`
+	StartingColumn string `json:"starting_column"`
+	EndingColumn   string `json:"ending_column"`
+}
+
+// AppendObjectsResult represents the result of appending objects as rows
+type AppendObjectsResult struct {
+	Success      bool     `json:"success"`
+	DryRun       bool     `json:"dry_run,omitempty"`
+	Sheet        string   `json:"sheet"`
+	SheetCreated bool     `json:"sheet_created,omitempty"`
+	Headers      []string `json:"headers"`
+	HeadersAdded int      `json:"headers_added,omitempty"`
+	RowsAdded    int      `json:"rows_added"`
+	StartingRow  int      `json:"starting_row"`
+	EndingRow    int      `json:"ending_row"`
+}
+
 // CreateFileResult represents the result of creating a new XLSX file
 type CreateFileResult struct {
 	Success     bool   `json:"success"`
+	DryRun      bool   `json:"dry_run,omitempty"`
 	File        string `json:"file"`
 	SheetName   string `json:"sheet_name"`
 	RowsWritten int    `json:"rows_written,omitempty"`
+	FrozenRows  int    `json:"frozen_rows,omitempty"`
+	Backup      string `json:"backup,omitempty"` // Copy of the overwritten file, if one was made
+}
+
+// CreateFileOptions configures CreateFileWithOptions
+type CreateFileOptions struct {
+	Overwrite    bool // Replace an existing file
+	FreezeHeader bool // Freeze the header row when headers are given
+	StyleHeaders bool // Make the header row bold with a light fill
+	DryRun       bool // Build the workbook but do not write the file
+	Backup       bool // Copy an existing file to path+BackupSuffix, once the workbook is built, before replacing it
+}
+
+// CreateSheetOptions configures CreateSheetWithOptions
+type CreateSheetOptions struct {
+	StyleHeaders bool // Make the header row bold with a light fill
+}
+
+// FreezeResult represents the result of freezing or unfreezing panes
+type FreezeResult struct {
+	Success     bool   `json:"success"`
+	DryRun      bool   `json:"dry_run,omitempty"`
+	Sheet       string `json:"sheet"`
+	Rows        int    `json:"rows"`                    // Frozen rows at the top
+	Cols        int    `json:"cols"`                    // Frozen columns at the left
+	TopLeftCell string `json:"top_left_cell,omitempty"` // First scrollable cell; empty when unfrozen
 }
 
 // SheetResult represents the result of a sheet operation (create/delete)
 type SheetResult struct {
 	Success bool   `json:"success"`
+	DryRun  bool   `json:"dry_run,omitempty"`
 	Sheet   string `json:"sheet"`
+	Backup  string `json:"backup,omitempty"` // Copy of the file before the change, if one was made
+}
+
+// SheetVisibilityResult represents the result of showing or hiding a sheet
+type SheetVisibilityResult struct {
+	Success    bool   `json:"success"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+	Sheet      string `json:"sheet"`
+	Visibility string `json:"visibility"` // visible, hidden or veryHidden
+	Previous   string `json:"previous"`   // The state before the change
 }
 
 // DeleteRowsResult represents the result of deleting rows
 type DeleteRowsResult struct {
-	Success     bool `json:"success"`
-	RowsDeleted int  `json:"rows_deleted"`
+	Success         bool   `json:"success"`
+	DryRun          bool   `json:"dry_run,omitempty"`
+	RowsDeleted     int    `json:"rows_deleted"`
+	RowsWithData    int    `json:"rows_with_data"`             // Rows in the range that held a value or formula
+	RefsInvalidated int    `json:"refs_invalidated,omitempty"` // References into the deleted rows set to #REF!
+	Backup          string `json:"backup,omitempty"`           // Copy of the file before the change, if one was made
+}
+
+// ClearRangeResult represents the result of clearing a range of cells
+type ClearRangeResult struct {
+	Success      bool   `json:"success"`
+	DryRun       bool   `json:"dry_run,omitempty"`
+	Range        string `json:"range"`
+	CellsCleared int    `json:"cells_cleared"`
+}
+
+// CropResult represents the result of cropping a sheet to a range
+type CropResult struct {
+	Success   bool   `json:"success"`
+	DryRun    bool   `json:"dry_run,omitempty"`
+	Sheet     string `json:"sheet"`
+	Kept      string `json:"kept"`  // Original range that was kept
+	Range     string `json:"range"` // Where the kept cells are now, starting at A1
+	CellsKept int    `json:"cells_kept"`
+}
+
+// SortResult represents the result of sorting the rows of a sheet
+type SortResult struct {
+	Success    bool   `json:"success"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+	Sheet      string `json:"sheet"`
+	Range      string `json:"range,omitempty"` // Rows that were reordered
+	RowsSorted int    `json:"rows_sorted"`
+}
+
+// SwapResult represents the result of swapping two rows or two columns
+type SwapResult struct {
+	Success    bool   `json:"success"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+	Sheet      string `json:"sheet"`
+	First      string `json:"first"`  // Row number or column letter
+	Second     string `json:"second"` // Row number or column letter
+	CellsMoved int    `json:"cells_moved"`
+}
+
+// MergeResult represents the result of merging or unmerging cells
+type MergeResult struct {
+	Success bool   `json:"success"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+	Sheet   string `json:"sheet"`
+	Range   string `json:"range"`
+	Regions int    `json:"regions"` // Merged regions created or removed
+}
+
+// StyleResult represents the result of styling a range of cells
+type StyleResult struct {
+	Success     bool   `json:"success"`
+	DryRun      bool   `json:"dry_run,omitempty"`
+	Sheet       string `json:"sheet"`
+	Range       string `json:"range"`
+	CellsStyled int    `json:"cells_styled"`
+}
+
+// ReplaceChange describes one cell rewritten by Replace
+type ReplaceChange struct {
+	Sheet    string `json:"sheet"`
+	Address  string `json:"address"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+	Formula  bool   `json:"formula,omitempty"` // Replacement was made in the formula text
+}
+
+// ReplaceResult represents the result of a find-and-replace
+type ReplaceResult struct {
+	Success      bool            `json:"success"`
+	DryRun       bool            `json:"dry_run,omitempty"`
+	CellsChanged int             `json:"cells_changed"`
+	Changes      []ReplaceChange `json:"changes"`
+}
+
+// SetWhereResult represents the result of setting every matching cell to a value
+type SetWhereResult struct {
+	Success      bool            `json:"success"`
+	DryRun       bool            `json:"dry_run,omitempty"`
+	CellsChanged int             `json:"cells_changed"`
+	Changes      []ReplaceChange `json:"changes"`
+}
+
+// ConvertDatesResult represents the result of converting serial dates in a column
+type ConvertDatesResult struct {
+	Success        bool   `json:"success"`
+	DryRun         bool   `json:"dry_run,omitempty"`
+	Column         string `json:"column"`
+	CellsConverted int    `json:"cells_converted"`
+	CellsSkipped   int    `json:"cells_skipped"`
+	FirstDate      string `json:"first_date,omitempty"`
+	Date1904       bool   `json:"date1904,omitempty"`
+}
+
+// DropdownResult represents the result of adding a dropdown list validation
+type DropdownResult struct {
+	Success bool   `json:"success"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+	Sheet   string `json:"sheet"`
+	Range   string `json:"range"`
+	Source  string `json:"source"`
+}
+
+// ImportOptions configures ImportCSV
+type ImportOptions struct {
+	Delimiter rune // Field separator (default ',')
+	Header    bool // The first record is a header; it is dropped when the sheet already has rows
+}
+
+// ImportResult represents the result of importing CSV records into a sheet
+type ImportResult struct {
+	Success       bool   `json:"success"`
+	DryRun        bool   `json:"dry_run,omitempty"`
+	Sheet         string `json:"sheet"`
+	SheetCreated  bool   `json:"sheet_created,omitempty"`
+	HeaderSkipped bool   `json:"header_skipped,omitempty"`
+	RowsAdded     int    `json:"rows_added"`
+	Columns       int    `json:"columns"`
+	StartingRow   int    `json:"starting_row"`
+	EndingRow     int    `json:"ending_row"`
+}
+
+// CombineOptions configures CombineCSV
+type CombineOptions struct {
+	Delimiter rune // Field separator (default ',')
+	Overwrite bool // Replace an existing file
+	DryRun    bool // Build the workbook but do not write the file
+}
+
+// CombinedSheet describes one CSV file written to its own sheet by CombineCSV
+type CombinedSheet struct {
+	Sheet   string `json:"sheet"`
+	Source  string `json:"source"`
+	Rows    int    `json:"rows"`
+	Columns int    `json:"columns"`
+}
+
+// CombineResult represents the result of combining CSV files into a workbook
+type CombineResult struct {
+	Success bool            `json:"success"`
+	DryRun  bool            `json:"dry_run,omitempty"`
+	File    string          `json:"file"`
+	Sheets  []CombinedSheet `json:"sheets"`
+}
+
+// NamedRangeResult represents the result of adding or deleting a named range
+type NamedRangeResult struct {
+	Success  bool   `json:"success"`
+	DryRun   bool   `json:"dry_run,omitempty"`
+	Name     string `json:"name"`
+	RefersTo string `json:"refers_to"`
+}
+
+// CommentResult represents the result of adding or deleting a cell comment
+type CommentResult struct {
+	Success  bool   `json:"success"`
+	DryRun   bool   `json:"dry_run,omitempty"`
+	Sheet    string `json:"sheet"`
+	Cell     string `json:"cell"`
+	Replaced bool   `json:"replaced,omitempty"`
+	Deleted  bool   `json:"deleted,omitempty"`
+}
+
+// PivotWriteResult represents the result of writing a pivot to a new sheet
+type PivotWriteResult struct {
+	Success bool   `json:"success"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+	Sheet   string `json:"sheet"`
+	Source  string `json:"source"`
+	Op      string `json:"op"`
+	Rows    int    `json:"rows"`
+	Columns int    `json:"columns"`
+	Ignored int    `json:"ignored"`
+}
+
+// DedupResult represents the result of removing duplicate rows
+type DedupResult struct {
+	Success           bool   `json:"success"`
+	DryRun            bool   `json:"dry_run,omitempty"`
+	Sheet             string `json:"sheet"`
+	Range             string `json:"range,omitempty"` // Rows that were rewritten
+	RowsScanned       int    `json:"rows_scanned"`
+	DuplicatesRemoved int    `json:"duplicates_removed"`
+}
+
+// CompactResult represents the result of removing empty rows and columns
+type CompactResult struct {
+	Success     bool   `json:"success"`
+	DryRun      bool   `json:"dry_run,omitempty"`
+	Sheet       string `json:"sheet"`
+	RowsRemoved int    `json:"rows_removed"`
+	ColsRemoved int    `json:"cols_removed"`
+}
+
+// FillResult represents the result of forward-filling a column
+type FillResult struct {
+	Success     bool   `json:"success"`
+	DryRun      bool   `json:"dry_run,omitempty"`
+	Sheet       string `json:"sheet"`
+	Column      string `json:"column"`
+	Direction   string `json:"direction"`
+	Range       string `json:"range,omitempty"`
+	CellsFilled int    `json:"cells_filled"`
 }
@@ -0,0 +1,130 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// testVBAProject stands in for a vbaProject.bin: excelize only checks for
+// the OLE compound file signature
+var testVBAProject = append([]byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}, make([]byte, 504)...)
+
+// createXLSMTestFile creates a macro-enabled workbook with two sheets
+func createXLSMTestFile(t *testing.T, name string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	f := excelize.NewFile()
+	defer f.Close()
+	if _, err := f.NewSheet("Macros"); err != nil {
+		t.Fatalf("failed to add sheet: %v", err)
+	}
+	if err := f.SetCellValue("Sheet1", "A1", "Total"); err != nil {
+		t.Fatalf("failed to set cell: %v", err)
+	}
+	if err := f.AddVBAProject(testVBAProject); err != nil {
+		t.Fatalf("failed to add VBA project: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
+// readZipEntry returns one part of a saved workbook package
+func readZipEntry(t *testing.T, path, name string) []byte {
+	t.Helper()
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("failed to open package: %v", err)
+	}
+	defer zr.Close()
+	for _, file := range zr.File {
+		if file.Name != name {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", name, err)
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return data
+	}
+	return nil
+}
+
+func TestXLSM_GetSheets(t *testing.T) {
+	path := createXLSMTestFile(t, "macros.xlsm")
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer f.Close()
+
+	sheets, err := GetSheets(f)
+	if err != nil {
+		t.Fatalf("GetSheets failed: %v", err)
+	}
+	if want := []string{"Sheet1", "Macros"}; !reflect.DeepEqual(sheets, want) {
+		t.Errorf("GetSheets = %v, want %v", sheets, want)
+	}
+	if !HasMacros(f) {
+		t.Error("HasMacros = false, want true")
+	}
+}
+
+func TestXLSM_WritePreservesMacros(t *testing.T) {
+	path := createXLSMTestFile(t, "macros.xlsm")
+
+	if _, err := WriteCell(path, "Sheet1", "B1", 42, "auto"); err != nil {
+		t.Fatalf("WriteCell failed: %v", err)
+	}
+
+	if got := readZipEntry(t, path, "xl/vbaProject.bin"); !bytes.Equal(got, testVBAProject) {
+		t.Errorf("vbaProject.bin was not preserved (%d bytes)", len(got))
+	}
+	if types := readZipEntry(t, path, "[Content_Types].xml"); !strings.Contains(string(types), excelize.ContentTypeMacro) {
+		t.Errorf("content types lost the macro-enabled workbook type: %s", types)
+	}
+	if got := readCell(t, path, "Sheet1", "B1"); got != "42" {
+		t.Errorf("B1 = %q, want 42", got)
+	}
+}
+
+func TestXLSM_RefusesMacrosUnderXLSX(t *testing.T) {
+	xlsm := createXLSMTestFile(t, "macros.xlsm")
+	path := filepath.Join(filepath.Dir(xlsm), "renamed.xlsx")
+	if err := os.Rename(xlsm, path); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = WriteCell(path, "Sheet1", "B1", 42, "auto")
+	if !errors.Is(err, ErrMacroExtension) {
+		t.Fatalf("WriteCell error = %v, want ErrMacroExtension", err)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("file was modified despite the refused write")
+	}
+}